@@ -2,6 +2,7 @@ package parquet
 
 import (
 	"io"
+	"math"
 
 	"github.com/parquet-go/parquet-go/bloom"
 	"github.com/parquet-go/parquet-go/bloom/xxhash"
@@ -109,6 +110,20 @@ func SplitBlockFilter(bitsPerValue uint, path ...string) BloomFilterColumn {
 	}
 }
 
+// defaultBloomFilterBitsPerValue is the number of bits per value of the bloom
+// filters declared with the "bloom" tag when no false positive probability is
+// given, which gives a false positive probability below 1%.
+const defaultBloomFilterBitsPerValue = 10
+
+// bloomFilterBitsPerValue returns the number of bits per value that a split
+// block bloom filter needs to have a false positive probability of fpp.
+//
+// Each value sets 8 bits in a block, the formula is the one used by other
+// parquet implementations to size their filters.
+func bloomFilterBitsPerValue(fpp float64) uint {
+	return uint(math.Ceil(-8 / math.Log(1-math.Pow(fpp, 1.0/8))))
+}
+
 type splitBlockFilter struct {
 	bitsPerValue uint
 	path         []string
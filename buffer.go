@@ -510,6 +510,12 @@ type bufferPool struct {
 	//   364K, 546K, 819K ...
 	//
 	buckets [bufferPoolBucketCount]sync.Pool
+
+	// Pools created by newReservedBufferPool retain a fixed set of buffers
+	// which, unlike the buffers held in the buckets, are not released when
+	// the garbage collector runs.
+	reserved     chan *buffer
+	reservedSize int
 }
 
 func (p *bufferPool) newBuffer(bufferSize, bucketSize int) *buffer {
@@ -525,13 +531,34 @@ func (p *bufferPool) newBuffer(bufferSize, bucketSize int) *buffer {
 	return b
 }
 
+// newReservedBufferPool creates a buffer pool which preallocates count buffers
+// of the given size.
+func newReservedBufferPool(count, size int) *bufferPool {
+	p := &bufferPool{
+		reserved:     make(chan *buffer, count),
+		reservedSize: size,
+	}
+	for range count {
+		b := p.newBuffer(size, size)
+		b.refc = 0
+		p.reserved <- b
+	}
+	return p
+}
+
 // get returns a buffer from the levelled buffer pool. size is used to choose
 // the appropriate pool.
 func (p *bufferPool) get(bufferSize int) *buffer {
 	bucketIndex, bucketSize := bufferPoolBucketIndexAndSizeOfGet(bufferSize)
 
 	b := (*buffer)(nil)
-	if bucketIndex >= 0 {
+	if p.reserved != nil && bufferSize <= p.reservedSize {
+		select {
+		case b = <-p.reserved:
+		default:
+		}
+	}
+	if b == nil && bucketIndex >= 0 {
 		b, _ = p.buckets[bucketIndex].Get().(*buffer)
 	}
 
@@ -555,6 +582,13 @@ func (p *bufferPool) put(b *buffer) {
 	if b.refCount() != 0 {
 		panic("BUG: buffer returned to pool with a non-zero reference count")
 	}
+	if p.reserved != nil && cap(b.data) == p.reservedSize {
+		select {
+		case p.reserved <- b:
+			return
+		default:
+		}
+	}
 	if bucketIndex, _ := bufferPoolBucketIndexAndSizeOfPut(cap(b.data)); bucketIndex >= 0 {
 		p.buckets[bucketIndex].Put(b)
 	}
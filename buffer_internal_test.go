@@ -54,3 +54,37 @@ func TestBufferPoolBucketIndexAndSizeOf(t *testing.T) {
 		})
 	}
 }
+
+func TestReservedBufferPool(t *testing.T) {
+	p := newReservedBufferPool(2, 8192)
+
+	b1 := p.get(100)
+	b2 := p.get(8192)
+	if cap(b1.data) != 8192 || cap(b2.data) != 8192 {
+		t.Fatalf("buffers were not acquired from the reserve: cap=%d,%d", cap(b1.data), cap(b2.data))
+	}
+	if len(b1.data) != 100 || len(b2.data) != 8192 {
+		t.Fatalf("wrong buffer sizes: len=%d,%d", len(b1.data), len(b2.data))
+	}
+
+	// The reserve is exhausted, and larger buffers never come from it.
+	b3 := p.get(100)
+	b4 := p.get(8193)
+	if cap(b3.data) == 8192 || cap(b4.data) == 8192 {
+		t.Fatalf("buffers were unexpectedly acquired from the reserve: cap=%d,%d", cap(b3.data), cap(b4.data))
+	}
+
+	b1.unref()
+	if b := p.get(4096); b != b1 {
+		t.Fatal("released buffer was not returned to the reserve")
+	} else {
+		b.unref()
+	}
+
+	b2.unref()
+	b3.unref()
+	b4.unref()
+	if n := len(p.reserved); n != 2 {
+		t.Fatalf("wrong number of reserved buffers: want=2 got=%d", n)
+	}
+}
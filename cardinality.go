@@ -0,0 +1,73 @@
+package parquet
+
+import (
+	"math"
+	"math/bits"
+
+	"github.com/parquet-go/parquet-go/bloom/xxhash"
+)
+
+const (
+	// hyperLogLogPrecision is the number of bits of the hashes used to select
+	// registers of the counter, the relative error of the estimates is about
+	// 1.04/sqrt(2^precision), or 0.8%.
+	hyperLogLogPrecision = 14
+	hyperLogLogRegisters = 1 << hyperLogLogPrecision
+)
+
+// hyperLogLog is a probabilistic counter estimating the number of distinct
+// values it observed using a fixed amount of memory.
+type hyperLogLog struct {
+	registers [hyperLogLogRegisters]uint8
+	buffer    []byte
+}
+
+func (h *hyperLogLog) add(v Value) {
+	h.buffer = v.AppendBytes(h.buffer[:0])
+	h.addHash(xxhash.Sum64(h.buffer))
+}
+
+func (h *hyperLogLog) addHash(hash uint64) {
+	i := hash >> (64 - hyperLogLogPrecision)
+	rank := uint8(bits.LeadingZeros64(hash<<hyperLogLogPrecision|1<<(hyperLogLogPrecision-1))) + 1
+	if rank > h.registers[i] {
+		h.registers[i] = rank
+	}
+}
+
+func (h *hyperLogLog) estimate() int64 {
+	const m = float64(hyperLogLogRegisters)
+	const alpha = 0.7213 / (1 + 1.079/m)
+
+	sum, zeros := 0.0, 0
+	for _, r := range h.registers {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+
+	estimate := alpha * m * m / sum
+	if estimate <= 2.5*m && zeros != 0 {
+		// Small cardinalities are better estimated by linear counting.
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return int64(math.Round(estimate))
+}
+
+// estimateDistinctCount extrapolates the number of distinct values of a column
+// from a sample of n values holding d distinct values, f1 of which were seen
+// only once, when the column contains a total of N values.
+//
+// The function uses the Duj1 estimator described by Haas and Stokes in
+// "Estimating the number of classes in a finite population", which accounts
+// for values that were absent from the sample based on the proportion of
+// values seen once.
+func estimateDistinctCount(n, d, f1, N int64) int64 {
+	if n >= N || n == 0 {
+		return d
+	}
+	q := float64(n) / float64(N)
+	estimate := int64(math.Round(float64(n) * float64(d) / (float64(n-f1) + float64(f1)*q)))
+	return min(max(estimate, d), N)
+}
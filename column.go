@@ -96,10 +96,16 @@ func (c *Column) Pages() Pages {
 	if c.file == nil {
 		return emptyPages{}
 	}
-	return c.PagesFrom(c.file.reader)
+	// Column chunks may be stored in other files than the one holding the
+	// metadata, each chunk is read from its own file.
+	return c.pagesFrom((*FileColumnChunk).reader)
 }
 
 func (c *Column) PagesFrom(reader io.ReaderAt) Pages {
+	return c.pagesFrom(func(*FileColumnChunk) io.ReaderAt { return reader })
+}
+
+func (c *Column) pagesFrom(readerOf func(*FileColumnChunk) io.ReaderAt) Pages {
 	if c.index < 0 || c.file == nil {
 		return emptyPages{}
 	}
@@ -107,7 +113,8 @@ func (c *Column) PagesFrom(reader io.ReaderAt) Pages {
 		pages: make([]FilePages, len(c.file.rowGroups)),
 	}
 	for i := range r.pages {
-		r.pages[i].init(c.file.rowGroups[i].(*FileRowGroup).columns[c.index].(*FileColumnChunk), reader)
+		chunk := c.file.rowGroups[i].(*FileRowGroup).columns[c.index].(*FileColumnChunk)
+		r.pages[i].init(chunk, readerOf(chunk))
 	}
 	return r
 }
@@ -211,6 +218,17 @@ func openColumns(file *File, metadata *format.FileMetaData, columnIndexes []form
 		return nil, err
 	}
 
+	// The repetition type of the root is meaningless, but some producers set
+	// it to optional or repeated. The levels of values written by those
+	// producers do not account for it, so the root is always opened as a
+	// required group; a copy of the schema element is made to leave the file
+	// metadata unchanged.
+	if c.schema.RepetitionType != nil && *c.schema.RepetitionType != format.Required {
+		root := *c.schema
+		root.RepetitionType = nil
+		c.schema = &root
+	}
+
 	// Validate that there aren't extra entries in the row group columns,
 	// which would otherwise indicate that there are dangling data pages
 	// in the file.
@@ -395,6 +413,28 @@ func isLeafSchemaElement(element *format.SchemaElement) bool {
 	return element.Type != nil
 }
 
+// decimalTypeOf returns the type of decimal values stored in the physical type
+// of the schema element s, which must be set.
+func decimalTypeOf(s *format.SchemaElement, decimal format.DecimalType) Type {
+	var typ Type
+	switch kind := Kind(*s.Type); kind {
+	case Int32:
+		typ = Int32Type
+	case Int64:
+		typ = Int64Type
+	case FixedLenByteArray:
+		if s.TypeLength == nil {
+			panic("DECIMAL using FIXED_LEN_BYTE_ARRAY must specify a length")
+		}
+		typ = FixedLenByteArrayType(int(*s.TypeLength))
+	case ByteArray:
+		typ = ByteArrayType
+	default:
+		panic("DECIMAL must be of type INT32, INT64, BYTE_ARRAY or FIXED_LEN_BYTE_ARRAY but got " + kind.String())
+	}
+	return &decimalType{decimal: decimal, Type: typ}
+}
+
 func schemaElementTypeOf(s *format.SchemaElement) Type {
 	if lt := s.LogicalType; lt != nil {
 		// A logical type exists, the Type interface implementations in this
@@ -411,26 +451,8 @@ func schemaElementTypeOf(s *format.SchemaElement) Type {
 		case lt.Enum != nil:
 			return (*enumType)(lt.Enum)
 		case lt.Decimal != nil:
-			// A parquet decimal can be one of several different physical types.
-			if t := s.Type; t != nil {
-				var typ Type
-				switch kind := Kind(*s.Type); kind {
-				case Int32:
-					typ = Int32Type
-				case Int64:
-					typ = Int64Type
-				case FixedLenByteArray:
-					if s.TypeLength == nil {
-						panic("DECIMAL using FIXED_LEN_BYTE_ARRAY must specify a length")
-					}
-					typ = FixedLenByteArrayType(int(*s.TypeLength))
-				default:
-					panic("DECIMAL must be of type INT32, INT64, or FIXED_LEN_BYTE_ARRAY but got " + kind.String())
-				}
-				return &decimalType{
-					decimal: *lt.Decimal,
-					Type:    typ,
-				}
+			if s.Type != nil {
+				return decimalTypeOf(s, *lt.Decimal)
 			}
 		case lt.Date != nil:
 			return (*dateType)(lt.Date)
@@ -448,6 +470,8 @@ func schemaElementTypeOf(s *format.SchemaElement) Type {
 			return (*bsonType)(lt.Bson)
 		case lt.UUID != nil:
 			return (*uuidType)(lt.UUID)
+		case lt.Float16 != nil:
+			return (*float16Type)(lt.Float16)
 		}
 	}
 
@@ -467,33 +491,14 @@ func schemaElementTypeOf(s *format.SchemaElement) Type {
 		case deprecated.Enum:
 			return &enumType{}
 		case deprecated.Decimal:
-			if s.Scale != nil && s.Precision != nil {
-				// A parquet decimal can be one of several different physical types.
-				if t := s.Type; t != nil {
-					var typ Type
-					switch kind := Kind(*s.Type); kind {
-					case Int32:
-						typ = Int32Type
-					case Int64:
-						typ = Int64Type
-					case FixedLenByteArray:
-						if s.TypeLength == nil {
-							panic("DECIMAL using FIXED_LEN_BYTE_ARRAY must specify a length")
-						}
-						typ = FixedLenByteArrayType(int(*s.TypeLength))
-					case ByteArray:
-						typ = ByteArrayType
-					default:
-						panic("DECIMAL must be of type INT32, INT64, BYTE_ARRAY or FIXED_LEN_BYTE_ARRAY but got " + kind.String())
-					}
-					return &decimalType{
-						decimal: format.DecimalType{
-							Scale:     *s.Scale,
-							Precision: *s.Precision,
-						},
-						Type: typ,
-					}
+			// The scale of legacy decimals is optional and defaults to zero,
+			// the precision is required.
+			if s.Precision != nil && s.Type != nil {
+				decimal := format.DecimalType{Precision: *s.Precision}
+				if s.Scale != nil {
+					decimal.Scale = *s.Scale
 				}
+				return decimalTypeOf(s, decimal)
 			}
 		case deprecated.Date:
 			return &dateType{}
@@ -526,7 +531,11 @@ func schemaElementTypeOf(s *format.SchemaElement) Type {
 		case deprecated.Bson:
 			return &bsonType{}
 		case deprecated.Interval:
-			// TODO
+			// Columns of other lengths cannot hold INTERVAL values, they are
+			// read as plain fixed length byte arrays.
+			if s.Type != nil && Kind(*s.Type) == FixedLenByteArray && s.TypeLength != nil && *s.TypeLength == intervalLength {
+				return &intervalType{}
+			}
 		}
 	}
 
@@ -581,10 +590,10 @@ func (c *Column) decompress(compressedPageData []byte, uncompressedPageSize int3
 // DecodeDataPageV1 decodes a data page from the header, compressed data, and
 // optional dictionary passed as arguments.
 func (c *Column) DecodeDataPageV1(header DataPageHeaderV1, page []byte, dict Dictionary) (Page, error) {
-	return c.decodeDataPageV1(header, &buffer{data: page}, dict, -1)
+	return c.decodeDataPageV1(header, &buffer{data: page}, dict, -1, nil)
 }
 
-func (c *Column) decodeDataPageV1(header DataPageHeaderV1, page *buffer, dict Dictionary, size int32) (Page, error) {
+func (c *Column) decodeDataPageV1(header DataPageHeaderV1, page *buffer, dict Dictionary, size int32, transform *ColumnTransform) (Page, error) {
 	var pageData = page.data
 	var err error
 
@@ -622,16 +631,16 @@ func (c *Column) decodeDataPageV1(header DataPageHeaderV1, page *buffer, dict Di
 		numValues -= countLevelsNotEqual(definitionLevels.data, c.maxDefinitionLevel)
 	}
 
-	return c.decodeDataPage(header, numValues, repetitionLevels, definitionLevels, page, pageData, dict)
+	return c.decodeDataPage(header, numValues, repetitionLevels, definitionLevels, page, pageData, dict, transform)
 }
 
 // DecodeDataPageV2 decodes a data page from the header, compressed data, and
 // optional dictionary passed as arguments.
 func (c *Column) DecodeDataPageV2(header DataPageHeaderV2, page []byte, dict Dictionary) (Page, error) {
-	return c.decodeDataPageV2(header, &buffer{data: page}, dict, -1)
+	return c.decodeDataPageV2(header, &buffer{data: page}, dict, -1, nil)
 }
 
-func (c *Column) decodeDataPageV2(header DataPageHeaderV2, page *buffer, dict Dictionary, size int32) (Page, error) {
+func (c *Column) decodeDataPageV2(header DataPageHeaderV2, page *buffer, dict Dictionary, size int32, transform *ColumnTransform) (Page, error) {
 	var numValues = int(header.NumValues())
 	var pageData = page.data
 	var err error
@@ -687,10 +696,13 @@ func (c *Column) decodeDataPageV2(header DataPageHeaderV2, page *buffer, dict Di
 	}
 
 	numValues -= int(header.NumNulls())
-	return c.decodeDataPage(header, numValues, repetitionLevels, definitionLevels, page, pageData, dict)
+	return c.decodeDataPage(header, numValues, repetitionLevels, definitionLevels, page, pageData, dict, transform)
 }
 
-func (c *Column) decodeDataPage(header DataPageHeader, numValues int, repetitionLevels, definitionLevels, page *buffer, data []byte, dict Dictionary) (Page, error) {
+// decodeDataPage decodes the values of a data page, reversing the column
+// transform of the values of pages which are not dictionary-encoded when
+// transform is not nil.
+func (c *Column) decodeDataPage(header DataPageHeader, numValues int, repetitionLevels, definitionLevels, page *buffer, data []byte, dict Dictionary, transform *ColumnTransform) (Page, error) {
 	pageEncoding := LookupEncoding(header.Encoding())
 	pageType := c.Type()
 
@@ -728,6 +740,11 @@ func (c *Column) decodeDataPage(header DataPageHeader, numValues int, repetition
 	if err != nil {
 		return nil, err
 	}
+	if transform != nil && !isDictionaryEncoding(pageEncoding) {
+		if values, err = transformValues(pageType, c.Index(), numValues, values, transform.Inverse); err != nil {
+			return nil, fmt.Errorf("reversing column transform: %w", err)
+		}
+	}
 
 	newPage := pageType.NewPage(c.Index(), numValues, values)
 	switch {
@@ -3,9 +3,11 @@ package parquet
 import (
 	"bytes"
 	"cmp"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"math/bits"
 	"reflect"
 	"slices"
@@ -13,11 +15,13 @@ import (
 	"time"
 	"unsafe"
 
+	"github.com/google/uuid"
 	"github.com/parquet-go/parquet-go/deprecated"
 	"github.com/parquet-go/parquet-go/encoding/plain"
 	"github.com/parquet-go/parquet-go/internal/bitpack"
 	"github.com/parquet-go/parquet-go/internal/unsafecast"
 	"github.com/parquet-go/parquet-go/sparse"
+	"golang.org/x/sys/cpu"
 )
 
 // ColumnBuffer is an interface representing columns of a row group.
@@ -2004,6 +2008,42 @@ func writeRowsFuncOf(t reflect.Type, schema *Schema, path columnPath) writeRowsF
 		return writeRowsFuncOfJSON(t, schema, path)
 	}
 
+	if c := lookupConverter(t); c != nil {
+		return writeRowsFuncOfConverter(t, schema, path, c)
+	}
+
+	switch t.Kind() {
+	case reflect.Uint32, reflect.Uint64:
+		if leaf, exists := schema.Lookup(path...); exists {
+			if lt := leaf.Node.Type().LogicalType(); lt != nil && lt.Decimal != nil {
+				return writeRowsFuncOfUnsignedDecimal(t, schema, path, int(lt.Decimal.Precision))
+			}
+		}
+	case reflect.Float32, reflect.Float64:
+		if leaf, exists := schema.Lookup(path...); exists {
+			switch typ := leaf.Node.Type().(type) {
+			case *floatDecimalType:
+				return writeRowsFuncOfFloatDecimal(t, schema, path, typ)
+			case *float16Type:
+				if t.Kind() == reflect.Float32 {
+					return writeRowsFuncOfFloat16(schema, path)
+				}
+			}
+		}
+	case reflect.Int64:
+		if leaf, exists := schema.Lookup(path...); exists {
+			if typ, ok := leaf.Node.Type().(goUnitScaler); ok {
+				return writeRowsFuncOfScaledInt64(t, schema, path, typ)
+			}
+		}
+	case reflect.String:
+		if leaf, exists := schema.Lookup(path...); exists {
+			if lt := leaf.Node.Type().LogicalType(); lt != nil && lt.UUID != nil {
+				return writeRowsFuncOfUUIDString(schema, path)
+			}
+		}
+	}
+
 	switch t {
 	case reflect.TypeOf(deprecated.Int96{}):
 		return writeRowsFuncOfRequired(t, schema, path)
@@ -2032,9 +2072,7 @@ func writeRowsFuncOf(t reflect.Type, schema *Schema, path columnPath) writeRowsF
 		}
 
 	case reflect.Array:
-		if t.Elem().Kind() == reflect.Uint8 {
-			return writeRowsFuncOfArray(t, schema, path)
-		}
+		return writeRowsFuncOfArray(t, schema, path)
 
 	case reflect.Pointer:
 		return writeRowsFuncOfPointer(t, schema, path)
@@ -2055,12 +2093,38 @@ func writeRowsFuncOfRequired(t reflect.Type, schema *Schema, path columnPath) wr
 	if columnIndex < 0 {
 		panic("parquet: column not found: " + path.String())
 	}
+	if !canWriteGoTypeTo(t, column.node.Type().Kind()) {
+		panic(fmt.Sprintf("parquet: cannot write values of Go type %s to column %s of type %s", t, path, column.node.Type()))
+	}
 	return func(columns []ColumnBuffer, rows sparse.Array, levels columnLevels) error {
 		columns[columnIndex].writeValues(rows, levels)
 		return nil
 	}
 }
 
+// canWriteGoTypeTo returns true if the memory of Go values of type t can be read
+// by column buffers of the given kind, which is the case for numeric types of
+// the size of the kind; other types are written to byte array columns.
+func canWriteGoTypeTo(t reflect.Type, kind Kind) bool {
+	numeric := false
+	switch t.Kind() {
+	case reflect.Int, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		numeric = true
+	}
+	switch kind {
+	case Boolean:
+		return t.Kind() == reflect.Bool
+	case Int32, Float:
+		return numeric && t.Size() == 4
+	case Int64, Double:
+		return numeric && t.Size() == 8
+	default:
+		return !numeric && t.Kind() != reflect.Bool
+	}
+}
+
 func writeRowsFuncOfOptional(t reflect.Type, schema *Schema, path columnPath, writeRows writeRowsFunc) writeRowsFunc {
 	if t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8 { // assume nested list; []byte is scalar
 		return func(columns []ColumnBuffer, rows sparse.Array, levels columnLevels) error {
@@ -2171,12 +2235,52 @@ func writeRowsFuncOfOptional(t reflect.Type, schema *Schema, path columnPath, wr
 
 func writeRowsFuncOfArray(t reflect.Type, schema *Schema, path columnPath) writeRowsFunc {
 	column := schema.lazyLoadState().mapping.lookup(path)
-	arrayLen := t.Len()
+	if column.node != nil && column.node.Type().Kind() != FixedLenByteArray {
+		return writeRowsFuncOfRepeatedArray(t, schema, path)
+	}
+	arrayLen := int(t.Size())
 	columnLen := column.node.Type().Length()
 	if arrayLen != columnLen {
 		panic(fmt.Sprintf("cannot convert Go values of type "+typeNameOf(t)+" to FIXED_LEN_BYTE_ARRAY(%d)", columnLen))
 	}
-	return writeRowsFuncOfRequired(t, schema, path)
+	writeRows := writeRowsFuncOfRequired(t, schema, path)
+	elemSize := int(t.Elem().Size())
+	if !cpu.IsBigEndian || elemSize == 1 {
+		return writeRows
+	}
+
+	// The elements of packed arrays are stored in little-endian order, on
+	// big-endian CPUs the arrays are copied to swap their bytes.
+	return func(columns []ColumnBuffer, rows sparse.Array, levels columnLevels) error {
+		buffer := make([]byte, rows.Len()*arrayLen)
+		for i := range rows.Len() {
+			copy(buffer[i*arrayLen:], unsafe.Slice((*byte)(rows.Index(i)), arrayLen))
+		}
+		swapPackedArray(buffer, elemSize)
+		return writeRows(columns, makeArray(unsafe.Pointer(unsafe.SliceData(buffer)), rows.Len(), uintptr(arrayLen)), levels)
+	}
+}
+
+// writeRowsFuncOfRepeatedArray writes arrays to repeated columns by presenting
+// them as slices of the same length.
+func writeRowsFuncOfRepeatedArray(t reflect.Type, schema *Schema, path columnPath) writeRowsFunc {
+	arrayLen := t.Len()
+	writeRows := writeRowsFuncOfSlice(reflect.SliceOf(t.Elem()), schema, path)
+
+	return func(columns []ColumnBuffer, rows sparse.Array, levels columnLevels) error {
+		if rows.Len() == 0 {
+			return writeRows(columns, rows, levels)
+		}
+
+		for i := range rows.Len() {
+			s := sliceHeader{base: rows.Index(i), len: arrayLen, cap: arrayLen}
+			if err := writeRows(columns, makeArray(unsafe.Pointer(&s), 1, unsafe.Sizeof(s)), levels); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
 }
 
 func writeRowsFuncOfPointer(t reflect.Type, schema *Schema, path columnPath) writeRowsFunc {
@@ -2209,6 +2313,29 @@ func writeRowsFuncOfPointer(t reflect.Type, schema *Schema, path columnPath) wri
 		}
 	}
 
+	if node := lookupColumnPath(schema, path); node != nil && node.Required() {
+		// The pointer maps to a required column, which happens when the schema
+		// was created with the MakePointersRequired option; the definition
+		// level does not change and nil pointers cannot be represented.
+		return func(columns []ColumnBuffer, rows sparse.Array, levels columnLevels) error {
+			if rows.Len() == 0 {
+				return writeRows(columns, rows, levels)
+			}
+
+			for i := range rows.Len() {
+				p := *(*unsafe.Pointer)(rows.Index(i))
+				if p == nil {
+					return fmt.Errorf("cannot write nil pointer of type %s to required parquet column %q", t, path)
+				}
+				if err := writeRows(columns, makeArray(p, 1, elemSize), levels); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}
+	}
+
 	return func(columns []ColumnBuffer, rows sparse.Array, levels columnLevels) error {
 		if rows.Len() == 0 {
 			return writeRows(columns, rows, levels)
@@ -2238,10 +2365,15 @@ func writeRowsFuncOfSlice(t reflect.Type, schema *Schema, path columnPath) write
 
 	// When the element is a pointer type, the writeRows function will be an
 	// instance returned by writeRowsFuncOfPointer, which handles incrementing
-	// the definition level if the pointer value is not nil.
+	// the definition level if the pointer value is not nil. If the elements
+	// are optional, as in a list of optional values, the pointer accounts for
+	// the optional level, and the repeated level must still be incremented
+	// when the slice has elements.
 	definitionLevelIncrement := byte(0)
 	if elemType.Kind() != reflect.Ptr {
 		definitionLevelIncrement = 1
+	} else if node := lookupColumnPath(schema, path); node != nil && node.Optional() {
+		definitionLevelIncrement = 1
 	}
 
 	return func(columns []ColumnBuffer, rows sparse.Array, levels columnLevels) error {
@@ -2301,6 +2433,13 @@ func writeRowsFuncOfStruct(t reflect.Type, schema *Schema, path columnPath) writ
 			}
 		})
 
+		// The repetition of the column in the schema takes precedence over the
+		// struct tags, which differ when writing with a schema that overrides
+		// the one generated from the Go type.
+		if node := lookupColumnPath(schema, path.append(f.Name)); node != nil {
+			optional = node.Optional()
+		}
+
 		writeRows := writeRowsFuncOf(f.Type, schema, columnPath)
 		if optional {
 			kind := f.Type.Kind()
@@ -2445,6 +2584,14 @@ func writeRowsFuncOfMap(t reflect.Type, schema *Schema, path columnPath) writeRo
 		return nil
 	}
 
+	sorted := node != nil && isSortedMap(node)
+	compareKeys := compareFuncOf(keyType)
+	if sorted {
+		compareKeys = sortedMapKeyCompareFuncOf(keyType, findByPath(schema, keyPath))
+	} else if compareKeys == nil && node != nil && isOrderedMap(node) {
+		compareKeys = orderedMapKeyCompareFuncOf(keyType, findByPath(schema, keyPath))
+	}
+
 	return func(columns []ColumnBuffer, rows sparse.Array, levels columnLevels) error {
 		if rows.Len() == 0 {
 			return writeKeyValues(columns, rows, rows, levels)
@@ -2453,7 +2600,6 @@ func writeRowsFuncOfMap(t reflect.Type, schema *Schema, path columnPath) writeRo
 		levels.repetitionDepth++
 		mapKey := reflect.Value{}
 		mapValue := reflect.Value{}
-		compareKeys := compareFuncOf(keyType)
 		if compareKeys == nil {
 			mapKey = reflect.New(keyType).Elem()
 			mapValue = reflect.New(valueType).Elem()
@@ -2477,6 +2623,14 @@ func writeRowsFuncOfMap(t reflect.Type, schema *Schema, path columnPath) writeRo
 				keys := m.MapKeys()
 				slices.SortFunc(keys, compareKeys)
 
+				if sorted {
+					for j := 1; j < len(keys); j++ {
+						if compareKeys(keys[j-1], keys[j]) == 0 {
+							return fmt.Errorf("cannot write map with duplicate key %v to sorted map column %q", keys[j], path)
+						}
+					}
+				}
+
 				for _, key := range keys {
 					value := m.MapIndex(key)
 
@@ -2542,6 +2696,20 @@ func writeRowsFuncOfJSON(t reflect.Type, schema *Schema, path columnPath) writeR
 		if t.Elem().Kind() == reflect.Uint8 {
 			return writeRowsFuncOfRequired(t, schema, path)
 		}
+		// Slices mapped to repeated columns (e.g. []json.RawMessage) hold one
+		// document per element.
+		if node := lookupColumnPath(schema, path); node != nil && node.Repeated() {
+			return writeRowsFuncOfSlice(t, schema, path)
+		}
+	case reflect.Pointer:
+		// Pointers to strings or byte arrays tagged with "json" map to optional
+		// columns, nil pointers are written as null values.
+		switch elem := t.Elem(); {
+		case elem.Kind() == reflect.String, elem.Kind() == reflect.Slice && elem.Elem().Kind() == reflect.Uint8:
+			if node := lookupColumnPath(schema, path); node != nil && node.Optional() {
+				return writeRowsFuncOfPointer(t, schema, path)
+			}
+		}
 	}
 
 	// Otherwise handle with a json.Marshal
@@ -2571,6 +2739,183 @@ func writeRowsFuncOfJSON(t reflect.Type, schema *Schema, path columnPath) writeR
 	}
 }
 
+// writeRowsFuncOfUUIDString returns a writeRowsFunc parsing the textual
+// representation of UUIDs held in Go strings to write them to UUID columns.
+func writeRowsFuncOfUUIDString(schema *Schema, path columnPath) writeRowsFunc {
+	writeRows := writeRowsFuncOfRequired(reflect.TypeOf(uuid.UUID{}), schema, path)
+
+	return func(columns []ColumnBuffer, rows sparse.Array, levels columnLevels) error {
+		if rows.Len() == 0 {
+			return writeRows(columns, rows, levels)
+		}
+		for i := range rows.Len() {
+			s := *(*string)(rows.Index(i))
+			u, err := uuid.Parse(s)
+			if err != nil {
+				return fmt.Errorf("cannot write invalid UUID %q to column %q: %w", s, path, err)
+			}
+			if err := writeRows(columns, makeArray(unsafe.Pointer(&u), 1, unsafe.Sizeof(u)), levels); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func writeRowsFuncOfUnsignedDecimal(t reflect.Type, schema *Schema, path columnPath, precision int) writeRowsFunc {
+	column, _ := schema.Lookup(path...)
+	maxValue := decimalMaxUnsignedValue(precision)
+
+	if t.Kind() == reflect.Uint32 && column.Node.Type().Kind() == Int64 {
+		// Decimals with a precision greater than 9 are stored in INT64 columns,
+		// the values are widened since the column buffer reads 64 bits values;
+		// all uint32 values fit in the precision of these decimals.
+		writeRows := writeRowsFuncOfRequired(reflect.TypeOf(uint64(0)), schema, path)
+		var values []uint64
+		return func(columns []ColumnBuffer, rows sparse.Array, levels columnLevels) error {
+			values = values[:0]
+			for i := range rows.Len() {
+				values = append(values, uint64(*(*uint32)(rows.Index(i))))
+			}
+			return writeRows(columns, makeArrayOf(values), levels)
+		}
+	}
+
+	if t.Kind() == reflect.Uint64 && column.Node.Type().Kind() == Int32 {
+		// Decimals with a precision up to 9 are stored in INT32 columns, the
+		// values are narrowed after checking that they fit in the precision.
+		writeRows := writeRowsFuncOfRequired(reflect.TypeOf(int32(0)), schema, path)
+		var values []int32
+		return func(columns []ColumnBuffer, rows sparse.Array, levels columnLevels) error {
+			values = values[:0]
+			for i := range rows.Len() {
+				value := *(*uint64)(rows.Index(i))
+				if value > maxValue {
+					return fmt.Errorf("value %d of column %s exceeds the precision of the decimal type", value, path)
+				}
+				values = append(values, int32(value))
+			}
+			return writeRows(columns, makeArrayOf(values), levels)
+		}
+	}
+
+	writeRows := writeRowsFuncOfRequired(t, schema, path)
+
+	return func(columns []ColumnBuffer, rows sparse.Array, levels columnLevels) error {
+		for i := range rows.Len() {
+			var value uint64
+			if t.Kind() == reflect.Uint32 {
+				value = uint64(*(*uint32)(rows.Index(i)))
+			} else {
+				value = *(*uint64)(rows.Index(i))
+			}
+			if value > maxValue {
+				return fmt.Errorf("value %d of column %s exceeds the precision of the decimal type", value, path)
+			}
+		}
+		return writeRows(columns, rows, levels)
+	}
+}
+
+func writeRowsFuncOfFloatDecimal(t reflect.Type, schema *Schema, path columnPath, typ *floatDecimalType) writeRowsFunc {
+	bitSize := t.Bits()
+
+	if typ.Type.Kind() == Int32 {
+		writeRows := writeRowsFuncOfRequired(reflect.TypeOf(int32(0)), schema, path)
+		var values []int32
+		return func(columns []ColumnBuffer, rows sparse.Array, levels columnLevels) error {
+			values = values[:0]
+			for i := range rows.Len() {
+				v, err := typ.fromFloat(floatAt(rows, i, bitSize), bitSize)
+				if err != nil {
+					return fmt.Errorf("writing value of column %s: %w", path, err)
+				}
+				values = append(values, v.int32())
+			}
+			return writeRows(columns, makeArrayOf(values), levels)
+		}
+	}
+
+	writeRows := writeRowsFuncOfRequired(reflect.TypeOf(int64(0)), schema, path)
+	var values []int64
+	return func(columns []ColumnBuffer, rows sparse.Array, levels columnLevels) error {
+		values = values[:0]
+		for i := range rows.Len() {
+			v, err := typ.fromFloat(floatAt(rows, i, bitSize), bitSize)
+			if err != nil {
+				return fmt.Errorf("writing value of column %s: %w", path, err)
+			}
+			values = append(values, v.int64())
+		}
+		return writeRows(columns, makeArrayOf(values), levels)
+	}
+}
+
+func writeRowsFuncOfFloat16(schema *Schema, path columnPath) writeRowsFunc {
+	writeRows := writeRowsFuncOfRequired(reflect.TypeOf([2]byte{}), schema, path)
+	var values [][2]byte
+
+	return func(columns []ColumnBuffer, rows sparse.Array, levels columnLevels) error {
+		if rows.Len() == 0 {
+			return writeRows(columns, rows, levels)
+		}
+		array := rows.Float32Array()
+		values = values[:0]
+		for i := range array.Len() {
+			var b [2]byte
+			binary.LittleEndian.PutUint16(b[:], float32ToFloat16(array.Index(i)))
+			values = append(values, b)
+		}
+		return writeRows(columns, makeArrayOf(values), levels)
+	}
+}
+
+func floatAt(rows sparse.Array, i, bitSize int) float64 {
+	if bitSize == 32 {
+		return float64(*(*float32)(rows.Index(i)))
+	}
+	return *(*float64)(rows.Index(i))
+}
+
+func writeRowsFuncOfScaledInt64(t reflect.Type, schema *Schema, path columnPath, typ goUnitScaler) writeRowsFunc {
+	if leaf, _ := schema.Lookup(path...); leaf.Node.Type().Kind() == Int32 {
+		return writeRowsFuncOfScaledInt32(schema, path, typ)
+	}
+	writeRows := writeRowsFuncOfRequired(t, schema, path)
+	var values []int64
+
+	return func(columns []ColumnBuffer, rows sparse.Array, levels columnLevels) error {
+		if rows.Len() == 0 {
+			return writeRows(columns, rows, levels)
+		}
+		array := rows.Int64Array()
+		values = values[:0]
+		for i := range array.Len() {
+			values = append(values, typ.fromGoUnit(array.Index(i)))
+		}
+		return writeRows(columns, makeArrayOf(values), levels)
+	}
+}
+
+// writeRowsFuncOfScaledInt32 is like writeRowsFuncOfScaledInt64 for columns
+// holding INT32 values, such as TIME columns in milliseconds.
+func writeRowsFuncOfScaledInt32(schema *Schema, path columnPath, typ goUnitScaler) writeRowsFunc {
+	writeRows := writeRowsFuncOfRequired(reflect.TypeOf(int32(0)), schema, path)
+	var values []int32
+
+	return func(columns []ColumnBuffer, rows sparse.Array, levels columnLevels) error {
+		if rows.Len() == 0 {
+			return writeRows(columns, rows, levels)
+		}
+		array := rows.Int64Array()
+		values = values[:0]
+		for i := range array.Len() {
+			values = append(values, int32(typ.fromGoUnit(array.Index(i))))
+		}
+		return writeRows(columns, makeArrayOf(values), levels)
+	}
+}
+
 func writeRowsFuncOfTime(_ reflect.Type, schema *Schema, path columnPath) writeRowsFunc {
 	t := reflect.TypeOf(int64(0))
 	elemSize := uintptr(t.Size())
@@ -2583,6 +2928,8 @@ func writeRowsFuncOfTime(_ reflect.Type, schema *Schema, path columnPath) writeR
 		unit = lt.Timestamp.Unit
 	}
 
+	epochType, hasEpoch := col.Node.Type().(*epochTimestampType)
+
 	// Check if the column is optional
 	isOptional := col.Node.Optional()
 
@@ -2618,6 +2965,8 @@ func writeRowsFuncOfTime(_ reflect.Type, schema *Schema, path columnPath) writeR
 
 			var val int64
 			switch {
+			case hasEpoch:
+				val = epochType.unitsSinceEpoch(t)
 			case unit.Millis != nil:
 				val = t.UnixMilli()
 			case unit.Micros != nil:
@@ -2635,3 +2984,84 @@ func writeRowsFuncOfTime(_ reflect.Type, schema *Schema, path columnPath) writeR
 		return nil
 	}
 }
+
+// canonicalFloatColumnBuffer wraps the column buffers of FLOAT and DOUBLE
+// columns to normalize the values written to them: negative zeros are replaced
+// by positive zeros, and all NaN values are replaced by the same quiet NaN.
+//
+// This is used to implement the CanonicalizeFloats writer option.
+type canonicalFloatColumnBuffer struct {
+	ColumnBuffer
+	float32s []float32
+	float64s []float64
+	values   []Value
+}
+
+func newCanonicalFloatColumnBuffer(base ColumnBuffer) *canonicalFloatColumnBuffer {
+	return &canonicalFloatColumnBuffer{ColumnBuffer: base}
+}
+
+func (col *canonicalFloatColumnBuffer) Clone() ColumnBuffer {
+	return newCanonicalFloatColumnBuffer(col.ColumnBuffer.Clone())
+}
+
+func (col *canonicalFloatColumnBuffer) WriteValues(values []Value) (int, error) {
+	col.values = append(col.values[:0], values...)
+	for i, v := range col.values {
+		switch v.Kind() {
+		case Float:
+			col.values[i].u64 = uint64(math.Float32bits(canonicalFloat32(v.Float())))
+		case Double:
+			col.values[i].u64 = math.Float64bits(canonicalFloat64(v.Double()))
+		}
+	}
+	return col.ColumnBuffer.WriteValues(col.values)
+}
+
+func (col *canonicalFloatColumnBuffer) writeValues(rows sparse.Array, levels columnLevels) {
+	switch col.Type().Kind() {
+	case Float:
+		col.float32s = slices.Grow(col.float32s[:0], rows.Len())[:rows.Len()]
+		sparse.GatherFloat32(col.float32s, rows.Float32Array())
+		for i, f := range col.float32s {
+			col.float32s[i] = canonicalFloat32(f)
+		}
+		rows = sparse.MakeFloat32Array(col.float32s).UnsafeArray()
+	case Double:
+		col.float64s = slices.Grow(col.float64s[:0], rows.Len())[:rows.Len()]
+		sparse.GatherFloat64(col.float64s, rows.Float64Array())
+		for i, f := range col.float64s {
+			col.float64s[i] = canonicalFloat64(f)
+		}
+		rows = sparse.MakeFloat64Array(col.float64s).UnsafeArray()
+	}
+	col.ColumnBuffer.writeValues(rows, levels)
+}
+
+// Bit patterns of the quiet NaN values that all NaN values are replaced with.
+const (
+	canonicalNaN32 = 0x7FC00000
+	canonicalNaN64 = 0x7FF8000000000000
+)
+
+func canonicalFloat32(f float32) float32 {
+	switch {
+	case f == 0:
+		return 0
+	case f != f:
+		return math.Float32frombits(canonicalNaN32)
+	default:
+		return f
+	}
+}
+
+func canonicalFloat64(f float64) float64 {
+	switch {
+	case f == 0:
+		return 0
+	case f != f:
+		return math.Float64frombits(canonicalNaN64)
+	default:
+		return f
+	}
+}
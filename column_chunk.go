@@ -2,7 +2,11 @@ package parquet
 
 import (
 	"errors"
+	"fmt"
 	"io"
+	"iter"
+
+	"github.com/parquet-go/parquet-go/sparse"
 )
 
 var (
@@ -77,6 +81,15 @@ type ColumnChunkValueReader interface {
 
 // NewColumnChunkValueReader creates a new ColumnChunkValueReader for the given
 // column chunk.
+//
+// The returned reader also implements BooleanReader, Int32Reader, Int64Reader,
+// FloatReader, and DoubleReader, which decode the values of the column chunk
+// directly into slices of Go values instead of slices of Value, avoiding the
+// intermediary representation for numeric workloads. These methods return an
+// error wrapping ErrInvalidConversion if the column chunk has a different type,
+// and an error if they encounter null values, since null values have no
+// representation in the slices; they are intended to read columns of required
+// values, or optional columns which do not have nulls.
 func NewColumnChunkValueReader(column ColumnChunk) ColumnChunkValueReader {
 	return &columnChunkValueReader{pages: column.Pages(), release: Release}
 }
@@ -86,6 +99,9 @@ type columnChunkValueReader struct {
 	page    Page
 	values  ValueReader
 	release func(Page)
+	// Buffer used to read the values of pages which do not expose their
+	// values in typed slices.
+	buffer []Value
 }
 
 func (r *columnChunkValueReader) clear() {
@@ -123,12 +139,9 @@ func (r *columnChunkValueReader) ReadValues(values []Value) (int, error) {
 
 	for {
 		if r.values == nil {
-			p, err := r.pages.ReadPage()
-			if err != nil {
+			if err := r.readPage(); err != nil {
 				return 0, err
 			}
-			r.page = p
-			r.values = p.Values()
 		}
 
 		n, err := r.values.ReadValues(values)
@@ -145,6 +158,126 @@ func (r *columnChunkValueReader) ReadValues(values []Value) (int, error) {
 	}
 }
 
+func (r *columnChunkValueReader) readPage() error {
+	p, err := r.pages.ReadPage()
+	if err != nil {
+		return err
+	}
+	r.page = p
+	r.values = p.Values()
+	return nil
+}
+
+func (r *columnChunkValueReader) ReadBooleans(values []bool) (int, error) {
+	return readColumnChunkValues(r, Boolean, values, func(v ValueReader, values []bool) (int, error) {
+		if typed, ok := v.(BooleanReader); ok {
+			return typed.ReadBooleans(values)
+		}
+		return readValuesInto(r, v, values, Value.Boolean)
+	})
+}
+
+func (r *columnChunkValueReader) ReadInt32s(values []int32) (int, error) {
+	return readColumnChunkValues(r, Int32, values, func(v ValueReader, values []int32) (int, error) {
+		if typed, ok := v.(Int32Reader); ok {
+			return typed.ReadInt32s(values)
+		}
+		if indexed, ok := v.(*indexedPageValues); ok {
+			return indexed.lookup(sparse.MakeInt32Array(values).UnsafeArray())
+		}
+		return readValuesInto(r, v, values, Value.Int32)
+	})
+}
+
+func (r *columnChunkValueReader) ReadInt64s(values []int64) (int, error) {
+	return readColumnChunkValues(r, Int64, values, func(v ValueReader, values []int64) (int, error) {
+		if typed, ok := v.(Int64Reader); ok {
+			return typed.ReadInt64s(values)
+		}
+		if indexed, ok := v.(*indexedPageValues); ok {
+			return indexed.lookup(sparse.MakeInt64Array(values).UnsafeArray())
+		}
+		return readValuesInto(r, v, values, Value.Int64)
+	})
+}
+
+func (r *columnChunkValueReader) ReadFloats(values []float32) (int, error) {
+	return readColumnChunkValues(r, Float, values, func(v ValueReader, values []float32) (int, error) {
+		if typed, ok := v.(FloatReader); ok {
+			return typed.ReadFloats(values)
+		}
+		if indexed, ok := v.(*indexedPageValues); ok {
+			return indexed.lookup(sparse.MakeFloat32Array(values).UnsafeArray())
+		}
+		return readValuesInto(r, v, values, Value.Float)
+	})
+}
+
+func (r *columnChunkValueReader) ReadDoubles(values []float64) (int, error) {
+	return readColumnChunkValues(r, Double, values, func(v ValueReader, values []float64) (int, error) {
+		if typed, ok := v.(DoubleReader); ok {
+			return typed.ReadDoubles(values)
+		}
+		if indexed, ok := v.(*indexedPageValues); ok {
+			return indexed.lookup(sparse.MakeFloat64Array(values).UnsafeArray())
+		}
+		return readValuesInto(r, v, values, Value.Double)
+	})
+}
+
+// readValuesInto reads values from v and converts them with valueOf, used for
+// pages which do not expose typed readers (e.g. optional pages without nulls).
+func readValuesInto[T any](r *columnChunkValueReader, v ValueReader, values []T, valueOf func(Value) T) (int, error) {
+	if len(r.buffer) < len(values) {
+		r.buffer = make([]Value, max(len(values), defaultValueBufferSize))
+	}
+	n, err := v.ReadValues(r.buffer[:len(values)])
+	for i, value := range r.buffer[:n] {
+		values[i] = valueOf(value)
+	}
+	clearValues(r.buffer[:n])
+	return n, err
+}
+
+// readColumnChunkValues reads the values of the column chunk into a slice of Go
+// values, loading the pages of the column chunk and verifying that they hold
+// values of the given kind and no null values.
+func readColumnChunkValues[T any](r *columnChunkValueReader, kind Kind, values []T, read func(ValueReader, []T) (int, error)) (int, error) {
+	if r.pages == nil {
+		return 0, io.EOF
+	}
+	if len(values) == 0 {
+		return 0, nil
+	}
+
+	for {
+		if r.values == nil {
+			if err := r.readPage(); err != nil {
+				return 0, err
+			}
+		}
+
+		if pageKind := r.page.Type().Kind(); pageKind != kind {
+			return 0, fmt.Errorf("cannot read %s values from column %d of type %s: %w", kind, r.page.Column(), pageKind, ErrInvalidConversion)
+		}
+		if r.page.NumNulls() > 0 {
+			return 0, fmt.Errorf("cannot read %s values from column %d which has null values", kind, r.page.Column())
+		}
+
+		n, err := read(r.values, values)
+		if n > 0 {
+			return n, nil
+		}
+		if err == nil {
+			return 0, io.ErrNoProgress
+		}
+		if err != io.EOF {
+			return 0, err
+		}
+		r.clear()
+	}
+}
+
 func (r *columnChunkValueReader) SeekToRow(rowIndex int64) error {
 	if r.pages == nil {
 		return io.ErrClosedPipe
@@ -156,6 +289,60 @@ func (r *columnChunkValueReader) SeekToRow(rowIndex int64) error {
 	return nil
 }
 
+// ColumnChunkRowValues returns an iterator over the values of the row at
+// rowIndex in the column chunk.
+//
+// The function is intended to be used on repeated columns where rows may hold
+// very long lists of values; the values are read incrementally from the pages
+// of the column chunk instead of being materialized all at once, which bounds
+// the memory footprint to a small buffer of values.
+//
+// Rows with null or empty lists produce a single null value with a definition
+// level lower than the maximum definition level of the column.
+//
+// The values are only valid until the next iteration, programs that need to
+// retain them must use Value.Clone.
+func ColumnChunkRowValues(column ColumnChunk, rowIndex int64) iter.Seq2[Value, error] {
+	return func(yield func(Value, error) bool) {
+		r := NewColumnChunkValueReader(column)
+		defer r.Close()
+
+		if err := r.SeekToRow(rowIndex); err != nil {
+			yield(Value{}, fmt.Errorf("seeking to row %d of column %d: %w", rowIndex, column.Column(), err))
+			return
+		}
+
+		values := make([]Value, defaultValueBufferSize)
+		found := false
+
+		for {
+			n, err := r.ReadValues(values)
+
+			for _, v := range values[:n] {
+				if v.repetitionLevel == 0 {
+					if found {
+						return
+					}
+					found = true
+				}
+				if !yield(v, nil) {
+					return
+				}
+			}
+
+			if err != nil {
+				switch {
+				case err != io.EOF:
+					yield(Value{}, err)
+				case !found:
+					yield(Value{}, fmt.Errorf("reading row %d of column %d: %w", rowIndex, column.Column(), ErrSeekOutOfRange))
+				}
+				return
+			}
+		}
+	}
+}
+
 type pageAndValueWriter interface {
 	PageWriter
 	ValueWriter
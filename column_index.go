@@ -582,6 +582,29 @@ func (i *fixedLenByteArrayColumnIndexer) ColumnIndex() format.ColumnIndex {
 	)
 }
 
+// float16ColumnIndexer is the indexer of FLOAT16 columns, the bounds of pages
+// are not truncated and their order is the order of the floating point values.
+type float16ColumnIndexer struct {
+	fixedLenByteArrayColumnIndexer
+}
+
+func newFloat16ColumnIndexer() *float16ColumnIndexer {
+	return &float16ColumnIndexer{
+		fixedLenByteArrayColumnIndexer: fixedLenByteArrayColumnIndexer{size: 2},
+	}
+}
+
+func (i *float16ColumnIndexer) ColumnIndex() format.ColumnIndex {
+	minValues := splitFixedLenByteArrays(i.minValues, i.size)
+	maxValues := splitFixedLenByteArrays(i.maxValues, i.size)
+	return i.columnIndex(
+		minValues,
+		maxValues,
+		orderOfFloat16(minValues),
+		orderOfFloat16(maxValues),
+	)
+}
+
 type uint32ColumnIndexer struct {
 	baseColumnIndexer
 	minValues []uint32
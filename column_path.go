@@ -19,6 +19,10 @@ func (path columnPath) less(other columnPath) bool {
 	return stringsAreOrdered(path, other)
 }
 
+func (path columnPath) startsWith(prefix columnPath) bool {
+	return len(path) >= len(prefix) && stringsAreEqual(path[:len(prefix)], prefix)
+}
+
 func (path columnPath) String() string {
 	return strings.Join(path, ".")
 }
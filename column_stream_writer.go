@@ -0,0 +1,224 @@
+package parquet
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ColumnStreamWriter writes parquet files from values pushed to each column
+// independently, instead of rows.
+//
+// This lower level API is intended for applications like query engines which
+// produce the values of each column separately, and possibly at different
+// rates. The writer buffers the values of columns that are ahead of the others,
+// and assembles rows as soon as all the columns have received their values.
+//
+// The caller has explicit control over the structure of rows through the
+// repetition and definition levels of the values it pushes, which follow the
+// same rules as the values of a Row:
+//
+//	// Push a row with two elements to a repeated column, then a null value
+//	// and a non-null value to an optional column.
+//	w.Push("tags", parquet.ByteArrayValue(a).Level(0, 1, 0), parquet.ByteArrayValue(b).Level(1, 1, 0))
+//	w.Push("name", parquet.NullValue().Level(0, 0, 0))
+//	w.Push("name", parquet.ByteArrayValue(c).Level(0, 1, 0))
+//
+// The column index of values is ignored, it is set by the writer from the name
+// of the column that values are pushed to.
+//
+// ColumnStreamWriter values are not safe to use concurrently from multiple
+// goroutines.
+type ColumnStreamWriter struct {
+	writer  *Writer
+	columns map[string]*columnStream
+	streams []columnStream
+	rows    []Row
+}
+
+type columnStream struct {
+	leaf   LeafColumn
+	values []Value
+	// Offsets of the first value of each row buffered in values.
+	rowOffsets []int
+}
+
+// NewColumnStreamWriter constructs a writer producing a parquet file with the
+// given schema to output.
+//
+// The function panics if the options carry an invalid configuration.
+func NewColumnStreamWriter(output io.Writer, schema *Schema, options ...WriterOption) *ColumnStreamWriter {
+	leaves := schema.Columns()
+	w := &ColumnStreamWriter{
+		writer:  NewWriter(output, append([]WriterOption{schema}, options...)...),
+		columns: make(map[string]*columnStream, len(leaves)),
+		streams: make([]columnStream, len(leaves)),
+	}
+	for i, path := range leaves {
+		leaf, _ := schema.Lookup(path...)
+		w.streams[i].leaf = leaf
+		w.columns[strings.Join(path, ".")] = &w.streams[i]
+	}
+	return w
+}
+
+// Push appends values to a column.
+//
+// Columns are identified by their path in the schema, with the names of nested
+// fields separated by dots (e.g. "address.country"). Values with a repetition
+// level of zero start a new row, the first value pushed to a column must start
+// a row.
+//
+// Values are copied, the caller may reuse the memory that they reference after
+// the method returned. If one of the values is invalid (e.g. its levels exceed
+// the maximum levels of the column), the method returns an error and none of
+// the values are pushed.
+func (w *ColumnStreamWriter) Push(column string, values ...Value) error {
+	c := w.columns[column]
+	if c == nil {
+		return fmt.Errorf("column %q does not exist in the schema", column)
+	}
+
+	// All the values are validated before any of them is buffered, so the
+	// column is left unchanged when the method returns an error.
+	for i, v := range values {
+		if err := checkValueLevels(column, v, c.leaf.MaxRepetitionLevel, c.leaf.MaxDefinitionLevel); err != nil {
+			return err
+		}
+		if i == 0 && v.RepetitionLevel() != 0 && len(c.rowOffsets) == 0 {
+			return fmt.Errorf("column %q: the first value must have a repetition level of zero", column)
+		}
+	}
+
+	for _, v := range values {
+		repetitionLevel := int(v.RepetitionLevel())
+		definitionLevel := int(v.DefinitionLevel())
+		if repetitionLevel == 0 {
+			c.rowOffsets = append(c.rowOffsets, len(c.values))
+		}
+		c.values = append(c.values, v.Clone().Level(repetitionLevel, definitionLevel, c.leaf.ColumnIndex))
+	}
+
+	// The last row of the column may still receive values, so it is only
+	// known to be complete once all columns started another row.
+	return w.writeRows(false)
+}
+
+// Flush writes the buffered rows to a row group.
+//
+// The method returns ErrMisalignedColumns if the columns have not received
+// values for the same number of rows.
+func (w *ColumnStreamWriter) Flush() error {
+	if err := w.writeAlignedRows(); err != nil {
+		return err
+	}
+	return w.writer.Flush()
+}
+
+// Close flushes the buffered rows and writes the parquet footer.
+//
+// The method returns ErrMisalignedColumns if the columns have not received
+// values for the same number of rows.
+func (w *ColumnStreamWriter) Close() error {
+	if err := w.writeAlignedRows(); err != nil {
+		return err
+	}
+	return w.writer.Close()
+}
+
+// Schema returns the schema of rows written by w.
+func (w *ColumnStreamWriter) Schema() *Schema { return w.writer.Schema() }
+
+func (w *ColumnStreamWriter) writeAlignedRows() error {
+	for i := range w.streams[1:] {
+		a, b := &w.streams[0], &w.streams[i+1]
+		if len(a.rowOffsets) != len(b.rowOffsets) {
+			return fmt.Errorf("%w: column %q has %d rows, column %q has %d rows", ErrMisalignedColumns,
+				columnPath(a.leaf.Path), len(a.rowOffsets),
+				columnPath(b.leaf.Path), len(b.rowOffsets),
+			)
+		}
+	}
+	return w.writeRows(true)
+}
+
+// writeRows writes the rows that all the columns have values for. When final
+// is true, the last row of each column is considered complete.
+func (w *ColumnStreamWriter) writeRows(final bool) error {
+	if len(w.streams) == 0 {
+		return nil
+	}
+
+	numRows := len(w.streams[0].rowOffsets)
+	for i := range w.streams {
+		numRows = min(numRows, len(w.streams[i].rowOffsets))
+	}
+	if !final && numRows > 0 {
+		// Rows are complete only if a column has started the next row.
+		for i := range w.streams {
+			if len(w.streams[i].rowOffsets) == numRows {
+				numRows--
+				break
+			}
+		}
+	}
+	if numRows == 0 {
+		return nil
+	}
+
+	if cap(w.rows) < numRows {
+		w.rows = make([]Row, numRows)
+	}
+	rows := w.rows[:numRows]
+	defer clearRows(rows)
+
+	for i := range w.streams {
+		c := &w.streams[i]
+		for j := range rows {
+			rows[j] = append(rows[j], c.values[c.rowOffsets[j]:c.rowEnd(j)]...)
+		}
+	}
+
+	if _, err := w.writer.WriteRows(rows); err != nil {
+		return err
+	}
+
+	for i := range w.streams {
+		c := &w.streams[i]
+		end := c.rowEnd(numRows - 1)
+		n := copy(c.values, c.values[end:])
+		clear(c.values[n:])
+		c.values = c.values[:n]
+		m := copy(c.rowOffsets, c.rowOffsets[numRows:])
+		c.rowOffsets = c.rowOffsets[:m]
+		for j := range c.rowOffsets {
+			c.rowOffsets[j] -= end
+		}
+	}
+	return nil
+}
+
+// checkValueLevels validates the repetition and definition levels of a value
+// written to a column with the given maximum levels.
+func checkValueLevels(column string, v Value, maxRepetitionLevel, maxDefinitionLevel int) error {
+	repetitionLevel := int(v.RepetitionLevel())
+	definitionLevel := int(v.DefinitionLevel())
+	switch {
+	case repetitionLevel > maxRepetitionLevel:
+		return fmt.Errorf("column %q: repetition level %d exceeds the maximum of %d", column, repetitionLevel, maxRepetitionLevel)
+	case definitionLevel > maxDefinitionLevel:
+		return fmt.Errorf("column %q: definition level %d exceeds the maximum of %d", column, definitionLevel, maxDefinitionLevel)
+	case v.IsNull() && definitionLevel == maxDefinitionLevel:
+		return fmt.Errorf("column %q: null values must have a definition level lower than %d", column, maxDefinitionLevel)
+	case !v.IsNull() && definitionLevel != maxDefinitionLevel:
+		return fmt.Errorf("column %q: non-null values must have a definition level of %d", column, maxDefinitionLevel)
+	}
+	return nil
+}
+
+func (c *columnStream) rowEnd(row int) int {
+	if row+1 < len(c.rowOffsets) {
+		return c.rowOffsets[row+1]
+	}
+	return len(c.values)
+}
@@ -0,0 +1,107 @@
+package parquet_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestColumnStreamWriter(t *testing.T) {
+	type Row struct {
+		ID   int64    `parquet:"id"`
+		Name *string  `parquet:"name,optional"`
+		Tags []string `parquet:"tags"`
+	}
+
+	name := "bob"
+	want := []Row{
+		{ID: 1, Name: &name, Tags: []string{"a", "b"}},
+		{ID: 2, Tags: []string{}},
+		{ID: 3, Name: &name, Tags: []string{"c"}},
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewColumnStreamWriter(buf, parquet.SchemaOf(new(Row)))
+
+	// The id column is produced ahead of the other columns, its values must
+	// be buffered until the rows can be assembled.
+	for _, id := range []int64{1, 2, 3} {
+		if err := w.Push("id", parquet.Int64Value(id)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Push("tags",
+		parquet.ByteArrayValue([]byte("a")).Level(0, 1, 0),
+		parquet.ByteArrayValue([]byte("b")).Level(1, 1, 0),
+		parquet.NullValue(),
+		parquet.ByteArrayValue([]byte("c")).Level(0, 1, 0),
+	); err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range []parquet.Value{
+		parquet.ByteArrayValue([]byte(name)).Level(0, 1, 0),
+		parquet.NullValue(),
+		parquet.ByteArrayValue([]byte(name)).Level(0, 1, 0),
+	} {
+		if err := w.Push("name", v); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := w.Push("tags", parquet.ByteArrayValue([]byte("d")).Level(2, 1, 0)); err == nil {
+		t.Error("expected an error when pushing a value with an invalid repetition level")
+	}
+	// None of the values are pushed when one of them is invalid, otherwise
+	// the tags column would have an extra row.
+	if err := w.Push("tags",
+		parquet.ByteArrayValue([]byte("e")).Level(0, 1, 0),
+		parquet.ByteArrayValue([]byte("f")).Level(2, 1, 0),
+	); err == nil {
+		t.Error("expected an error when pushing a batch with an invalid value")
+	}
+	if err := w.Push("name", parquet.NullValue().Level(0, 1, 0)); err == nil {
+		t.Error("expected an error when pushing a null value with the maximum definition level")
+	}
+	if err := w.Push("missing", parquet.NullValue()); err == nil {
+		t.Error("expected an error when pushing values to a column which does not exist")
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("rows mismatch:\nwant = %+v\ngot  = %+v", want, got)
+	}
+}
+
+func TestColumnStreamWriterMisalignedColumns(t *testing.T) {
+	type Row struct {
+		A int64 `parquet:"a"`
+		B int64 `parquet:"b"`
+	}
+
+	w := parquet.NewColumnStreamWriter(new(bytes.Buffer), parquet.SchemaOf(new(Row)))
+	if err := w.Push("a", parquet.Int64Value(1), parquet.Int64Value(2)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Push("b", parquet.Int64Value(1)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Flush(); !errors.Is(err, parquet.ErrMisalignedColumns) {
+		t.Errorf("expected ErrMisalignedColumns, got %v", err)
+	}
+	if err := w.Push("b", parquet.Int64Value(2)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -2,8 +2,11 @@ package parquet_test
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	"math/rand"
+	"reflect"
 	"testing"
 
 	"github.com/google/uuid"
@@ -606,3 +609,240 @@ func TestColumnPages_SeekToRow(t *testing.T) {
 		}
 	}
 }
+
+func TestColumnChunkRowValues(t *testing.T) {
+	type Row struct {
+		ID     int64   `parquet:"id"`
+		Values []int64 `parquet:"values"`
+	}
+
+	const largeListSize = 100_000
+	large := make([]int64, largeListSize)
+	for i := range large {
+		large[i] = int64(i)
+	}
+
+	rows := []Row{
+		{ID: 0, Values: []int64{1, 2, 3}},
+		{ID: 1, Values: large},
+		{ID: 2, Values: []int64{}},
+		{ID: 3, Values: []int64{42}},
+	}
+
+	buffer := new(bytes.Buffer)
+	writer := parquet.NewGenericWriter[Row](buffer, parquet.PageBufferSize(1024))
+	if _, err := writer.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	column := f.RowGroups()[0].ColumnChunks()[1]
+
+	for rowIndex, row := range rows {
+		n := 0
+		for value, err := range parquet.ColumnChunkRowValues(column, int64(rowIndex)) {
+			if err != nil {
+				t.Fatalf("row %d: %v", rowIndex, err)
+			}
+			if len(row.Values) == 0 {
+				if !value.IsNull() {
+					t.Errorf("row %d: expected a null value for the empty list, got %v", rowIndex, value)
+				}
+				continue
+			}
+			if n >= len(row.Values) {
+				t.Fatalf("row %d: too many values", rowIndex)
+			}
+			if got := value.Int64(); got != row.Values[n] {
+				t.Fatalf("row %d: value %d mismatch: want=%d got=%d", rowIndex, n, row.Values[n], got)
+			}
+			n++
+		}
+		if n != len(row.Values) {
+			t.Errorf("row %d: wrong number of values: want=%d got=%d", rowIndex, len(row.Values), n)
+		}
+	}
+
+	// Stop consuming the large list after a few values.
+	n := 0
+	for _, err := range parquet.ColumnChunkRowValues(column, 1) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n++; n == 10 {
+			break
+		}
+	}
+
+	var outOfRange error
+	for _, err := range parquet.ColumnChunkRowValues(column, int64(len(rows))) {
+		outOfRange = err
+	}
+	if !errors.Is(outOfRange, parquet.ErrSeekOutOfRange) {
+		t.Errorf("expected an out of range error reading a row beyond the end of the column chunk, got %v", outOfRange)
+	}
+}
+
+func TestColumnChunkValueReaderTypedValues(t *testing.T) {
+	type Row struct {
+		ID       int64   `parquet:"id"`
+		Count    int32   `parquet:"count,dict"`
+		Ratio    float32 `parquet:"ratio"`
+		Score    float64 `parquet:"score,dict"`
+		Flag     bool    `parquet:"flag"`
+		Optional *int64  `parquet:"optional,optional"`
+		Nullable *int64  `parquet:"nullable,optional"`
+	}
+
+	rows := make([]Row, 1000)
+	for i := range rows {
+		v := int64(i) * 3
+		rows[i] = Row{
+			ID:       int64(i),
+			Count:    int32(i % 7),
+			Ratio:    float32(i) / 2,
+			Score:    float64(i%5) * 1.5,
+			Flag:     i%3 == 0,
+			Optional: &v,
+		}
+		if i == 500 {
+			rows[i].Nullable = &v
+		}
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows, parquet.PageBufferSize(256)); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	columns := f.RowGroups()[0].ColumnChunks()
+
+	readAll := func(t *testing.T, columnIndex int, read func(parquet.ColumnChunkValueReader) (int, error)) int {
+		r := parquet.NewColumnChunkValueReader(columns[columnIndex])
+		defer r.Close()
+		total := 0
+		for {
+			n, err := read(r)
+			total += n
+			if err != nil {
+				if err != io.EOF {
+					t.Fatal(err)
+				}
+				return total
+			}
+		}
+	}
+
+	check := func(t *testing.T, name string, want, got any) {
+		if !reflect.DeepEqual(want, got) {
+			t.Errorf("wrong %s values", name)
+		}
+	}
+
+	t.Run("Int64", func(t *testing.T) {
+		want, got := make([]int64, len(rows)), make([]int64, 0, len(rows))
+		for i, row := range rows {
+			want[i] = row.ID
+		}
+		buf := make([]int64, 33)
+		readAll(t, 0, func(r parquet.ColumnChunkValueReader) (int, error) {
+			n, err := r.(parquet.Int64Reader).ReadInt64s(buf)
+			got = append(got, buf[:n]...)
+			return n, err
+		})
+		check(t, "id", want, got)
+	})
+
+	t.Run("Int32Dictionary", func(t *testing.T) {
+		want, got := make([]int32, len(rows)), make([]int32, 0, len(rows))
+		for i, row := range rows {
+			want[i] = row.Count
+		}
+		buf := make([]int32, 33)
+		readAll(t, 1, func(r parquet.ColumnChunkValueReader) (int, error) {
+			n, err := r.(parquet.Int32Reader).ReadInt32s(buf)
+			got = append(got, buf[:n]...)
+			return n, err
+		})
+		check(t, "count", want, got)
+	})
+
+	t.Run("Float", func(t *testing.T) {
+		want, got := make([]float32, len(rows)), make([]float32, 0, len(rows))
+		for i, row := range rows {
+			want[i] = row.Ratio
+		}
+		buf := make([]float32, 33)
+		readAll(t, 2, func(r parquet.ColumnChunkValueReader) (int, error) {
+			n, err := r.(parquet.FloatReader).ReadFloats(buf)
+			got = append(got, buf[:n]...)
+			return n, err
+		})
+		check(t, "ratio", want, got)
+	})
+
+	t.Run("DoubleDictionary", func(t *testing.T) {
+		want, got := make([]float64, len(rows)), make([]float64, 0, len(rows))
+		for i, row := range rows {
+			want[i] = row.Score
+		}
+		buf := make([]float64, 33)
+		readAll(t, 3, func(r parquet.ColumnChunkValueReader) (int, error) {
+			n, err := r.(parquet.DoubleReader).ReadDoubles(buf)
+			got = append(got, buf[:n]...)
+			return n, err
+		})
+		check(t, "score", want, got)
+	})
+
+	t.Run("Boolean", func(t *testing.T) {
+		want, got := make([]bool, len(rows)), make([]bool, 0, len(rows))
+		for i, row := range rows {
+			want[i] = row.Flag
+		}
+		buf := make([]bool, 33)
+		readAll(t, 4, func(r parquet.ColumnChunkValueReader) (int, error) {
+			n, err := r.(parquet.BooleanReader).ReadBooleans(buf)
+			got = append(got, buf[:n]...)
+			return n, err
+		})
+		check(t, "flag", want, got)
+	})
+
+	t.Run("OptionalWithoutNulls", func(t *testing.T) {
+		want, got := make([]int64, len(rows)), make([]int64, 0, len(rows))
+		for i, row := range rows {
+			want[i] = *row.Optional
+		}
+		buf := make([]int64, 33)
+		readAll(t, 5, func(r parquet.ColumnChunkValueReader) (int, error) {
+			n, err := r.(parquet.Int64Reader).ReadInt64s(buf)
+			got = append(got, buf[:n]...)
+			return n, err
+		})
+		check(t, "optional", want, got)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		r := parquet.NewColumnChunkValueReader(columns[6])
+		defer r.Close()
+		if _, err := r.(parquet.Int64Reader).ReadInt64s(make([]int64, 10)); err == nil {
+			t.Error("expected an error reading a column with null values")
+		}
+
+		r = parquet.NewColumnChunkValueReader(columns[0])
+		defer r.Close()
+		if _, err := r.(parquet.DoubleReader).ReadDoubles(make([]float64, 10)); !errors.Is(err, parquet.ErrInvalidConversion) {
+			t.Errorf("expected an invalid conversion error reading a column of a different type, got %v", err)
+		}
+	})
+}
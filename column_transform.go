@@ -0,0 +1,123 @@
+package parquet
+
+import (
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/parquet-go/parquet-go/encoding"
+	"github.com/parquet-go/parquet-go/format"
+)
+
+// Key of the key/value metadata of column chunks holding the name of the
+// transform that the values of their data pages were transformed with.
+const columnTransformKey = "parquet-go.column.transform"
+
+// ColumnTransform is a reversible transformation of the values of a column,
+// see TransformColumn for details.
+//
+// ColumnTransform implements the WriterOption, ReaderOption, and FileOption
+// interfaces so it can be passed directly to writers, readers, and files.
+type ColumnTransform struct {
+	// Name of the transform, recorded in the metadata of the column chunks.
+	Name string
+	// Path to the column that the transform applies to.
+	Path []string
+	// Forward transforms the values before they are encoded.
+	Forward func(values []Value)
+	// Inverse reverses Forward after the values are decoded.
+	Inverse func(values []Value)
+}
+
+// TransformColumn creates a configuration option which applies a reversible
+// transformation to the values of the column at path, before the values are
+// encoded in data pages and reversed after they are decoded. For example, a
+// delta-of-delta transform of monotonically increasing timestamps turns them
+// into small integers which the encodings and compression codecs of the column
+// store more compactly.
+//
+// The forward and inverse functions receive the non-null values of a data page
+// and modify them in place, they must produce values of the physical type of
+// the column. Pages are transformed independently, the inverse function only
+// sees values produced by forward on the same page. Dictionary-encoded pages
+// are not transformed, their values are already stored once in the dictionary.
+//
+// Statistics, page indexes, and bloom filters are computed from the values
+// before they are transformed, so filtering rows on transformed columns works
+// the same way as on other columns.
+//
+// Writers record the name of the transform in the metadata of the column
+// chunks. Readers and files must be configured with a transform of the same
+// name for the column to reverse it; reading the column chunks without it, or
+// with a transform of a different name, returns an error. Passing multiple
+// transforms for the same column keeps the last one.
+func TransformColumn(name string, forward, inverse func(values []Value), path ...string) *ColumnTransform {
+	return &ColumnTransform{
+		Name:    name,
+		Path:    slices.Clone(path),
+		Forward: forward,
+		Inverse: inverse,
+	}
+}
+
+// ConfigureWriter satisfies the WriterOption interface.
+func (t *ColumnTransform) ConfigureWriter(config *WriterConfig) {
+	config.ColumnTransforms = append(config.ColumnTransforms, t)
+}
+
+// ConfigureReader satisfies the ReaderOption interface.
+func (t *ColumnTransform) ConfigureReader(config *ReaderConfig) {
+	config.ColumnTransforms = append(config.ColumnTransforms, t)
+}
+
+// ConfigureFile satisfies the FileOption interface.
+func (t *ColumnTransform) ConfigureFile(config *FileConfig) {
+	config.ColumnTransforms = append(config.ColumnTransforms, t)
+}
+
+// lookupColumnTransform returns the last transform of transforms applying to
+// the column at path, or nil if there are none.
+func lookupColumnTransform(transforms []*ColumnTransform, path columnPath) *ColumnTransform {
+	for _, t := range slices.Backward(transforms) {
+		if path.equal(t.Path) {
+			return t
+		}
+	}
+	return nil
+}
+
+// columnTransformName returns the name of the transform that the column chunk
+// metadata records, or an empty string if the values were not transformed.
+func columnTransformName(metadata *format.ColumnMetaData) string {
+	for _, kv := range metadata.KeyValueMetadata {
+		if kv.Key == columnTransformKey {
+			return kv.Value
+		}
+	}
+	return ""
+}
+
+// transformValues applies fn to the numValues values of data, returning the
+// transformed values in a new buffer.
+func transformValues(typ Type, columnIndex, numValues int, data encoding.Values, fn func([]Value)) (encoding.Values, error) {
+	values := make([]Value, numValues)
+	reader := typ.NewPage(columnIndex, numValues, data).Values()
+	for n := 0; n < numValues; {
+		read, err := reader.ReadValues(values[n:])
+		n += read
+		if err != nil {
+			if err == io.EOF && n == numValues {
+				break
+			}
+			return encoding.Values{}, err
+		}
+	}
+
+	fn(values)
+
+	buffer := typ.NewColumnBuffer(columnIndex, numValues)
+	if _, err := buffer.WriteValues(values); err != nil {
+		return encoding.Values{}, fmt.Errorf("writing transformed values: %w", err)
+	}
+	return buffer.Page().Data(), nil
+}
@@ -0,0 +1,141 @@
+package parquet_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func deltaOfDeltaForward(values []parquet.Value) {
+	prev, prevDelta := int64(0), int64(0)
+	for i, v := range values {
+		delta := v.Int64() - prev
+		values[i] = parquet.Int64Value(delta - prevDelta)
+		prev, prevDelta = v.Int64(), delta
+	}
+}
+
+func deltaOfDeltaInverse(values []parquet.Value) {
+	prev, prevDelta := int64(0), int64(0)
+	for i, v := range values {
+		delta := prevDelta + v.Int64()
+		prev, prevDelta = prev+delta, delta
+		values[i] = parquet.Int64Value(prev)
+	}
+}
+
+func TestColumnTransform(t *testing.T) {
+	type Row struct {
+		Time     int64  `parquet:"dod_time"`
+		Optional *int64 `parquet:"dod_optional,optional"`
+	}
+	type PlainRow struct {
+		Time     int64  `parquet:"time"`
+		Optional *int64 `parquet:"optional,optional"`
+	}
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).UnixNano()
+	rows := make([]Row, 10_000)
+	plainRows := make([]PlainRow, len(rows))
+	for i := range rows {
+		rows[i].Time = start + int64(i)*int64(time.Second)
+		if i%3 != 0 {
+			v := start + int64(i)*int64(time.Millisecond)
+			rows[i].Optional = &v
+		}
+		plainRows[i] = PlainRow(rows[i])
+	}
+
+	timeTransform := parquet.TransformColumn("delta-of-delta", deltaOfDeltaForward, deltaOfDeltaInverse, "dod_time")
+	optionalTransform := parquet.TransformColumn("delta-of-delta", deltaOfDeltaForward, deltaOfDeltaInverse, "dod_optional")
+
+	options := []parquet.WriterOption{
+		parquet.Compression(&parquet.Zstd),
+		parquet.PageBufferSize(4096),
+		parquet.BloomFilters(parquet.SplitBlockFilter(10, "dod_time")),
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows, append(options, timeTransform, optionalTransform)...); err != nil {
+		t.Fatal(err)
+	}
+	plainBuf := new(bytes.Buffer)
+	if err := parquet.Write(plainBuf, plainRows, options...); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()), timeTransform, optionalTransform)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, rows) {
+		t.Error("rows read from the transformed file do not match the rows written")
+	}
+
+	// The transforms must be configured, with the same names, to read the
+	// transformed column chunks.
+	if _, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len())); err == nil {
+		t.Error("expected an error reading transformed columns without transforms")
+	}
+	renamed := parquet.TransformColumn("delta", deltaOfDeltaForward, deltaOfDeltaInverse, "dod_time")
+	if _, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()), renamed, optionalTransform); err == nil {
+		t.Error("expected an error reading transformed columns with a transform of a different name")
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()), timeTransform, optionalTransform)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plainFile, err := parquet.OpenFile(bytes.NewReader(plainBuf.Bytes()), int64(plainBuf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, name := range []string{"time", "optional"} {
+		size := f.Metadata().RowGroups[0].Columns[i].MetaData.TotalCompressedSize
+		plainSize := plainFile.Metadata().RowGroups[0].Columns[i].MetaData.TotalCompressedSize
+		t.Logf("%s: transformed=%d plain=%d", name, size, plainSize)
+		if size*2 > plainSize {
+			t.Errorf("%s: transformed column chunk is not smaller: transformed=%d plain=%d", name, size, plainSize)
+		}
+	}
+
+	// Statistics are computed from the values before the transform.
+	columnIndex, err := f.RowGroups()[0].ColumnChunks()[0].ColumnIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if min := columnIndex.MinValue(0).Int64(); min != start {
+		t.Errorf("wrong min value of the first page: want=%d got=%d", start, min)
+	}
+	last := columnIndex.NumPages() - 1
+	if max := columnIndex.MaxValue(last).Int64(); max != rows[len(rows)-1].Time {
+		t.Errorf("wrong max value of the last page: want=%d got=%d", rows[len(rows)-1].Time, max)
+	}
+
+	// Bloom filters are populated with the values before the transform.
+	filter := f.RowGroups()[0].ColumnChunks()[0].BloomFilter()
+	if ok, err := filter.Check(parquet.ValueOf(rows[42].Time)); err != nil {
+		t.Fatal(err)
+	} else if !ok {
+		t.Errorf("value %d not found in the bloom filter", rows[42].Time)
+	}
+
+	// Seeking into the middle of the column chunk reverses the transform of
+	// the pages independently.
+	reader := parquet.NewGenericReader[Row](f)
+	defer reader.Close()
+	if err := reader.SeekToRow(7_777); err != nil {
+		t.Fatal(err)
+	}
+	batch := make([]Row, 3)
+	if _, err := reader.Read(batch); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(batch, rows[7_777:7_780]) {
+		t.Errorf("wrong rows after seeking:\nwant: %+v\ngot:  %+v", rows[7_777:7_780], batch)
+	}
+}
@@ -2,6 +2,7 @@ package parquet
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/parquet-go/parquet-go/compress"
 	"github.com/parquet-go/parquet-go/compress/brotli"
@@ -88,7 +89,44 @@ func (u *unsupported) Decode(dst, src []byte) ([]byte, error) {
 }
 
 func (u *unsupported) error() error {
-	return fmt.Errorf("unsupported compression codec: %s", u.codec)
+	return fmt.Errorf("%w: %s", ErrUnsupportedCompressionCodec, u.codec)
+}
+
+// checkCompressionCodecs returns an error listing the columns of a parquet
+// file which have chunks compressed with codecs that are not supported.
+//
+// Reading pages of those columns would fail anyway, reporting all of them
+// up front gives a more actionable error than failing in the middle of
+// reading the file.
+func checkCompressionCodecs(metadata *format.FileMetaData) error {
+	var missing []string
+	var seen map[string]struct{}
+
+	for i := range metadata.RowGroups {
+		for j := range metadata.RowGroups[i].Columns {
+			columnChunk := &metadata.RowGroups[i].Columns[j]
+			codec := columnChunk.MetaData.Codec
+
+			if _, ok := LookupCompressionCodec(codec).(*unsupported); !ok {
+				continue
+			}
+
+			column := fmt.Sprintf("%q (%s)", columnPath(columnChunk.MetaData.PathInSchema), codec)
+			if _, ok := seen[column]; ok {
+				continue
+			}
+			if seen == nil {
+				seen = make(map[string]struct{})
+			}
+			seen[column] = struct{}{}
+			missing = append(missing, column)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s", ErrUnsupportedCompressionCodec, strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func isCompressed(c compress.Codec) bool {
@@ -29,6 +29,14 @@ const (
 	SpeedBestCompression = zstd.SpeedBestCompression
 )
 
+// LevelFromZstd returns the encoder level which most closely matches the given
+// zstd compression level, which ranges from 1 (fastest) to 22 (smallest).
+// Levels below 3 map to SpeedFastest, levels 3 to 5 to SpeedDefault, levels 6
+// to 9 to SpeedBetterCompression, and higher levels to SpeedBestCompression.
+func LevelFromZstd(level int) Level {
+	return zstd.EncoderLevelFromZstd(level)
+}
+
 const (
 	DefaultLevel = SpeedDefault
 
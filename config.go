@@ -2,12 +2,15 @@ package parquet
 
 import (
 	"fmt"
+	"io"
 	"maps"
 	"math"
 	"runtime/debug"
 	"slices"
+	"strconv"
 	"strings"
 	"sync"
+	"unicode/utf8"
 
 	"github.com/parquet-go/parquet-go/compress"
 	"github.com/parquet-go/parquet-go/encoding"
@@ -33,6 +36,8 @@ const (
 	DefaultSkipBloomFilters     = false
 	DefaultMaxRowsPerRowGroup   = math.MaxInt64
 	DefaultReadMode             = ReadModeSync
+	DefaultMaxOpenPartitions    = 64
+	DefaultCSVDelimiter         = ','
 )
 
 const (
@@ -94,13 +99,17 @@ func formatCreatedBy(application, version, build string) string {
 //		ReadMode:         ReadModeAsync,
 //	})
 type FileConfig struct {
-	SkipMagicBytes   bool
-	SkipPageIndex    bool
-	SkipBloomFilters bool
-	OptimisticRead   bool
-	ReadBufferSize   int
-	ReadMode         ReadMode
-	Schema           *Schema
+	SkipMagicBytes          bool
+	SkipPageIndex           bool
+	SkipBloomFilters        bool
+	OptimisticRead          bool
+	ReadBufferSize          int
+	ReadMode                ReadMode
+	Schema                  *Schema
+	VerifyMetadataChecksums bool
+	PreallocateBuffers      int64
+	ExternalColumnChunks    func(path string) (io.ReaderAt, error)
+	ColumnTransforms        []*ColumnTransform
 }
 
 // DefaultFileConfig returns a new FileConfig value initialized with the
@@ -137,12 +146,16 @@ func (c *FileConfig) Apply(options ...FileOption) {
 // ConfigureFile applies configuration options from c to config.
 func (c *FileConfig) ConfigureFile(config *FileConfig) {
 	*config = FileConfig{
-		SkipMagicBytes:   c.SkipMagicBytes,
-		SkipPageIndex:    c.SkipPageIndex,
-		SkipBloomFilters: c.SkipBloomFilters,
-		ReadBufferSize:   coalesceInt(c.ReadBufferSize, config.ReadBufferSize),
-		ReadMode:         ReadMode(coalesceInt(int(c.ReadMode), int(config.ReadMode))),
-		Schema:           coalesceSchema(c.Schema, config.Schema),
+		SkipMagicBytes:          c.SkipMagicBytes,
+		SkipPageIndex:           c.SkipPageIndex,
+		SkipBloomFilters:        c.SkipBloomFilters,
+		ReadBufferSize:          coalesceInt(c.ReadBufferSize, config.ReadBufferSize),
+		ReadMode:                ReadMode(coalesceInt(int(c.ReadMode), int(config.ReadMode))),
+		Schema:                  coalesceSchema(c.Schema, config.Schema),
+		VerifyMetadataChecksums: c.VerifyMetadataChecksums,
+		PreallocateBuffers:      coalesceInt64(c.PreallocateBuffers, config.PreallocateBuffers),
+		ExternalColumnChunks:    coalesceOpenFunc(c.ExternalColumnChunks, config.ExternalColumnChunks),
+		ColumnTransforms:        coalesceColumnTransforms(c.ColumnTransforms, config.ColumnTransforms),
 	}
 }
 
@@ -160,7 +173,10 @@ func (c *FileConfig) Validate() error {
 //		// ...
 //	})
 type ReaderConfig struct {
-	Schema *Schema
+	Schema               *Schema
+	SkipCorruptRowGroups bool
+	StrictSchema         bool
+	ColumnTransforms     []*ColumnTransform
 }
 
 // DefaultReaderConfig returns a new ReaderConfig value initialized with the
@@ -190,7 +206,10 @@ func (c *ReaderConfig) Apply(options ...ReaderOption) {
 // ConfigureReader applies configuration options from c to config.
 func (c *ReaderConfig) ConfigureReader(config *ReaderConfig) {
 	*config = ReaderConfig{
-		Schema: coalesceSchema(c.Schema, config.Schema),
+		Schema:               coalesceSchema(c.Schema, config.Schema),
+		SkipCorruptRowGroups: coalesceBool(c.SkipCorruptRowGroups, config.SkipCorruptRowGroups),
+		StrictSchema:         coalesceBool(c.StrictSchema, config.StrictSchema),
+		ColumnTransforms:     coalesceColumnTransforms(c.ColumnTransforms, config.ColumnTransforms),
 	}
 }
 
@@ -223,6 +242,22 @@ type WriterConfig struct {
 	Sorting              SortingConfig
 	SkipPageBounds       [][]string
 	Encodings            map[Kind]encoding.Encoding
+	ColumnIndexOrder     [][]string
+	ColumnChunkGroups    [][][]string
+	CheckSortingOrder    bool
+	MaxBufferedBytes     int64
+	SpillBuffers         BufferPool
+	VerifyOnClose        bool
+	CanonicalizeFloats   bool
+	AdaptiveCompression  []compress.Codec
+	NullBitmaps          []NullBitmapColumn
+	DeterministicOutput  bool
+	ConstantColumns      bool
+	MetadataChecksums    bool
+	MaxDictionarySize    int
+	DataPageSize         int
+	SchemaName           string
+	ColumnTransforms     []*ColumnTransform
 }
 
 // DefaultWriterConfig returns a new WriterConfig value initialized with the
@@ -295,6 +330,22 @@ func (c *WriterConfig) ConfigureWriter(config *WriterConfig) {
 		Sorting:              coalesceSortingConfig(c.Sorting, config.Sorting),
 		SkipPageBounds:       coalesceSkipPageBounds(c.SkipPageBounds, config.SkipPageBounds),
 		Encodings:            encodings,
+		ColumnIndexOrder:     coalesceColumnPaths(c.ColumnIndexOrder, config.ColumnIndexOrder),
+		ColumnChunkGroups:    coalesceColumnChunkGroups(c.ColumnChunkGroups, config.ColumnChunkGroups),
+		CheckSortingOrder:    coalesceBool(c.CheckSortingOrder, config.CheckSortingOrder),
+		MaxBufferedBytes:     coalesceInt64(c.MaxBufferedBytes, config.MaxBufferedBytes),
+		SpillBuffers:         coalesceBufferPool(c.SpillBuffers, config.SpillBuffers),
+		VerifyOnClose:        coalesceBool(c.VerifyOnClose, config.VerifyOnClose),
+		CanonicalizeFloats:   coalesceBool(c.CanonicalizeFloats, config.CanonicalizeFloats),
+		AdaptiveCompression:  coalesceCodecs(c.AdaptiveCompression, config.AdaptiveCompression),
+		NullBitmaps:          coalesceNullBitmaps(c.NullBitmaps, config.NullBitmaps),
+		DeterministicOutput:  coalesceBool(c.DeterministicOutput, config.DeterministicOutput),
+		ConstantColumns:      coalesceBool(c.ConstantColumns, config.ConstantColumns),
+		MetadataChecksums:    coalesceBool(c.MetadataChecksums, config.MetadataChecksums),
+		MaxDictionarySize:    coalesceInt(c.MaxDictionarySize, config.MaxDictionarySize),
+		DataPageSize:         coalesceInt(c.DataPageSize, config.DataPageSize),
+		SchemaName:           coalesceString(c.SchemaName, config.SchemaName),
+		ColumnTransforms:     coalesceColumnTransforms(c.ColumnTransforms, config.ColumnTransforms),
 	}
 }
 
@@ -306,6 +357,7 @@ func (c *WriterConfig) Validate() error {
 		validatePositiveInt(baseName+"ColumnIndexSizeLimit", c.ColumnIndexSizeLimit),
 		validatePositiveInt(baseName+"PageBufferSize", c.PageBufferSize),
 		validateOneOfInt(baseName+"DataPageVersion", c.DataPageVersion, 1, 2),
+		validateColumnTransforms(baseName+"ColumnTransforms", c.ColumnTransforms),
 		c.Sorting.Validate(),
 	)
 }
@@ -422,6 +474,227 @@ func (c *SortingConfig) ConfigureSorting(config *SortingConfig) {
 	*config = coalesceSortingConfig(*c, *config)
 }
 
+// The SchemaConfig type carries configuration options for schemas constructed
+// from Go types.
+//
+// SchemaConfig implements the SchemaOption interface so it can be used
+// directly as argument to the SchemaOf function when needed, for example:
+//
+//	schema := parquet.SchemaOf(row, &parquet.SchemaConfig{
+//		PointersRequired: true,
+//	})
+type SchemaConfig struct {
+	PointersRequired bool
+	TimestampUnit    TimeUnit
+	SortMapKeys      bool
+}
+
+// DefaultSchemaConfig returns a new SchemaConfig value initialized with the
+// default schema configuration.
+func DefaultSchemaConfig() *SchemaConfig {
+	return &SchemaConfig{}
+}
+
+// NewSchemaConfig constructs a new schema configuration applying the options
+// passed as arguments.
+//
+// The function returns an non-nil error if some of the options carried invalid
+// configuration values.
+func NewSchemaConfig(options ...SchemaOption) (*SchemaConfig, error) {
+	config := DefaultSchemaConfig()
+	config.Apply(options...)
+	return config, config.Validate()
+}
+
+// Validate returns a non-nil error if the configuration of c is invalid.
+func (c *SchemaConfig) Validate() error {
+	return nil
+}
+
+func (c *SchemaConfig) Apply(options ...SchemaOption) {
+	for _, opt := range options {
+		opt.ConfigureSchema(c)
+	}
+}
+
+func (c *SchemaConfig) ConfigureSchema(config *SchemaConfig) {
+	*config = SchemaConfig{
+		PointersRequired: coalesceBool(c.PointersRequired, config.PointersRequired),
+		TimestampUnit:    coalesceTimeUnit(c.TimestampUnit, config.TimestampUnit),
+		SortMapKeys:      coalesceBool(c.SortMapKeys, config.SortMapKeys),
+	}
+}
+
+// The MultiFileConfig type carries configuration options for writers producing
+// multiple parquet files.
+//
+// MultiFileConfig implements the MultiFileOption interface so it can be used
+// directly as argument to the NewMultiFileWriter function when needed, for
+// example:
+//
+//	writer := parquet.NewMultiFileWriter[Row](create, &parquet.MultiFileConfig{
+//		MaxRowsPerFile: 1_000_000,
+//	})
+type MultiFileConfig struct {
+	MaxRowsPerFile  int64
+	MaxBytesPerFile int64
+	KeyColumn       []string
+	WriterOptions   []WriterOption
+}
+
+// DefaultMultiFileConfig returns a new MultiFileConfig value initialized with
+// the default multi-file writer configuration.
+func DefaultMultiFileConfig() *MultiFileConfig {
+	return &MultiFileConfig{}
+}
+
+// NewMultiFileConfig constructs a new multi-file writer configuration applying
+// the options passed as arguments.
+//
+// The function returns an non-nil error if some of the options carried invalid
+// configuration values.
+func NewMultiFileConfig(options ...MultiFileOption) (*MultiFileConfig, error) {
+	config := DefaultMultiFileConfig()
+	config.Apply(options...)
+	return config, config.Validate()
+}
+
+// Validate returns a non-nil error if the configuration of c is invalid.
+func (c *MultiFileConfig) Validate() error {
+	const baseName = "parquet.(*MultiFileConfig)."
+	return errorInvalidConfiguration(
+		validateNonNegativeInt64(baseName+"MaxRowsPerFile", c.MaxRowsPerFile),
+		validateNonNegativeInt64(baseName+"MaxBytesPerFile", c.MaxBytesPerFile),
+	)
+}
+
+func (c *MultiFileConfig) Apply(options ...MultiFileOption) {
+	for _, opt := range options {
+		opt.ConfigureMultiFile(c)
+	}
+}
+
+func (c *MultiFileConfig) ConfigureMultiFile(config *MultiFileConfig) {
+	*config = MultiFileConfig{
+		MaxRowsPerFile:  coalesceInt64(c.MaxRowsPerFile, config.MaxRowsPerFile),
+		MaxBytesPerFile: coalesceInt64(c.MaxBytesPerFile, config.MaxBytesPerFile),
+		KeyColumn:       coalesceStrings(c.KeyColumn, config.KeyColumn),
+		WriterOptions:   append(slices.Clip(config.WriterOptions), c.WriterOptions...),
+	}
+}
+
+// The PartitionConfig type carries configuration options for partitioned
+// writers.
+//
+// PartitionConfig implements the PartitionOption interface so it can be used
+// directly as argument to the NewPartitionedWriter function when needed, for
+// example:
+//
+//	writer := parquet.NewPartitionedWriter[Row](dir, columns, &parquet.PartitionConfig{
+//		MaxOpenPartitions: 16,
+//	})
+type PartitionConfig struct {
+	MaxOpenPartitions int
+	WriterOptions     []WriterOption
+}
+
+// DefaultPartitionConfig returns a new PartitionConfig value initialized with
+// the default partitioned writer configuration.
+func DefaultPartitionConfig() *PartitionConfig {
+	return &PartitionConfig{
+		MaxOpenPartitions: DefaultMaxOpenPartitions,
+	}
+}
+
+// NewPartitionConfig constructs a new partitioned writer configuration applying
+// the options passed as arguments.
+//
+// The function returns an non-nil error if some of the options carried invalid
+// configuration values.
+func NewPartitionConfig(options ...PartitionOption) (*PartitionConfig, error) {
+	config := DefaultPartitionConfig()
+	config.Apply(options...)
+	return config, config.Validate()
+}
+
+// Validate returns a non-nil error if the configuration of c is invalid.
+func (c *PartitionConfig) Validate() error {
+	const baseName = "parquet.(*PartitionConfig)."
+	return errorInvalidConfiguration(
+		validatePositiveInt(baseName+"MaxOpenPartitions", c.MaxOpenPartitions),
+	)
+}
+
+func (c *PartitionConfig) Apply(options ...PartitionOption) {
+	for _, opt := range options {
+		opt.ConfigurePartition(c)
+	}
+}
+
+func (c *PartitionConfig) ConfigurePartition(config *PartitionConfig) {
+	*config = PartitionConfig{
+		MaxOpenPartitions: coalesceInt(c.MaxOpenPartitions, config.MaxOpenPartitions),
+		WriterOptions:     append(slices.Clip(config.WriterOptions), c.WriterOptions...),
+	}
+}
+
+// The CSVConfig type carries configuration options for converting parquet
+// files to CSV.
+//
+// CSVConfig implements the CSVOption interface so it can be used directly as
+// argument to the ToCSV function when needed, for example:
+//
+//	err := parquet.ToCSV(w, r, size, &parquet.CSVConfig{
+//		Delimiter: ';',
+//		NullToken: "NULL",
+//	})
+type CSVConfig struct {
+	Delimiter  rune
+	SkipHeader bool
+	NullToken  string
+}
+
+// DefaultCSVConfig returns a new CSVConfig value initialized with the default
+// CSV configuration.
+func DefaultCSVConfig() *CSVConfig {
+	return &CSVConfig{
+		Delimiter: DefaultCSVDelimiter,
+	}
+}
+
+// NewCSVConfig constructs a new CSV configuration applying the options passed
+// as arguments.
+//
+// The function returns an non-nil error if some of the options carried invalid
+// configuration values.
+func NewCSVConfig(options ...CSVOption) (*CSVConfig, error) {
+	config := DefaultCSVConfig()
+	config.Apply(options...)
+	return config, config.Validate()
+}
+
+// Validate returns a non-nil error if the configuration of c is invalid.
+func (c *CSVConfig) Validate() error {
+	const baseName = "parquet.(*CSVConfig)."
+	return errorInvalidConfiguration(
+		validateCSVDelimiter(baseName+"Delimiter", c.Delimiter),
+	)
+}
+
+func (c *CSVConfig) Apply(options ...CSVOption) {
+	for _, opt := range options {
+		opt.ConfigureCSV(c)
+	}
+}
+
+func (c *CSVConfig) ConfigureCSV(config *CSVConfig) {
+	*config = CSVConfig{
+		Delimiter:  coalesceRune(c.Delimiter, config.Delimiter),
+		SkipHeader: coalesceBool(c.SkipHeader, config.SkipHeader),
+		NullToken:  coalesceString(c.NullToken, config.NullToken),
+	}
+}
+
 // FileOption is an interface implemented by types that carry configuration
 // options for parquet files.
 type FileOption interface {
@@ -452,6 +725,30 @@ type SortingOption interface {
 	ConfigureSorting(*SortingConfig)
 }
 
+// SchemaOption is an interface implemented by types that carry configuration
+// options for schemas constructed from Go types.
+type SchemaOption interface {
+	ConfigureSchema(*SchemaConfig)
+}
+
+// MultiFileOption is an interface implemented by types that carry configuration
+// options for writers producing multiple parquet files.
+type MultiFileOption interface {
+	ConfigureMultiFile(*MultiFileConfig)
+}
+
+// PartitionOption is an interface implemented by types that carry configuration
+// options for partitioned writers.
+type PartitionOption interface {
+	ConfigurePartition(*PartitionConfig)
+}
+
+// CSVOption is an interface implemented by types that carry configuration
+// options for converting parquet files to CSV.
+type CSVOption interface {
+	ConfigureCSV(*CSVConfig)
+}
+
 // SkipMagicBytes is a file configuration option which prevents automatically
 // reading the magic bytes when opening a parquet file, when set to true. This
 // is useful as an optimization when programs can trust that they are dealing
@@ -513,6 +810,62 @@ func ReadBufferSize(size int) FileOption {
 	return fileOption(func(config *FileConfig) { config.ReadBufferSize = size })
 }
 
+// PreallocateBuffers is a file configuration option which allocates the buffers
+// that compressed pages are read into when the file is opened, instead of
+// acquiring them from a pool shared by all files as pages are read.
+//
+// The buffers are sized after the largest page of the file, or the largest
+// column chunk when the file has no offset index, and there are enough of them
+// to read pages of all the columns concurrently. They remain allocated for as
+// long as the file is in use, which trades memory for fewer allocations when
+// streaming the content of the file, since the buffers of the shared pool are
+// released when the garbage collector runs. The buffers holding the decoded
+// pages are still acquired from the shared pool, since they are retained for
+// as long as the program uses the pages.
+//
+// The total size of the buffers is bounded by the limit passed as argument, the
+// size of each buffer is reduced to fit the limit, pages larger than the
+// buffers fall back to using the shared pool.
+//
+// Defaults to zero, which disables preallocation.
+func PreallocateBuffers(limit int64) FileOption {
+	return fileOption(func(config *FileConfig) { config.PreallocateBuffers = limit })
+}
+
+// ExternalColumnChunks is a file configuration option which enables reading
+// column chunks stored in other files than the one holding the metadata, as
+// referenced by the file_path field of column chunks. This is used by datasets
+// where a metadata-only file (e.g. a _metadata summary file) describes the row
+// groups of multiple data files, which can then be read as a single file.
+//
+// The open function is called with the file_path of column chunks the first
+// time that their content is read, and once per path. It returns a reader of
+// the file, where the offsets recorded in the column chunk metadata are
+// resolved. Paths are passed as recorded in the metadata, they are usually
+// relative to the location of the metadata file. The program is responsible
+// for closing the files after it stopped using the parquet file.
+//
+// The page indexes and bloom filters of column chunks stored in other files are
+// read from these files when the program accesses them, instead of when the
+// file is opened.
+//
+// Without this option, reading column chunks stored in other files returns an
+// error.
+func ExternalColumnChunks(open func(path string) (io.ReaderAt, error)) FileOption {
+	return fileOption(func(config *FileConfig) { config.ExternalColumnChunks = open })
+}
+
+// VerifyMetadataChecksums is a file configuration option which verifies the
+// checksums of the file and column chunk metadata recorded by writers using
+// the MetadataChecksums option, when set to true. Opening the file fails with
+// an error wrapping ErrCorruptedMetadata if one of the checksums mismatches.
+// Metadata that do not have checksums are not verified.
+//
+// Defaults to false.
+func VerifyMetadataChecksums(verify bool) FileOption {
+	return fileOption(func(config *FileConfig) { config.VerifyMetadataChecksums = verify })
+}
+
 // FileSchema is used to pass a known schema in while opening a Parquet file.
 // This optimization is only useful if your application is currently opening
 // an extremely large number of parquet files with the same, known schema.
@@ -522,6 +875,41 @@ func FileSchema(schema *Schema) FileOption {
 	return fileOption(func(config *FileConfig) { config.Schema = schema })
 }
 
+// SkipCorruptRowGroups is a reader configuration option which makes readers
+// skip the row groups that fail to be decoded (e.g. because of checksum
+// mismatches) instead of returning an error, when set to true.
+//
+// Rows read from a row group before the corruption was detected are still
+// returned, the remaining rows of the row group are skipped. Readers return
+// io.EOF when reaching the end of the file, applications are notified of the
+// data loss by calling the SkippedRowGroups method of the readers, which
+// returns an error wrapping the errors that caused row groups to be skipped.
+// The Read function returns the rows which could be read along with this
+// error.
+//
+// Defaults to false.
+func SkipCorruptRowGroups(enabled bool) ReaderOption {
+	return readerOption(func(config *ReaderConfig) { config.SkipCorruptRowGroups = enabled })
+}
+
+// StrictSchema is a reader configuration option which requires the schema that
+// rows are read with to have exactly the same columns as the file, when set to
+// true.
+//
+// By default, readers are lenient: columns of the file which are absent from
+// the schema are ignored, and columns of the schema which are absent from the
+// file are read as null values (e.g. zero values of the corresponding struct
+// fields). Pipelines which require schemas to match exactly can enable this
+// option to have readers report ErrSchemaMismatch instead. Since reader
+// constructors cannot return errors, they panic with the error when the schema
+// configured on the reader, or generated from the Go type of rows, does not
+// match the file.
+//
+// Defaults to false.
+func StrictSchema(enabled bool) ReaderOption {
+	return readerOption(func(config *ReaderConfig) { config.StrictSchema = enabled })
+}
+
 // PageBufferSize configures the size of column page buffers on parquet writers.
 //
 // Note that the page buffer size refers to the in-memory buffers where pages
@@ -561,6 +949,186 @@ func MaxRowsPerRowGroup(numRows int64) WriterOption {
 	return writerOption(func(config *WriterConfig) { config.MaxRowsPerRowGroup = numRows })
 }
 
+// MaxBufferedBytes configures an upper bound on the amount of memory that a
+// writer uses to buffer the rows of the row group being constructed.
+//
+// The memory accounted for includes the column buffers holding values that were
+// not yet encoded, the dictionaries, and the encoded pages retained in memory
+// until the row group is flushed. When the limit is crossed, the writer flushes
+// the current row group early; applications that prefer producing fewer, larger
+// row groups may combine this option with SpillBuffers to move the encoded pages
+// out of memory instead.
+//
+// Note that the limit is checked between batches of rows, so the memory
+// footprint of a writer may briefly exceed it.
+//
+// Defaults to unlimited.
+func MaxBufferedBytes(size int64) WriterOption {
+	return writerOption(func(config *WriterConfig) { config.MaxBufferedBytes = size })
+}
+
+// MaxDictionarySize configures an upper bound on the size of the dictionary of
+// dictionary-encoded columns, in bytes.
+//
+// When the dictionary of a column chunk grows beyond the limit, the writer
+// stops inserting values in it and encodes the remaining pages of the column
+// chunk with the PLAIN encoding, which bounds the memory used by columns with
+// high cardinality. The dictionary page is still written for the pages that
+// were encoded before the fallback, and the next row group starts with an
+// empty dictionary. Readers can tell dictionary-encoded and fallback pages
+// apart from the encoding of each page, which is also summarized in the
+// encoding stats of the column chunk metadata.
+//
+// Note that the limit is checked between batches of rows, so the dictionary
+// may exceed it by the values of the last rows written before the fallback.
+//
+// Defaults to unlimited.
+func MaxDictionarySize(size int) WriterOption {
+	return writerOption(func(config *WriterConfig) { config.MaxDictionarySize = size })
+}
+
+// DataPageSize configures the target size of data pages, in bytes, measured
+// after encoding and before compression (the uncompressed page size recorded
+// in page headers).
+//
+// By default, columns flush a page when the in-memory buffer of its values
+// reaches the PageBufferSize, which does not account for the encoding of the
+// values: dictionary-encoded and delta-encoded pages are usually much smaller
+// than their buffers. With this option, each column adjusts the size of its
+// buffer to the ratio between the sizes of the encoded pages and their
+// buffers observed on the previous pages, so pages get close to the target
+// regardless of the encoding. When a column falls back from the dictionary to
+// the PLAIN encoding (see MaxDictionarySize), the ratio is learned again from
+// the pages written with the new encoding. Buffers at most double in size
+// after each page and never grow beyond four times the larger of the data page
+// size and PageBufferSize, which bounds the memory used by columns of values
+// that encode to very small pages (e.g. constant values); the pages of those
+// columns are then smaller than the target.
+//
+// The target is a soft limit: pages always contain whole rows, and the first
+// page of a column chunk is only sized by the target. Row group boundaries
+// take precedence, when a row group is flushed (because it reached the
+// MaxRowsPerRowGroup or MaxBufferedBytes limits, or Flush was called), the
+// pages of all columns are flushed as well, so the last page of each column
+// chunk may be smaller than the target. Row groups smaller than the target
+// hold a single page per column.
+//
+// Defaults to zero, which sizes pages with PageBufferSize.
+func DataPageSize(size int) WriterOption {
+	return writerOption(func(config *WriterConfig) { config.DataPageSize = size })
+}
+
+// SchemaName configures the name of the root node of the schema written in the
+// footer of parquet files, which readers usually present as the name of the
+// message.
+//
+// Schemas generated from Go types are named after the type, this option allows
+// programs to use a different name, for example when the files are consumed by
+// a system which expects a specific message name. Only the root node is
+// renamed, the paths of columns are unchanged since they do not include the
+// name of the root.
+//
+// Defaults to the name of the schema that the writer was configured with.
+func SchemaName(name string) WriterOption {
+	return writerOption(func(config *WriterConfig) { config.SchemaName = name })
+}
+
+// SpillBuffers configures the buffer pool used by writers to spill column pages
+// when the limit configured by MaxBufferedBytes is crossed.
+//
+// When spilling, the writer flushes the partially filled column buffers to new
+// pages and moves all the pages held in memory to buffers obtained from the
+// pool; the pages are read back from those buffers when the row group is
+// written to the output. The row group is only flushed early if spilling did
+// not bring the memory usage back under the limit (e.g. because dictionaries
+// grew too large).
+//
+// The option is usually set to a pool created by NewFileBufferPool. It has no
+// effect unless MaxBufferedBytes is also set.
+func SpillBuffers(buffers BufferPool) WriterOption {
+	return writerOption(func(config *WriterConfig) { config.SpillBuffers = buffers })
+}
+
+// VerifyOnClose configures writers to read back the files they produce when
+// they are closed, and verify that the number of rows and a hash of the values
+// of each column match what was written. Close returns an error wrapping
+// ErrVerificationFailed if the verification does not succeed.
+//
+// When the output implements io.ReaderAt and io.Seeker (e.g. *os.File), the
+// file is read back from the output, starting at the position that the output
+// was at when the writer was created or reset; the output must then be open
+// for reading as well as writing. Otherwise, the writer retains a copy of the
+// bytes written in memory.
+//
+// Verifying files roughly doubles the cost of writing them, the option should
+// only be enabled when data integrity matters more than write throughput.
+//
+// Defaults to false.
+func VerifyOnClose(enabled bool) WriterOption {
+	return writerOption(func(config *WriterConfig) { config.VerifyOnClose = enabled })
+}
+
+// CanonicalizeFloats configures writers to normalize the values of FLOAT and
+// DOUBLE columns: negative zeros are written as positive zeros, and NaN values
+// are all written with the same quiet NaN bit pattern.
+//
+// Normalizing floating point values guarantees that writing the same logical
+// values produces byte-identical files, and that -0.0 and 0.0 are not recorded
+// as distinct min and max values in the column statistics.
+//
+// Defaults to false.
+func CanonicalizeFloats(enabled bool) WriterOption {
+	return writerOption(func(config *WriterConfig) { config.CanonicalizeFloats = enabled })
+}
+
+// DeterministicOutput configures writers to produce byte-identical files when
+// given identical rows and options, which is useful to store files in content
+// addressed systems or to compare them in tests.
+//
+// When enabled, the key/value metadata are sorted by key when the file footer
+// is written, regardless of the order in which they were set, and the default
+// "created_by" metadata omits the version of the library, which depends on
+// the build of the program. A value configured with CreatedBy is retained.
+// The keys of maps are written in ascending order instead of the iteration
+// order of Go maps, which is random.
+//
+// Defaults to false.
+func DeterministicOutput(enabled bool) WriterOption {
+	return writerOption(func(config *WriterConfig) { config.DeterministicOutput = enabled })
+}
+
+// ConstantColumns configures writers to detect pages where all the non-null
+// values of a column are equal, and encode them with a dictionary holding this
+// single value. The indexes of such pages are written as a single run of zeros
+// taking only a few bytes, which drastically reduces the size of columns that
+// repeat the same value in every row of a row group, such as partition keys.
+//
+// Pages of a column chunk whose values differ, or whose value is different from
+// the one of the previous constant pages, are written with the encoding of the
+// column. The option has no effect on dictionary-encoded columns, nor on FLOAT
+// and DOUBLE columns where the page bounds cannot tell whether the values are
+// bitwise identical.
+//
+// Defaults to false.
+func ConstantColumns(enabled bool) WriterOption {
+	return writerOption(func(config *WriterConfig) { config.ConstantColumns = enabled })
+}
+
+// MetadataChecksums configures writers to record CRC32 checksums of the file
+// metadata and of the metadata of each column chunk, complementing the page
+// checksums which only cover the page data. The checksums are stored in the
+// key/value metadata of the file and of the column chunks, which keeps the
+// files readable by all parquet implementations.
+//
+// Files written with this option can be opened with VerifyMetadataChecksums
+// to detect corruptions of the footer when the file is opened, instead of
+// reading invalid offsets or statistics.
+//
+// Defaults to false.
+func MetadataChecksums(enabled bool) WriterOption {
+	return writerOption(func(config *WriterConfig) { config.MetadataChecksums = enabled })
+}
+
 // CreatedBy creates a configuration option which sets the name of the
 // application that created a parquet file.
 //
@@ -653,6 +1221,54 @@ func Compression(codec compress.Codec) WriterOption {
 	return writerOption(func(config *WriterConfig) { config.Compression = codec })
 }
 
+// AdaptiveCompression creates a configuration option which makes writers
+// select the compression codec of each column chunk among the list of codecs
+// passed as arguments.
+//
+// When the first page of a column chunk is written, a sample of the page data
+// is compressed with each of the codecs, and the codec producing the smallest
+// output is used for all the pages of the column chunk. Different columns, and
+// different row groups of the same column, may therefore end up compressed
+// with different codecs. To bound the cost of the selection, samples are
+// limited to the first 64 KiB of the page data.
+//
+// Columns which declare their compression codec explicitly (e.g. with struct
+// tags) are not affected by this option, nor are columns when the option is
+// called with an empty list of codecs.
+func AdaptiveCompression(codecs ...compress.Codec) WriterOption {
+	codecs = slices.Clone(codecs)
+	return writerOption(func(config *WriterConfig) { config.AdaptiveCompression = codecs })
+}
+
+// NullBitmapColumn describes a boolean column emitted by writers next to an
+// optional column, see NullBitmap for details.
+type NullBitmapColumn struct {
+	// Name of the boolean column, which is added at the root of the schema.
+	Name string
+	// Path to the optional column that the null bitmap is computed from.
+	Path []string
+}
+
+// NullBitmap creates a configuration option which makes writers emit a boolean
+// column with the given name, holding true for each row where the value of the
+// optional column at path is not null, and false otherwise.
+//
+// The information is redundant with the definition levels of the optional
+// column, but some analytics engines prefer consuming an explicit presence
+// mask. The column is computed when pages of the optional column are written,
+// applications do not provide its values; it is added after the other columns
+// at the root of the schema of the file, while the schema used to write rows
+// is left unchanged. The optional column must not be nested in a repeated
+// field, and the name must not conflict with the fields at the root of the
+// schema, otherwise the writer constructor panics.
+//
+// This option is additive, it may be used multiple times to emit null bitmaps
+// of multiple columns.
+func NullBitmap(name string, path ...string) WriterOption {
+	column := NullBitmapColumn{Name: name, Path: slices.Clone(path)}
+	return writerOption(func(config *WriterConfig) { config.NullBitmaps = append(config.NullBitmaps, column) })
+}
+
 // SortingWriterConfig is a writer option which applies configuration specific
 // to sorting writers.
 func SortingWriterConfig(options ...SortingOption) WriterOption {
@@ -660,6 +1276,28 @@ func SortingWriterConfig(options ...SortingOption) WriterOption {
 	return writerOption(func(config *WriterConfig) { config.Sorting.Apply(options...) })
 }
 
+// SortingColumnsChecked is a writer option which records the sorting columns
+// in the metadata of row groups, like SortingWriterConfig(SortingColumns(...)),
+// and verifies that the rows are written in this order.
+//
+// Each row is compared to the previous one with the Compare method of the
+// types of the sorting columns. When a row is out of order, the rows preceding
+// it are written, and the write method returns an error wrapping
+// ErrUnsortedRows. This prevents producing files which declare sorting columns
+// that their rows do not follow, at the cost of comparing each row written.
+//
+// Row groups written with WriteRowGroup are verified as well. When a row group
+// declares that it is sorted on the checked columns, only its first row is
+// compared to the rows written before it and its pages can still be copied to
+// the output; the rows of other row groups are decoded to be compared.
+func SortingColumnsChecked(columns ...SortingColumn) WriterOption {
+	columns = slices.Clone(columns)
+	return writerOption(func(config *WriterConfig) {
+		config.Sorting.SortingColumns = columns
+		config.CheckSortingOrder = true
+	})
+}
+
 // SkipPageBounds lists the path to a column that shouldn't have bounds written to the
 // footer of the parquet file. This is useful for data blobs, like a raw html file,
 // where the bounds are not meaningful.
@@ -669,6 +1307,44 @@ func SkipPageBounds(path ...string) WriterOption {
 	return writerOption(func(config *WriterConfig) { config.SkipPageBounds = append(config.SkipPageBounds, path) })
 }
 
+// ColumnIndexOrder creates a configuration option which defines the exact order
+// in which leaf columns are assigned column indexes in the written files,
+// overriding the default depth-first order of the schema fields.
+//
+// The list of columns must be a permutation of all the leaf columns of the
+// schema. Since parquet lays out columns by walking the schema, the leaves of
+// a group must remain contiguous in the requested order; the writer panics if
+// the order is not a permutation of the schema leaves or cannot be represented.
+//
+// This option is useful to align the layout of parquet files with external
+// catalogs which expect columns at specific indexes.
+func ColumnIndexOrder(columns ...[]string) WriterOption {
+	columns = slices.Clone(columns)
+	return writerOption(func(config *WriterConfig) { config.ColumnIndexOrder = columns })
+}
+
+// ColumnChunkGroups creates a configuration option which changes the physical
+// order of column chunks in row groups, laying out the chunks of the columns
+// of each group next to each other. Clustering columns with similar data, or
+// columns which are usually read together, improves the locality of reads
+// that only touch a subset of the columns.
+//
+// Each group is a list of paths to leaf columns or to groups of the schema,
+// which select all the leaf columns they contain. The chunks are written in
+// the order of the groups, followed by the chunks of the columns which are
+// not part of any group in the order of the schema. The writer panics if a
+// path does not exist in the schema or if a column is part of more than one
+// group.
+//
+// The option only changes the position of the column chunks in the file: the
+// schema, the column indexes and the order of the column chunks in the file
+// metadata remain the same, which keeps the files readable by any parquet
+// reader.
+func ColumnChunkGroups(groups ...[][]string) WriterOption {
+	groups = slices.Clone(groups)
+	return writerOption(func(config *WriterConfig) { config.ColumnChunkGroups = groups })
+}
+
 // DefaultEncodingFor creates a configuration option which sets the default encoding
 // used by a writer for columns with the specified primitive type where none were defined.
 //
@@ -754,6 +1430,144 @@ func DropDuplicatedRows(drop bool) SortingOption {
 	return sortingOption(func(config *SortingConfig) { config.DropDuplicatedRows = drop })
 }
 
+// MakePointersRequired is a schema configuration option which makes struct
+// fields of pointer types generate required columns instead of optional ones.
+//
+// The option is useful for Go types which use pointers for convenience rather
+// than to express nullability. Fields explicitly tagged "optional" still
+// generate optional columns. Writing rows where required pointer fields are
+// nil results in an error.
+//
+// Pointers in slice elements and map values are not affected by the option.
+func MakePointersRequired() SchemaOption {
+	return schemaOption(func(config *SchemaConfig) { config.PointersRequired = true })
+}
+
+// SortMapKeys is a schema configuration option which makes Go maps generate
+// columns of sorted maps, where keys are unique and written in ascending order
+// (see SortedMap).
+//
+// The option is useful to produce canonical files, where writing the same
+// rows always results in the same output regardless of the iteration order of
+// Go maps.
+func SortMapKeys() SchemaOption {
+	return schemaOption(func(config *SchemaConfig) { config.SortMapKeys = true })
+}
+
+// NormalizeTimestamps is a schema configuration option which makes all the
+// timestamp columns of the schema use the given time unit, regardless of the
+// unit declared in struct tags.
+//
+// The option is useful when Go types mix timestamp precisions, for example
+// because they were defined by different teams, while the programs consuming
+// the parquet files prefer uniform units. Values of time.Time fields are
+// converted to the unit when written. Values of int64 fields, which carry
+// timestamps expressed in the unit of their struct tag, are scaled to the unit
+// when written and back to the unit of the field when read. Values converted to
+// a coarser unit are truncated.
+//
+// Defaults to using the units declared in struct tags.
+func NormalizeTimestamps(unit TimeUnit) SchemaOption {
+	return schemaOption(func(config *SchemaConfig) { config.TimestampUnit = unit })
+}
+
+// MaxRowsPerFile configures the maximum number of rows that a multi-file
+// writer writes to each file before rolling over to a new file.
+//
+// Defaults to unlimited.
+func MaxRowsPerFile(numRows int64) MultiFileOption {
+	return multiFileOption(func(config *MultiFileConfig) { config.MaxRowsPerFile = numRows })
+}
+
+// MaxBytesPerFile configures the size at which a multi-file writer rolls over
+// to a new file.
+//
+// The size of a file is estimated from the bytes already written to it and the
+// memory held by its buffered row group, the actual size of files may differ
+// from the limit after the buffered rows get encoded and compressed.
+//
+// Defaults to unlimited.
+func MaxBytesPerFile(size int64) MultiFileOption {
+	return multiFileOption(func(config *MultiFileConfig) { config.MaxBytesPerFile = size })
+}
+
+// ManifestKeyColumn configures the column which a multi-file writer records
+// the minimum and maximum values of in the manifest entries of each file.
+//
+// The path must be the path of a leaf column in the schema of the files.
+func ManifestKeyColumn(path ...string) MultiFileOption {
+	path = slices.Clone(path)
+	return multiFileOption(func(config *MultiFileConfig) { config.KeyColumn = path })
+}
+
+// MultiFileWriterOptions configures the options applied to the writer of each
+// file created by a multi-file writer.
+func MultiFileWriterOptions(options ...WriterOption) MultiFileOption {
+	options = slices.Clone(options)
+	return multiFileOption(func(config *MultiFileConfig) {
+		config.WriterOptions = append(config.WriterOptions, options...)
+	})
+}
+
+// MaxOpenPartitions configures the maximum number of partition files that a
+// partitioned writer keeps open at the same time.
+//
+// When the limit is reached, the least recently written partition file is
+// closed; if rows are later written to the same partition, they are written to
+// a new file in the partition directory.
+//
+// Defaults to 64.
+func MaxOpenPartitions(numPartitions int) PartitionOption {
+	return partitionOption(func(config *PartitionConfig) { config.MaxOpenPartitions = numPartitions })
+}
+
+// PartitionWriterOptions configures the options applied to the writer of each
+// file created by a partitioned writer.
+func PartitionWriterOptions(options ...WriterOption) PartitionOption {
+	options = slices.Clone(options)
+	return partitionOption(func(config *PartitionConfig) {
+		config.WriterOptions = append(config.WriterOptions, options...)
+	})
+}
+
+type partitionOption func(*PartitionConfig)
+
+func (opt partitionOption) ConfigurePartition(config *PartitionConfig) { opt(config) }
+
+// CSVDelimiter configures the character separating fields of CSV records.
+//
+// Defaults to ','.
+func CSVDelimiter(delimiter rune) CSVOption {
+	return csvOption(func(config *CSVConfig) { config.Delimiter = delimiter })
+}
+
+// CSVSkipHeader is a CSV configuration option which prevents writing a header
+// record with the names of the columns, when set to true.
+//
+// Defaults to false.
+func CSVSkipHeader(skip bool) CSVOption {
+	return csvOption(func(config *CSVConfig) { config.SkipHeader = skip })
+}
+
+// CSVNullToken configures the text written to CSV fields for null values.
+//
+// Defaults to an empty string.
+func CSVNullToken(token string) CSVOption {
+	return csvOption(func(config *CSVConfig) { config.NullToken = token })
+}
+
+type csvOption func(*CSVConfig)
+
+func (opt csvOption) ConfigureCSV(config *CSVConfig) { opt(config) }
+
+type multiFileOption func(*MultiFileConfig)
+
+func (opt multiFileOption) ConfigureMultiFile(config *MultiFileConfig) { opt(config) }
+
+type schemaOption func(*SchemaConfig)
+
+func (opt schemaOption) ConfigureSchema(config *SchemaConfig) { opt(config) }
+
 type fileOption func(*FileConfig)
 
 func (opt fileOption) ConfigureFile(config *FileConfig) { opt(config) }
@@ -778,6 +1592,13 @@ func coalesceBool(i1, i2 bool) bool {
 	return i1 || i2
 }
 
+func coalesceRune(r1, r2 rune) rune {
+	if r1 != 0 {
+		return r1
+	}
+	return r2
+}
+
 func coalesceInt(i1, i2 int) int {
 	if i1 != 0 {
 		return i1
@@ -849,6 +1670,34 @@ func coalesceSkipPageBounds(b1, b2 [][]string) [][]string {
 	return b2
 }
 
+func coalesceColumnPaths(p1, p2 [][]string) [][]string {
+	if p1 != nil {
+		return p1
+	}
+	return p2
+}
+
+func coalesceColumnChunkGroups(g1, g2 [][][]string) [][][]string {
+	if g1 != nil {
+		return g1
+	}
+	return g2
+}
+
+func coalesceStrings(s1, s2 []string) []string {
+	if s1 != nil {
+		return s1
+	}
+	return s2
+}
+
+func coalesceCodecs(c1, c2 []compress.Codec) []compress.Codec {
+	if c1 != nil {
+		return c1
+	}
+	return c2
+}
+
 func coalesceCompression(c1, c2 compress.Codec) compress.Codec {
 	if c1 != nil {
 		return c1
@@ -856,6 +1705,34 @@ func coalesceCompression(c1, c2 compress.Codec) compress.Codec {
 	return c2
 }
 
+func coalesceNullBitmaps(b1, b2 []NullBitmapColumn) []NullBitmapColumn {
+	if b1 != nil {
+		return b1
+	}
+	return b2
+}
+
+func coalesceColumnTransforms(t1, t2 []*ColumnTransform) []*ColumnTransform {
+	if t1 != nil {
+		return t1
+	}
+	return t2
+}
+
+func coalesceOpenFunc(f1, f2 func(string) (io.ReaderAt, error)) func(string) (io.ReaderAt, error) {
+	if f1 != nil {
+		return f1
+	}
+	return f2
+}
+
+func coalesceTimeUnit(u1, u2 TimeUnit) TimeUnit {
+	if u1 != nil {
+		return u1
+	}
+	return u2
+}
+
 func validatePositiveInt(optionName string, optionValue int) error {
 	if optionValue > 0 {
 		return nil
@@ -870,6 +1747,13 @@ func validatePositiveInt64(optionName string, optionValue int64) error {
 	return errorInvalidOptionValue(optionName, optionValue)
 }
 
+func validateNonNegativeInt64(optionName string, optionValue int64) error {
+	if optionValue >= 0 {
+		return nil
+	}
+	return errorInvalidOptionValue(optionName, optionValue)
+}
+
 func validateOneOfInt(optionName string, optionValue int, supportedValues ...int) error {
 	if slices.Contains(supportedValues, optionValue) {
 		return nil
@@ -877,6 +1761,26 @@ func validateOneOfInt(optionName string, optionValue int, supportedValues ...int
 	return errorInvalidOptionValue(optionName, optionValue)
 }
 
+func validateCSVDelimiter(optionName string, optionValue rune) error {
+	switch optionValue {
+	case '"', '\r', '\n', utf8.RuneError:
+	default:
+		if utf8.ValidRune(optionValue) {
+			return nil
+		}
+	}
+	return errorInvalidOptionValue(optionName, strconv.QuoteRune(optionValue))
+}
+
+func validateColumnTransforms(optionName string, optionValue []*ColumnTransform) error {
+	for _, t := range optionValue {
+		if t.Name == "" || t.Forward == nil || t.Inverse == nil {
+			return errorInvalidOptionValue(optionName, columnPath(t.Path))
+		}
+	}
+	return nil
+}
+
 func validateNotNil(optionName string, optionValue any) error {
 	if optionValue != nil {
 		return nil
@@ -214,9 +214,13 @@ func (c *conversion) Convert(rows []Row) (int, error) {
 			// taget columns we ensure that the right value is always written
 			// to the output row.
 			for i := range columnValues {
-				// Fix: If we have a zero Value{}, convert it to a properly typed null value
-				if columnValues[i].Kind() == Kind(0) {
-					columnValues[i] = ZeroValue(conv.targetKind)
+				// Zero values carry no kind, they are converted to properly
+				// typed null values of the target column.
+				if v := &columnValues[i]; v.kind == 0 {
+					nullValue := ZeroValue(conv.targetKind)
+					nullValue.repetitionLevel = v.repetitionLevel
+					nullValue.definitionLevel = v.definitionLevel
+					*v = nullValue
 				}
 
 				columnValues[i].columnIndex = ^int16(columnIndex)
@@ -866,6 +870,24 @@ func convertByteArrayToInt96(v Value) (Value, error) {
 	}), nil
 }
 
+func convertFloat16ToFloat(v Value) (Value, error) {
+	return v.convertToFloat(float16ToFloat32(binary.LittleEndian.Uint16(v.byteArray()))), nil
+}
+
+func convertFloat16ToDouble(v Value) (Value, error) {
+	return v.convertToDouble(float64(float16ToFloat32(binary.LittleEndian.Uint16(v.byteArray())))), nil
+}
+
+func convertFloatToFloat16(v Value) (Value, error) {
+	b := binary.LittleEndian.AppendUint16(nil, float32ToFloat16(v.float()))
+	return v.convertToFixedLenByteArray(b), nil
+}
+
+func convertDoubleToFloat16(v Value) (Value, error) {
+	b := binary.LittleEndian.AppendUint16(nil, float32ToFloat16(float32(v.double())))
+	return v.convertToFixedLenByteArray(b), nil
+}
+
 func convertByteArrayToFloat(v Value) (Value, error) {
 	b := make([]byte, 4)
 	copy(b, v.byteArray())
@@ -1040,6 +1062,14 @@ func convertTimestampToTimeMicros(v Value, u format.TimeUnit, sourceZone, target
 	return v.convertToInt64(int64(microseconds)), nil
 }
 
+func convertTimeToTime(v Value, sourceUnit, targetUnit format.TimeUnit) (Value, error) {
+	n := scaleTimestamp(v.int64(), sourceUnit, targetUnit)
+	if targetUnit.Millis != nil {
+		return v.convertToInt32(int32(n)), nil
+	}
+	return v.convertToInt64(n), nil
+}
+
 func convertTimestampToTimestamp(v Value, sourceUnit, targetUnit format.TimeUnit) (Value, error) {
 	sourceScale := timeUnitDuration(sourceUnit).Nanoseconds()
 	targetScale := timeUnitDuration(targetUnit).Nanoseconds()
@@ -1,7 +1,11 @@
 package parquet_test
 
 import (
+	"bytes"
+	"math"
+	"math/big"
 	"reflect"
+	"slices"
 	"testing"
 	"time"
 
@@ -442,6 +446,17 @@ var conversionTests = [...]struct {
 			Extra: "",
 		},
 	},
+
+	{
+		scenario: "keep boolean values when removing a column",
+		from: struct {
+			ID     int64 `parquet:"id"`
+			Active bool  `parquet:"active"`
+		}{ID: 1, Active: true},
+		to: struct {
+			Active bool `parquet:"active"`
+		}{Active: true},
+	},
 }
 
 func TestConvert(t *testing.T) {
@@ -479,6 +494,51 @@ func TestConvert(t *testing.T) {
 	}
 }
 
+func TestConvertZeroValues(t *testing.T) {
+	type From struct {
+		ID   int64   `parquet:"id"`
+		Name *string `parquet:"name,optional"`
+		Flag bool    `parquet:"flag"`
+	}
+	type To struct {
+		Name *string `parquet:"name,optional"`
+		Flag bool    `parquet:"flag"`
+	}
+
+	to := parquet.SchemaOf(To{})
+	from := parquet.SchemaOf(From{})
+	conv, err := parquet.Convert(to, from)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rows := []parquet.Row{from.Deconstruct(nil, From{ID: 1, Flag: true})}
+	if _, err := conv.Convert(rows); err != nil {
+		t.Fatal(err)
+	}
+
+	// The null value of the name column is typed, while the boolean value,
+	// which has a kind of zero, is retained.
+	row := rows[0]
+	if len(row) != 2 {
+		t.Fatalf("wrong number of values: %d", len(row))
+	}
+	if name := row[0]; name.Kind() != parquet.ByteArray || name.DefinitionLevel() != 0 || name.Column() != 0 {
+		t.Errorf("wrong null value of the name column: kind=%v definition level=%d column=%d", name.Kind(), name.DefinitionLevel(), name.Column())
+	}
+	if flag := row[1]; flag.Kind() != parquet.Boolean || !flag.Boolean() {
+		t.Errorf("wrong value of the flag column: %v", flag)
+	}
+
+	var got To
+	if err := to.Reconstruct(&got, row); err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != nil || !got.Flag {
+		t.Errorf("wrong reconstructed value: %+v", got)
+	}
+}
+
 func newInt64(i int64) *int64    { return &i }
 func newString(s string) *string { return &s }
 
@@ -1234,3 +1294,140 @@ type convertMissingColumn struct {
 func (m convertMissingColumn) Column(_ int) int                        { return -1 }
 func (m convertMissingColumn) Schema() *parquet.Schema                 { return m.schema }
 func (m convertMissingColumn) Convert(rows []parquet.Row) (int, error) { return len(rows), nil }
+
+// decimalBytes returns the big-endian two's complement representation of n
+// sign-extended to size bytes.
+func decimalBytes(n *big.Int, size int) []byte {
+	if n.Sign() >= 0 {
+		return n.FillBytes(make([]byte, size))
+	}
+	m := new(big.Int).Lsh(big.NewInt(1), uint(8*size))
+	return m.Add(m, n).FillBytes(make([]byte, size))
+}
+
+func TestConvertNegativeDecimals(t *testing.T) {
+	int64Type := parquet.Decimal(2, 18, parquet.Int64Type).Type()
+
+	for _, size := range []int{1, 4, 8, 9, 16} {
+		flbaType := parquet.Decimal(2, 18, parquet.FixedLenByteArrayType(size)).Type()
+		// The largest magnitude representable by size bytes is 2^(8*size-1).
+		limit := new(big.Int).Lsh(big.NewInt(1), uint(8*size-1))
+
+		for _, n := range []int64{0, 1, -1, -2, -128, -129, math.MinInt32, math.MinInt32 + 1, -999999999999999999, math.MinInt64, math.MaxInt64} {
+			fits := big.NewInt(n).CmpAbs(limit) < 0 || big.NewInt(n).Cmp(new(big.Int).Neg(limit)) == 0
+
+			v, err := flbaType.ConvertValue(parquet.Int64Value(n), int64Type)
+			if !fits {
+				if err == nil {
+					t.Errorf("FIXED_LEN_BYTE_ARRAY(%d): expected an error converting %d", size, n)
+				}
+				continue
+			}
+			if err != nil {
+				t.Fatalf("FIXED_LEN_BYTE_ARRAY(%d): converting %d: %v", size, n, err)
+			}
+			if got, want := v.ByteArray(), decimalBytes(big.NewInt(n), size); !bytes.Equal(got, want) {
+				t.Errorf("FIXED_LEN_BYTE_ARRAY(%d): wrong encoding of %d: want=%x got=%x", size, n, want, got)
+			}
+
+			r, err := int64Type.ConvertValue(v, flbaType)
+			if err != nil {
+				t.Fatalf("FIXED_LEN_BYTE_ARRAY(%d): converting %x back: %v", size, v.ByteArray(), err)
+			}
+			if r.Int64() != n {
+				t.Errorf("FIXED_LEN_BYTE_ARRAY(%d): wrong round-trip value: want=%d got=%d", size, n, r.Int64())
+			}
+		}
+	}
+
+	t.Run("precision boundary", func(t *testing.T) {
+		type Row struct {
+			Price [16]byte `parquet:"price,decimal(0:38)"`
+		}
+
+		maxValue := new(big.Int).Sub(new(big.Int).Exp(big.NewInt(10), big.NewInt(38), nil), big.NewInt(1))
+		values := []*big.Int{
+			new(big.Int).Neg(maxValue),
+			new(big.Int).Add(new(big.Int).Neg(maxValue), big.NewInt(1)),
+			big.NewInt(math.MinInt64),
+			big.NewInt(-1),
+			big.NewInt(0),
+			big.NewInt(1),
+			new(big.Int).Sub(maxValue, big.NewInt(1)),
+			maxValue,
+		}
+
+		rows := make([]Row, len(values))
+		for i, n := range values {
+			copy(rows[i].Price[:], decimalBytes(n, 16))
+		}
+		buf := new(bytes.Buffer)
+		if err := parquet.Write(buf, rows); err != nil {
+			t.Fatal(err)
+		}
+		got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(got, rows) {
+			t.Errorf("rows mismatch:\nwant: %x\ngot:  %x", rows, got)
+		}
+
+		typ := parquet.SchemaOf(Row{}).Fields()[0].Type()
+		for i := 1; i < len(rows); i++ {
+			a := parquet.FixedLenByteArrayValue(rows[i-1].Price[:])
+			b := parquet.FixedLenByteArrayValue(rows[i].Price[:])
+			if typ.Compare(a, b) >= 0 || typ.Compare(b, a) <= 0 {
+				t.Errorf("wrong ordering of %d and %d", values[i-1], values[i])
+			}
+		}
+
+		// Sign-extending to a larger type and truncating back must preserve
+		// the values.
+		wideType := parquet.Decimal(0, 38, parquet.FixedLenByteArrayType(20)).Type()
+		for i, n := range values {
+			v, err := wideType.ConvertValue(parquet.FixedLenByteArrayValue(rows[i].Price[:]), typ)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := decimalBytes(n, 20); !bytes.Equal(v.ByteArray(), want) {
+				t.Errorf("wrong sign extension of %d: want=%x got=%x", n, want, v.ByteArray())
+			}
+			v, err = typ.ConvertValue(v, wideType)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(v.ByteArray(), rows[i].Price[:]) {
+				t.Errorf("wrong truncation of %d: want=%x got=%x", n, rows[i].Price, v.ByteArray())
+			}
+		}
+	})
+
+	t.Run("read into int64", func(t *testing.T) {
+		type FixedLenByteArrayRow struct {
+			Price [9]byte `parquet:"price,decimal(2:20)"`
+		}
+		type Int64Row struct {
+			Price int64 `parquet:"price,decimal(2:20)"`
+		}
+
+		values := []int64{-1, -100, math.MinInt64, math.MaxInt64, 12345}
+		rows := make([]FixedLenByteArrayRow, len(values))
+		for i, n := range values {
+			copy(rows[i].Price[:], decimalBytes(big.NewInt(n), 9))
+		}
+		buf := new(bytes.Buffer)
+		if err := parquet.Write(buf, rows); err != nil {
+			t.Fatal(err)
+		}
+		got, err := parquet.Read[Int64Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, row := range got {
+			if row.Price != values[i] {
+				t.Errorf("row %d: wrong value: want=%d got=%d", i, values[i], row.Price)
+			}
+		}
+	})
+}
@@ -0,0 +1,224 @@
+package parquet
+
+import (
+	"encoding"
+	"fmt"
+	"maps"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parquet-go/parquet-go/deprecated"
+	"github.com/parquet-go/parquet-go/sparse"
+)
+
+// RegisterConverter registers functions converting Go values of type T to and
+// from parquet values, allowing types that the package does not know about
+// (e.g. decimal or identifier types from third-party packages) to be used in
+// the Go types that schemas are generated from.
+//
+// The node argument defines the parquet representation of values of type T. It
+// must be a leaf node; its type determines the kind of values that toParquet
+// must return and fromParquet receives. For example, a decimal type could be
+// registered with String() to be stored as text, or with a Decimal node to be
+// stored as a fixed-length byte array.
+//
+// Converters apply to struct fields of type T or *T, and to elements of slices
+// of T. Struct tags such as "optional" or the compression and encoding options
+// remain applicable to fields of type T.
+//
+// Schemas are cached when they are first created, programs must register their
+// converters before generating schemas of types using T, typically in an init
+// function. Registering a converter for a type which already has one replaces
+// the previous converter. The function panics if node is not a leaf.
+func RegisterConverter[T any](node Node, toParquet func(T) (Value, error), fromParquet func(Value) (T, error)) {
+	if !node.Leaf() {
+		panic("parquet converters must be registered with a leaf node")
+	}
+
+	t := reflect.TypeFor[T]()
+	c := &converter{
+		node: node,
+		toParquet: func(v reflect.Value) (Value, error) {
+			return toParquet(v.Interface().(T))
+		},
+		fromParquet: func(v Value, dst reflect.Value) error {
+			x, err := fromParquet(v)
+			if err != nil {
+				return err
+			}
+			dst.Set(reflect.ValueOf(&x).Elem())
+			return nil
+		},
+	}
+
+	convertersMutex.Lock()
+	defer convertersMutex.Unlock()
+
+	registry := make(map[reflect.Type]*converter)
+	if current := converters.Load(); current != nil {
+		maps.Copy(registry, *current)
+	}
+	registry[t] = c
+	converters.Store(&registry)
+}
+
+type converter struct {
+	node        Node
+	toParquet   func(reflect.Value) (Value, error)
+	fromParquet func(Value, reflect.Value) error
+}
+
+var (
+	// The registry is copied on write so lookups, which happen when
+	// converting each value, do not need to synchronize with registrations.
+	convertersMutex sync.Mutex
+	converters      atomic.Pointer[map[reflect.Type]*converter]
+)
+
+func lookupConverter(t reflect.Type) *converter {
+	if registry := converters.Load(); registry != nil {
+		if c := (*registry)[t]; c != nil {
+			return c
+		}
+	}
+	if t == intervalValueType {
+		return &intervalConverter
+	}
+	return binaryMarshalerConverterOf(t)
+}
+
+// converterCache remembers the converter of the last Go type seen by the
+// function converting the values of a leaf column. Functions almost always see
+// values of the same type, which saves looking up the registry for each value.
+type converterCache struct {
+	last atomic.Pointer[cachedConverter]
+}
+
+type cachedConverter struct {
+	typ       reflect.Type
+	converter *converter
+}
+
+func (cache *converterCache) lookup(t reflect.Type) *converter {
+	if c := cache.last.Load(); c != nil && c.typ == t {
+		return c.converter
+	}
+	c := &cachedConverter{typ: t, converter: lookupConverter(t)}
+	cache.last.Store(c)
+	return c.converter
+}
+
+var (
+	binaryMarshalerType   = reflect.TypeFor[encoding.BinaryMarshaler]()
+	binaryUnmarshalerType = reflect.TypeFor[encoding.BinaryUnmarshaler]()
+
+	// Cache of the converters of types implementing encoding.BinaryMarshaler
+	// and encoding.BinaryUnmarshaler, types which do not implement them are
+	// cached with a nil converter.
+	binaryMarshalerConverters sync.Map // map[reflect.Type]*converter
+)
+
+// binaryMarshalerConverterOf returns a converter storing values of type t as
+// BYTE_ARRAY values if t implements encoding.BinaryMarshaler and
+// encoding.BinaryUnmarshaler, or nil if it does not.
+func binaryMarshalerConverterOf(t reflect.Type) *converter {
+	if c, ok := binaryMarshalerConverters.Load(t); ok {
+		return c.(*converter)
+	}
+	var c *converter
+	if isBinaryMarshalerType(t) {
+		c = &converter{
+			node: Leaf(ByteArrayType),
+			toParquet: func(v reflect.Value) (Value, error) {
+				if !t.Implements(binaryMarshalerType) {
+					// The method has a pointer receiver.
+					if v.CanAddr() {
+						v = v.Addr()
+					} else {
+						p := reflect.New(t)
+						p.Elem().Set(v)
+						v = p
+					}
+				}
+				b, err := v.Interface().(encoding.BinaryMarshaler).MarshalBinary()
+				if err != nil {
+					return Value{}, err
+				}
+				return ByteArrayValue(b), nil
+			},
+			fromParquet: func(v Value, dst reflect.Value) error {
+				p := reflect.New(t)
+				if err := p.Interface().(encoding.BinaryUnmarshaler).UnmarshalBinary(v.byteArray()); err != nil {
+					return err
+				}
+				dst.Set(p.Elem())
+				return nil
+			},
+		}
+	}
+	actual, _ := binaryMarshalerConverters.LoadOrStore(t, c)
+	return actual.(*converter)
+}
+
+func isBinaryMarshalerType(t reflect.Type) bool {
+	switch t {
+	case reflect.TypeOf(deprecated.Int96{}), reflect.TypeOf(uuid.UUID{}), reflect.TypeOf(time.Time{}):
+		// These types have a native parquet representation.
+		return false
+	}
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return false
+	}
+	p := reflect.PointerTo(t)
+	return p.Implements(binaryMarshalerType) && p.Implements(binaryUnmarshalerType)
+}
+
+func (c *converter) makeValue(value reflect.Value) Value {
+	v, err := c.toParquet(value)
+	if err != nil {
+		panic(fmt.Errorf("converting Go value of type %s to parquet value: %w", value.Type(), err))
+	}
+	return v
+}
+
+func (c *converter) assignValue(dst reflect.Value, src Value) error {
+	if err := c.fromParquet(src, dst); err != nil {
+		return fmt.Errorf("converting parquet value to Go value of type %s: %w", dst.Type(), err)
+	}
+	return nil
+}
+
+func writeRowsFuncOfConverter(t reflect.Type, schema *Schema, path columnPath, c *converter) writeRowsFunc {
+	column := schema.lazyLoadState().mapping.lookup(path)
+	columnIndex := column.columnIndex
+	if columnIndex < 0 {
+		panic("parquet: column not found: " + path.String())
+	}
+	valueColumnIndex := ^columnIndex
+
+	return func(columns []ColumnBuffer, rows sparse.Array, levels columnLevels) error {
+		if rows.Len() == 0 {
+			columns[columnIndex].writeValues(rows, levels)
+			return nil
+		}
+
+		values := make([]Value, rows.Len())
+		for i := range values {
+			v, err := c.toParquet(reflect.NewAt(t, rows.Index(i)).Elem())
+			if err != nil {
+				return fmt.Errorf("converting Go value of type %s to parquet value: %w", t, err)
+			}
+			v.repetitionLevel = levels.repetitionLevel
+			v.definitionLevel = levels.definitionLevel
+			v.columnIndex = valueColumnIndex
+			values[i] = v
+		}
+
+		_, err := columns[columnIndex].WriteValues(values)
+		return err
+	}
+}
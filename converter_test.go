@@ -0,0 +1,208 @@
+package parquet_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// money emulates a third-party type with unexported fields, which the package
+// would not know how to represent without a converter.
+type money struct {
+	units int64
+	cents int64
+}
+
+func (m money) String() string { return fmt.Sprintf("%d.%02d", m.units, m.cents) }
+
+// label emulates a type which has a natural string representation but a
+// different underlying Go type.
+type label struct{ parts []string }
+
+// point is an opaque type implementing encoding.BinaryMarshaler and
+// encoding.BinaryUnmarshaler, which the package stores as a byte array.
+type point struct {
+	x, y int32
+}
+
+func (p point) MarshalBinary() ([]byte, error) {
+	return binary.BigEndian.AppendUint32(binary.BigEndian.AppendUint32(nil, uint32(p.x)), uint32(p.y)), nil
+}
+
+func (p *point) UnmarshalBinary(b []byte) error {
+	if len(b) != 8 {
+		return fmt.Errorf("invalid point of length %d", len(b))
+	}
+	p.x = int32(binary.BigEndian.Uint32(b))
+	p.y = int32(binary.BigEndian.Uint32(b[4:]))
+	return nil
+}
+
+func init() {
+	parquet.RegisterConverter(parquet.Decimal(2, 18, parquet.Int64Type),
+		func(m money) (parquet.Value, error) {
+			return parquet.Int64Value(m.units*100 + m.cents), nil
+		},
+		func(v parquet.Value) (money, error) {
+			return money{units: v.Int64() / 100, cents: v.Int64() % 100}, nil
+		},
+	)
+
+	parquet.RegisterConverter(parquet.String(),
+		func(l label) (parquet.Value, error) {
+			if len(l.parts) == 0 {
+				return parquet.Value{}, errors.New("empty label")
+			}
+			return parquet.ByteArrayValue([]byte(strings.Join(l.parts, "/"))), nil
+		},
+		func(v parquet.Value) (label, error) {
+			return label{parts: strings.Split(v.String(), "/")}, nil
+		},
+	)
+}
+
+func TestRegisterConverter(t *testing.T) {
+	type Row struct {
+		ID      int64   `parquet:"id"`
+		Price   money   `parquet:"price"`
+		Refund  *money  `parquet:"refund,optional"`
+		Labels  []label `parquet:"labels,list"`
+		Primary label   `parquet:"primary,dict"`
+	}
+
+	schema := parquet.SchemaOf(new(Row))
+	const want = `message Row {
+	required int64 id (INT(64,true));
+	required int64 price (DECIMAL(18,2));
+	optional int64 refund (DECIMAL(18,2));
+	required group labels (LIST) {
+		repeated group list {
+			required binary element (STRING);
+		}
+	}
+	required binary primary (STRING);
+}`
+	if got := schema.String(); got != want {
+		t.Fatalf("wrong schema:\nwant:\n%s\ngot:\n%s", want, got)
+	}
+
+	rows := []Row{
+		{
+			ID:      1,
+			Price:   money{units: 12, cents: 34},
+			Labels:  []label{{parts: []string{"a", "b"}}, {parts: []string{"c"}}},
+			Primary: label{parts: []string{"x"}},
+		},
+		{
+			ID:      2,
+			Price:   money{units: 5},
+			Refund:  &money{units: 1, cents: 50},
+			Labels:  []label{},
+			Primary: label{parts: []string{"y", "z"}},
+		},
+	}
+
+	t.Run("GenericWriter", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		if err := parquet.Write(buf, rows); err != nil {
+			t.Fatal(err)
+		}
+		got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, rows) {
+			t.Errorf("rows mismatch:\nwant = %+v\ngot  = %+v", rows, got)
+		}
+	})
+
+	t.Run("Deconstruct", func(t *testing.T) {
+		for _, want := range rows {
+			row := schema.Deconstruct(nil, &want)
+			if price := row[1]; price.Int64() != want.Price.units*100+want.Price.cents {
+				t.Errorf("wrong price value: %v", price)
+			}
+			var got Row
+			if err := schema.Reconstruct(&got, row); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("row mismatch:\nwant = %+v\ngot  = %+v", want, got)
+			}
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		w := parquet.NewGenericWriter[Row](buf)
+		_, err := w.Write([]Row{{ID: 3}})
+		if err == nil || !strings.Contains(err.Error(), "empty label") {
+			t.Errorf("expected the error of the converter to be returned, got %v", err)
+		}
+	})
+}
+
+func TestBinaryMarshalerFields(t *testing.T) {
+	type Row struct {
+		ID     int64   `parquet:"id"`
+		Origin point   `parquet:"origin"`
+		Target *point  `parquet:"target,optional"`
+		Path   []point `parquet:"path,list"`
+	}
+
+	schema := parquet.SchemaOf(new(Row))
+	const want = `message Row {
+	required int64 id (INT(64,true));
+	required binary origin;
+	optional binary target;
+	required group path (LIST) {
+		repeated group list {
+			required binary element;
+		}
+	}
+}`
+	if got := schema.String(); got != want {
+		t.Fatalf("wrong schema:\nwant:\n%s\ngot:\n%s", want, got)
+	}
+
+	rows := []Row{
+		{ID: 1, Origin: point{x: 1, y: -2}, Path: []point{{x: 3, y: 4}, {x: 5, y: 6}}},
+		{ID: 2, Target: &point{x: -7, y: 8}, Path: []point{}},
+	}
+
+	t.Run("GenericWriter", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		if err := parquet.Write(buf, rows); err != nil {
+			t.Fatal(err)
+		}
+		got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, rows) {
+			t.Errorf("rows mismatch:\nwant = %+v\ngot  = %+v", rows, got)
+		}
+	})
+
+	t.Run("Deconstruct", func(t *testing.T) {
+		for _, want := range rows {
+			row := schema.Deconstruct(nil, want)
+			if b, _ := want.Origin.MarshalBinary(); !bytes.Equal(row[1].ByteArray(), b) {
+				t.Errorf("wrong origin value: %v", row[1])
+			}
+			var got Row
+			if err := schema.Reconstruct(&got, row); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("row mismatch:\nwant = %+v\ngot  = %+v", want, got)
+			}
+		}
+	})
+}
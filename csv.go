@@ -0,0 +1,378 @@
+package parquet
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parquet-go/parquet-go/format"
+)
+
+// ToCSV converts the parquet file of the given size read from r to CSV, writing
+// the records to w.
+//
+// Each top-level field of the schema becomes a CSV column, and rows are
+// streamed to w as they are read from the file. Values are formatted according
+// to their logical type:
+//
+//   - timestamps are formatted as RFC 3339 date-times, dates as YYYY-MM-DD and
+//     times as HH:MM:SS with fractional seconds
+//   - decimals are scaled, for example the unscaled value 1234 of a decimal
+//     with a scale of 2 is written as 12.34
+//   - UUIDs use their canonical textual representation
+//   - strings, enums and JSON documents are written as-is
+//   - other byte arrays are base64-encoded
+//
+// CSV cannot represent nested data, so columns of repeated fields and groups
+// (including lists and maps) are written as JSON documents: repeated fields
+// and lists become JSON arrays, groups become JSON objects keyed by field name,
+// and maps become JSON objects with the string representation of keys. Leaf
+// values of those documents use the formatting described above, except for
+// booleans and numbers (other than decimals) which are written as JSON
+// booleans and numbers.
+//
+// Null values are written as the null token configured with CSVNullToken,
+// which defaults to an empty field.
+func ToCSV(w io.Writer, r io.ReaderAt, size int64, options ...CSVOption) error {
+	config, err := NewCSVConfig(options...)
+	if err != nil {
+		return err
+	}
+	f, err := OpenFile(r, size)
+	if err != nil {
+		return err
+	}
+
+	fields := f.Schema().Fields()
+	columns := make([]int, len(fields)+1)
+	for i, field := range fields {
+		columns[i+1] = columns[i] + int(numLeafColumnsOf(field))
+	}
+
+	out := csv.NewWriter(w)
+	out.Comma = config.Delimiter
+
+	record := make([]string, len(fields))
+	if !config.SkipHeader {
+		for i, field := range fields {
+			record[i] = field.Name()
+		}
+		if err := out.Write(record); err != nil {
+			return err
+		}
+	}
+
+	reader := NewReader(f)
+	defer reader.Close()
+
+	rows := make([]Row, defaultRowBufferSize)
+	values := make([][]Value, columns[len(fields)])
+
+	for {
+		n, err := reader.ReadRows(rows)
+
+		for _, row := range rows[:n] {
+			row.Range(func(columnIndex int, columnValues []Value) bool {
+				values[columnIndex] = columnValues
+				return true
+			})
+
+			for i, field := range fields {
+				field, err := formatCSVField(field, values[columns[i]:columns[i+1]], config.NullToken)
+				if err != nil {
+					return fmt.Errorf("formatting CSV field %q: %w", fields[i].Name(), err)
+				}
+				record[i] = field
+			}
+
+			if err := out.Write(record); err != nil {
+				return err
+			}
+		}
+
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return err
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
+
+func formatCSVField(node Node, columns [][]Value, nullToken string) (string, error) {
+	if node.Leaf() && !node.Repeated() {
+		v := columns[0][0]
+		if v.IsNull() {
+			return nullToken, nil
+		}
+		return formatCSVValue(node, v), nil
+	}
+
+	value, err := jsonValueOf(node, columns, 0, 0)
+	if err != nil {
+		return "", err
+	}
+	if value == nil {
+		return nullToken, nil
+	}
+	b, err := json.Marshal(value)
+	return string(b), err
+}
+
+// jsonValueOf assembles the values of the columns of node into a value
+// which can be encoded to JSON.
+func jsonValueOf(node Node, columns [][]Value, definitionLevel, repetitionDepth byte) (any, error) {
+	switch {
+	case node.Optional():
+		definitionLevel++
+		if len(columns) > 0 && columns[0][0].definitionLevel < definitionLevel {
+			return nil, nil
+		}
+		return jsonValueOf(Required(node), columns, definitionLevel, repetitionDepth)
+
+	case node.Repeated():
+		definitionLevel++
+		repetitionDepth++
+		elems := []any{}
+		if len(columns) == 0 || columns[0][0].definitionLevel < definitionLevel {
+			return elems, nil
+		}
+		var err error
+		forEachRepetition(columns, repetitionDepth, func(columns [][]Value) {
+			if err == nil {
+				var elem any
+				elem, err = jsonValueOf(Required(node), columns, definitionLevel, repetitionDepth)
+				elems = append(elems, elem)
+			}
+		})
+		return elems, err
+
+	case isList(node):
+		return jsonValueOf(Repeated(listElementOf(node)), columns, definitionLevel, repetitionDepth)
+
+	case isMap(node):
+		keyValue := mapKeyValueOf(node)
+		keyNode, valueNode := keyValue.Fields()[0], keyValue.Fields()[1]
+		definitionLevel++
+		repetitionDepth++
+		m := map[string]any{}
+		if columns[0][0].definitionLevel < definitionLevel {
+			return m, nil
+		}
+		numKeyColumns := numLeafColumnsOf(keyNode)
+		var err error
+		forEachRepetition(columns, repetitionDepth, func(columns [][]Value) {
+			if err != nil {
+				return
+			}
+			var key, value any
+			if key, err = jsonValueOf(keyNode, columns[:numKeyColumns], definitionLevel, repetitionDepth); err != nil {
+				return
+			}
+			if value, err = jsonValueOf(valueNode, columns[numKeyColumns:], definitionLevel, repetitionDepth); err != nil {
+				return
+			}
+			switch k := key.(type) {
+			case string:
+				m[k] = value
+			default:
+				b, _ := json.Marshal(k)
+				m[string(b)] = value
+			}
+		})
+		return m, err
+
+	case node.Leaf():
+		v := columns[0][0]
+		if v.IsNull() {
+			return nil, nil
+		}
+		return jsonLeafValueOf(node, v)
+
+	default:
+		fields := node.Fields()
+		group := make(map[string]any, len(fields))
+		for _, field := range fields {
+			n := numLeafColumnsOf(field)
+			value, err := jsonValueOf(field, columns[:n], definitionLevel, repetitionDepth)
+			if err != nil {
+				return nil, err
+			}
+			group[field.Name()] = value
+			columns = columns[n:]
+		}
+		return group, nil
+	}
+}
+
+// forEachRepetition calls do with the values of each repetition of the columns
+// at the given repetition depth.
+func forEachRepetition(columns [][]Value, repetitionDepth byte, do func([][]Value)) {
+	values := make([][]Value, len(columns))
+	for len(columns[0]) > 0 {
+		for i, column := range columns {
+			n := 1
+			for n < len(column) && column[n].repetitionLevel > repetitionDepth {
+				n++
+			}
+			values[i], columns[i] = column[:n], column[n:]
+		}
+		do(values)
+	}
+}
+
+// jsonLeafValueOf returns the JSON representation of a non-null value of a
+// leaf column. NaN and infinite floating point values have no representation
+// in JSON, the function returns an error for those values.
+func jsonLeafValueOf(node Node, v Value) (any, error) {
+	lt := node.Type().LogicalType()
+	if lt != nil && (lt.Decimal != nil || lt.Timestamp != nil || lt.Date != nil || lt.Time != nil) {
+		if lt.Decimal != nil {
+			return json.Number(formatCSVValue(node, v)), nil
+		}
+		return formatCSVValue(node, v), nil
+	}
+	switch v.Kind() {
+	case Boolean:
+		return v.Boolean(), nil
+	case Int32:
+		if lt != nil && lt.Integer != nil && !lt.Integer.IsSigned {
+			return v.Uint32(), nil
+		}
+		return v.Int32(), nil
+	case Int64:
+		if lt != nil && lt.Integer != nil && !lt.Integer.IsSigned {
+			return v.Uint64(), nil
+		}
+		return v.Int64(), nil
+	case Float:
+		return jsonNumberOf(float64(v.Float()), 32)
+	case Double:
+		return jsonNumberOf(v.Double(), 64)
+	default:
+		return formatCSVValue(node, v), nil
+	}
+}
+
+func jsonNumberOf(f float64, bitSize int) (json.Number, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return "", fmt.Errorf("cannot encode floating point value %v to JSON", f)
+	}
+	return json.Number(strconv.FormatFloat(f, 'g', -1, bitSize)), nil
+}
+
+// formatCSVValue returns the textual representation of a non-null value of a
+// leaf column.
+func formatCSVValue(node Node, v Value) string {
+	lt := node.Type().LogicalType()
+
+	switch {
+	case lt == nil:
+	case lt.Timestamp != nil:
+		t := timeOfUnit(v.Int64(), lt.Timestamp.Unit)
+		if !lt.Timestamp.IsAdjustedToUTC {
+			// Local timestamps are not tied to a time zone, the offset is
+			// omitted to avoid implying that they are expressed in UTC.
+			return t.Format("2006-01-02T15:04:05.999999999")
+		}
+		return t.Format(time.RFC3339Nano)
+	case lt.Date != nil:
+		return time.Unix(int64(v.Int32())*86400, 0).UTC().Format(time.DateOnly)
+	case lt.Time != nil:
+		var d time.Duration
+		if v.Kind() == Int32 {
+			d = time.Duration(v.Int32()) * time.Millisecond
+		} else {
+			d = timeOfUnit(v.Int64(), lt.Time.Unit).Sub(time.Unix(0, 0))
+		}
+		return time.Unix(0, 0).UTC().Add(d).Format("15:04:05.999999999")
+	case lt.Decimal != nil:
+		return formatDecimal(v, int(lt.Decimal.Scale))
+	case lt.UUID != nil:
+		if u, err := uuid.FromBytes(v.ByteArray()); err == nil {
+			return u.String()
+		}
+	case lt.Float16 != nil:
+		return strconv.FormatFloat(float64(float16ToFloat32(binary.LittleEndian.Uint16(v.ByteArray()))), 'g', -1, 32)
+	case lt.UTF8 != nil, lt.Enum != nil, lt.Json != nil:
+		return string(v.ByteArray())
+	case lt.Integer != nil && !lt.Integer.IsSigned:
+		if v.Kind() == Int32 {
+			return strconv.FormatUint(uint64(v.Uint32()), 10)
+		}
+		return strconv.FormatUint(v.Uint64(), 10)
+	}
+
+	switch v.Kind() {
+	case Boolean:
+		return strconv.FormatBool(v.Boolean())
+	case Int32:
+		return strconv.FormatInt(int64(v.Int32()), 10)
+	case Int64:
+		return strconv.FormatInt(v.Int64(), 10)
+	case Float:
+		return strconv.FormatFloat(float64(v.Float()), 'g', -1, 32)
+	case Double:
+		return strconv.FormatFloat(v.Double(), 'g', -1, 64)
+	case ByteArray, FixedLenByteArray:
+		return base64.StdEncoding.EncodeToString(v.ByteArray())
+	default:
+		return v.String()
+	}
+}
+
+func timeOfUnit(value int64, unit format.TimeUnit) time.Time {
+	switch {
+	case unit.Millis != nil:
+		return time.UnixMilli(value).UTC()
+	case unit.Micros != nil:
+		return time.UnixMicro(value).UTC()
+	default:
+		return time.Unix(0, value).UTC()
+	}
+}
+
+// formatDecimal returns the textual representation of a decimal value, which
+// may be stored as an integer or a big-endian two's complement byte array.
+func formatDecimal(v Value, scale int) string {
+	var unscaled big.Int
+	switch v.Kind() {
+	case Int32:
+		unscaled.SetInt64(int64(v.Int32()))
+	case Int64:
+		unscaled.SetInt64(v.Int64())
+	default:
+		b := v.ByteArray()
+		unscaled.SetBytes(b)
+		if len(b) > 0 && b[0]&0x80 != 0 {
+			unscaled.Sub(&unscaled, new(big.Int).Lsh(big.NewInt(1), uint(8*len(b))))
+		}
+	}
+
+	digits := unscaled.String()
+	if scale <= 0 {
+		return digits
+	}
+
+	sign := ""
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+}
@@ -0,0 +1,121 @@
+package parquet_test
+
+import (
+	"bytes"
+	"math"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestToCSV(t *testing.T) {
+	type Address struct {
+		City string `parquet:"city"`
+		Zip  *int32 `parquet:"zip,optional"`
+	}
+	type Row struct {
+		ID        int64             `parquet:"id"`
+		Name      *string           `parquet:"name,optional"`
+		Active    bool              `parquet:"active"`
+		Score     float64           `parquet:"score"`
+		Price     int64             `parquet:"price,decimal(2:18)"`
+		Created   time.Time         `parquet:"created,timestamp(millisecond)"`
+		Day       int32             `parquet:"day,date"`
+		UUID      [16]byte          `parquet:"uuid,uuid"`
+		Payload   []byte            `parquet:"payload"`
+		Tags      []string          `parquet:"tags,list"`
+		Address   *Address          `parquet:"address,optional"`
+		Scores    map[string]int32  `parquet:"scores"`
+		Histogram []int64           `parquet:"histogram"`
+		Labels    map[string]string `parquet:"labels,optional"`
+	}
+
+	name := "Léa, \"the\" first"
+	zip := int32(75001)
+	rows := []Row{
+		{
+			ID:        1,
+			Name:      &name,
+			Active:    true,
+			Score:     0.5,
+			Price:     -1205,
+			Created:   time.Date(2024, 3, 1, 12, 30, 45, 123e6, time.UTC),
+			Day:       19783,
+			UUID:      uuid.MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8"),
+			Payload:   []byte{0, 1, 2, 255},
+			Tags:      []string{"a", "b"},
+			Address:   &Address{City: "Paris", Zip: &zip},
+			Scores:    map[string]int32{"x": 1, "y": 2},
+			Histogram: []int64{3, 4},
+			Labels:    map[string]string{"env": "prod"},
+		},
+		{
+			ID:      2,
+			Price:   7,
+			Created: time.Unix(0, 0),
+			Address: &Address{City: "Lyon"},
+		},
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows); err != nil {
+		t.Fatal(err)
+	}
+
+	golden, err := os.ReadFile("testdata/to_csv.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("default", func(t *testing.T) {
+		out := new(strings.Builder)
+		if err := parquet.ToCSV(out, bytes.NewReader(buf.Bytes()), int64(buf.Len())); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := out.String(), string(golden); got != want {
+			t.Errorf("wrong CSV output:\nwant:\n%s\ngot:\n%s", want, got)
+		}
+	})
+
+	t.Run("options", func(t *testing.T) {
+		out := new(strings.Builder)
+		if err := parquet.ToCSV(out, bytes.NewReader(buf.Bytes()), int64(buf.Len()),
+			parquet.CSVDelimiter('\t'),
+			parquet.CSVSkipHeader(true),
+			parquet.CSVNullToken(`\N`),
+		); err != nil {
+			t.Fatal(err)
+		}
+		records := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+		if len(records) != len(rows) {
+			t.Fatalf("wrong number of records: want=%d got=%d", len(rows), len(records))
+		}
+		if fields := strings.Split(records[1], "\t"); fields[0] != "2" || fields[1] != `\N` || fields[13] != `\N` {
+			t.Errorf("wrong fields in second record: %q", fields)
+		}
+	})
+
+	t.Run("NaN in JSON field", func(t *testing.T) {
+		type Row struct {
+			Values []float64 `parquet:"values,list"`
+		}
+		buf := new(bytes.Buffer)
+		if err := parquet.Write(buf, []Row{{Values: []float64{1, math.NaN()}}}); err != nil {
+			t.Fatal(err)
+		}
+		err := parquet.ToCSV(new(strings.Builder), bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err == nil || !strings.Contains(err.Error(), "cannot encode floating point value NaN") {
+			t.Errorf("expected an error for a NaN value in a JSON field, got %v", err)
+		}
+	})
+
+	t.Run("invalid delimiter", func(t *testing.T) {
+		if err := parquet.ToCSV(new(strings.Builder), bytes.NewReader(buf.Bytes()), int64(buf.Len()), parquet.CSVDelimiter('"')); err == nil {
+			t.Error("expected an error for an invalid delimiter")
+		}
+	})
+}
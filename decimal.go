@@ -0,0 +1,218 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// The unscaled values of decimals stored as fixed length byte arrays are
+// big-endian two's complement integers, the sign of the value is carried by
+// the most significant bit of the first byte. The functions below manipulate
+// those representations without going through math/big, which would allocate.
+
+// decimalSignByte returns the byte used to sign-extend the unscaled value b,
+// 0xFF for negative values and 0x00 otherwise.
+func decimalSignByte(b []byte) byte {
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		return 0xFF
+	}
+	return 0x00
+}
+
+// appendDecimalBytes appends the 8 bytes big-endian two's complement
+// representation of n to b.
+func appendDecimalBytes(b []byte, n int64) []byte {
+	return binary.BigEndian.AppendUint64(b, uint64(n))
+}
+
+// resizeDecimalBytes returns a copy of the unscaled value b with the given
+// size. The value is sign-extended if size is greater than the length of b,
+// and truncated otherwise; the boolean is false if the value cannot be
+// represented in size bytes.
+func resizeDecimalBytes(b []byte, size int) ([]byte, bool) {
+	sign := decimalSignByte(b)
+	c := make([]byte, size)
+
+	if size >= len(b) {
+		n := size - len(b)
+		for i := range c[:n] {
+			c[i] = sign
+		}
+		copy(c[n:], b)
+		return c, true
+	}
+
+	n := len(b) - size
+	for _, x := range b[:n] {
+		if x != sign {
+			return nil, false
+		}
+	}
+	if size > 0 && (b[n]^sign)&0x80 != 0 {
+		// The most significant bit of the truncated value would flip the sign.
+		return nil, false
+	}
+	if size == 0 && sign != 0 {
+		return nil, false
+	}
+	copy(c, b[n:])
+	return c, true
+}
+
+// decimalBytesToInt64 decodes the unscaled value b, the boolean is false if
+// the value does not fit in a 64 bits integer.
+func decimalBytesToInt64(b []byte) (int64, bool) {
+	c, ok := resizeDecimalBytes(b, 8)
+	if !ok {
+		return 0, false
+	}
+	return int64(binary.BigEndian.Uint64(c)), true
+}
+
+// decimalOverflowError returns the error reported when the unscaled value of v
+// cannot be represented by the physical type of the decimal type to.
+func decimalOverflowError(v Value, from, to Type) error {
+	return fmt.Errorf("%s to %s: %s: value overflows the target type: %w", from, to, formatDecimal(v, 0), ErrInvalidConversion)
+}
+
+// compareDecimalBytes compares the unscaled values a and b as signed integers.
+func compareDecimalBytes(a, b []byte) int {
+	signA, signB := decimalSignByte(a), decimalSignByte(b)
+	switch {
+	case signA != signB:
+		if signA != 0 {
+			return -1
+		}
+		return +1
+	case len(a) < len(b):
+		a, _ = resizeDecimalBytes(a, len(b))
+	case len(a) > len(b):
+		b, _ = resizeDecimalBytes(b, len(a))
+	}
+	// Two's complement integers of the same length and sign have the same
+	// ordering as their unsigned representation.
+	return bytes.Compare(a, b)
+}
+
+// decimalRounding is the rounding mode applied when converting floating point
+// values to decimals which cannot represent all their digits.
+type decimalRounding int8
+
+const (
+	// Rounds to the nearest decimal, ties are rounded to the even neighbor.
+	decimalRoundHalfEven decimalRounding = iota
+	// Rounds to the nearest decimal, ties are rounded away from zero.
+	decimalRoundHalfUp
+	// Discards the digits which cannot be represented, rounding toward zero.
+	decimalRoundTruncate
+)
+
+func (r decimalRounding) String() string {
+	switch r {
+	case decimalRoundHalfEven:
+		return "half-even"
+	case decimalRoundHalfUp:
+		return "half-up"
+	case decimalRoundTruncate:
+		return "truncate"
+	default:
+		return "decimalRounding(" + strconv.Itoa(int(r)) + ")"
+	}
+}
+
+func parseDecimalRounding(s string) (decimalRounding, error) {
+	switch s {
+	case "half-even":
+		return decimalRoundHalfEven, nil
+	case "half-up":
+		return decimalRoundHalfUp, nil
+	case "truncate":
+		return decimalRoundTruncate, nil
+	default:
+		return 0, fmt.Errorf("invalid decimal rounding mode: %s", s)
+	}
+}
+
+// decimalFromFloat returns the unscaled value of the decimal with the given
+// scale and precision representing f, which is a float of bitSize bits.
+//
+// The rounding is applied to the shortest decimal representation of f, which
+// is the one that the Go program sees (e.g. when printing the value), instead
+// of its exact binary value: 0.135 is rounded to 0.14 in half-up mode even if
+// the closest float64 is slightly less than 0.135.
+func decimalFromFloat(f float64, bitSize, scale, precision int, rounding decimalRounding) (int64, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("%v cannot be represented by a decimal", f)
+	}
+
+	s := strconv.FormatFloat(f, 'f', -1, bitSize)
+	s, negative := strings.CutPrefix(s, "-")
+	integer, fraction, _ := strings.Cut(s, ".")
+
+	var rest string
+	if len(fraction) > scale {
+		fraction, rest = fraction[:scale], fraction[scale:]
+	}
+
+	var n uint64
+	var numDigits int
+	for _, digits := range [...]string{integer, fraction} {
+		for _, c := range []byte(digits) {
+			if n != 0 || c != '0' {
+				numDigits++
+			}
+			if numDigits > precision {
+				return 0, fmt.Errorf("%v exceeds the precision of decimal(%d,%d)", f, precision, scale)
+			}
+			n = 10*n + uint64(c-'0')
+		}
+	}
+	for range scale - len(fraction) {
+		if n != 0 {
+			numDigits++
+		}
+		if numDigits > precision {
+			return 0, fmt.Errorf("%v exceeds the precision of decimal(%d,%d)", f, precision, scale)
+		}
+		n *= 10
+	}
+
+	if rest != "" {
+		var roundUp bool
+		switch rounding {
+		case decimalRoundHalfEven:
+			roundUp = rest[0] > '5' || (rest[0] == '5' && (strings.TrimRight(rest[1:], "0") != "" || n%2 == 1))
+		case decimalRoundHalfUp:
+			roundUp = rest[0] >= '5'
+		}
+		if roundUp {
+			n++
+		}
+	}
+
+	if n > decimalMaxUnsignedValue(precision) {
+		return 0, fmt.Errorf("%v exceeds the precision of decimal(%d,%d)", f, precision, scale)
+	}
+	if negative {
+		return -int64(n), nil
+	}
+	return int64(n), nil
+}
+
+// decimalToFloat returns the float of bitSize bits which is the closest to the
+// decimal with the given unscaled value and scale.
+func decimalToFloat(n int64, bitSize, scale int) float64 {
+	// Both operands are exact and the division is correctly rounded when the
+	// unscaled value fits in the mantissa and the power of ten is exact.
+	const maxExactPow10 = 22
+	if bitSize == 64 && scale <= maxExactPow10 && n > -1<<53 && n < 1<<53 {
+		return float64(n) / math.Pow10(scale)
+	}
+	s := strconv.FormatInt(n, 10) + "e-" + strconv.Itoa(scale)
+	f, _ := strconv.ParseFloat(s, bitSize)
+	return f
+}
@@ -0,0 +1,211 @@
+package parquet
+
+import (
+	"io"
+	"sync"
+
+	"github.com/parquet-go/parquet-go/encoding/thrift"
+	"github.com/parquet-go/parquet-go/format"
+)
+
+// DecoderPool is a pool of readers for parquet files sharing the same schema.
+//
+// Applications reading many files of the same shape pay for parsing the schema
+// of each file, for computing the conversion to the schema that rows are read
+// with, and for allocating the buffers used to decode rows. The pool retains
+// those structures across files: the schemas of files are keyed by the schema
+// elements recorded in their metadata, so files of a shape that was seen before
+// reuse the schema and conversion computed for it, and readers returned to the
+// pool keep their buffers to decode the rows of the next file.
+//
+// DecoderPool instances are safe to use concurrently from multiple goroutines.
+type DecoderPool struct {
+	config *ReaderConfig
+
+	mutex   sync.Mutex
+	schemas map[string]*decoderPoolSchema
+	free    []decoderPoolEntry
+}
+
+// decoderPoolSchema holds the structures computed for the files of a schema.
+type decoderPoolSchema struct {
+	fileSchema *Schema
+	conversion Conversion
+}
+
+type decoderPoolEntry struct {
+	reader *Reader
+	rows   *rowGroupRows
+}
+
+// NewDecoderPool constructs a pool of readers reading rows of the given schema.
+//
+// If schema is nil, the readers use the schema of the files that they read.
+// The options are applied to all readers obtained from the pool, the Schema
+// option is ignored since the pool is keyed by its schema.
+func NewDecoderPool(schema *Schema, options ...ReaderOption) (*DecoderPool, error) {
+	config, err := NewReaderConfig(options...)
+	if err != nil {
+		return nil, err
+	}
+	config.Schema = schema
+	return &DecoderPool{config: config}, nil
+}
+
+// Schema returns the schema of rows read by readers of the pool, or nil if the
+// readers use the schema of the files.
+func (p *DecoderPool) Schema() *Schema { return p.config.Schema }
+
+// Get opens the parquet file of the given size read from input and returns a
+// reader positioned on its first row.
+//
+// The file options are used when opening the file, except for FileSchema which
+// is managed by the pool.
+//
+// The reader should be returned to the pool by calling Put when the program is
+// done using it; readers that are not returned are garbage collected normally.
+func (p *DecoderPool) Get(input io.ReaderAt, size int64, options ...FileOption) (*Reader, error) {
+	p.mutex.Lock()
+	entry := decoderPoolEntry{reader: new(Reader)}
+	if n := len(p.free); n > 0 {
+		entry, p.free = p.free[n-1], p.free[:n-1]
+	}
+	p.mutex.Unlock()
+
+	// The file schema is looked up in the pool once the metadata was read, the
+	// option only prevents OpenFile from constructing one.
+	options = append(options[:len(options):len(options)], FileSchema(decoderPoolFileSchema))
+	f, err := OpenFile(input, size, options...)
+	if err != nil {
+		p.release(entry)
+		return nil, err
+	}
+
+	key, err := decoderPoolSchemaKey(f.metadata.Schema)
+	if err != nil {
+		p.release(entry)
+		return nil, err
+	}
+
+	p.mutex.Lock()
+	cached := p.schemas[key]
+	p.mutex.Unlock()
+
+	if cached == nil {
+		cached = &decoderPoolSchema{fileSchema: NewSchema(f.root.Name(), f.root)}
+
+		if schema := p.config.Schema; schema != nil && !EqualNodes(schema, cached.fileSchema) {
+			if cached.conversion, err = Convert(schema, cached.fileSchema); err != nil {
+				p.release(entry)
+				return nil, err
+			}
+		}
+
+		p.mutex.Lock()
+		if p.schemas == nil {
+			p.schemas = make(map[string]*decoderPoolSchema)
+		}
+		p.schemas[key] = cached
+		p.mutex.Unlock()
+	}
+
+	fileSchema, conversion := cached.fileSchema, cached.conversion
+	f.schema = fileSchema
+
+	schema := p.config.Schema
+	if schema == nil {
+		schema = fileSchema
+	}
+	if p.config.StrictSchema {
+		if err := checkStrictSchema(schema, fileSchema); err != nil {
+			p.release(entry)
+			return nil, err
+		}
+	}
+
+	r := entry.reader
+	r.strictSchema = p.config.StrictSchema
+	r.skipCorruptRowGroups = p.config.SkipCorruptRowGroups
+	r.file = reader{file: f, schema: schema}
+	r.selection = allRowGroups(f)
+
+	if r.skipCorruptRowGroups {
+		r.file.rowGroup, _ = selectRowGroups(f, schema, r.selection, true)
+	} else {
+		rowGroup := fileRowGroupOf(f)
+		r.file.rowGroup = rowGroup
+		r.file.rows = decoderPoolRowsOf(rowGroup, entry.rows)
+		if conversion != nil {
+			r.file.rowGroup = ConvertRowGroup(rowGroup, conversion)
+			if r.file.rows != nil {
+				r.file.rows = &convertedRows{
+					Closer: r.file.rows,
+					rows:   r.file.rows,
+					conv:   conversion,
+				}
+			}
+		}
+	}
+
+	r.read.init(schema, r.file.rowGroup)
+	return r, nil
+}
+
+// Put returns r to the pool, closing it and clearing the state that it held
+// for the file it was reading.
+//
+// The reader must have been obtained by calling Get on the same pool, and must
+// not be used after calling Put. Errors occurring when closing the reader are
+// discarded.
+func (p *DecoderPool) Put(r *Reader) {
+	entry := decoderPoolEntry{reader: r}
+	switch rows := r.file.rows.(type) {
+	case *rowGroupRows:
+		entry.rows = rows
+	case *convertedRows:
+		entry.rows, _ = rows.rows.(*rowGroupRows)
+	}
+	r.Close()
+	p.release(entry)
+}
+
+// decoderPoolFileSchema is passed to OpenFile in place of the schemas that the
+// pool sets on the files that it opens.
+var decoderPoolFileSchema = NewSchema("", Group{})
+
+// decoderPoolSchemaKey returns the key identifying the schema of files in the
+// pool, which is the serialized form of the schema elements of their metadata.
+func decoderPoolSchemaKey(schema []format.SchemaElement) (string, error) {
+	b, err := thrift.Marshal(new(thrift.CompactProtocol), &format.FileMetaData{Schema: schema})
+	return string(b), err
+}
+
+func (p *DecoderPool) release(entry decoderPoolEntry) {
+	r := entry.reader
+	clearRows(r.rowbuf)
+	*r = Reader{rowbuf: r.rowbuf[:0]}
+
+	p.mutex.Lock()
+	p.free = append(p.free, entry)
+	p.mutex.Unlock()
+}
+
+// decoderPoolRowsOf returns the rows of rowGroup, reusing the buffers of spare
+// if the row group is one that would be read with a rowGroupRows. The function
+// returns nil if a new Rows instance has to be created by the row group.
+func decoderPoolRowsOf(rowGroup RowGroup, spare *rowGroupRows) Rows {
+	if spare == nil {
+		return nil
+	}
+	switch g := rowGroup.(type) {
+	case *FileRowGroup:
+		if g.file.config.ReadMode == ReadModeAsync {
+			return nil
+		}
+	case *multiRowGroup:
+	default:
+		return nil
+	}
+	spare.init(rowGroup.Schema(), rowGroup.ColumnChunks())
+	return spare
+}
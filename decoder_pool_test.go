@@ -0,0 +1,206 @@
+package parquet_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+type decoderPoolRow struct {
+	ID    int64   `parquet:"id"`
+	Name  string  `parquet:"name"`
+	Score float64 `parquet:"score"`
+}
+
+func writeDecoderPoolFile[T any](tb testing.TB, rows []T) *bytes.Reader {
+	tb.Helper()
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows); err != nil {
+		tb.Fatal(err)
+	}
+	return bytes.NewReader(buf.Bytes())
+}
+
+func readDecoderPoolRows(tb testing.TB, r *parquet.Reader) []decoderPoolRow {
+	tb.Helper()
+	var rows []decoderPoolRow
+	for {
+		var row decoderPoolRow
+		if err := r.Read(&row); err != nil {
+			if errors.Is(err, io.EOF) {
+				return rows
+			}
+			tb.Fatal(err)
+		}
+		rows = append(rows, row)
+	}
+}
+
+func TestDecoderPool(t *testing.T) {
+	pool, err := parquet.NewDecoderPool(parquet.SchemaOf(decoderPoolRow{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range 3 {
+		want := make([]decoderPoolRow, 10+i)
+		for j := range want {
+			want[j] = decoderPoolRow{ID: int64(j), Name: fmt.Sprintf("file-%d-row-%d", i, j), Score: float64(i * j)}
+		}
+		f := writeDecoderPoolFile(t, want)
+
+		r, err := pool.Get(f, f.Size())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n := r.NumRows(); n != int64(len(want)) {
+			t.Errorf("file %d: wrong number of rows: want=%d got=%d", i, len(want), n)
+		}
+		if got := readDecoderPoolRows(t, r); !reflect.DeepEqual(got, want) {
+			t.Errorf("file %d: rows mismatch:\nwant: %+v\ngot:  %+v", i, want, got)
+		}
+		pool.Put(r)
+	}
+
+	t.Run("different file schema", func(t *testing.T) {
+		type otherRow struct {
+			Name  string `parquet:"name"`
+			ID    int64  `parquet:"id"`
+			Extra bool   `parquet:"extra"`
+		}
+		f := writeDecoderPoolFile(t, []otherRow{{Name: "a", ID: 1}, {Name: "b", ID: 2, Extra: true}})
+
+		r, err := pool.Get(f, f.Size())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer pool.Put(r)
+
+		rows := make([]parquet.Row, 2)
+		n, err := r.ReadRows(rows)
+		if n != 2 {
+			t.Fatalf("wrong number of rows read: want=2 got=%d (%v)", n, err)
+		}
+		var got decoderPoolRow
+		if err := pool.Schema().Reconstruct(&got, rows[1]); err != nil {
+			t.Fatal(err)
+		}
+		if want := (decoderPoolRow{ID: 2, Name: "b"}); got != want {
+			t.Errorf("wrong row: want=%+v got=%+v", want, got)
+		}
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		f := bytes.NewReader([]byte("not a parquet file"))
+		if _, err := pool.Get(f, f.Size()); err == nil {
+			t.Error("expected an error opening an invalid file")
+		}
+	})
+}
+
+func TestDecoderPoolSchemas(t *testing.T) {
+	type otherRow struct {
+		Key   string `parquet:"key"`
+		Value int32  `parquet:"value"`
+	}
+
+	pool, err := parquet.NewDecoderPool(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := []*bytes.Reader{
+		writeDecoderPoolFile(t, []decoderPoolRow{{ID: 1, Name: "a"}}),
+		writeDecoderPoolFile(t, []otherRow{{Key: "b", Value: 2}}),
+		writeDecoderPoolFile(t, []decoderPoolRow{{ID: 3, Name: "c"}}),
+		writeDecoderPoolFile(t, []otherRow{{Key: "d", Value: 4}}),
+	}
+
+	schemas := make([]*parquet.Schema, len(files))
+	for i, f := range files {
+		r, err := pool.Get(f, f.Size())
+		if err != nil {
+			t.Fatal(err)
+		}
+		schemas[i] = r.Schema()
+
+		rows := make([]parquet.Row, 1)
+		if n, err := r.ReadRows(rows); n != 1 {
+			t.Fatalf("file %d: wrong number of rows read: want=1 got=%d (%v)", i, n, err)
+		}
+		pool.Put(r)
+	}
+
+	// Files of a schema seen before share the schema computed for the first
+	// of them, even when files of other schemas were read in between.
+	if schemas[0] != schemas[2] || schemas[1] != schemas[3] {
+		t.Error("the schemas of files with the same shape were not reused")
+	}
+	if schemas[0] == schemas[1] {
+		t.Error("files of different shapes were given the same schema")
+	}
+}
+
+func BenchmarkDecoderPool(b *testing.B) {
+	const numFiles = 100
+
+	files := make([]*bytes.Reader, numFiles)
+	for i := range files {
+		rows := make([]decoderPoolRow, 10)
+		for j := range rows {
+			rows[j] = decoderPoolRow{ID: int64(j), Name: fmt.Sprintf("row-%d", j), Score: float64(j)}
+		}
+		files[i] = writeDecoderPoolFile(b, rows)
+	}
+
+	schema := parquet.SchemaOf(decoderPoolRow{})
+	rows := make([]parquet.Row, 16)
+
+	readAll := func(r *parquet.Reader) {
+		for {
+			_, err := r.ReadRows(rows)
+			if err != nil {
+				if !errors.Is(err, io.EOF) {
+					b.Fatal(err)
+				}
+				return
+			}
+		}
+	}
+
+	b.Run("without pool", func(b *testing.B) {
+		for range b.N {
+			for _, f := range files {
+				f, err := parquet.OpenFile(f, f.Size())
+				if err != nil {
+					b.Fatal(err)
+				}
+				r := parquet.NewReader(f, schema)
+				readAll(r)
+				r.Close()
+			}
+		}
+	})
+
+	b.Run("with pool", func(b *testing.B) {
+		pool, err := parquet.NewDecoderPool(schema)
+		if err != nil {
+			b.Fatal(err)
+		}
+		for range b.N {
+			for _, f := range files {
+				r, err := pool.Get(f, f.Size())
+				if err != nil {
+					b.Fatal(err)
+				}
+				readAll(r)
+				pool.Put(r)
+			}
+		}
+	})
+}
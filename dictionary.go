@@ -1,6 +1,7 @@
 package parquet
 
 import (
+	"fmt"
 	"io"
 	"math/bits"
 	"unsafe"
@@ -863,6 +864,13 @@ func (d *fixedLenByteArrayDictionary) Lookup(indexes []int32, values []Value) {
 }
 
 func (d *fixedLenByteArrayDictionary) Bounds(indexes []int32) (min, max Value) {
+	if len(indexes) > 0 && d.isFloat16() {
+		var minValue, maxValue []byte
+		for _, i := range indexes {
+			minValue, maxValue = updateBoundsFloat16(minValue, maxValue, d.index(i))
+		}
+		return d.makeValueBytes(minValue), d.makeValueBytes(maxValue)
+	}
 	if len(indexes) > 0 {
 		base := d.index(indexes[0])
 		minValue := unsafecast.String(base)
@@ -1330,6 +1338,39 @@ func (r *indexedPageValues) ReadValues(values []Value) (n int, err error) {
 	return n, err
 }
 
+// lookup writes the values of the dictionary at the next indexes of the page to
+// rows, which must be an array of values of the kind of the dictionary.
+func (r *indexedPageValues) lookup(rows sparse.Array) (n int, err error) {
+	if n = len(r.page.values) - r.offset; n == 0 {
+		return 0, io.EOF
+	}
+	if n > rows.Len() {
+		n = rows.Len()
+	}
+	indexes := r.page.values[r.offset : r.offset+n]
+	switch dict := r.page.typ.dict.(type) {
+	case *int32Dictionary:
+		dict.lookup(indexes, rows)
+	case *int64Dictionary:
+		dict.lookup(indexes, rows)
+	case *uint32Dictionary:
+		dict.lookup(indexes, rows)
+	case *uint64Dictionary:
+		dict.lookup(indexes, rows)
+	case *floatDictionary:
+		dict.lookup(indexes, rows)
+	case *doubleDictionary:
+		dict.lookup(indexes, rows)
+	default:
+		return 0, fmt.Errorf("cannot lookup values of dictionary of type %s: %w", dict.Type(), ErrInvalidConversion)
+	}
+	r.offset += n
+	if r.offset == len(r.page.values) {
+		err = io.EOF
+	}
+	return n, err
+}
+
 // indexedColumnBuffer is an implementation of the ColumnBuffer interface which
 // builds a page of indexes into a parent dictionary when values are written.
 type indexedColumnBuffer struct{ indexedPage }
@@ -0,0 +1,173 @@
+package parquet
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// RowDiff describes a difference found by DiffRows between the rows of two
+// readers.
+type RowDiff struct {
+	// Index of the row where the difference was found.
+	Row int64
+	// Index and path of the leaf column holding different values. Column is
+	// -1 when one of the readers had more rows than the other, Path is then
+	// nil.
+	Column int
+	Path   []string
+	// Values of the column in the row read from each reader. When a reader
+	// had fewer rows than the other, its values are nil and the values of the
+	// other reader are those of the whole row.
+	A, B []Value
+}
+
+// String returns a human-readable representation of d.
+func (d RowDiff) String() string {
+	switch {
+	case d.Column < 0 && d.A == nil:
+		return fmt.Sprintf("row %d: missing from the first reader", d.Row)
+	case d.Column < 0:
+		return fmt.Sprintf("row %d: missing from the second reader", d.Row)
+	default:
+		return fmt.Sprintf("row %d: column %q: %v != %v", d.Row, columnPath(d.Path), d.A, d.B)
+	}
+}
+
+// DiffRows compares the rows read from a and b, which must have the same
+// schema, and returns the differences that were found.
+//
+// Rows are streamed from both readers and compared column by column: the
+// values of a column differ if the readers produced a different number of
+// values for the row, or values with different repetition or definition
+// levels, or values that are not equal. Floating point values are compared
+// by their bit patterns, so NaN values are equal to themselves. When one of
+// the readers has more rows than the other, a single difference is reported
+// for the first row missing from the shorter reader.
+//
+// Comparison stops after maxDiffs differences were found, setting maxDiffs to
+// 1 reports the first difference only, which avoids reading the rest of the
+// inputs. A maxDiffs value of zero or less collects all the differences.
+//
+// An error wrapping ErrSchemaMismatch is returned if the schemas of a and b
+// are not equal.
+func DiffRows(a, b RowReaderWithSchema, maxDiffs int) ([]RowDiff, error) {
+	schema := a.Schema()
+	if !EqualNodes(schema, b.Schema()) {
+		return nil, fmt.Errorf("cannot diff rows of readers with different schemas: %w", ErrSchemaMismatch)
+	}
+
+	columns := schema.Columns()
+	columnsA := make([][]Value, len(columns))
+	columnsB := make([][]Value, len(columns))
+
+	readerA := diffRowReader{reader: a, rows: make([]Row, defaultRowBufferSize)}
+	readerB := diffRowReader{reader: b, rows: make([]Row, defaultRowBufferSize)}
+
+	var diffs []RowDiff
+	for rowIndex := int64(0); maxDiffs <= 0 || len(diffs) < maxDiffs; rowIndex++ {
+		rowA, err := readerA.next()
+		if err != nil && !errors.Is(err, io.EOF) {
+			return diffs, err
+		}
+		rowB, err := readerB.next()
+		if err != nil && !errors.Is(err, io.EOF) {
+			return diffs, err
+		}
+
+		if rowA == nil || rowB == nil {
+			diff := RowDiff{Row: rowIndex, Column: -1}
+			switch {
+			case rowA != nil:
+				diff.A = rowA.Clone()
+			case rowB != nil:
+				diff.B = rowB.Clone()
+			default:
+				return diffs, nil
+			}
+			return append(diffs, diff), nil
+		}
+
+		clear(columnsA)
+		clear(columnsB)
+		rowA.Range(func(columnIndex int, values []Value) bool {
+			columnsA[columnIndex] = values
+			return true
+		})
+		rowB.Range(func(columnIndex int, values []Value) bool {
+			columnsB[columnIndex] = values
+			return true
+		})
+
+		for columnIndex := range columns {
+			if maxDiffs > 0 && len(diffs) == maxDiffs {
+				break
+			}
+			valuesA, valuesB := columnsA[columnIndex], columnsB[columnIndex]
+			if !equalDiffValues(valuesA, valuesB) {
+				diffs = append(diffs, RowDiff{
+					Row:    rowIndex,
+					Column: columnIndex,
+					Path:   columns[columnIndex],
+					A:      Row(valuesA).Clone(),
+					B:      Row(valuesB).Clone(),
+				})
+			}
+		}
+	}
+
+	return diffs, nil
+}
+
+func equalDiffValues(a, b []Value) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		v1, v2 := &a[i], &b[i]
+		if v1.repetitionLevel != v2.repetitionLevel || v1.definitionLevel != v2.definitionLevel || v1.kind != v2.kind {
+			return false
+		}
+		switch v1.Kind() {
+		case Float:
+			if math.Float32bits(v1.float()) != math.Float32bits(v2.float()) {
+				return false
+			}
+		case Double:
+			if math.Float64bits(v1.double()) != math.Float64bits(v2.double()) {
+				return false
+			}
+		default:
+			if !Equal(*v1, *v2) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// diffRowReader reads rows one at a time from a reader, buffering them in
+// batches. The rows returned by next remain valid until the next batch is
+// read.
+type diffRowReader struct {
+	reader RowReader
+	rows   []Row
+	index  int
+	count  int
+	err    error
+}
+
+func (r *diffRowReader) next() (Row, error) {
+	for r.index == r.count {
+		if r.err != nil {
+			return nil, r.err
+		}
+		clearRows(r.rows)
+		r.index = 0
+		r.count, r.err = r.reader.ReadRows(r.rows)
+	}
+	row := r.rows[r.index]
+	r.index++
+	return row, nil
+}
@@ -0,0 +1,122 @@
+package parquet_test
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestDiffRows(t *testing.T) {
+	type Row struct {
+		ID    int64    `parquet:"id"`
+		Name  *string  `parquet:"name,optional"`
+		Score float64  `parquet:"score"`
+		Tags  []string `parquet:"tags,list"`
+	}
+
+	makeRows := func(n int) []Row {
+		rows := make([]Row, n)
+		for i := range rows {
+			name := "name-" + strconv.Itoa(i)
+			rows[i] = Row{
+				ID:    int64(i),
+				Name:  &name,
+				Score: math.NaN(),
+				Tags:  []string{"a", strconv.Itoa(i % 7)},
+			}
+		}
+		return rows
+	}
+
+	write := func(rows []Row) *parquet.Reader {
+		buf := new(bytes.Buffer)
+		if err := parquet.Write(buf, rows); err != nil {
+			t.Fatal(err)
+		}
+		return parquet.NewReader(bytes.NewReader(buf.Bytes()))
+	}
+
+	expected := makeRows(1000)
+	actual := makeRows(1001)
+	actual[500].Name = nil
+	actual[700].Tags = append(actual[700].Tags, "b")
+	actual[700].ID = -1
+
+	t.Run("identical", func(t *testing.T) {
+		diffs, err := parquet.DiffRows(write(expected), write(makeRows(1000)), 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(diffs) != 0 {
+			t.Errorf("expected no differences, got %v", diffs)
+		}
+	})
+
+	t.Run("first difference", func(t *testing.T) {
+		diffs, err := parquet.DiffRows(write(expected), write(actual), 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(diffs) != 1 {
+			t.Fatalf("wrong number of differences: want=1 got=%d", len(diffs))
+		}
+		diff := diffs[0]
+		if diff.Row != 500 || diff.Column != 1 || !reflect.DeepEqual(diff.Path, []string{"name"}) {
+			t.Errorf("wrong difference: %v", diff)
+		}
+		if len(diff.A) != 1 || string(diff.A[0].ByteArray()) != "name-500" {
+			t.Errorf("wrong values of the first reader: %v", diff.A)
+		}
+		if len(diff.B) != 1 || !diff.B[0].IsNull() {
+			t.Errorf("wrong values of the second reader: %v", diff.B)
+		}
+	})
+
+	t.Run("all differences", func(t *testing.T) {
+		diffs, err := parquet.DiffRows(write(expected), write(actual), 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []struct {
+			row    int64
+			column int
+		}{
+			{row: 500, column: 1},
+			{row: 700, column: 0},
+			{row: 700, column: 3},
+			{row: 1000, column: -1},
+		}
+		if len(diffs) != len(want) {
+			t.Fatalf("wrong number of differences: want=%d got=%d: %v", len(want), len(diffs), diffs)
+		}
+		for i, w := range want {
+			if diffs[i].Row != w.row || diffs[i].Column != w.column {
+				t.Errorf("wrong difference %d: want row=%d column=%d, got %v", i, w.row, w.column, diffs[i])
+			}
+		}
+		if last := diffs[len(diffs)-1]; last.A != nil || len(last.B) == 0 {
+			t.Errorf("wrong values of the missing row: %v", last)
+		} else if got, want := last.String(), "row 1000: missing from the first reader"; got != want {
+			t.Errorf("wrong description: want=%q got=%q", want, got)
+		}
+	})
+
+	t.Run("different schemas", func(t *testing.T) {
+		type Other struct {
+			ID int64 `parquet:"id"`
+		}
+		buf := new(bytes.Buffer)
+		if err := parquet.Write(buf, []Other{{ID: 1}}); err != nil {
+			t.Fatal(err)
+		}
+		_, err := parquet.DiffRows(write(expected), parquet.NewReader(bytes.NewReader(buf.Bytes())), 0)
+		if !errors.Is(err, parquet.ErrSchemaMismatch) {
+			t.Errorf("expected schema mismatch error, got %v", err)
+		}
+	})
+}
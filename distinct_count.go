@@ -0,0 +1,101 @@
+package parquet
+
+import (
+	"io"
+	"strconv"
+)
+
+// distinctCountKeyPrefix is the prefix of the keys of the file key/value
+// metadata holding the exact number of distinct values of columns declared
+// with the "ndv" tag, followed by the dotted path of the column.
+const distinctCountKeyPrefix = "parquet-go.column.ndv."
+
+func distinctCountKey(path columnPath) string {
+	return distinctCountKeyPrefix + path.String()
+}
+
+// DistinctCount returns the exact number of distinct values of the column at
+// path, as recorded by the writer of the file for columns declared with the
+// "ndv" tag. Null values are not counted.
+//
+// The ok boolean is false if the file does not record the distinct count of
+// the column.
+func (f *File) DistinctCount(path ...string) (count int64, ok bool) {
+	value, ok := f.Lookup(distinctCountKey(path))
+	if !ok {
+		return 0, false
+	}
+	count, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || count < 0 {
+		return 0, false
+	}
+	return count, true
+}
+
+// distinctValues is the set of distinct values written to a column, keyed by
+// the byte representation of the values (see Value.AppendBytes).
+//
+// The set retains a copy of each distinct value, the memory it uses grows with
+// the cardinality of the column.
+type distinctValues struct {
+	set    map[string]struct{}
+	buffer []byte
+	values []Value
+}
+
+func newDistinctValues() *distinctValues {
+	return &distinctValues{set: make(map[string]struct{})}
+}
+
+func (d *distinctValues) reset() { clear(d.set) }
+
+func (d *distinctValues) count() int64 { return int64(len(d.set)) }
+
+func (d *distinctValues) write(values []Value) {
+	for i := range values {
+		if values[i].IsNull() {
+			continue
+		}
+		d.buffer = values[i].AppendBytes(d.buffer[:0])
+		if _, found := d.set[string(d.buffer)]; !found {
+			d.set[string(d.buffer)] = struct{}{}
+		}
+	}
+}
+
+func (d *distinctValues) writePage(page Page) error {
+	if d.values == nil {
+		d.values = make([]Value, defaultValueBufferSize)
+	}
+	r := page.Values()
+	for {
+		n, err := r.ReadValues(d.values)
+		d.write(d.values[:n])
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// DistinctCount returns the exact number of distinct values of the column at
+// path, as recorded in the metadata of the file being read. See
+// File.DistinctCount for details.
+//
+// Unlike EstimateCardinality, the method never reads the column values; ok is
+// false if the file does not record the distinct count of the column, or the
+// Reader was not created with a File.
+func (r *Reader) DistinctCount(path ...string) (count int64, ok bool) {
+	if r.file.file == nil {
+		return 0, false
+	}
+	return r.file.file.DistinctCount(path...)
+}
+
+// DistinctCount returns the exact number of distinct values of a column. See
+// Reader.DistinctCount for details.
+func (r *GenericReader[T]) DistinctCount(path ...string) (count int64, ok bool) {
+	return r.base.DistinctCount(path...)
+}
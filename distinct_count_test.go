@@ -0,0 +1,86 @@
+package parquet_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestDistinctCount(t *testing.T) {
+	type Row struct {
+		Name     string   `parquet:"name,ndv"`
+		Category string   `parquet:"category,dict,ndv"`
+		Tags     []string `parquet:"tags,list,ndv"`
+		Score    *int64   `parquet:"score,optional,ndv"`
+		Other    int64    `parquet:"other"`
+	}
+
+	names := map[string]struct{}{}
+	categories := map[string]struct{}{}
+	tags := map[string]struct{}{}
+	scores := map[int64]struct{}{}
+
+	rows := make([]Row, 10_000)
+	for i := range rows {
+		row := Row{
+			Name:     fmt.Sprintf("name-%d", i%3_333),
+			Category: fmt.Sprintf("category-%d", i%7),
+			Other:    int64(i),
+		}
+		for j := range i % 3 {
+			row.Tags = append(row.Tags, fmt.Sprintf("tag-%d", (i+j)%50))
+		}
+		if i%5 != 0 {
+			score := int64(i % 101)
+			row.Score = &score
+			scores[score] = struct{}{}
+		}
+		names[row.Name] = struct{}{}
+		categories[row.Category] = struct{}{}
+		for _, tag := range row.Tags {
+			tags[tag] = struct{}{}
+		}
+		rows[i] = row
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows, parquet.MaxRowsPerRowGroup(1_000), parquet.PageBufferSize(1024)); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(f.RowGroups()); n != 10 {
+		t.Fatalf("wrong number of row groups: want=10 got=%d", n)
+	}
+
+	for _, test := range []struct {
+		path []string
+		want int
+	}{
+		{[]string{"name"}, len(names)},
+		{[]string{"category"}, len(categories)},
+		{[]string{"tags", "list", "element"}, len(tags)},
+		{[]string{"score"}, len(scores)},
+	} {
+		count, ok := f.DistinctCount(test.path...)
+		if !ok {
+			t.Errorf("%v: distinct count not found", test.path)
+		} else if count != int64(test.want) {
+			t.Errorf("%v: wrong distinct count: want=%d got=%d", test.path, test.want, count)
+		}
+	}
+
+	if _, ok := f.DistinctCount("other"); ok {
+		t.Error("distinct count recorded for a column without the ndv tag")
+	}
+
+	reader := parquet.NewGenericReader[Row](bytes.NewReader(buf.Bytes()))
+	defer reader.Close()
+	if count, ok := reader.DistinctCount("tags", "list", "element"); !ok || count != int64(len(tags)) {
+		t.Errorf("wrong distinct count read from the reader: want=%d got=%d (ok=%t)", len(tags), count, ok)
+	}
+}
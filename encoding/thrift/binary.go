@@ -30,6 +30,46 @@ func (p *BinaryProtocol) Features() Features {
 	return 0
 }
 
+// The Type constants use the identifiers of the compact protocol, the binary
+// protocol identifies types with different values. Encoders only use TRUE with
+// protocols that coalesce boolean fields, it is mapped to the identifier that
+// the binary protocol reserves for VOID so it is not confused with BOOL.
+//
+// https://github.com/apache/thrift/blob/master/doc/specs/thrift-binary-protocol.md#struct-encoding
+var (
+	binaryTypeOf = [...]byte{
+		STOP:   0,
+		TRUE:   1,
+		FALSE:  2,
+		I8:     3,
+		I16:    6,
+		I32:    8,
+		I64:    10,
+		DOUBLE: 4,
+		BINARY: 11,
+		LIST:   15,
+		SET:    14,
+		MAP:    13,
+		STRUCT: 12,
+	}
+
+	typeOfBinaryType = [...]Type{
+		0:  STOP,
+		1:  TRUE,
+		2:  BOOL,
+		3:  I8,
+		4:  DOUBLE,
+		6:  I16,
+		8:  I32,
+		10: I64,
+		11: BINARY,
+		12: STRUCT,
+		13: MAP,
+		14: SET,
+		15: LIST,
+	}
+)
+
 type binaryReader struct {
 	p *BinaryProtocol
 	r io.Reader
@@ -149,19 +189,22 @@ func (r *binaryReader) ReadMessage() (Message, error) {
 }
 
 func (r *binaryReader) ReadField() (Field, error) {
-	t, err := r.ReadInt8()
+	t, err := r.readType()
 	if err != nil {
 		return Field{}, err
 	}
+	if t == STOP {
+		return Field{Type: STOP}, nil
+	}
 	i, err := r.ReadInt16()
 	if err != nil {
-		return Field{}, err
+		return Field{}, dontExpectEOF(err)
 	}
-	return Field{ID: i, Type: Type(t)}, nil
+	return Field{ID: i, Type: t}, nil
 }
 
 func (r *binaryReader) ReadList() (List, error) {
-	t, err := r.ReadInt8()
+	t, err := r.readType()
 	if err != nil {
 		return List{}, err
 	}
@@ -169,7 +212,7 @@ func (r *binaryReader) ReadList() (List, error) {
 	if err != nil {
 		return List{}, dontExpectEOF(err)
 	}
-	return List{Size: n, Type: Type(t)}, nil
+	return List{Size: n, Type: t}, nil
 }
 
 func (r *binaryReader) ReadSet() (Set, error) {
@@ -178,11 +221,11 @@ func (r *binaryReader) ReadSet() (Set, error) {
 }
 
 func (r *binaryReader) ReadMap() (Map, error) {
-	k, err := r.ReadByte()
+	k, err := r.readType()
 	if err != nil {
 		return Map{}, err
 	}
-	v, err := r.ReadByte()
+	v, err := r.readType()
 	if err != nil {
 		return Map{}, dontExpectEOF(err)
 	}
@@ -190,7 +233,7 @@ func (r *binaryReader) ReadMap() (Map, error) {
 	if err != nil {
 		return Map{}, dontExpectEOF(err)
 	}
-	return Map{Size: n, Key: Type(k), Value: Type(v)}, nil
+	return Map{Size: n, Key: k, Value: v}, nil
 }
 
 func (r *binaryReader) ReadByte() (byte, error) {
@@ -212,6 +255,17 @@ func (r *binaryReader) ReadByte() (byte, error) {
 	}
 }
 
+func (r *binaryReader) readType() (Type, error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return STOP, err
+	}
+	if int(b) >= len(typeOfBinaryType) || (b != 0 && typeOfBinaryType[b] == STOP) {
+		return STOP, fmt.Errorf("unknown thrift type in binary protocol: %d", b)
+	}
+	return typeOfBinaryType[b], nil
+}
+
 func (r *binaryReader) read(n int) ([]byte, error) {
 	_, err := io.ReadFull(r.r, r.b[:n])
 	return r.b[:n], err
@@ -313,14 +367,17 @@ func (w *binaryWriter) WriteMessage(m Message) error {
 }
 
 func (w *binaryWriter) WriteField(f Field) error {
-	if err := w.writeByte(byte(f.Type)); err != nil {
+	if err := w.writeType(f.Type); err != nil {
 		return err
 	}
+	if f.Type == STOP {
+		return nil
+	}
 	return w.WriteInt16(f.ID)
 }
 
 func (w *binaryWriter) WriteList(l List) error {
-	if err := w.writeByte(byte(l.Type)); err != nil {
+	if err := w.writeType(l.Type); err != nil {
 		return err
 	}
 	return w.WriteInt32(l.Size)
@@ -331,15 +388,22 @@ func (w *binaryWriter) WriteSet(s Set) error {
 }
 
 func (w *binaryWriter) WriteMap(m Map) error {
-	if err := w.writeByte(byte(m.Key)); err != nil {
+	if err := w.writeType(m.Key); err != nil {
 		return err
 	}
-	if err := w.writeByte(byte(m.Value)); err != nil {
+	if err := w.writeType(m.Value); err != nil {
 		return err
 	}
 	return w.WriteInt32(m.Size)
 }
 
+func (w *binaryWriter) writeType(t Type) error {
+	if t < 0 || int(t) >= len(binaryTypeOf) {
+		return fmt.Errorf("cannot encode thrift type in binary protocol: %d", t)
+	}
+	return w.writeByte(binaryTypeOf[t])
+}
+
 func (w *binaryWriter) write(b []byte) error {
 	_, err := w.w.Write(b)
 	return err
@@ -12,6 +12,12 @@ var (
 	// data.
 	ErrCorrupted = errors.New("corrupted parquet page")
 
+	// ErrCorruptedMetadata is an error returned when opening a parquet file
+	// with the VerifyMetadataChecksums option, if the checksum recorded in the
+	// file or column chunk metadata does not match the one computed from the
+	// metadata that were read.
+	ErrCorruptedMetadata = errors.New("corrupted parquet file metadata")
+
 	// ErrMissingRootColumn is an error returned when opening an invalid parquet
 	// file which does not have a root column.
 	ErrMissingRootColumn = errors.New("parquet file is missing a root column")
@@ -29,6 +35,16 @@ var (
 	// destination.
 	ErrRowGroupSortingColumnsMismatch = errors.New("cannot write row groups with mismatching sorting columns")
 
+	// ErrUnsortedRowGroup is an error returned when reading rows of a row
+	// group produced by MergeRowGroups if one of the merged row groups was not
+	// sorted on the sorting columns of the merge.
+	ErrUnsortedRowGroup = errors.New("merged row group is not sorted on the sorting columns")
+
+	// ErrUnsortedRows is an error returned when writing rows to a writer
+	// configured with SortingColumnsChecked if the rows are not sorted on the
+	// sorting columns of the writer.
+	ErrUnsortedRows = errors.New("rows are not sorted on the sorting columns of the writer")
+
 	// ErrSeekOutOfRange is an error returned when seeking to a row index which
 	// is less than the first row of a page.
 	ErrSeekOutOfRange = errors.New("seek to row index out of page range")
@@ -60,6 +76,26 @@ var (
 	// physical types.
 	ErrInvalidConversion = errors.New("invalid conversion between parquet values")
 
+	// ErrUnsupportedCompressionCodec is an error returned when reading data
+	// compressed with a codec which is not supported by the package, and
+	// when opening parquet files with column chunks using such codecs.
+	ErrUnsupportedCompressionCodec = errors.New("unsupported compression codec")
+
+	// ErrVerificationFailed is an error returned when closing a writer created
+	// with the VerifyOnClose option, if the content of the file read back from
+	// the output does not match what was written.
+	ErrVerificationFailed = errors.New("verification of the written parquet file failed")
+
+	// ErrMisalignedColumns is an error returned when flushing a
+	// ColumnStreamWriter while the columns have received values for different
+	// numbers of rows.
+	ErrMisalignedColumns = errors.New("columns have values for different numbers of rows")
+
+	// ErrSchemaMismatch is an error returned by readers configured with the
+	// StrictSchema option when the schema that rows are read with does not
+	// have the same columns as the file.
+	ErrSchemaMismatch = errors.New("schema does not match the columns of the parquet file")
+
 	// ErrMalformedRepetitionLevel is returned when a page reader encounters
 	// a repetition level which does not start at the beginning of a row.
 	ErrMalformedRepetitionLevel = errors.New("parquet-go encountered a malformed data page which does not start at the beginning of a row")
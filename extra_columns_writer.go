@@ -0,0 +1,283 @@
+package parquet
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// ExtraColumnsWriter writes rows made of a value of type T and of a variable
+// set of extra columns, which are not part of the schema of T.
+//
+// The schema of the output file is the schema of T extended with one top-level
+// column for each extra column. Extra columns are either declared when
+// creating the writer, in which case rows are written as they are received,
+// or inferred from the values passed to Write. When inferred, the schema can
+// only be finalized once all the rows were seen: rows are then buffered in
+// memory until Close is called, and the extra columns are the union of the
+// keys of all the rows.
+//
+// ExtraColumnsWriter values are not safe to use concurrently from multiple
+// goroutines.
+type ExtraColumnsWriter[T any] struct {
+	output  io.Writer
+	options []WriterOption
+	base    *Schema
+	writer  *Writer
+	columns []extraColumnsMapping
+	extra   map[string]struct{}
+	buffer  []extraColumnsRow
+	values  [][]Value
+	rows    [1]Row
+}
+
+type extraColumnsMapping struct {
+	// Index of the column in the schema of T, or -1 for extra columns.
+	baseColumnIndex    int
+	extraName          string
+	extraKind          Kind
+	maxDefinitionLevel byte
+}
+
+type extraColumnsRow struct {
+	base  Row
+	extra map[string]Value
+}
+
+// NewExtraColumnsWriter constructs a writer of rows of type T extended with
+// extra columns.
+//
+// The extra node declares the extra columns, it must be a group of leaf
+// columns which are not repeated, and whose names do not collide with the
+// top-level fields of T. Values of optional extra columns may be missing from
+// the rows, values of required extra columns must always be present.
+//
+// When extra is nil, the extra columns are inferred from the rows passed to
+// Write: each key becomes an optional column with the physical type of its
+// values, and no logical type. Columns of keys that only had null values are
+// written as optional BYTE_ARRAY columns.
+//
+// The function panics if the options carry an invalid configuration, or if
+// the extra columns are not valid.
+func NewExtraColumnsWriter[T any](output io.Writer, extra Node, options ...WriterOption) *ExtraColumnsWriter[T] {
+	if _, err := NewWriterConfig(options...); err != nil {
+		panic(err)
+	}
+
+	base := schemaOf(dereference(typeOf[T]()))
+	w := &ExtraColumnsWriter[T]{
+		output:  output,
+		options: options,
+		base:    base,
+		values:  make([][]Value, len(base.Columns())),
+	}
+
+	if extra != nil {
+		w.extra = make(map[string]struct{}, len(extra.Fields()))
+		for _, field := range extra.Fields() {
+			if !field.Leaf() || field.Repeated() {
+				panic(fmt.Errorf("extra column %q must be a leaf column which is not repeated", field.Name()))
+			}
+			if fieldByName(base, field.Name()) != nil {
+				panic(fmt.Errorf("extra column %q collides with a field of %v", field.Name(), typeOf[T]()))
+			}
+			w.extra[field.Name()] = struct{}{}
+		}
+		w.open(extra.Fields())
+	}
+	return w
+}
+
+// Schema returns the schema of the rows written to the file, or nil if the
+// extra columns are inferred and the writer was not closed yet.
+func (w *ExtraColumnsWriter[T]) Schema() *Schema {
+	if w.writer == nil {
+		return nil
+	}
+	return w.writer.Schema()
+}
+
+// Write writes a row made of the value of type T and of the extra columns.
+//
+// When the extra columns were declared, an error is returned if the map holds
+// keys that are not extra columns, or values of a different type than their
+// column.
+func (w *ExtraColumnsWriter[T]) Write(row T, extra map[string]Value) error {
+	base, err := w.base.deconstruct(nil, &row)
+	if err != nil {
+		return err
+	}
+
+	for name := range extra {
+		if fieldByName(w.base, name) != nil {
+			return fmt.Errorf("extra column %q collides with a field of %v", name, typeOf[T]())
+		}
+	}
+
+	if w.writer == nil {
+		values := make(map[string]Value, len(extra))
+		for name, value := range extra {
+			values[name] = value.Clone()
+		}
+		w.buffer = append(w.buffer, extraColumnsRow{
+			base:  base.Clone(),
+			extra: values,
+		})
+		return nil
+	}
+
+	return w.writeRow(base, extra)
+}
+
+// Close finalizes the schema if the extra columns were inferred, writes the
+// buffered rows, and flushes the footer of the parquet file.
+func (w *ExtraColumnsWriter[T]) Close() error {
+	if w.writer == nil {
+		fields, err := w.inferExtraColumns()
+		if err != nil {
+			return err
+		}
+		w.open(fields)
+
+		for i, row := range w.buffer {
+			if err := w.writeRow(row.base, row.extra); err != nil {
+				return err
+			}
+			w.buffer[i] = extraColumnsRow{}
+		}
+		w.buffer = nil
+	}
+	return w.writer.Close()
+}
+
+func (w *ExtraColumnsWriter[T]) open(extra []Field) {
+	group := make(Group, len(w.base.Fields())+len(extra))
+	for _, field := range w.base.Fields() {
+		group[field.Name()] = field
+	}
+	for _, field := range extra {
+		group[field.Name()] = field
+	}
+
+	options := make([]WriterOption, 0, len(w.options)+1)
+	options = append(options, w.options...)
+	options = append(options, NewSchema(w.base.Name(), group))
+	w.writer = NewWriter(w.output, options...)
+
+	forEachLeafColumnOf(w.writer.Schema(), func(leaf leafColumn) {
+		mapping := extraColumnsMapping{
+			baseColumnIndex:    -1,
+			maxDefinitionLevel: leaf.maxDefinitionLevel,
+		}
+		if _, ok := w.extra[leaf.path[0]]; ok {
+			mapping.extraName = leaf.path[0]
+			mapping.extraKind = leaf.node.Type().Kind()
+		} else {
+			column, _ := w.base.Lookup(leaf.path...)
+			mapping.baseColumnIndex = column.ColumnIndex
+		}
+		w.columns = append(w.columns, mapping)
+	})
+}
+
+func (w *ExtraColumnsWriter[T]) inferExtraColumns() ([]Field, error) {
+	types := make(map[string]Type)
+	for _, row := range w.buffer {
+		for name, value := range row.extra {
+			typ, seen := types[name]
+			if value.IsNull() {
+				if !seen {
+					types[name] = nil
+				}
+				continue
+			}
+			valueType := extraColumnTypeOf(value)
+			if typ != nil && (typ.Kind() != valueType.Kind() || typ.Length() != valueType.Length()) {
+				return nil, fmt.Errorf("extra column %q has values of different types: %v and %v", name, typ, valueType)
+			}
+			types[name] = valueType
+		}
+	}
+
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	w.extra = make(map[string]struct{}, len(names))
+	fields := make([]Field, len(names))
+	for i, name := range names {
+		typ := types[name]
+		if typ == nil {
+			typ = ByteArrayType
+		}
+		w.extra[name] = struct{}{}
+		fields[i] = &groupField{Node: Optional(Leaf(typ)), name: name}
+	}
+	return fields, nil
+}
+
+func (w *ExtraColumnsWriter[T]) writeRow(base Row, extra map[string]Value) error {
+	clear(w.values)
+	base.Range(func(columnIndex int, values []Value) bool {
+		w.values[columnIndex] = values
+		return true
+	})
+
+	for name := range extra {
+		if _, ok := w.extra[name]; !ok {
+			return fmt.Errorf("%q is not an extra column of the schema", name)
+		}
+	}
+
+	row := w.rows[0][:0]
+	for columnIndex, column := range w.columns {
+		if column.baseColumnIndex >= 0 {
+			for _, value := range w.values[column.baseColumnIndex] {
+				value.columnIndex = ^makeColumnIndex(columnIndex)
+				row = append(row, value)
+			}
+			continue
+		}
+
+		value, ok := extra[column.extraName]
+		switch {
+		case !ok || value.IsNull():
+			if column.maxDefinitionLevel == 0 {
+				return fmt.Errorf("missing value for required extra column %q", column.extraName)
+			}
+			value = NullValue().Level(0, 0, columnIndex)
+		case value.Kind() != column.extraKind:
+			return fmt.Errorf("cannot write %v value to extra column %q of type %v", value.Kind(), column.extraName, column.extraKind)
+		default:
+			value = value.Level(0, int(column.maxDefinitionLevel), columnIndex)
+		}
+		row = append(row, value)
+	}
+
+	w.rows[0] = row
+	_, err := w.writer.WriteRows(w.rows[:])
+	return err
+}
+
+func extraColumnTypeOf(value Value) Type {
+	switch value.Kind() {
+	case Boolean:
+		return BooleanType
+	case Int32:
+		return Int32Type
+	case Int64:
+		return Int64Type
+	case Int96:
+		return Int96Type
+	case Float:
+		return FloatType
+	case Double:
+		return DoubleType
+	case FixedLenByteArray:
+		return FixedLenByteArrayType(len(value.byteArray()))
+	default:
+		return ByteArrayType
+	}
+}
@@ -0,0 +1,143 @@
+package parquet_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestExtraColumnsWriter(t *testing.T) {
+	type Record struct {
+		ID   int64    `parquet:"id"`
+		Name string   `parquet:"name"`
+		Tags []string `parquet:"tags,list"`
+	}
+
+	type Output struct {
+		ID    int64    `parquet:"id"`
+		Name  string   `parquet:"name"`
+		Tags  []string `parquet:"tags,list"`
+		Color *string  `parquet:"color,optional"`
+		Size  *int64   `parquet:"size,optional"`
+	}
+
+	red, blue := "red", "blue"
+	size := int64(42)
+
+	records := []Record{
+		{ID: 1, Name: "one", Tags: []string{"a", "b"}},
+		{ID: 2, Name: "two"},
+		{ID: 3, Name: "three", Tags: []string{"c"}},
+	}
+	extras := []map[string]parquet.Value{
+		{"color": parquet.ByteArrayValue([]byte(red))},
+		{"size": parquet.Int64Value(size)},
+		{"color": parquet.ByteArrayValue([]byte(blue)), "size": parquet.NullValue()},
+	}
+	want := []Output{
+		{ID: 1, Name: "one", Tags: []string{"a", "b"}, Color: &red},
+		{ID: 2, Name: "two", Tags: []string{}, Size: &size},
+		{ID: 3, Name: "three", Tags: []string{"c"}, Color: &blue},
+	}
+
+	check := func(t *testing.T, buf *bytes.Buffer) {
+		t.Helper()
+		f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		columns := f.Schema().Columns()
+		if len(columns) != 5 {
+			t.Fatalf("wrong number of columns: %v", columns)
+		}
+		for _, path := range [][]string{{"color"}, {"size"}} {
+			leaf, ok := f.Schema().Lookup(path...)
+			if !ok {
+				t.Fatalf("missing extra column %q", path)
+			}
+			if !leaf.Node.Optional() {
+				t.Errorf("extra column %q must be optional", path)
+			}
+		}
+
+		rows := make([]Output, len(want)+1)
+		n, _ := parquet.NewGenericReader[Output](f).Read(rows)
+		if n != len(want) {
+			t.Fatalf("wrong number of rows: want=%d got=%d", len(want), n)
+		}
+		if !reflect.DeepEqual(rows[:n], want) {
+			t.Errorf("wrong rows:\nwant: %+v\ngot:  %+v", want, rows[:n])
+		}
+	}
+
+	t.Run("inferred", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		w := parquet.NewExtraColumnsWriter[Record](buf, nil)
+		for i, record := range records {
+			if err := w.Write(record, extras[i]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if w.Schema() != nil {
+			t.Error("schema must not be finalized before closing the writer")
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		check(t, buf)
+	})
+
+	t.Run("declared", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		w := parquet.NewExtraColumnsWriter[Record](buf, parquet.Group{
+			"color": parquet.Optional(parquet.String()),
+			"size":  parquet.Optional(parquet.Int(64)),
+		})
+		if w.Schema() == nil {
+			t.Fatal("schema must be finalized when extra columns are declared")
+		}
+		for i, record := range records {
+			if err := w.Write(record, extras[i]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.Write(Record{}, map[string]parquet.Value{"weight": parquet.DoubleValue(1)}); err == nil {
+			t.Error("expected an error writing an undeclared extra column")
+		}
+		if err := w.Write(Record{}, map[string]parquet.Value{"size": parquet.DoubleValue(1)}); err == nil {
+			t.Error("expected an error writing a value of the wrong type")
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		check(t, buf)
+	})
+
+	t.Run("conflicting types", func(t *testing.T) {
+		w := parquet.NewExtraColumnsWriter[Record](new(bytes.Buffer), nil)
+		w.Write(records[0], map[string]parquet.Value{"size": parquet.Int64Value(1)})
+		w.Write(records[1], map[string]parquet.Value{"size": parquet.ByteArrayValue([]byte("large"))})
+		if err := w.Close(); err == nil {
+			t.Error("expected an error closing a writer with extra columns of conflicting types")
+		}
+	})
+
+	t.Run("collision", func(t *testing.T) {
+		w := parquet.NewExtraColumnsWriter[Record](new(bytes.Buffer), nil)
+		if err := w.Write(records[0], map[string]parquet.Value{"name": parquet.ByteArrayValue(nil)}); err == nil {
+			t.Error("expected an error writing an extra column with the name of a field")
+		}
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		type Amount struct {
+			Value uint64 `parquet:"value,decimal(0:5)"`
+		}
+		w := parquet.NewExtraColumnsWriter[Amount](new(bytes.Buffer), nil)
+		if err := w.Write(Amount{Value: 1_000_000}, nil); err == nil {
+			t.Error("expected an error writing a value exceeding the precision of the decimal")
+		}
+	})
+}
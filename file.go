@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"hash/crc32"
 	"io"
+	"math"
 	"slices"
 	"sort"
 	"strings"
@@ -34,6 +35,9 @@ type File struct {
 	offsetIndexes []format.OffsetIndex
 	rowGroups     []RowGroup
 	config        *FileConfig
+	buffers       *bufferPool
+	// Files referenced by the file_path of column chunks, indexed by path.
+	externalFiles map[string]*externalFile
 }
 
 type FileView interface {
@@ -54,6 +58,11 @@ type FileView interface {
 // Only the parquet magic bytes and footer are read, column chunks and other
 // parts of the file are left untouched; this means that successfully opening
 // a file does not validate that the pages have valid checksums.
+//
+// The footer is expected to be encoded with the thrift compact protocol, which
+// is the protocol mandated by the parquet specification and the one used by
+// Writer. Footers encoded with the thrift binary protocol, which some tools
+// emit, are detected and decoded as well.
 func OpenFile(r io.ReaderAt, size int64, options ...FileOption) (*File, error) {
 	c, err := NewFileConfig(options...)
 	if err != nil {
@@ -113,12 +122,22 @@ func OpenFile(r io.ReaderAt, size int64, options ...FileOption) (*File, error) {
 		}
 	}
 
-	if err := thrift.Unmarshal(&f.protocol, footerData, &f.metadata); err != nil {
+	if err := thrift.Unmarshal(footerProtocol(footerData), footerData, &f.metadata); err != nil {
 		return nil, fmt.Errorf("reading parquet file metadata: %w", err)
 	}
+	if c.VerifyMetadataChecksums {
+		if err := verifyMetadataChecksums(&f.protocol, &f.metadata); err != nil {
+			return nil, fmt.Errorf("reading parquet file metadata: %w", err)
+		}
+	}
 	if len(f.metadata.Schema) == 0 {
 		return nil, ErrMissingRootColumn
 	}
+	if err := checkCompressionCodecs(&f.metadata); err != nil {
+		return nil, fmt.Errorf("opening parquet file: %w", err)
+	}
+
+	f.externalFiles = makeExternalFiles(&f.metadata, c.ExternalColumnChunks)
 
 	if !c.SkipPageIndex {
 		if f.columnIndexes, f.offsetIndexes, err = f.ReadPageIndex(); err != nil {
@@ -139,6 +158,10 @@ func OpenFile(r io.ReaderAt, size int64, options ...FileOption) (*File, error) {
 	rowGroups := makeFileRowGroups(f, columns)
 	f.rowGroups = makeRowGroups(rowGroups)
 
+	if c.PreallocateBuffers > 0 {
+		f.buffers = preallocateBuffers(f, c.PreallocateBuffers)
+	}
+
 	if !c.SkipBloomFilters {
 		section := io.NewSectionReader(r, 0, size)
 		rbuf, rbufpool := getBufioReader(section, c.ReadBufferSize)
@@ -154,7 +177,9 @@ func OpenFile(r io.ReaderAt, size int64, options ...FileOption) (*File, error) {
 			for j := range g.columns {
 				c := g.columns[j].(*FileColumnChunk)
 
-				if offset := c.chunk.MetaData.BloomFilterOffset; offset > 0 {
+				// The bloom filters of column chunks stored in other files
+				// are read when the program accesses them.
+				if offset := c.chunk.MetaData.BloomFilterOffset; offset > 0 && c.chunk.FilePath == "" {
 					section.Seek(offset, io.SeekStart)
 					rbuf.Reset(section)
 
@@ -183,6 +208,59 @@ func OpenFile(r io.ReaderAt, size int64, options ...FileOption) (*File, error) {
 	return f, nil
 }
 
+// preallocateBuffers returns a pool of buffers large enough to hold the
+// compressed pages of the file, with a total size bounded by limit. The size of
+// pages is known from the offset index when the file has one, otherwise the
+// buffers are sized after the largest column chunk. The function returns nil
+// if the limit is too small to preallocate buffers.
+func preallocateBuffers(f *File, limit int64) *bufferPool {
+	numColumns, largestPage := 0, int64(0)
+	for i, rowGroup := range f.metadata.RowGroups {
+		numColumns = max(numColumns, len(rowGroup.Columns))
+		for j, chunk := range rowGroup.Columns {
+			if k := i*len(rowGroup.Columns) + j; k < len(f.offsetIndexes) && len(f.offsetIndexes[k].PageLocations) > 0 {
+				for _, page := range f.offsetIndexes[k].PageLocations {
+					largestPage = max(largestPage, int64(page.CompressedPageSize))
+				}
+			} else {
+				largestPage = max(largestPage, chunk.MetaData.TotalCompressedSize)
+			}
+		}
+	}
+	// Each column holds up to two compressed pages at a time, the page being
+	// decoded and the next page prefetched in ReadModeAsync.
+	count := 2 * numColumns
+	if count == 0 {
+		return nil
+	}
+	size := min(largestPage, limit/int64(count))
+	if size < bufferPoolMinSize {
+		return nil
+	}
+	return newReservedBufferPool(count, int(size))
+}
+
+// bufferPool returns the pool that the buffers holding the compressed pages of
+// f are acquired from.
+func (f *File) bufferPool() *bufferPool {
+	if f != nil && f.buffers != nil {
+		return f.buffers
+	}
+	return &buffers
+}
+
+// footerProtocol returns the thrift protocol that the footer data is encoded
+// with. The first field of the file metadata is the version, with id 1 and type
+// I32, which the binary protocol encodes as the bytes 0x08 0x00 0x01. In the
+// compact protocol, these bytes would be a field with id zero, which is never
+// valid, so they are sufficient to tell the protocols apart.
+func footerProtocol(data []byte) thrift.Protocol {
+	if len(data) >= 3 && data[0] == 0x08 && data[1] == 0x00 && data[2] == 0x01 {
+		return new(thrift.BinaryProtocol)
+	}
+	return new(thrift.CompactProtocol)
+}
+
 // ReadPageIndex reads the page index section of the parquet file f.
 //
 // If the file did not contain a page index, the method returns two empty slices
@@ -214,15 +292,21 @@ func (f *File) ReadPageIndex() ([]format.ColumnIndex, []format.OffsetIndex, erro
 		return nil, nil, nil
 	}
 
-	columnIndexOffset := f.metadata.RowGroups[0].Columns[0].ColumnIndexOffset
-	offsetIndexOffset := f.metadata.RowGroups[0].Columns[0].OffsetIndexOffset
+	columnIndexOffset := int64(0)
+	offsetIndexOffset := int64(0)
 	columnIndexLength := int64(0)
 	offsetIndexLength := int64(0)
 
+	// The page indexes of column chunks stored in other files are not part of
+	// the sections read from f, they are read from their files when the
+	// program accesses them.
 	forEachColumnChunk := func(do func(int, int, *format.ColumnChunk) error) error {
 		for i := range f.metadata.RowGroups {
 			for j := range f.metadata.RowGroups[i].Columns {
 				c := &f.metadata.RowGroups[i].Columns[j]
+				if c.FilePath != "" {
+					continue
+				}
 				if err := do(i, j, c); err != nil {
 					return err
 				}
@@ -232,6 +316,15 @@ func (f *File) ReadPageIndex() ([]format.ColumnIndex, []format.OffsetIndex, erro
 	}
 
 	forEachColumnChunk(func(_, _ int, c *format.ColumnChunk) error {
+		// The sections start at the index of the first column chunk that has
+		// one, which may not be the first column when some columns were
+		// written without page indexes.
+		if c.ColumnIndexOffset > 0 && (columnIndexOffset == 0 || c.ColumnIndexOffset < columnIndexOffset) {
+			columnIndexOffset = c.ColumnIndexOffset
+		}
+		if c.OffsetIndexOffset > 0 && (offsetIndexOffset == 0 || c.OffsetIndexOffset < offsetIndexOffset) {
+			offsetIndexOffset = c.OffsetIndexOffset
+		}
 		columnIndexLength += int64(c.ColumnIndexLength)
 		offsetIndexLength += int64(c.OffsetIndexLength)
 		return nil
@@ -417,14 +510,20 @@ func (g *FileRowGroup) init(file *File, columns []*Column, rowGroup *format.RowG
 			chunk:    &rowGroup.Columns[i],
 		}
 
-		if file.hasIndexes() {
+		if file.hasIndexes() && rowGroup.Columns[i].FilePath == "" {
 			j := (int(rowGroup.Ordinal) * len(columns)) + i
 
 			fileColumnIndexes[i] = FileColumnIndex{index: &file.columnIndexes[j], kind: columns[i].Type().Kind()}
 			fileOffsetIndexes[i] = FileOffsetIndex{index: &file.offsetIndexes[j]}
 
-			fileColumnChunks[i].columnIndex.Store(&fileColumnIndexes[i])
-			fileColumnChunks[i].offsetIndex.Store(&fileOffsetIndexes[i])
+			// Column chunks written without page indexes keep nil indexes,
+			// so pages are located by scanning the column chunk.
+			if rowGroup.Columns[i].ColumnIndexOffset > 0 {
+				fileColumnChunks[i].columnIndex.Store(&fileColumnIndexes[i])
+			}
+			if rowGroup.Columns[i].OffsetIndexOffset > 0 {
+				fileColumnChunks[i].offsetIndex.Store(&fileOffsetIndexes[i])
+			}
 		}
 
 		g.columns[i] = &fileColumnChunks[i]
@@ -504,6 +603,33 @@ type FileColumnChunk struct {
 // File returns the file that this column chunk belongs to.
 func (c *FileColumnChunk) File() *File { return c.file }
 
+// reader returns the reader of the file that the column chunk is stored in,
+// which is either the file it belongs to, or the file referenced by the
+// file_path of the column chunk.
+func (c *FileColumnChunk) reader() io.ReaderAt {
+	if c.chunk.FilePath != "" {
+		return c.file.externalFiles[c.chunk.FilePath]
+	}
+	return c.file.reader
+}
+
+// transform returns the column transform that the values of the data pages of
+// the column chunk must be reversed with, or nil if they were not transformed.
+func (c *FileColumnChunk) transform() (*ColumnTransform, error) {
+	name := columnTransformName(&c.chunk.MetaData)
+	if name == "" {
+		return nil, nil
+	}
+	t := lookupColumnTransform(c.file.config.ColumnTransforms, c.column.Path())
+	if t == nil {
+		return nil, fmt.Errorf("values of the column chunk were transformed by %q but no transform is configured for the column", name)
+	}
+	if t.Name != name {
+		return nil, fmt.Errorf("values of the column chunk were transformed by %q but the transform configured for the column is %q", name, t.Name)
+	}
+	return t, nil
+}
+
 // Node returns the node that this column chunk belongs to in the parquet schema.
 func (c *FileColumnChunk) Node() Node { return c.column }
 
@@ -528,9 +654,71 @@ func (c *FileColumnChunk) Bounds() (min, max Value, ok bool) {
 	return min, max, hasMinValue && hasMaxValue
 }
 
+// Statistics returns the statistics recorded in the metadata of the column
+// chunk: the min and max values, the number of null values, and the number of
+// distinct values. The ok boolean is false if the writer of the file did not
+// record statistics for the column chunk.
+//
+// Writers are not required to populate all the statistics, the min and max
+// values are null when they are absent or cannot be decoded (e.g. when they
+// were truncated to a length that does not match the column type), and the
+// distinct count is zero when it is unknown. Writers may also truncate the
+// min and max values of byte arrays, in which case the values are bounds of
+// the column chunk values rather than values of the column chunk.
+//
+// Files produced by older writers may only have the deprecated min and max
+// fields of the statistics, which were computed with a signed comparison of
+// the values. They are used when the min_value and max_value fields are absent
+// and the column has a signed sort order, the min and max values are null for
+// the other columns.
+//
+// The values returned by this method share memory with the file metadata and
+// must be treated as read-only.
+func (c *FileColumnChunk) Statistics() (min, max Value, nullCount, distinctCount int64, ok bool) {
+	stats := &c.chunk.MetaData.Statistics
+	if stats.MinValue == nil && stats.MaxValue == nil && stats.Min == nil && stats.Max == nil && stats.NullCount == 0 && stats.DistinctCount == 0 {
+		return min, max, 0, 0, false
+	}
+	columnType := c.Type()
+	minValue, maxValue := stats.MinValue, stats.MaxValue
+	if minValue == nil && maxValue == nil && hasSignedSortOrder(columnType) {
+		minValue, maxValue = stats.Min, stats.Max
+	}
+	min = statisticsValue(columnType, minValue)
+	max = statisticsValue(columnType, maxValue)
+	return min, max, stats.NullCount, stats.DistinctCount, true
+}
+
+// hasSignedSortOrder returns true if values of type t are ordered by a signed
+// comparison, which is the order of the deprecated min and max statistics.
+func hasSignedSortOrder(t Type) bool {
+	switch t.Kind() {
+	case Boolean, Int32, Int64, Float, Double:
+		lt := t.LogicalType()
+		return lt == nil || lt.Integer == nil || lt.Integer.IsSigned
+	default:
+		return false
+	}
+}
+
+func statisticsValue(typ Type, data []byte) Value {
+	if data == nil {
+		return Value{}
+	}
+	kind := typ.Kind()
+	if kind == FixedLenByteArray && len(data) != typ.Length() {
+		return Value{}
+	}
+	v, err := parseValue(kind, data)
+	if err != nil {
+		return Value{}
+	}
+	return v
+}
+
 // Pages returns a page reader for the column chunk.
 func (c *FileColumnChunk) Pages() Pages {
-	pages := Pages(c.PagesFrom(c.file.reader))
+	pages := Pages(c.PagesFrom(c.reader()))
 	if c.file.config.ReadMode == ReadModeAsync {
 		pages = AsyncPages(pages)
 	}
@@ -551,7 +739,7 @@ func (c *FileColumnChunk) PagesFrom(reader io.ReaderAt) *FilePages {
 // ColumnIndex returns the column index of the column chunk, or an error if it
 // didn't exist or couldn't be read.
 func (c *FileColumnChunk) ColumnIndex() (ColumnIndex, error) {
-	index, err := c.ColumnIndexFrom(c.file.reader)
+	index, err := c.ColumnIndexFrom(c.reader())
 	if err != nil {
 		return nil, err
 	}
@@ -574,7 +762,7 @@ func (c *FileColumnChunk) ColumnIndexFrom(reader io.ReaderAt) (*FileColumnIndex,
 // OffsetIndex returns the offset index of the column chunk, or an error if it
 // didn't exist or couldn't be read.
 func (c *FileColumnChunk) OffsetIndex() (OffsetIndex, error) {
-	index, err := c.OffsetIndexFrom(c.file.reader)
+	index, err := c.OffsetIndexFrom(c.reader())
 	if err != nil {
 		return nil, err
 	}
@@ -597,7 +785,7 @@ func (c *FileColumnChunk) OffsetIndexFrom(reader io.ReaderAt) (*FileOffsetIndex,
 // BloomFilter returns the bloom filter of the column chunk, or nil if it didn't
 // have one.
 func (c *FileColumnChunk) BloomFilter() BloomFilter {
-	filter, err := c.BloomFilterFrom(c.file.reader)
+	filter, err := c.BloomFilterFrom(c.reader())
 	switch err {
 	case nil:
 		return filter
@@ -621,6 +809,65 @@ func (c *FileColumnChunk) BloomFilterFrom(reader io.ReaderAt) (*FileBloomFilter,
 	return filter, nil
 }
 
+// DictionaryPageStats describes the dictionary page of a column chunk.
+type DictionaryPageStats struct {
+	// Number of entries in the dictionary.
+	NumValues int64
+	// Size of the dictionary page in the file, excluding the page header.
+	CompressedSize int64
+	// Size of the PLAIN-encoded values of the dictionary page, before
+	// compression.
+	UncompressedSize int64
+}
+
+// DictionaryPageStats returns the number of entries and sizes of the dictionary
+// page of the column chunk. The values are read from the header of the page,
+// neither the dictionary nor the data pages are decoded.
+//
+// The method returns ok=false if the column chunk had no dictionary page.
+func (c *FileColumnChunk) DictionaryPageStats() (stats DictionaryPageStats, ok bool, err error) {
+	return c.DictionaryPageStatsFrom(c.reader())
+}
+
+// DictionaryPageStatsFrom is like DictionaryPageStats but uses the reader passed
+// as argument to read the page header.
+func (c *FileColumnChunk) DictionaryPageStatsFrom(reader io.ReaderAt) (stats DictionaryPageStats, ok bool, err error) {
+	if c.chunk.MetaData.TotalCompressedSize == 0 {
+		return stats, false, nil
+	}
+	// Some writers do not set the dictionary page offset, the dictionary page
+	// is then the first page of the column chunk.
+	offset := c.chunk.MetaData.DictionaryPageOffset
+	if offset == 0 {
+		offset = c.chunk.MetaData.DataPageOffset
+	}
+
+	section := io.NewSectionReader(reader, offset, c.chunk.MetaData.TotalCompressedSize)
+	rbuf, rbufpool := getBufioReader(section, 1024)
+	defer putBufioReader(rbuf, rbufpool)
+
+	header := format.PageHeader{}
+	compact := thrift.CompactProtocol{}
+	decoder := thrift.NewDecoder(compact.NewReader(rbuf))
+
+	if err := decoder.Decode(&header); err != nil {
+		return stats, false, fmt.Errorf("decoding page header of column %d: %w", c.Column(), err)
+	}
+	if header.Type != format.DictionaryPage {
+		return stats, false, nil
+	}
+	if header.DictionaryPageHeader == nil {
+		return stats, false, ErrMissingPageHeader
+	}
+
+	stats = DictionaryPageStats{
+		NumValues:        int64(header.DictionaryPageHeader.NumValues),
+		CompressedSize:   int64(header.CompressedPageSize),
+		UncompressedSize: int64(header.UncompressedPageSize),
+	}
+	return stats, true, nil
+}
+
 // NumValues returns the number of values in the column chunk.
 func (c *FileColumnChunk) NumValues() int64 {
 	return c.chunk.MetaData.NumValues
@@ -635,7 +882,7 @@ func (c *FileColumnChunk) NullCount() int64 {
 }
 
 func (c *FileColumnChunk) readColumnIndex() (*FileColumnIndex, error) {
-	return c.readColumnIndexFrom(c.file.reader)
+	return c.readColumnIndexFrom(c.reader())
 }
 
 func (c *FileColumnChunk) readColumnIndexFrom(reader io.ReaderAt) (*FileColumnIndex, error) {
@@ -703,6 +950,10 @@ func (c *FileColumnChunk) readBloomFilter(reader io.ReaderAt) (*FileBloomFilter,
 	if offset == 0 {
 		return nil, nil
 	}
+	if c.chunk.FilePath != "" {
+		// The size of the file that the column chunk is stored in is unknown.
+		length = math.MaxInt64 - offset
+	}
 
 	section := io.NewSectionReader(reader, offset, length)
 	rbuf, rbufpool := getBufioReader(section, 1024)
@@ -716,7 +967,10 @@ func (c *FileColumnChunk) readBloomFilter(reader io.ReaderAt) (*FileBloomFilter,
 		return nil, fmt.Errorf("decoding bloom filter header: %w", err)
 	}
 
-	offset, _ = section.Seek(0, io.SeekCurrent)
+	// The position of the section reader is relative to the bloom filter
+	// offset, and includes the bytes buffered after the header.
+	position, _ := section.Seek(0, io.SeekCurrent)
+	offset += position - int64(rbuf.Buffered())
 	filter := newBloomFilter(reader, offset, &header)
 
 	if !c.bloomFilter.CompareAndSwap(nil, filter) {
@@ -934,7 +1188,7 @@ func (f *FilePages) readDictionary() error {
 		return err
 	}
 
-	page := buffers.get(int(header.CompressedPageSize))
+	page := f.chunk.file.bufferPool().get(int(header.CompressedPageSize))
 	defer page.unref()
 
 	if _, err := io.ReadFull(rbuf, page.data); err != nil {
@@ -965,7 +1219,11 @@ func (f *FilePages) readDataPageV1(header *format.PageHeader, page *buffer) (Pag
 			return nil, err
 		}
 	}
-	return f.chunk.column.decodeDataPageV1(DataPageHeaderV1{header.DataPageHeader}, page, f.dictionary, header.UncompressedPageSize)
+	transform, err := f.chunk.transform()
+	if err != nil {
+		return nil, err
+	}
+	return f.chunk.column.decodeDataPageV1(DataPageHeaderV1{header.DataPageHeader}, page, f.dictionary, header.UncompressedPageSize, transform)
 }
 
 func (f *FilePages) readDataPageV2(header *format.PageHeader, page *buffer) (Page, error) {
@@ -980,11 +1238,15 @@ func (f *FilePages) readDataPageV2(header *format.PageHeader, page *buffer) (Pag
 			return nil, err
 		}
 	}
-	return f.chunk.column.decodeDataPageV2(DataPageHeaderV2{header.DataPageHeaderV2}, page, f.dictionary, header.UncompressedPageSize)
+	transform, err := f.chunk.transform()
+	if err != nil {
+		return nil, err
+	}
+	return f.chunk.column.decodeDataPageV2(DataPageHeaderV2{header.DataPageHeaderV2}, page, f.dictionary, header.UncompressedPageSize, transform)
 }
 
 func (f *FilePages) readPage(header *format.PageHeader, reader *bufio.Reader) (*buffer, error) {
-	page := buffers.get(int(header.CompressedPageSize))
+	page := f.chunk.file.bufferPool().get(int(header.CompressedPageSize))
 	defer page.unref()
 
 	if _, err := io.ReadFull(reader, page.data); err != nil {
@@ -1191,3 +1453,47 @@ func (r *optimisticFileReaderAt) ReadAt(p []byte, off int64) (n int, err error)
 	rn, err := r.reader.ReadAt(p, off)
 	return n + rn, err
 }
+
+// externalFile is an io.ReaderAt of a file referenced by the file_path of
+// column chunks, which is opened on the first read.
+type externalFile struct {
+	path   string
+	open   func(path string) (io.ReaderAt, error)
+	once   sync.Once
+	reader io.ReaderAt
+	err    error
+}
+
+func makeExternalFiles(metadata *format.FileMetaData, open func(string) (io.ReaderAt, error)) map[string]*externalFile {
+	var files map[string]*externalFile
+	for i := range metadata.RowGroups {
+		for j := range metadata.RowGroups[i].Columns {
+			path := metadata.RowGroups[i].Columns[j].FilePath
+			if path == "" || files[path] != nil {
+				continue
+			}
+			if files == nil {
+				files = make(map[string]*externalFile)
+			}
+			files[path] = &externalFile{path: path, open: open}
+		}
+	}
+	return files
+}
+
+func (f *externalFile) ReadAt(b []byte, off int64) (int, error) {
+	f.once.Do(func() {
+		if f.open == nil {
+			f.err = fmt.Errorf("column chunks stored in %q cannot be read without the ExternalColumnChunks file option", f.path)
+			return
+		}
+		f.reader, f.err = f.open(f.path)
+		if f.err != nil {
+			f.err = fmt.Errorf("opening file of column chunks %q: %w", f.path, f.err)
+		}
+	})
+	if f.err != nil {
+		return 0, f.err
+	}
+	return f.reader.ReadAt(b, off)
+}
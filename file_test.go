@@ -2,19 +2,26 @@ package parquet_test
 
 import (
 	"bytes"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
+	"math/big"
 	"math/rand"
 	"os"
 	"path/filepath"
+	"reflect"
 	"runtime"
+	"slices"
 	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/compress"
+	"github.com/parquet-go/parquet-go/encoding/thrift"
+	"github.com/parquet-go/parquet-go/format"
 )
 
 var testdataFiles []string
@@ -262,6 +269,124 @@ func TestFileTypes(t *testing.T) {
 	}
 }
 
+func TestOpenFileLegacyDecimals(t *testing.T) {
+	// The decimal columns of these files are only annotated with the legacy
+	// converted type, the schema elements have no logical type.
+	tests := []struct {
+		file      string
+		kind      parquet.Kind
+		scale     int32
+		precision int32
+		rewrite   func(*format.SchemaElement)
+	}{
+		{file: "int32_decimal.parquet", kind: parquet.Int32, scale: 2, precision: 4},
+		{file: "int64_decimal.parquet", kind: parquet.Int64, scale: 2, precision: 10},
+		{file: "fixed_length_decimal.parquet", kind: parquet.FixedLenByteArray, scale: 2, precision: 25},
+		{file: "fixed_length_decimal_legacy.parquet", kind: parquet.FixedLenByteArray, scale: 2, precision: 13},
+		{file: "byte_array_decimal.parquet", kind: parquet.ByteArray, scale: 2, precision: 4},
+
+		{
+			file: "int32_decimal.parquet", kind: parquet.Int32, scale: 0, precision: 4,
+			// The scale of legacy decimals is optional and defaults to zero.
+			rewrite: func(e *format.SchemaElement) { e.Scale = nil },
+		},
+		{
+			file: "byte_array_decimal.parquet", kind: parquet.ByteArray, scale: 2, precision: 4,
+			// Decimals may also be stored in byte arrays when annotated with
+			// the logical type.
+			rewrite: func(e *format.SchemaElement) {
+				e.LogicalType = &format.LogicalType{Decimal: &format.DecimalType{Scale: 2, Precision: 4}}
+				e.ConvertedType = nil
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.file, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("testdata", test.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if test.rewrite != nil {
+				data = rewriteFileSchema(t, data, test.rewrite)
+			}
+
+			f, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			column := f.Root().Column("value")
+			columnType := column.Type()
+
+			if kind := columnType.Kind(); kind != test.kind {
+				t.Errorf("wrong physical type: want=%s got=%s", test.kind, kind)
+			}
+			decimal := columnType.LogicalType().Decimal
+			if decimal == nil {
+				t.Fatalf("column has no decimal logical type: %s", columnType)
+			}
+			if decimal.Scale != test.scale || decimal.Precision != test.precision {
+				t.Errorf("wrong decimal type: want=DECIMAL(%d,%d) got=%s", test.precision, test.scale, decimal)
+			}
+
+			rows := make([]parquet.Row, f.NumRows()+1)
+			n, err := parquet.NewReader(f).ReadRows(rows)
+			if err != nil && !errors.Is(err, io.EOF) {
+				t.Fatal(err)
+			}
+			if n != 24 {
+				t.Fatalf("wrong number of rows: want=24 got=%d", n)
+			}
+			// The files hold the decimal values 1.00 to 24.00.
+			for i, row := range rows[:n] {
+				value := row[0]
+				var unscaled int64
+				switch test.kind {
+				case parquet.Int32:
+					unscaled = int64(value.Int32())
+				case parquet.Int64:
+					unscaled = value.Int64()
+				default:
+					unscaled = new(big.Int).SetBytes(value.ByteArray()).Int64()
+				}
+				if want := int64(100 * (i + 1)); unscaled != want {
+					t.Errorf("row %d: wrong unscaled value: want=%d got=%d", i, want, unscaled)
+				}
+				if i > 0 && columnType.Compare(rows[i-1][0], value) >= 0 {
+					t.Errorf("row %d: decimal values are not ordered", i)
+				}
+			}
+		})
+	}
+}
+
+// rewriteFileSchema rewrites the footer of the parquet file in data, applying
+// rewrite to the schema elements of its leaf columns.
+func rewriteFileSchema(t *testing.T, data []byte, rewrite func(*format.SchemaElement)) []byte {
+	t.Helper()
+	footerSize := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	footerOffset := len(data) - 8 - footerSize
+
+	metadata := new(format.FileMetaData)
+	if err := thrift.Unmarshal(new(thrift.CompactProtocol), data[footerOffset:len(data)-8], metadata); err != nil {
+		t.Fatal(err)
+	}
+	for i := range metadata.Schema {
+		if metadata.Schema[i].Type != nil {
+			rewrite(&metadata.Schema[i])
+		}
+	}
+	footer, err := thrift.Marshal(new(thrift.CompactProtocol), metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rewritten := append([]byte{}, data[:footerOffset]...)
+	rewritten = append(rewritten, footer...)
+	rewritten = binary.LittleEndian.AppendUint32(rewritten, uint32(len(footer)))
+	return append(rewritten, "PAR1"...)
+}
+
 func TestOpenFileOptimisticRead(t *testing.T) {
 	f, err := os.Open("testdata/alltypes_tiny_pages_plain.parquet")
 	if err != nil {
@@ -287,6 +412,122 @@ func TestOpenFileOptimisticRead(t *testing.T) {
 	}
 }
 
+// lzoCodec simulates a codec which parquet-go does not support by advertising
+// the LZO codec in column chunk metadata.
+type lzoCodec struct{ compress.Codec }
+
+func (lzoCodec) CompressionCodec() format.CompressionCodec { return format.LZO }
+
+func TestOpenFileUnsupportedCompressionCodec(t *testing.T) {
+	lzo := lzoCodec{&parquet.Snappy}
+	schema := parquet.NewSchema("test", parquet.Group{
+		"id":    parquet.Int(64),
+		"name":  parquet.Compressed(parquet.String(), lzo),
+		"email": parquet.Compressed(parquet.String(), lzo),
+	})
+
+	output := new(bytes.Buffer)
+	writer := parquet.NewWriter(output, schema, parquet.MaxRowsPerRowGroup(2))
+	for i := range 5 {
+		row := parquet.Row{
+			parquet.ValueOf(fmt.Sprintf("user%d@example.com", i)).Level(0, 0, 0),
+			parquet.ValueOf(int64(i)).Level(0, 0, 1),
+			parquet.ValueOf(fmt.Sprintf("user%d", i)).Level(0, 0, 2),
+		}
+		if _, err := writer.WriteRows([]parquet.Row{row}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := parquet.OpenFile(bytes.NewReader(output.Bytes()), int64(output.Len()))
+	if !errors.Is(err, parquet.ErrUnsupportedCompressionCodec) {
+		t.Fatalf("expected an unsupported compression codec error, got %v", err)
+	}
+
+	// Each column is listed once even though it spans multiple row groups.
+	const want = `opening parquet file: unsupported compression codec: "email" (LZO), "name" (LZO)`
+	if got := err.Error(); got != want {
+		t.Errorf("wrong error message:\nwant: %s\ngot:  %s", want, got)
+	}
+}
+
+func TestOpenFileMetadataChecksums(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name"`
+	}
+	rows := []Row{{ID: 1, Name: "Zoidberg"}, {ID: 2, Name: "Xavier"}}
+
+	write := func(t *testing.T, options ...parquet.WriterOption) []byte {
+		t.Helper()
+		buf := new(bytes.Buffer)
+		if err := parquet.Write(buf, rows, options...); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+
+	open := func(data []byte, options ...parquet.FileOption) (*parquet.File, error) {
+		return parquet.OpenFile(bytes.NewReader(data), int64(len(data)), options...)
+	}
+
+	// corrupt replaces the first occurrence of old in the footer with new,
+	// which must have the same length so the footer remains decodable.
+	corrupt := func(t *testing.T, data []byte, old, new string) []byte {
+		t.Helper()
+		footerSize := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+		footerStart := len(data) - 8 - footerSize
+		i := bytes.Index(data[footerStart:], []byte(old))
+		if i < 0 {
+			t.Fatalf("%q not found in the footer", old)
+		}
+		data = bytes.Clone(data)
+		copy(data[footerStart+i:], new)
+		return data
+	}
+
+	data := write(t, parquet.MetadataChecksums(true), parquet.KeyValueMetadata("hello", "world"))
+
+	f, err := open(data, parquet.VerifyMetadataChecksums(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := f.Lookup("hello"); !ok {
+		t.Error("key/value metadata set on the writer are missing")
+	}
+
+	t.Run("file metadata", func(t *testing.T) {
+		corrupted := corrupt(t, data, "world", "w0rld")
+		if _, err := open(corrupted, parquet.VerifyMetadataChecksums(true)); !errors.Is(err, parquet.ErrCorruptedMetadata) {
+			t.Fatalf("expected a corrupted metadata error, got %v", err)
+		}
+		// Without verification, the corruption goes unnoticed.
+		if _, err := open(corrupted); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("column metadata", func(t *testing.T) {
+		// The name is part of the statistics of the column chunk.
+		_, err := open(corrupt(t, data, "Zoidberg", "Zoidbert"), parquet.VerifyMetadataChecksums(true))
+		if !errors.Is(err, parquet.ErrCorruptedMetadata) {
+			t.Fatalf("expected a corrupted metadata error, got %v", err)
+		}
+		if !strings.Contains(err.Error(), `column "name"`) {
+			t.Errorf("error does not mention the corrupted column: %v", err)
+		}
+	})
+
+	t.Run("without checksums", func(t *testing.T) {
+		if _, err := open(write(t), parquet.VerifyMetadataChecksums(true)); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
 func TestIssue229(t *testing.T) {
 	// https://github.com/grafana/tempo/blob/5cae77c9cf8da51e0db7c5556b19d305130ea9c4/tempodb/encoding/vparquet2/schema.go
 	type Attribute struct {
@@ -1130,3 +1371,538 @@ func TestRepeatedEmptyGroup(t *testing.T) {
 		// have no columns to store repetition levels
 	}
 }
+
+func TestOpenFileRepeatedRoot(t *testing.T) {
+	// The root of the schema of this file is declared as repeated, the levels
+	// of values were written as if it was a regular required message.
+	type Row struct {
+		ID   int64    `parquet:"id"`
+		Name *string  `parquet:"name,optional"`
+		Tags []string `parquet:"tags"`
+	}
+
+	f, err := os.Open("testdata/repeated_root.parquet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	s, err := f.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := parquet.OpenFile(f, s.Size())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root := p.Metadata().Schema[0]; root.RepetitionType == nil || *root.RepetitionType != format.Repeated {
+		t.Fatal("the fixture is expected to have a repeated root")
+	}
+	if p.Root().Repeated() {
+		t.Error("the root column must not be repeated")
+	}
+
+	rows, err := parquet.Read[Row](f, s.Size())
+	if err != nil {
+		t.Fatal(err)
+	}
+	alice, bob := "alice", "bob"
+	want := []Row{
+		{ID: 1, Name: &alice, Tags: []string{"x", "y"}},
+		{ID: 2, Tags: []string{}},
+		{ID: 3, Name: &bob, Tags: []string{"z"}},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("wrong number of rows: want=%d got=%d", len(want), len(rows))
+	}
+	for i := range want {
+		if rows[i].ID != want[i].ID || fmt.Sprint(rows[i].Tags) != fmt.Sprint(want[i].Tags) ||
+			(rows[i].Name == nil) != (want[i].Name == nil) || (rows[i].Name != nil && *rows[i].Name != *want[i].Name) {
+			t.Errorf("row %d mismatch: want=%+v got=%+v", i, want[i], rows[i])
+		}
+	}
+
+	anyRows, err := parquet.Read[any](f, s.Size())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(anyRows) != len(want) {
+		t.Errorf("wrong number of rows read as maps: want=%d got=%d", len(want), len(anyRows))
+	}
+}
+
+func TestFileColumnChunkDictionaryPageStats(t *testing.T) {
+	type Row struct {
+		Name  string `parquet:"name,dict,snappy"`
+		Value int64  `parquet:"value"`
+	}
+
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i] = Row{Name: strings.Repeat(fmt.Sprint(i%5), 100), Value: int64(i)}
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	columnChunks := f.RowGroups()[0].ColumnChunks()
+
+	stats, ok, err := columnChunks[0].(*parquet.FileColumnChunk).DictionaryPageStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatal("missing dictionary page stats of dictionary-encoded column")
+	}
+	// PLAIN-encoded byte arrays are prefixed with their length.
+	if want := (parquet.DictionaryPageStats{NumValues: 5, UncompressedSize: 5 * (4 + 100)}); stats.NumValues != want.NumValues || stats.UncompressedSize != want.UncompressedSize {
+		t.Errorf("wrong dictionary page stats: want=%+v got=%+v", want, stats)
+	}
+	if stats.CompressedSize <= 0 || stats.CompressedSize >= stats.UncompressedSize {
+		t.Errorf("wrong compressed size of the dictionary page: %+v", stats)
+	}
+
+	if _, ok, err := columnChunks[1].(*parquet.FileColumnChunk).DictionaryPageStats(); err != nil {
+		t.Fatal(err)
+	} else if ok {
+		t.Error("unexpected dictionary page stats of column without a dictionary")
+	}
+}
+
+func TestFileColumnChunkBloomFilterSkipped(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id,bloom"`
+		Name string `parquet:"name,bloom"`
+	}
+
+	rows := make([]Row, 100)
+	for i := range rows {
+		rows[i] = Row{ID: int64(i), Name: fmt.Sprintf("name-%d", i)}
+	}
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows); err != nil {
+		t.Fatal(err)
+	}
+
+	// The bloom filters are read when the program accesses them rather than
+	// when opening the file, their offset must be computed from the start of
+	// the file.
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()), parquet.SkipBloomFilters(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	chunks := f.RowGroups()[0].ColumnChunks()
+	for _, row := range rows {
+		for i, value := range []parquet.Value{parquet.ValueOf(row.ID), parquet.ValueOf(row.Name)} {
+			filter := chunks[i].BloomFilter()
+			if filter == nil {
+				t.Fatalf("missing bloom filter of column %d", i)
+			}
+			if ok, err := filter.Check(value); err != nil {
+				t.Fatal(err)
+			} else if !ok {
+				t.Errorf("value %v not found in the bloom filter of column %d", value, i)
+			}
+		}
+	}
+}
+
+func TestFileColumnChunkStatistics(t *testing.T) {
+	type Row struct {
+		Name  string   `parquet:"name"`
+		Score *int64   `parquet:"score,optional"`
+		Hash  [4]byte  `parquet:"hash"`
+		Blob  []byte   `parquet:"blob,nostats"`
+		Tags  []string `parquet:"tags"`
+	}
+
+	rows := make([]Row, 100)
+	for i := range rows {
+		rows[i] = Row{
+			Name: fmt.Sprintf("name-%02d", i),
+			Hash: [4]byte{byte(i), 1, 2, 3},
+			Blob: []byte{byte(i)},
+		}
+		if i%4 != 0 {
+			score := int64(i - 50)
+			rows[i].Score = &score
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows); err != nil {
+		t.Fatal(err)
+	}
+
+	columnChunks := func(t *testing.T, data []byte) []parquet.ColumnChunk {
+		t.Helper()
+		f, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return f.RowGroups()[0].ColumnChunks()
+	}
+	statistics := func(chunk parquet.ColumnChunk) (min, max parquet.Value, nullCount, distinctCount int64, ok bool) {
+		return chunk.(*parquet.FileColumnChunk).Statistics()
+	}
+
+	chunks := columnChunks(t, buf.Bytes())
+
+	if min, max, nullCount, _, ok := statistics(chunks[0]); !ok {
+		t.Error("missing statistics of the name column")
+	} else if min.String() != "name-00" || max.String() != "name-99" || nullCount != 0 {
+		t.Errorf("wrong statistics of the name column: min=%v max=%v nulls=%d", min, max, nullCount)
+	}
+
+	if min, max, nullCount, _, ok := statistics(chunks[1]); !ok {
+		t.Error("missing statistics of the score column")
+	} else if min.Int64() != -49 || max.Int64() != 49 || nullCount != 25 {
+		t.Errorf("wrong statistics of the score column: min=%v max=%v nulls=%d", min, max, nullCount)
+	}
+
+	if min, max, _, _, ok := statistics(chunks[2]); !ok {
+		t.Error("missing statistics of the hash column")
+	} else if !bytes.Equal(min.ByteArray(), []byte{0, 1, 2, 3}) || !bytes.Equal(max.ByteArray(), []byte{99, 1, 2, 3}) {
+		t.Errorf("wrong statistics of the hash column: min=%v max=%v", min, max)
+	}
+
+	if _, _, _, _, ok := statistics(chunks[3]); ok {
+		t.Error("unexpected statistics of the column declared with the nostats tag")
+	}
+
+	// Columns which only hold null values have a null count but no bounds.
+	if min, max, nullCount, _, ok := statistics(chunks[4]); !ok {
+		t.Error("missing statistics of the tags column")
+	} else if !min.IsNull() || !max.IsNull() || nullCount != 100 {
+		t.Errorf("wrong statistics of the tags column: min=%v max=%v nulls=%d", min, max, nullCount)
+	}
+
+	// Rewrite the footer with statistics that other writers may produce:
+	// distinct counts, min/max values truncated to a length that does not
+	// match the column type, and the deprecated min and max fields.
+	data := buf.Bytes()
+	footerSize := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	footerOffset := len(data) - 8 - footerSize
+	var metadata format.FileMetaData
+	if err := thrift.Unmarshal(new(thrift.CompactProtocol), data[footerOffset:len(data)-8], &metadata); err != nil {
+		t.Fatal(err)
+	}
+	columns := metadata.RowGroups[0].Columns
+	columns[0].MetaData.Statistics.DistinctCount = 100
+	columns[0].MetaData.Statistics.MaxValue = []byte("name-a")
+	columns[2].MetaData.Statistics.MinValue = columns[2].MetaData.Statistics.MinValue[:2]
+	scoreStats := &columns[1].MetaData.Statistics
+	scoreStats.Min, scoreStats.Max = scoreStats.MinValue, scoreStats.MaxValue
+	scoreStats.MinValue, scoreStats.MaxValue = nil, nil
+	blobStats := &columns[3].MetaData.Statistics
+	blobStats.Min, blobStats.Max = []byte{0}, []byte{99}
+	footer, err := thrift.Marshal(new(thrift.CompactProtocol), &metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data = append(slices.Clip(data[:footerOffset]), footer...)
+	data = binary.LittleEndian.AppendUint32(data, uint32(len(footer)))
+	data = append(data, "PAR1"...)
+
+	chunks = columnChunks(t, data)
+
+	if _, max, _, distinctCount, ok := statistics(chunks[0]); !ok {
+		t.Error("missing statistics of the name column")
+	} else if max.String() != "name-a" || distinctCount != 100 {
+		t.Errorf("wrong statistics of the name column: max=%v distinct=%d", max, distinctCount)
+	}
+
+	if min, max, _, _, ok := statistics(chunks[2]); !ok {
+		t.Error("missing statistics of the hash column")
+	} else if !min.IsNull() || !bytes.Equal(max.ByteArray(), []byte{99, 1, 2, 3}) {
+		t.Errorf("wrong statistics of the hash column with a truncated min value: min=%v max=%v", min, max)
+	}
+
+	if min, max, _, _, ok := statistics(chunks[1]); !ok {
+		t.Error("missing statistics of the score column")
+	} else if min.Int64() != -49 || max.Int64() != 49 {
+		t.Errorf("wrong statistics of the score column with deprecated min and max: min=%v max=%v", min, max)
+	}
+
+	// The deprecated min and max of byte arrays were compared as signed bytes,
+	// which does not match the order of the column.
+	if min, max, _, _, ok := statistics(chunks[3]); !ok {
+		t.Error("missing statistics of the blob column")
+	} else if !min.IsNull() || !max.IsNull() {
+		t.Errorf("wrong statistics of the blob column with deprecated min and max: min=%v max=%v", min, max)
+	}
+}
+
+func TestOpenFileFooterProtocols(t *testing.T) {
+	type Row struct {
+		ID    int64   `parquet:"id"`
+		Name  string  `parquet:"name"`
+		Valid bool    `parquet:"valid"`
+		Score float64 `parquet:"score,optional"`
+	}
+	rows := []Row{
+		{ID: 1, Name: "one", Valid: true, Score: 0.5},
+		{ID: 2, Name: "two"},
+		{ID: 3, Name: "three", Valid: true, Score: -1},
+	}
+
+	compact := new(bytes.Buffer)
+	if err := parquet.Write(compact, rows, parquet.KeyValueMetadata("key", "value")); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		scenario string
+		protocol thrift.Protocol
+		prefix   []byte
+	}{
+		// The version field (id 1, type I32) starts the file metadata.
+		{scenario: "compact", protocol: new(thrift.CompactProtocol), prefix: []byte{0x15, 0x04}},
+		{scenario: "binary", protocol: new(thrift.BinaryProtocol), prefix: []byte{0x08, 0x00, 0x01, 0x00, 0x00, 0x00, 0x02}},
+	} {
+		t.Run(test.scenario, func(t *testing.T) {
+			data := withFooterProtocol(t, compact.Bytes(), test.protocol)
+			footerSize := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+			footer := data[len(data)-8-footerSize : len(data)-8]
+			if !bytes.HasPrefix(footer, test.prefix) {
+				t.Fatalf("footer does not start with the expected bytes: want=% x got=% x", test.prefix, footer[:len(test.prefix)])
+			}
+
+			f, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if value, ok := f.Lookup("key"); !ok || value != "value" {
+				t.Errorf("wrong key/value metadata: %q, %t", value, ok)
+			}
+			got, err := parquet.Read[Row](bytes.NewReader(data), int64(len(data)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(got, rows) {
+				t.Errorf("wrong rows:\nwant: %+v\ngot:  %+v", rows, got)
+			}
+		})
+	}
+}
+
+// withFooterProtocol returns a copy of the parquet file data with the footer
+// encoded with the given thrift protocol.
+func withFooterProtocol(t *testing.T, data []byte, protocol thrift.Protocol) []byte {
+	t.Helper()
+	footerSize := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	footerOffset := len(data) - 8 - footerSize
+
+	var metadata format.FileMetaData
+	if err := thrift.Unmarshal(new(thrift.CompactProtocol), data[footerOffset:len(data)-8], &metadata); err != nil {
+		t.Fatal(err)
+	}
+	footer, err := thrift.Marshal(protocol, &metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := append([]byte{}, data[:footerOffset]...)
+	b = append(b, footer...)
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(footer)))
+	return append(b, "PAR1"...)
+}
+
+func TestOpenFilePreallocateBuffers(t *testing.T) {
+	data := makeSeekBenchFile(t, 2000)
+
+	read := func(options ...parquet.FileOption) []benchRow {
+		f, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)), options...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := parquet.NewGenericReader[benchRow](f)
+		defer r.Close()
+		rows := make([]benchRow, f.NumRows())
+		if n, err := r.Read(rows); n != len(rows) {
+			t.Fatalf("reading rows: n=%d err=%v", n, err)
+		}
+		return rows
+	}
+
+	want := read()
+	for _, limit := range []int64{1, 64 * 1024, 64 * 1024 * 1024} {
+		t.Run(fmt.Sprintf("limit=%d", limit), func(t *testing.T) {
+			if got := read(parquet.PreallocateBuffers(limit)); !reflect.DeepEqual(got, want) {
+				t.Error("rows read with preallocated buffers differ from rows read without")
+			}
+		})
+	}
+}
+
+func BenchmarkFilePreallocateBuffers(b *testing.B) {
+	data := makeSeekBenchFile(b, 30_000)
+
+	for _, limit := range []int64{0, 64 * 1024 * 1024} {
+		b.Run(fmt.Sprintf("limit=%d", limit), func(b *testing.B) {
+			f, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)),
+				parquet.SkipBloomFilters(true),
+				parquet.PreallocateBuffers(limit),
+			)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for range b.N {
+				for _, rowGroup := range f.RowGroups() {
+					for _, chunk := range rowGroup.ColumnChunks() {
+						pages := chunk.Pages()
+						for {
+							p, err := pages.ReadPage()
+							if errors.Is(err, io.EOF) {
+								break
+							}
+							if err != nil {
+								b.Fatal(err)
+							}
+							parquet.Release(p)
+						}
+						pages.Close()
+					}
+					// Simulate the garbage collection cycles of a program
+					// streaming the file, the buffers of the shared pool are
+					// released after two cycles.
+					runtime.GC()
+					runtime.GC()
+				}
+			}
+		})
+	}
+}
+
+func TestOpenFileExternalColumnChunks(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name,dict"`
+	}
+
+	// Write two data files, and a metadata file referencing their row groups.
+	var rows []Row
+	var metadata format.FileMetaData
+	dataFiles := map[string][]byte{}
+	for i, path := range []string{"part-0.parquet", "part-1.parquet"} {
+		part := make([]Row, 100)
+		for j := range part {
+			id := int64(i*len(part) + j)
+			part[j] = Row{ID: id, Name: fmt.Sprintf("name-%d", id%7)}
+		}
+		rows = append(rows, part...)
+
+		buf := new(bytes.Buffer)
+		w := parquet.NewGenericWriter[Row](buf,
+			parquet.MaxRowsPerRowGroup(60),
+			parquet.BloomFilters(parquet.SplitBlockFilter(10, "name")),
+		)
+		if _, err := w.Write(part); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		dataFiles[path] = buf.Bytes()
+
+		f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if i == 0 {
+			metadata = *f.Metadata()
+			metadata.RowGroups = nil
+			metadata.NumRows = 0
+		}
+		for _, rowGroup := range f.Metadata().RowGroups {
+			rowGroup.Ordinal = int16(len(metadata.RowGroups))
+			rowGroup.Columns = slices.Clone(rowGroup.Columns)
+			for j := range rowGroup.Columns {
+				rowGroup.Columns[j].FilePath = path
+			}
+			metadata.RowGroups = append(metadata.RowGroups, rowGroup)
+			metadata.NumRows += rowGroup.NumRows
+		}
+	}
+
+	footer, err := thrift.Marshal(new(thrift.CompactProtocol), &metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	metadataFile := []byte("PAR1")
+	metadataFile = append(metadataFile, footer...)
+	metadataFile = binary.LittleEndian.AppendUint32(metadataFile, uint32(len(footer)))
+	metadataFile = append(metadataFile, "PAR1"...)
+
+	var opened []string
+	open := func(path string) (io.ReaderAt, error) {
+		opened = append(opened, path)
+		data, ok := dataFiles[path]
+		if !ok {
+			return nil, os.ErrNotExist
+		}
+		return bytes.NewReader(data), nil
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(metadataFile), int64(len(metadataFile)), parquet.ExternalColumnChunks(open))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(opened) != 0 {
+		t.Errorf("data files must not be opened with the metadata file: %q", opened)
+	}
+	if n := len(f.RowGroups()); n != 4 {
+		t.Fatalf("wrong number of row groups: want=4 got=%d", n)
+	}
+
+	got := make([]Row, len(rows))
+	n, err := parquet.NewGenericReader[Row](f).Read(got)
+	if err != nil && err != io.EOF {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got[:n], rows) {
+		t.Error("rows read from the metadata file do not match the rows of the data files")
+	}
+	slices.Sort(opened)
+	if want := []string{"part-0.parquet", "part-1.parquet"}; !slices.Equal(opened, want) {
+		t.Errorf("data files must be opened once: want=%q got=%q", want, opened)
+	}
+
+	// The page indexes and bloom filters are read from the data files.
+	chunk := f.RowGroups()[3].ColumnChunks()[1]
+	columnIndex, err := chunk.ColumnIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if min := columnIndex.MinValue(0).String(); min != "name-0" {
+		t.Errorf("wrong min value in the column index: %q", min)
+	}
+	if _, err := chunk.OffsetIndex(); err != nil {
+		t.Fatal(err)
+	}
+	filter := chunk.BloomFilter()
+	if filter == nil {
+		t.Fatal("missing bloom filter")
+	}
+	if ok, err := filter.Check(parquet.ValueOf("name-3")); err != nil || !ok {
+		t.Errorf("value missing from the bloom filter: ok=%t err=%v", ok, err)
+	}
+
+	// Without the option, reading the column chunks fails.
+	f, err = parquet.OpenFile(bytes.NewReader(metadataFile), int64(len(metadataFile)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parquet.NewGenericReader[Row](f).Read(got); err == nil || err == io.EOF {
+		t.Errorf("expected an error reading external column chunks without an opener, got %v", err)
+	}
+}
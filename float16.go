@@ -0,0 +1,157 @@
+package parquet
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// FLOAT16 values are IEEE 754 binary16 floating point numbers stored in two
+// bytes fixed length byte arrays, in little-endian byte order. Go has no half
+// precision type, the values are converted to and from float32, which can
+// represent all the binary16 values exactly.
+
+const (
+	float16SignMask     = 0x8000
+	float16ExponentMask = 0x7C00
+	float16MantissaMask = 0x03FF
+	float16QuietNaN     = 0x0200
+)
+
+// float16ToFloat32 converts the binary16 value h to the float32 with the same
+// value. The sign and payload of NaN values are preserved.
+func float16ToFloat32(h uint16) float32 {
+	sign := uint32(h&float16SignMask) << 16
+	exponent := uint32(h&float16ExponentMask) >> 10
+	mantissa := uint32(h & float16MantissaMask)
+
+	switch exponent {
+	case 0:
+		// Zeros and subnormals, the value is mantissa * 2^-24 which float32
+		// represents exactly as a normal number.
+		f := float32(mantissa) / (1 << 24)
+		return math.Float32frombits(sign | math.Float32bits(f))
+	case 0x1F:
+		// Infinities and NaNs.
+		return math.Float32frombits(sign | 0x7F800000 | mantissa<<13)
+	default:
+		return math.Float32frombits(sign | (exponent+127-15)<<23 | mantissa<<13)
+	}
+}
+
+// float32ToFloat16 converts f to the closest binary16 value, rounding ties to
+// even. Values which are too large for binary16 are converted to infinities,
+// values which are too small are converted to subnormals or zeros. NaN values
+// remain NaN, their payload is truncated.
+func float32ToFloat16(f float32) uint16 {
+	bits := math.Float32bits(f)
+	sign := uint16(bits>>16) & float16SignMask
+	exponent := int(bits>>23) & 0xFF
+	mantissa := bits & 0x7FFFFF
+
+	if exponent == 0xFF {
+		if mantissa == 0 {
+			return sign | float16ExponentMask
+		}
+		payload := uint16(mantissa >> 13)
+		if payload == 0 {
+			// The payload was entirely in the low bits, which would turn the
+			// NaN into an infinity.
+			payload = float16QuietNaN
+		}
+		return sign | float16ExponentMask | payload
+	}
+
+	switch e := exponent - 127 + 15; {
+	case e >= 0x1F:
+		return sign | float16ExponentMask
+
+	case e > 0:
+		// The rounding may carry into the exponent, which gives the correct
+		// result, including when the value overflows to infinity.
+		h := uint16(e)<<10 | uint16(mantissa>>13)
+		return sign | roundToEven(h, mantissa&0x1FFF, 1<<12)
+
+	default:
+		// The value is a binary16 subnormal, which is expressed in units of
+		// 2^-24. Float32 subnormals are always too small to be represented.
+		shift := 126 - exponent
+		if exponent == 0 || shift > 24 {
+			return sign
+		}
+		mantissa |= 0x800000
+		h := uint16(mantissa >> shift)
+		return sign | roundToEven(h, mantissa&(1<<shift-1), 1<<(shift-1))
+	}
+}
+
+// roundToEven returns h incremented by one if the remainder of the truncation
+// which produced it is more than half of a unit, or exactly half and h is odd.
+func roundToEven(h uint16, remainder, half uint32) uint16 {
+	if remainder > half || (remainder == half && h&1 != 0) {
+		h++
+	}
+	return h
+}
+
+func float16IsNaN(h uint16) bool {
+	return h&float16ExponentMask == float16ExponentMask && h&float16MantissaMask != 0
+}
+
+func compareFloat16(a, b []byte) int {
+	return compareFloat32(
+		float16ToFloat32(binary.LittleEndian.Uint16(a)),
+		float16ToFloat32(binary.LittleEndian.Uint16(b)),
+	)
+}
+
+// boundsFloat16 returns the smallest and largest values of data, which holds
+// a sequence of FLOAT16 values. NaN values are ignored unless all the values
+// are NaN.
+func boundsFloat16(data []byte) (min, max []byte) {
+	for i := 0; i+2 <= len(data); i += 2 {
+		min, max = updateBoundsFloat16(min, max, data[i:i+2:i+2])
+	}
+	return min, max
+}
+
+// updateBoundsFloat16 returns the bounds extended to include value, min and
+// max are nil if no values were seen yet.
+func updateBoundsFloat16(min, max, value []byte) ([]byte, []byte) {
+	switch {
+	case min == nil:
+		return value, value
+	case float16IsNaN(binary.LittleEndian.Uint16(min)):
+		return value, value
+	case float16IsNaN(binary.LittleEndian.Uint16(value)):
+	case compareFloat16(value, min) < 0:
+		min = value
+	case compareFloat16(value, max) > 0:
+		max = value
+	}
+	return min, max
+}
+
+// orderOfFloat16 returns the ordering of the FLOAT16 values, with the same
+// convention as orderOfBytes.
+func orderOfFloat16(data [][]byte) int {
+	if len(data) < 2 {
+		return 0
+	}
+	ascending, descending := true, true
+	for i := 1; i < len(data); i++ {
+		switch compareFloat16(data[i-1], data[i]) {
+		case -1:
+			descending = false
+		case +1:
+			ascending = false
+		}
+	}
+	switch {
+	case ascending:
+		return +1
+	case descending:
+		return -1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,216 @@
+package parquet_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestFloat16(t *testing.T) {
+	type Row struct {
+		Value float32 `parquet:"value,float16"`
+	}
+
+	const want = `message Row {
+	required fixed_len_byte_array(2) value (FLOAT16);
+}`
+	if got := parquet.SchemaOf(new(Row)).String(); got != want {
+		t.Fatalf("wrong schema:\nwant:\n%s\ngot:\n%s", want, got)
+	}
+
+	tests := []struct {
+		scenario string
+		value    float32
+		bits     uint16
+		read     float32
+	}{
+		{scenario: "zero", value: 0, bits: 0x0000, read: 0},
+		{scenario: "negative zero", value: float32(math.Copysign(0, -1)), bits: 0x8000, read: float32(math.Copysign(0, -1))},
+		{scenario: "one", value: 1, bits: 0x3C00, read: 1},
+		{scenario: "negative", value: -2, bits: 0xC000, read: -2},
+		{scenario: "inexact", value: 0.1, bits: 0x2E66, read: 0.0999755859375},
+		{scenario: "tie to even (down)", value: 1 + 0x1p-11, bits: 0x3C00, read: 1},
+		{scenario: "tie to even (up)", value: 1 + 0x3p-11, bits: 0x3C02, read: 1 + 0x1p-9},
+		{scenario: "largest normal", value: 65504, bits: 0x7BFF, read: 65504},
+		{scenario: "rounded to largest normal", value: 65519, bits: 0x7BFF, read: 65504},
+		{scenario: "overflow", value: 65520, bits: 0x7C00, read: float32(math.Inf(+1))},
+		{scenario: "negative overflow", value: -1e10, bits: 0xFC00, read: float32(math.Inf(-1))},
+		{scenario: "smallest normal", value: 0x1p-14, bits: 0x0400, read: 0x1p-14},
+		{scenario: "largest subnormal", value: 0x3FFp-24, bits: 0x03FF, read: 0x3FFp-24},
+		{scenario: "smallest subnormal", value: 0x1p-24, bits: 0x0001, read: 0x1p-24},
+		{scenario: "rounded to smallest subnormal", value: 0x1.8p-25, bits: 0x0001, read: 0x1p-24},
+		{scenario: "tie to zero", value: 0x1p-25, bits: 0x0000, read: 0},
+		{scenario: "negative subnormal", value: -0x1p-20, bits: 0x8010, read: -0x1p-20},
+		{scenario: "underflow", value: 1e-10, bits: 0x0000, read: 0},
+		{scenario: "positive infinity", value: float32(math.Inf(+1)), bits: 0x7C00, read: float32(math.Inf(+1))},
+		{scenario: "negative infinity", value: float32(math.Inf(-1)), bits: 0xFC00, read: float32(math.Inf(-1))},
+		{scenario: "NaN", value: float32(math.NaN()), bits: 0x7E00, read: float32(math.NaN())},
+	}
+
+	rows := make([]Row, len(tests))
+	for i, test := range tests {
+		rows[i].Value = test.value
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chunk := f.RowGroups()[0].ColumnChunks()[0]
+	pages := chunk.Pages()
+	defer pages.Close()
+	var values []parquet.Value
+	for {
+		p, err := pages.ReadPage()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		buffer := make([]parquet.Value, p.NumValues())
+		n, _ := p.Values().ReadValues(buffer)
+		values = append(values, buffer[:n]...)
+	}
+	if len(values) != len(tests) {
+		t.Fatalf("wrong number of values: want=%d got=%d", len(tests), len(values))
+	}
+
+	got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			if bits := binary.LittleEndian.Uint16(values[i].ByteArray()); bits != test.bits {
+				t.Errorf("wrong binary16 value of %v: want=%#04x got=%#04x", test.value, test.bits, bits)
+			}
+			if !sameFloat32(got[i].Value, test.read) {
+				t.Errorf("wrong value read: want=%v got=%v", test.read, got[i].Value)
+			}
+		})
+	}
+
+	t.Run("statistics", func(t *testing.T) {
+		columnIndex, err := chunk.ColumnIndex()
+		if err != nil {
+			t.Fatal(err)
+		}
+		minBits := binary.LittleEndian.Uint16(columnIndex.MinValue(0).ByteArray())
+		maxBits := binary.LittleEndian.Uint16(columnIndex.MaxValue(0).ByteArray())
+		if minBits != 0xFC00 || maxBits != 0x7C00 {
+			t.Errorf("wrong bounds: want=[0xfc00,0x7c00] got=[%#04x,%#04x]", minBits, maxBits)
+		}
+	})
+
+	t.Run("convert", func(t *testing.T) {
+		type Float32Row struct {
+			Value float32 `parquet:"value"`
+		}
+		got, err := parquet.Read[Float32Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, test := range tests {
+			if !sameFloat32(got[i].Value, test.read) {
+				t.Errorf("%s: wrong value read: want=%v got=%v", test.scenario, test.read, got[i].Value)
+			}
+		}
+	})
+
+	t.Run("Writer", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		w := parquet.NewWriter(buf, parquet.SchemaOf(new(Row)))
+		for i := range rows {
+			if err := w.Write(&rows[i]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, test := range tests {
+			if !sameFloat32(got[i].Value, test.read) {
+				t.Errorf("%s: wrong value read: want=%v got=%v", test.scenario, test.read, got[i].Value)
+			}
+		}
+	})
+}
+
+func TestFloat16Node(t *testing.T) {
+	schema := parquet.NewSchema("test", parquet.Group{
+		"half": parquet.Optional(parquet.Float16()),
+	})
+	rows := []parquet.Row{
+		{parquet.FixedLenByteArrayValue([]byte{0x00, 0x3C}).Level(0, 1, 0)},
+		{parquet.NullValue().Level(0, 0, 0)},
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewWriter(buf, schema)
+	if _, err := w.WriteRows(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, ok := f.Schema().Lookup("half")
+	if !ok {
+		t.Fatal("column not found")
+	}
+	if lt := leaf.Node.Type().LogicalType(); lt == nil || lt.Float16 == nil {
+		t.Fatalf("wrong logical type: %v", leaf.Node.Type())
+	}
+
+	type Row struct {
+		Half *float32 `parquet:"half,optional,float16"`
+	}
+	got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	one := float32(1)
+	want := []Row{{Half: &one}, {}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong rows: want=%+v got=%+v", want, got)
+	}
+
+	buf.Reset()
+	if err := parquet.Write(buf, want); err != nil {
+		t.Fatal(err)
+	}
+	got, err = parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong rows after round trip: want=%+v got=%+v", want, got)
+	}
+}
+
+func sameFloat32(a, b float32) bool {
+	if math.IsNaN(float64(a)) {
+		return math.IsNaN(float64(b))
+	}
+	return math.Float32bits(a) == math.Float32bits(b)
+}
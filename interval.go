@@ -0,0 +1,149 @@
+package parquet
+
+import (
+	"encoding/binary"
+	"fmt"
+	"reflect"
+
+	"github.com/parquet-go/parquet-go/deprecated"
+	"github.com/parquet-go/parquet-go/encoding"
+	"github.com/parquet-go/parquet-go/format"
+)
+
+// intervalLength is the size of INTERVAL values, which are made of three
+// little-endian unsigned 32 bits integers.
+const intervalLength = 12
+
+// IntervalValue is the Go representation of values of the INTERVAL type, which
+// is a duration made of a number of months, days, and milliseconds. The three
+// components are independent, a duration of 30 days is not the same as one
+// month.
+//
+// Fields of this type are mapped to INTERVAL columns in schemas generated from
+// Go types.
+type IntervalValue struct {
+	Months uint32
+	Days   uint32
+	Millis uint32
+}
+
+// Interval constructs a leaf node of INTERVAL type, which holds durations in
+// twelve bytes fixed length byte arrays.
+//
+// INTERVAL is only defined as a converted type, there is no equivalent logical
+// type. The values are read and written from Go values of type IntervalValue.
+//
+// https://github.com/apache/parquet-format/blob/master/LogicalTypes.md#interval
+func Interval() Node { return Leaf(&intervalType{}) }
+
+func appendInterval(b []byte, v IntervalValue) []byte {
+	b = binary.LittleEndian.AppendUint32(b, v.Months)
+	b = binary.LittleEndian.AppendUint32(b, v.Days)
+	return binary.LittleEndian.AppendUint32(b, v.Millis)
+}
+
+func parseInterval(b []byte) (IntervalValue, error) {
+	if len(b) != intervalLength {
+		return IntervalValue{}, fmt.Errorf("cannot read value of length %d as INTERVAL: %w", len(b), ErrInvalidConversion)
+	}
+	return IntervalValue{
+		Months: binary.LittleEndian.Uint32(b[0:]),
+		Days:   binary.LittleEndian.Uint32(b[4:]),
+		Millis: binary.LittleEndian.Uint32(b[8:]),
+	}, nil
+}
+
+type intervalType struct{}
+
+func (t *intervalType) String() string { return "INTERVAL" }
+
+func (t *intervalType) Kind() Kind { return FixedLenByteArray }
+
+func (t *intervalType) Length() int { return intervalLength }
+
+func (t *intervalType) EstimateSize(n int) int { return intervalLength * n }
+
+func (t *intervalType) EstimateNumValues(n int) int { return n / intervalLength }
+
+func (t *intervalType) Compare(a, b Value) int {
+	return fixedLenByteArrayType{length: intervalLength}.Compare(a, b)
+}
+
+func (t *intervalType) ColumnOrder() *format.ColumnOrder { return &typeDefinedColumnOrder }
+
+func (t *intervalType) PhysicalType() *format.Type { return &physicalTypes[FixedLenByteArray] }
+
+func (t *intervalType) LogicalType() *format.LogicalType { return nil }
+
+func (t *intervalType) ConvertedType() *deprecated.ConvertedType {
+	return &convertedTypes[deprecated.Interval]
+}
+
+func (t *intervalType) NewColumnIndexer(sizeLimit int) ColumnIndexer {
+	return newFixedLenByteArrayColumnIndexer(intervalLength, sizeLimit)
+}
+
+func (t *intervalType) NewDictionary(columnIndex, numValues int, data encoding.Values) Dictionary {
+	return newFixedLenByteArrayDictionary(t, makeColumnIndex(columnIndex), makeNumValues(numValues), data)
+}
+
+func (t *intervalType) NewColumnBuffer(columnIndex, numValues int) ColumnBuffer {
+	return newFixedLenByteArrayColumnBuffer(t, makeColumnIndex(columnIndex), makeNumValues(numValues))
+}
+
+func (t *intervalType) NewPage(columnIndex, numValues int, data encoding.Values) Page {
+	return newFixedLenByteArrayPage(t, makeColumnIndex(columnIndex), makeNumValues(numValues), data)
+}
+
+func (t *intervalType) NewValues(values []byte, offsets []uint32) encoding.Values {
+	return fixedLenByteArrayType{length: intervalLength}.NewValues(values, offsets)
+}
+
+func (t *intervalType) Encode(dst []byte, src encoding.Values, enc encoding.Encoding) ([]byte, error) {
+	return fixedLenByteArrayType{length: intervalLength}.Encode(dst, src, enc)
+}
+
+func (t *intervalType) Decode(dst encoding.Values, src []byte, enc encoding.Encoding) (encoding.Values, error) {
+	return fixedLenByteArrayType{length: intervalLength}.Decode(dst, src, enc)
+}
+
+func (t *intervalType) EstimateDecodeSize(numValues int, src []byte, enc encoding.Encoding) int {
+	return fixedLenByteArrayType{length: intervalLength}.EstimateDecodeSize(numValues, src, enc)
+}
+
+func (t *intervalType) AssignValue(dst reflect.Value, src Value) error {
+	if dst.Type() == intervalValueType {
+		return intervalConverter.assignValue(dst, src)
+	}
+	return fixedLenByteArrayType{length: intervalLength}.AssignValue(dst, src)
+}
+
+func (t *intervalType) ConvertValue(val Value, typ Type) (Value, error) {
+	return fixedLenByteArrayType{length: intervalLength}.ConvertValue(val, typ)
+}
+
+var (
+	intervalValueType = reflect.TypeFor[IntervalValue]()
+
+	// intervalConverter maps Go values of type IntervalValue to INTERVAL
+	// columns, it is used in place of a registered converter.
+	intervalConverter = converter{
+		node: Interval(),
+		toParquet: func(v reflect.Value) (Value, error) {
+			b := appendInterval(make([]byte, 0, intervalLength), v.Interface().(IntervalValue))
+			return FixedLenByteArrayValue(b), nil
+		},
+		fromParquet: func(v Value, dst reflect.Value) error {
+			if v.IsNull() {
+				dst.Set(reflect.Zero(dst.Type()))
+				return nil
+			}
+			interval, err := parseInterval(v.byteArray())
+			if err != nil {
+				return err
+			}
+			dst.Set(reflect.ValueOf(interval))
+			return nil
+		},
+	}
+)
@@ -0,0 +1,135 @@
+package parquet_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestInterval(t *testing.T) {
+	type Row struct {
+		Value    parquet.IntervalValue   `parquet:"value"`
+		Optional *parquet.IntervalValue  `parquet:"optional,optional"`
+		Repeated []parquet.IntervalValue `parquet:"repeated"`
+	}
+
+	const want = `message Row {
+	required fixed_len_byte_array(12) value (INTERVAL);
+	optional fixed_len_byte_array(12) optional (INTERVAL);
+	repeated fixed_len_byte_array(12) repeated (INTERVAL);
+}`
+	if got := parquet.SchemaOf(new(Row)).String(); got != want {
+		t.Fatalf("wrong schema:\nwant:\n%s\ngot:\n%s", want, got)
+	}
+
+	rows := []Row{
+		{Repeated: []parquet.IntervalValue{}},
+		{
+			Value:    parquet.IntervalValue{Months: 1, Days: 2, Millis: 3},
+			Optional: &parquet.IntervalValue{Months: 14},
+			Repeated: []parquet.IntervalValue{{Days: 30}, {Millis: 86_400_000}},
+		},
+		{
+			Value:    parquet.IntervalValue{Months: 0xFFFFFFFF, Days: 0xFFFFFFFF, Millis: 0xFFFFFFFF},
+			Repeated: []parquet.IntervalValue{{Months: 1}},
+		},
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := f.Schema().String(); got != want {
+		t.Errorf("wrong schema read from the file:\nwant:\n%s\ngot:\n%s", want, got)
+	}
+
+	values := make([]parquet.Value, 3)
+	pages := f.RowGroups()[0].ColumnChunks()[0].Pages()
+	defer pages.Close()
+	p, err := pages.ReadPage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, _ := p.Values().ReadValues(values); n != len(values) {
+		t.Fatalf("wrong number of values: want=%d got=%d", len(values), n)
+	}
+	wantBytes := []byte{1, 0, 0, 0, 2, 0, 0, 0, 3, 0, 0, 0}
+	if got := values[1].ByteArray(); !bytes.Equal(got, wantBytes) {
+		t.Errorf("wrong encoding of INTERVAL value: want=% x got=% x", wantBytes, got)
+	}
+
+	got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, rows) {
+		t.Errorf("wrong rows:\nwant: %+v\ngot:  %+v", rows, got)
+	}
+
+	t.Run("Writer", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		w := parquet.NewWriter(buf, parquet.SchemaOf(new(Row)))
+		for i := range rows {
+			if err := w.Write(&rows[i]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, rows) {
+			t.Errorf("wrong rows:\nwant: %+v\ngot:  %+v", rows, got)
+		}
+	})
+}
+
+func TestIntervalNode(t *testing.T) {
+	schema := parquet.NewSchema("test", parquet.Group{
+		"interval": parquet.Interval(),
+	})
+	rows := []parquet.Row{
+		{parquet.FixedLenByteArrayValue([]byte{2, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0}).Level(0, 0, 0)},
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewWriter(buf, schema)
+	if _, err := w.WriteRows(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	type Row struct {
+		Interval parquet.IntervalValue `parquet:"interval"`
+	}
+	got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Row{{Interval: parquet.IntervalValue{Months: 2, Millis: 256}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong rows: want=%+v got=%+v", want, got)
+	}
+}
+
+func TestIntervalInvalidLength(t *testing.T) {
+	var interval parquet.IntervalValue
+	value := parquet.FixedLenByteArrayValue(make([]byte, 16))
+	err := parquet.Interval().Type().AssignValue(reflect.ValueOf(&interval).Elem(), value)
+	if !errors.Is(err, parquet.ErrInvalidConversion) {
+		t.Errorf("expected an invalid conversion error but got %v", err)
+	}
+}
@@ -0,0 +1,128 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// JSONLines returns an io.Reader which yields the rows read from reader as
+// JSON lines (also known as NDJSON): each row is written as a JSON object on
+// its own line, with one key per top-level field of the schema, in the order
+// of the schema fields.
+//
+// Rows are read from reader and marshaled as the returned io.Reader is read,
+// the amount of data buffered is bounded by the size of a batch of rows, so
+// programs consuming the output slowly do not cause the whole file to be held
+// in memory.
+//
+// Values are formatted according to their logical type, using the same
+// conventions as the JSON documents of nested columns written by ToCSV:
+// timestamps are RFC 3339 date-times, decimals are JSON numbers with their
+// scale applied, strings and UUIDs are JSON strings, and other byte arrays
+// are base64-encoded. Lists and repeated fields become JSON arrays, groups
+// and maps become JSON objects, and null values are JSON nulls.
+//
+// The reader is not closed when the end of the rows is reached, the program
+// remains responsible for closing it.
+func JSONLines[T any](reader *GenericReader[T]) io.Reader {
+	schema := reader.Schema()
+	fields := schema.Fields()
+	columns := make([]int, len(fields)+1)
+	for i, field := range fields {
+		columns[i+1] = columns[i] + int(numLeafColumnsOf(field))
+	}
+	return &jsonLinesReader{
+		rows:    reader,
+		fields:  fields,
+		columns: columns,
+		values:  make([][]Value, columns[len(fields)]),
+	}
+}
+
+type jsonLinesReader struct {
+	rows    RowReader
+	fields  []Field
+	columns []int
+	values  [][]Value
+	buffer  []Row
+	offset  int
+	output  bytes.Buffer
+	err     error
+}
+
+func (r *jsonLinesReader) Read(b []byte) (int, error) {
+	for r.output.Len() == 0 {
+		if r.offset == len(r.buffer) {
+			if r.err != nil {
+				return 0, r.err
+			}
+			if err := r.readRows(); err != nil {
+				return 0, err
+			}
+			continue
+		}
+		if err := r.writeRow(r.buffer[r.offset]); err != nil {
+			r.err = err
+			r.buffer, r.offset = r.buffer[:0], 0
+			r.output.Reset()
+			return 0, err
+		}
+		r.offset++
+	}
+	return r.output.Read(b)
+}
+
+func (r *jsonLinesReader) readRows() error {
+	if cap(r.buffer) == 0 {
+		r.buffer = make([]Row, defaultRowBufferSize)
+	}
+	n, err := r.rows.ReadRows(r.buffer[:cap(r.buffer)])
+	r.buffer, r.offset = r.buffer[:n], 0
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.EOF
+		}
+		r.err = err
+		if n == 0 {
+			return err
+		}
+	} else if n == 0 {
+		r.err = io.ErrNoProgress
+		return r.err
+	}
+	return nil
+}
+
+func (r *jsonLinesReader) writeRow(row Row) error {
+	row.Range(func(columnIndex int, columnValues []Value) bool {
+		r.values[columnIndex] = columnValues
+		return true
+	})
+
+	r.output.WriteByte('{')
+	for i, field := range r.fields {
+		if i > 0 {
+			r.output.WriteByte(',')
+		}
+		name, err := json.Marshal(field.Name())
+		if err != nil {
+			return err
+		}
+		v, err := jsonValueOf(field, r.values[r.columns[i]:r.columns[i+1]], 0, 0)
+		if err != nil {
+			return fmt.Errorf("formatting JSON field %q: %w", field.Name(), err)
+		}
+		value, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("formatting JSON field %q: %w", field.Name(), err)
+		}
+		r.output.Write(name)
+		r.output.WriteByte(':')
+		r.output.Write(value)
+	}
+	r.output.WriteString("}\n")
+	return nil
+}
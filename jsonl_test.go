@@ -0,0 +1,91 @@
+package parquet_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestJSONLines(t *testing.T) {
+	type Address struct {
+		City string `parquet:"city" json:"city"`
+		Zip  *int32 `parquet:"zip,optional" json:"zip"`
+	}
+	type Row struct {
+		ID      int64     `parquet:"id" json:"id"`
+		Name    *string   `parquet:"name,optional" json:"name"`
+		Price   int64     `parquet:"price,decimal(2:18)" json:"-"`
+		Created time.Time `parquet:"created,timestamp(millisecond)" json:"created"`
+		Tags    []string  `parquet:"tags,list" json:"tags"`
+		Address *Address  `parquet:"address,optional" json:"address"`
+	}
+
+	name := "Léa"
+	zip := int32(75001)
+	rows := make([]Row, 100)
+	for i := range rows {
+		rows[i] = Row{
+			ID:      int64(i),
+			Price:   int64(i) * 101,
+			Created: time.Date(2024, 3, 1, 12, 30, 45, 123e6, time.UTC).Add(time.Duration(i) * time.Hour),
+			Tags:    []string{},
+		}
+		if i%2 == 0 {
+			rows[i].Name = &name
+			rows[i].Tags = []string{"a", fmt.Sprint(i)}
+			rows[i].Address = &Address{City: "Paris", Zip: &zip}
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows); err != nil {
+		t.Fatal(err)
+	}
+
+	reader := parquet.NewGenericReader[Row](bytes.NewReader(buf.Bytes()))
+	defer reader.Close()
+
+	// Reading one byte at a time exercises the buffering of partial lines.
+	lines := bufio.NewScanner(iotest.OneByteReader(parquet.JSONLines(reader)))
+	got := make([]Row, 0, len(rows))
+	for lines.Scan() {
+		var row Row
+		if err := json.Unmarshal(lines.Bytes(), &row); err != nil {
+			t.Fatalf("line %d: %v: %s", len(got), err, lines.Bytes())
+		}
+		// Decimals are written as JSON numbers with their scale applied.
+		var price struct{ Price json.Number }
+		if err := json.Unmarshal(lines.Bytes(), &price); err != nil {
+			t.Fatal(err)
+		}
+		if want := fmt.Sprintf("%d.%02d", rows[len(got)].Price/100, rows[len(got)].Price%100); string(price.Price) != want {
+			t.Errorf("line %d: wrong price: want=%s got=%s", len(got), want, price.Price)
+		}
+		row.Price = rows[len(got)].Price
+		got = append(got, row)
+	}
+	if err := lines.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, rows) {
+		t.Errorf("wrong rows:\nwant: %+v\ngot:  %+v", rows, got)
+	}
+
+	const firstLine = `{"id":0,"name":"Léa","price":0.00,"created":"2024-03-01T12:30:45.123Z","tags":["a","0"],"address":{"city":"Paris","zip":75001}}`
+	reader.Reset()
+	first, err := bufio.NewReader(parquet.JSONLines(reader)).ReadString('\n')
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != firstLine+"\n" {
+		t.Errorf("wrong first line:\nwant: %s\ngot:  %s", firstLine, first)
+	}
+}
@@ -22,6 +22,12 @@ import (
 // The sorting columns of each row group are also consulted to determine whether
 // the output can be represented. If sorting columns are configured on the merge
 // they must be a prefix of sorting columns of all row groups being merged.
+//
+// When the merge has sorting columns, the rows of the merged row groups are
+// streamed through a k-way merge instead of being buffered in memory. The rows
+// of each row group are verified to be sorted as they are read, reading rows of
+// the merged row group returns an error wrapping ErrUnsortedRowGroup if one of
+// the row groups was not sorted.
 func MergeRowGroups(rowGroups []RowGroup, options ...RowGroupOption) (RowGroup, error) {
 	config, err := NewRowGroupConfig(options...)
 	if err != nil {
@@ -77,7 +83,17 @@ func MergeRowGroups(rowGroups []RowGroup, options ...RowGroupOption) (RowGroup,
 		return newMultiRowGroup(schema, nil, mergedRowGroups), nil
 	}
 
+	for _, sortingColumn := range mergedSortingColumns {
+		if _, ok := schema.Lookup(sortingColumn.Path()...); !ok {
+			return nil, fmt.Errorf("cannot merge row groups: sorting column %q is not a column of the schema", columnPath(sortingColumn.Path()))
+		}
+	}
+
 	mergedCompare := compareRowsFuncOf(schema, mergedSortingColumns)
+	for i, rowGroup := range mergedRowGroups {
+		mergedRowGroups[i] = &sortedRowGroup{RowGroup: rowGroup, compare: mergedCompare}
+	}
+
 	// Optimization: detect non-overlapping row groups and create segments
 	rowGroupSegments := make([]RowGroup, 0)
 	for segment := range overlappingRowGroups(mergedRowGroups, schema, mergedSortingColumns, mergedCompare) {
@@ -324,6 +340,64 @@ func (r *mergedRowGroupRows) Schema() *Schema {
 	return r.schema
 }
 
+// sortedRowGroup is a row group merged by MergeRowGroups, the rows are
+// verified to be sorted as they are read.
+type sortedRowGroup struct {
+	RowGroup
+	compare func(Row, Row) int
+}
+
+func (g *sortedRowGroup) Rows() Rows {
+	return &sortedRows{Rows: g.RowGroup.Rows(), compare: g.compare}
+}
+
+type sortedRows struct {
+	Rows
+	compare  func(Row, Row) int
+	rowIndex int64
+	// Copy of the last row read, which the first row of the next read is
+	// compared to, nil if no rows were read since the last seek.
+	last Row
+	// The error is retained since the rows following an unsorted row were
+	// already consumed from the underlying rows.
+	err error
+}
+
+func (r *sortedRows) ReadRows(rows []Row) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	n, err := r.Rows.ReadRows(rows)
+
+	for i, row := range rows[:n] {
+		prev := r.last
+		if i > 0 {
+			prev = rows[i-1]
+		}
+		if prev != nil && r.compare(prev, row) > 0 {
+			r.rowIndex += int64(i)
+			r.err = fmt.Errorf("row %d: %w", r.rowIndex, ErrUnsortedRowGroup)
+			return i, r.err
+		}
+	}
+
+	if n > 0 {
+		// The values of the rows may reference buffers which are reused by the
+		// next read, the last row is cloned to remain valid.
+		r.last = rows[n-1].Clone()
+		r.rowIndex += int64(n)
+	}
+	return n, err
+}
+
+func (r *sortedRows) SeekToRow(rowIndex int64) error {
+	if err := r.Rows.SeekToRow(rowIndex); err != nil {
+		return err
+	}
+	r.rowIndex, r.last, r.err = rowIndex, nil, nil
+	return nil
+}
+
 // MergeRowReader constructs a RowReader which creates an ordered sequence of
 // all the readers using the given compare function as the ordering predicate.
 func MergeRowReaders(rows []RowReader, compare func(Row, Row) int) RowReader {
@@ -1035,6 +1035,94 @@ func TestMergeRowGroupsSeekToRow(t *testing.T) {
 	}()
 }
 
+func TestMergeRowGroupsVerifiesSortOrder(t *testing.T) {
+	type model struct {
+		A int64
+		B string
+	}
+
+	schema := parquet.SchemaOf(model{})
+	options := []parquet.RowGroupOption{
+		parquet.SortingRowGroupConfig(
+			parquet.SortingColumns(
+				parquet.Ascending("A"),
+			),
+		),
+	}
+
+	readAll := func(rowGroup parquet.RowGroup) ([]model, error) {
+		rows := rowGroup.Rows()
+		defer rows.Close()
+		var models []model
+		buf := make([]parquet.Row, 3)
+		for {
+			n, err := rows.ReadRows(buf)
+			for _, row := range buf[:n] {
+				var m model
+				if err := schema.Reconstruct(&m, row); err != nil {
+					return models, err
+				}
+				models = append(models, m)
+			}
+			if err != nil {
+				if err == io.EOF {
+					return models, nil
+				}
+				return models, err
+			}
+		}
+	}
+
+	t.Run("sorted", func(t *testing.T) {
+		merged, err := parquet.MergeRowGroups([]parquet.RowGroup{
+			sortedRowGroup(options, model{1, "a"}, model{4, "d"}, model{5, "e"}, model{8, "h"}),
+			sortedRowGroup(options, model{2, "b"}, model{3, "c"}, model{6, "f"}, model{7, "g"}),
+		}, options...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := readAll(merged)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, m := range got {
+			if m.A != int64(i+1) {
+				t.Fatalf("rows are not sorted: %v", got)
+			}
+		}
+		if len(got) != 8 {
+			t.Fatalf("wrong number of rows: %d", len(got))
+		}
+	})
+
+	t.Run("unsorted", func(t *testing.T) {
+		unsorted := parquet.NewBuffer(schema)
+		for _, m := range []model{{2, "b"}, {3, "c"}, {6, "f"}, {5, "e"}, {7, "g"}} {
+			unsorted.Write(m)
+		}
+		merged, err := parquet.MergeRowGroups([]parquet.RowGroup{
+			sortedRowGroup(options, model{1, "a"}, model{4, "d"}, model{8, "h"}),
+			unsorted,
+		}, options...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := readAll(merged); !errors.Is(err, parquet.ErrUnsortedRowGroup) {
+			t.Fatalf("expected an error reading unsorted rows, got %v", err)
+		}
+	})
+
+	t.Run("unknown sorting column", func(t *testing.T) {
+		_, err := parquet.MergeRowGroups([]parquet.RowGroup{
+			sortedRowGroup(options, model{1, "a"}),
+			sortedRowGroup(options, model{2, "b"}),
+		}, parquet.SortingRowGroupConfig(parquet.SortingColumns(parquet.Ascending("C"))))
+		if err == nil {
+			t.Fatal("expected an error merging on a column which is not in the schema")
+		}
+	})
+}
+
 func BenchmarkMergeRowGroups(b *testing.B) {
 	for _, test := range readerTests {
 		b.Run(test.scenario, func(b *testing.B) {
@@ -0,0 +1,140 @@
+package parquet
+
+import (
+	"fmt"
+	"hash/crc32"
+	"slices"
+	"strconv"
+
+	"github.com/parquet-go/parquet-go/encoding/thrift"
+	"github.com/parquet-go/parquet-go/format"
+)
+
+const (
+	// Keys of the key/value metadata holding the checksums written by the
+	// MetadataChecksums option. The file checksum is stored in the metadata of
+	// the file, the column checksums in the metadata of each column chunk.
+	fileMetadataChecksumKey   = "parquet-go.metadata.crc32"
+	columnMetadataChecksumKey = "parquet-go.column.crc32"
+)
+
+// addMetadataChecksums computes the checksums of the metadata of each column
+// chunk and of the whole file metadata, and records them in their key/value
+// metadata.
+//
+// The checksums are computed over the thrift encoding of the metadata, without
+// the key/value pair holding the checksum itself. Column checksums are added
+// first, the file checksum covers them.
+func addMetadataChecksums(protocol thrift.Protocol, metadata *format.FileMetaData) error {
+	for i := range metadata.RowGroups {
+		for j := range metadata.RowGroups[i].Columns {
+			columnMetaData := &metadata.RowGroups[i].Columns[j].MetaData
+			checksum, err := columnMetadataChecksum(protocol, columnMetaData)
+			if err != nil {
+				return err
+			}
+			columnMetaData.KeyValueMetadata = appendChecksum(columnMetaData.KeyValueMetadata, columnMetadataChecksumKey, checksum)
+		}
+	}
+	checksum, err := fileMetadataChecksum(protocol, metadata)
+	if err != nil {
+		return err
+	}
+	metadata.KeyValueMetadata = appendChecksum(metadata.KeyValueMetadata, fileMetadataChecksumKey, checksum)
+	return nil
+}
+
+// verifyMetadataChecksums validates the checksums recorded by
+// addMetadataChecksums. Metadata which do not have checksums are not verified.
+// The returned error wraps ErrCorruptedMetadata when a checksum mismatches.
+func verifyMetadataChecksums(protocol thrift.Protocol, metadata *format.FileMetaData) error {
+	// Column checksums are verified first so the error reports the column
+	// when the corruption is in the metadata of a column chunk, which the file
+	// checksum also covers.
+	for i := range metadata.RowGroups {
+		for j := range metadata.RowGroups[i].Columns {
+			columnMetaData := &metadata.RowGroups[i].Columns[j].MetaData
+			if err := verifyChecksum(columnMetaData.KeyValueMetadata, columnMetadataChecksumKey, func() (uint32, error) {
+				return columnMetadataChecksum(protocol, columnMetaData)
+			}); err != nil {
+				return fmt.Errorf("metadata of column %q in row group %d: %w", columnPath(columnMetaData.PathInSchema), i, err)
+			}
+		}
+	}
+	if err := verifyChecksum(metadata.KeyValueMetadata, fileMetadataChecksumKey, func() (uint32, error) {
+		return fileMetadataChecksum(protocol, metadata)
+	}); err != nil {
+		return fmt.Errorf("file metadata: %w", err)
+	}
+	return nil
+}
+
+func verifyChecksum(keyValueMetadata []format.KeyValue, key string, compute func() (uint32, error)) error {
+	i := slices.IndexFunc(keyValueMetadata, func(kv format.KeyValue) bool { return kv.Key == key })
+	if i < 0 {
+		return nil
+	}
+	want, err := strconv.ParseUint(keyValueMetadata[i].Value, 16, 32)
+	if err != nil {
+		return fmt.Errorf("malformed checksum %q: %w", keyValueMetadata[i].Value, ErrCorruptedMetadata)
+	}
+	got, err := compute()
+	if err != nil {
+		return err
+	}
+	if uint32(want) != got {
+		return fmt.Errorf("checksum mismatch: want=%08x got=%08x: %w", want, got, ErrCorruptedMetadata)
+	}
+	return nil
+}
+
+func fileMetadataChecksum(protocol thrift.Protocol, metadata *format.FileMetaData) (uint32, error) {
+	m := *metadata
+	m.KeyValueMetadata = withoutKeyValue(m.KeyValueMetadata, fileMetadataChecksumKey)
+	return thriftChecksum(protocol, &m)
+}
+
+func columnMetadataChecksum(protocol thrift.Protocol, metadata *format.ColumnMetaData) (uint32, error) {
+	m := *metadata
+	m.KeyValueMetadata = withoutKeyValue(m.KeyValueMetadata, columnMetadataChecksumKey)
+	return thriftChecksum(protocol, &m)
+}
+
+func thriftChecksum(protocol thrift.Protocol, v any) (uint32, error) {
+	b, err := thrift.Marshal(protocol, v)
+	if err != nil {
+		return 0, err
+	}
+	return crc32.ChecksumIEEE(b), nil
+}
+
+func appendChecksum(keyValueMetadata []format.KeyValue, key string, checksum uint32) []format.KeyValue {
+	// The slice is clipped so appending never writes to memory shared with the
+	// metadata that the writer retains.
+	return append(slices.Clip(withoutKeyValue(keyValueMetadata, key)), format.KeyValue{
+		Key:   key,
+		Value: fmt.Sprintf("%08x", checksum),
+	})
+}
+
+// withoutKeyValue returns the key/value pairs of keyValueMetadata except the
+// one with the given key. The result is nil if no pairs remain, so the thrift
+// encoding is the same whether the list was absent or only held the key.
+func withoutKeyValue(keyValueMetadata []format.KeyValue, key string) []format.KeyValue {
+	if !slices.ContainsFunc(keyValueMetadata, func(kv format.KeyValue) bool { return kv.Key == key }) {
+		if len(keyValueMetadata) == 0 {
+			return nil
+		}
+		return keyValueMetadata
+	}
+	result := make([]format.KeyValue, 0, len(keyValueMetadata)-1)
+	for _, kv := range keyValueMetadata {
+		if kv.Key != key {
+			result = append(result, kv)
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
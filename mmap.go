@@ -0,0 +1,113 @@
+package parquet
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// MappedFile is an io.ReaderAt backed by a memory mapping of a local file.
+//
+// The mapping is created lazily on the first call to ReadAt, and the pages of
+// the file are then loaded and evicted by the operating system page cache,
+// which avoids issuing a system call for each read and keeps memory usage
+// bounded when reading very large files. On platforms which do not support
+// memory mapping, reads are served by the underlying *os.File.
+//
+// MappedFile implements the Size method, which allows it to be passed directly
+// to NewReader or NewGenericReader:
+//
+//	f, err := parquet.MapFile("file.parquet")
+//	if err != nil {
+//		...
+//	}
+//	defer f.Close()
+//	rows := parquet.NewGenericReader[RowType](f)
+//
+// The mapping is released when the MappedFile is closed; the program must not
+// read from readers or files opened on a MappedFile after closing it.
+type MappedFile struct {
+	file *os.File
+	size int64
+	once sync.Once
+	data []byte
+	err  error
+	// Reads hold the read lock for the duration of the copy, so the mapping
+	// cannot be released by Close while it is being read.
+	mutex  sync.RWMutex
+	closed bool
+}
+
+// MapFile opens the file at path to be read through a memory mapping.
+func MapFile(path string) (*MappedFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	s, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if size := s.Size(); int64(int(size)) != size {
+		f.Close()
+		return nil, fmt.Errorf("cannot map %s: file of %d bytes exceeds the address space", path, size)
+	}
+	return &MappedFile{file: f, size: s.Size()}, nil
+}
+
+// Size returns the size of the mapped file.
+func (m *MappedFile) Size() int64 { return m.size }
+
+// ReadAt satisfies the io.ReaderAt interface.
+func (m *MappedFile) ReadAt(b []byte, off int64) (int, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	if m.closed {
+		return 0, os.ErrClosed
+	}
+
+	m.once.Do(func() { m.data, m.err = mmap(m.file, m.size) })
+	if m.err != nil {
+		return 0, m.err
+	}
+	if m.data == nil {
+		return m.file.ReadAt(b, off)
+	}
+
+	if off < 0 {
+		return 0, fmt.Errorf("read at negative offset %d of mapped file", off)
+	}
+	if off >= m.size {
+		return 0, io.EOF
+	}
+	n := copy(b, m.data[off:])
+	if n < len(b) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// Close releases the memory mapping and closes the underlying file.
+func (m *MappedFile) Close() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.closed {
+		return os.ErrClosed
+	}
+	m.closed = true
+
+	// Prevent the mapping from being created after the file was closed.
+	m.once.Do(func() {})
+
+	var err error
+	if m.data != nil {
+		err = munmap(m.data)
+		m.data = nil
+	}
+	if closeErr := m.file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
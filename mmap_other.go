@@ -0,0 +1,11 @@
+//go:build !unix
+
+package parquet
+
+import "os"
+
+// Memory mapping is not supported on this platform, a nil mapping causes reads
+// to be served by the file.
+func mmap(f *os.File, size int64) ([]byte, error) { return nil, nil }
+
+func munmap(data []byte) error { return nil }
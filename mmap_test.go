@@ -0,0 +1,125 @@
+//go:build unix
+
+package parquet_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestMapFile(t *testing.T) {
+	type Row struct {
+		ID    int64   `parquet:"id"`
+		Name  string  `parquet:"name"`
+		Score float64 `parquet:"score"`
+	}
+
+	rows := make([]Row, 500_000)
+	for i := range rows {
+		rows[i] = Row{ID: int64(i), Name: "row-" + string(rune('a'+i%26)), Score: float64(i) / 3}
+	}
+
+	path := filepath.Join(t.TempDir(), "large.parquet")
+	output, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := parquet.Write(output, rows, parquet.MaxRowsPerRowGroup(100_000)); err != nil {
+		t.Fatal(err)
+	}
+	if err := output.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.MapFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Size() != s.Size() {
+		t.Errorf("wrong size of mapped file: want=%d got=%d", s.Size(), f.Size())
+	}
+
+	reader := parquet.NewGenericReader[Row](f)
+	got := make([]Row, len(rows))
+	if n, err := reader.Read(got); n != len(rows) {
+		t.Fatalf("wrong number of rows read: want=%d got=%d (%v)", len(rows), n, err)
+	}
+	if err := reader.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, rows) {
+		t.Error("rows read from the mapped file do not match the rows written")
+	}
+
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.ReadAt(make([]byte, 4), 0); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("reading from a closed mapped file: want=%v got=%v", os.ErrClosed, err)
+	}
+	if err := f.Close(); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("closing a closed mapped file: want=%v got=%v", os.ErrClosed, err)
+	}
+}
+
+func TestMapFileConcurrentClose(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data")
+	data := make([]byte, 1<<20)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for range 10 {
+		f, err := parquet.MapFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Reads either complete with the content of the file or fail because
+		// the file was closed, they never observe the mapping being released.
+		var started, done sync.WaitGroup
+		for range 4 {
+			started.Add(1)
+			done.Add(1)
+			go func() {
+				defer done.Done()
+				b := make([]byte, 64*1024)
+				for i := 0; ; i++ {
+					off := int64(i*len(b)) % int64(len(data))
+					n, err := f.ReadAt(b, off)
+					if i == 0 {
+						started.Done()
+					}
+					if err != nil {
+						if !errors.Is(err, os.ErrClosed) {
+							t.Error(err)
+						}
+						return
+					}
+					if !reflect.DeepEqual(b[:n], data[off:off+int64(n)]) {
+						t.Errorf("wrong data read at offset %d", off)
+						return
+					}
+				}
+			}()
+		}
+		started.Wait()
+		if err := f.Close(); err != nil {
+			t.Fatal(err)
+		}
+		done.Wait()
+	}
+}
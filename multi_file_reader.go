@@ -0,0 +1,149 @@
+package parquet
+
+import (
+	"fmt"
+	"io"
+)
+
+// MultiFileSource is one of the files read by a MultiFileReader.
+type MultiFileSource struct {
+	// Name identifies the file in the source column of the rows read from it,
+	// for example its path.
+	Name string
+	// File is the parquet file that the rows are read from.
+	File *File
+}
+
+// MultiFileReader reads rows of type T from a sequence of parquet files, such
+// as the files produced by a MultiFileWriter, as if they were a single file.
+//
+// The reader may be configured to record which file each row was read from in
+// a source column, which is a synthetic column of T holding the name of the
+// file, and is not stored in the files.
+//
+// MultiFileReader values are not safe to use concurrently from multiple
+// goroutines.
+type MultiFileReader[T any] struct {
+	sources []MultiFileSource
+	options []ReaderOption
+	schema  *Schema
+	reader  *Reader
+	index   int
+	rows    []Row
+	// Index and maximum definition level of the source column, the index is
+	// -1 when the reader has no source column.
+	sourceColumn          int
+	sourceDefinitionLevel byte
+	sourceName            []byte
+}
+
+// NewMultiFileReader constructs a reader of rows of type T from the given
+// sequence of files. The rows of each file are converted to the schema of T,
+// the options are applied to the readers of each file.
+//
+// When sourceColumn is not empty, it must be the name of a top-level field of
+// T holding strings, which is set to the name of the source of each row. The
+// files must not have a column of the same name.
+//
+// The function panics if T is not a struct type, or if the source column is
+// not valid.
+func NewMultiFileReader[T any](sources []MultiFileSource, sourceColumn string, options ...ReaderOption) *MultiFileReader[T] {
+	r := &MultiFileReader[T]{
+		sources:      sources,
+		options:      options,
+		schema:       schemaOf(dereference(typeOf[T]())),
+		sourceColumn: -1,
+	}
+
+	if sourceColumn != "" {
+		leaf, ok := r.schema.Lookup(sourceColumn)
+		if !ok {
+			panic(fmt.Errorf("source column %q is not a field of %v", sourceColumn, typeOf[T]()))
+		}
+		if leaf.Node.Repeated() || leaf.Node.Type().Kind() != ByteArray {
+			panic(fmt.Errorf("source column %q must be a string field which is not repeated", sourceColumn))
+		}
+		for _, source := range sources {
+			if _, exists := source.File.Schema().Lookup(sourceColumn); exists {
+				panic(fmt.Errorf("source column %q collides with a column of file %q", sourceColumn, source.Name))
+			}
+		}
+		r.sourceColumn = leaf.ColumnIndex
+		r.sourceDefinitionLevel = byte(leaf.MaxDefinitionLevel)
+	}
+	return r
+}
+
+// Read reads the next rows into the given slice, moving on to the next file
+// when all the rows of a file were read.
+//
+// The method returns the number of rows read and io.EOF when all the rows of
+// all the files were read.
+func (r *MultiFileReader[T]) Read(rows []T) (int, error) {
+	if cap(r.rows) < len(rows) {
+		r.rows = make([]Row, len(rows))
+	}
+
+	n := 0
+	for n < len(rows) {
+		if r.reader == nil {
+			if r.index == len(r.sources) {
+				return n, io.EOF
+			}
+			options := make([]ReaderOption, 0, len(r.options)+1)
+			options = append(options, r.options...)
+			options = append(options, r.schema)
+			r.reader = NewReader(r.sources[r.index].File, options...)
+			r.sourceName = []byte(r.sources[r.index].Name)
+		}
+
+		buffer := r.rows[:len(rows)-n]
+		m, err := r.reader.ReadRows(buffer)
+		for i, row := range buffer[:m] {
+			if r.sourceColumn >= 0 {
+				r.setSource(row)
+			}
+			if err := r.schema.Reconstruct(&rows[n+i], row); err != nil {
+				return n + i, err
+			}
+		}
+		n += m
+
+		switch err {
+		case nil:
+		case io.EOF:
+			if err := r.closeReader(); err != nil {
+				return n, err
+			}
+			r.index++
+		default:
+			return n, fmt.Errorf("reading rows of %q: %w", r.sources[r.index].Name, err)
+		}
+	}
+	return n, nil
+}
+
+// Close closes the reader, releasing the resources held by the reader of the
+// current file.
+func (r *MultiFileReader[T]) Close() error {
+	r.index = len(r.sources)
+	return r.closeReader()
+}
+
+func (r *MultiFileReader[T]) closeReader() error {
+	if r.reader == nil {
+		return nil
+	}
+	err := r.reader.Close()
+	r.reader = nil
+	return err
+}
+
+func (r *MultiFileReader[T]) setSource(row Row) {
+	for i := range row {
+		if row[i].Column() == r.sourceColumn {
+			row[i] = ByteArrayValue(r.sourceName).Level(0, int(r.sourceDefinitionLevel), r.sourceColumn)
+			return
+		}
+	}
+}
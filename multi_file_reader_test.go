@@ -0,0 +1,80 @@
+package parquet_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestMultiFileReaderSourceColumn(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name"`
+	}
+	type SourceRow struct {
+		ID     int64   `parquet:"id"`
+		Name   string  `parquet:"name"`
+		Source *string `parquet:"source,optional"`
+	}
+
+	var sources []parquet.MultiFileSource
+	var want []SourceRow
+	for i, numRows := range []int{3, 0, 200} {
+		name := fmt.Sprintf("part-%d.parquet", i)
+		rows := make([]Row, numRows)
+		for j := range rows {
+			rows[j] = Row{ID: int64(len(want)), Name: fmt.Sprintf("row-%d", j)}
+			want = append(want, SourceRow{ID: rows[j].ID, Name: rows[j].Name, Source: &name})
+		}
+		buf := new(bytes.Buffer)
+		if err := parquet.Write(buf, rows, parquet.MaxRowsPerRowGroup(64)); err != nil {
+			t.Fatal(err)
+		}
+		f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		sources = append(sources, parquet.MultiFileSource{Name: name, File: f})
+	}
+
+	reader := parquet.NewMultiFileReader[SourceRow](sources, "source")
+	defer reader.Close()
+
+	var got []SourceRow
+	for {
+		// Pointers of the rows are reused when reconstructing rows into
+		// the same buffer, each batch is read into a new one.
+		buffer := make([]SourceRow, 50)
+		n, err := reader.Read(buffer)
+		got = append(got, buffer[:n]...)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("wrong number of rows: want=%d got=%d", len(want), len(got))
+	}
+	for i := range want {
+		if got[i].ID != want[i].ID || got[i].Name != want[i].Name || got[i].Source == nil || *got[i].Source != *want[i].Source {
+			t.Fatalf("wrong row %d: want=%+v (%s) got=%+v", i, want[i], *want[i].Source, got[i])
+		}
+	}
+
+	for _, column := range []string{"missing", "id"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected a panic with source column %q", column)
+				}
+			}()
+			parquet.NewMultiFileReader[SourceRow](sources, column)
+		}()
+	}
+}
@@ -0,0 +1,200 @@
+package parquet
+
+import (
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/parquet-go/parquet-go/format"
+)
+
+// multiFileWriteBatchSize is the number of rows written to a file between
+// checks of its estimated size, when a size limit is configured.
+const multiFileWriteBatchSize = 64
+
+// ManifestEntry describes one of the files produced by a MultiFileWriter.
+type ManifestEntry struct {
+	// Path of the file, as returned by the function creating the files.
+	Path string
+	// Number of rows written to the file.
+	NumRows int64
+	// Size of the file in bytes.
+	Size int64
+	// Minimum and maximum values of the key column configured with the
+	// ManifestKeyColumn option. Both values are null if no key column was
+	// configured, or if all the values of the key column were null.
+	MinKey Value
+	MaxKey Value
+}
+
+// MultiFileWriter writes rows of type T to a sequence of parquet files, rolling
+// over to a new file when the limits configured by the MaxRowsPerFile and
+// MaxBytesPerFile options are reached.
+//
+// Each file produced by the writer is a complete parquet file. When the writer
+// is closed, it returns a manifest describing all the files that were written.
+//
+// MultiFileWriter values are not safe to use concurrently from multiple
+// goroutines.
+type MultiFileWriter[T any] struct {
+	create   func(fileIndex int) (string, io.WriteCloser, error)
+	config   *MultiFileConfig
+	writer   *GenericWriter[T]
+	file     io.WriteCloser
+	path     string
+	numRows  int64
+	manifest []ManifestEntry
+}
+
+// NewMultiFileWriter constructs a writer producing parquet files with rows of
+// type T.
+//
+// The create function is called to open each new file, it receives the index
+// of the file in the sequence (starting at zero) and returns the path of the
+// file, which is recorded in the manifest, and the output that the file is
+// written to. The writer closes the outputs after writing each file.
+//
+// The function panics if the options carry an invalid configuration, or if the
+// key column configured by ManifestKeyColumn does not exist in the schema.
+func NewMultiFileWriter[T any](create func(fileIndex int) (path string, output io.WriteCloser, err error), options ...MultiFileOption) *MultiFileWriter[T] {
+	config, err := NewMultiFileConfig(options...)
+	if err != nil {
+		panic(err)
+	}
+
+	if len(config.KeyColumn) > 0 {
+		writerConfig, err := NewWriterConfig(config.WriterOptions...)
+		if err != nil {
+			panic(err)
+		}
+		schema := writerConfig.Schema
+		if schema == nil {
+			schema = schemaOf(dereference(typeOf[T]()))
+		}
+		if _, ok := schema.Lookup(config.KeyColumn...); !ok {
+			panic(fmt.Errorf("manifest key column %q does not exist in the schema", columnPath(config.KeyColumn)))
+		}
+	}
+
+	return &MultiFileWriter[T]{create: create, config: config}
+}
+
+// Write writes rows to the current file, opening a new file when needed.
+func (w *MultiFileWriter[T]) Write(rows []T) (int, error) {
+	written := 0
+
+	for len(rows) > 0 {
+		if w.writer == nil {
+			if err := w.open(); err != nil {
+				return written, err
+			}
+		}
+
+		n := len(rows)
+		if w.config.MaxRowsPerFile > 0 {
+			n = int(min(int64(n), w.config.MaxRowsPerFile-w.numRows))
+		}
+		if w.config.MaxBytesPerFile > 0 {
+			n = min(n, multiFileWriteBatchSize)
+		}
+
+		n, err := w.writer.Write(rows[:n])
+		rows = rows[n:]
+		written += n
+		w.numRows += int64(n)
+		if err != nil {
+			return written, err
+		}
+
+		if w.full() {
+			if err := w.closeFile(); err != nil {
+				return written, err
+			}
+		}
+	}
+
+	return written, nil
+}
+
+// Close closes the current file and returns the manifest of all the files that
+// were written.
+func (w *MultiFileWriter[T]) Close() ([]ManifestEntry, error) {
+	if w.writer != nil {
+		if err := w.closeFile(); err != nil {
+			return nil, err
+		}
+	}
+	return slices.Clone(w.manifest), nil
+}
+
+func (w *MultiFileWriter[T]) full() bool {
+	if limit := w.config.MaxRowsPerFile; limit > 0 && w.numRows >= limit {
+		return true
+	}
+	if limit := w.config.MaxBytesPerFile; limit > 0 {
+		base := w.writer.base.writer
+		if base.writer.offset+base.bufferedBytes() >= limit {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *MultiFileWriter[T]) open() error {
+	fileIndex := len(w.manifest)
+	path, file, err := w.create(fileIndex)
+	if err != nil {
+		return fmt.Errorf("creating parquet file %d: %w", fileIndex, err)
+	}
+	w.path, w.file, w.numRows = path, file, 0
+	w.writer = NewGenericWriter[T](file, w.config.WriterOptions...)
+	return nil
+}
+
+func (w *MultiFileWriter[T]) closeFile() error {
+	writer, file := w.writer, w.file
+	w.writer, w.file = nil, nil
+
+	if err := writer.Close(); err != nil {
+		file.Close()
+		return fmt.Errorf("closing parquet file %s: %w", w.path, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("closing parquet file %s: %w", w.path, err)
+	}
+
+	base := writer.base.writer
+	entry := ManifestEntry{
+		Path:    w.path,
+		NumRows: base.fileMetaData.NumRows,
+		Size:    base.writer.offset,
+	}
+	if len(w.config.KeyColumn) > 0 {
+		leaf, _ := writer.Schema().Lookup(w.config.KeyColumn...)
+		entry.MinKey, entry.MaxKey = columnChunkBounds(base.fileMetaData, leaf.ColumnIndex, leaf.Node.Type())
+	}
+	w.manifest = append(w.manifest, entry)
+	return nil
+}
+
+// columnChunkBounds returns the minimum and maximum values recorded in the
+// statistics of the column chunks at columnIndex in the file metadata.
+func columnChunkBounds(metadata *format.FileMetaData, columnIndex int, columnType Type) (minValue, maxValue Value) {
+	kind := columnType.Kind()
+
+	for i := range metadata.RowGroups {
+		stats := &metadata.RowGroups[i].Columns[columnIndex].MetaData.Statistics
+		if stats.MinValue != nil {
+			if v := kind.Value(stats.MinValue); minValue.IsNull() || columnType.Compare(v, minValue) < 0 {
+				minValue = v.Clone()
+			}
+		}
+		if stats.MaxValue != nil {
+			if v := kind.Value(stats.MaxValue); maxValue.IsNull() || columnType.Compare(v, maxValue) > 0 {
+				maxValue = v.Clone()
+			}
+		}
+	}
+
+	return minValue, maxValue
+}
@@ -0,0 +1,147 @@
+package parquet_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestMultiFileWriter(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name"`
+	}
+
+	dir := t.TempDir()
+	create := func(fileIndex int) (string, io.WriteCloser, error) {
+		path := filepath.Join(dir, fmt.Sprintf("part-%05d.parquet", fileIndex))
+		f, err := os.Create(path)
+		return path, f, err
+	}
+
+	writer := parquet.NewMultiFileWriter[Row](create,
+		parquet.MaxRowsPerFile(300),
+		parquet.ManifestKeyColumn("id"),
+		parquet.MultiFileWriterOptions(parquet.MaxRowsPerRowGroup(100)),
+	)
+
+	const numRows = 1000
+	rows := make([]Row, numRows)
+	for i := range rows {
+		rows[i] = Row{ID: int64(numRows - i), Name: fmt.Sprintf("row-%d", i)}
+	}
+	// Write in uneven batches to exercise rolling over in the middle of a call.
+	for _, batch := range [][]Row{rows[:250], rows[250:700], rows[700:]} {
+		if n, err := writer.Write(batch); err != nil {
+			t.Fatal(err)
+		} else if n != len(batch) {
+			t.Fatalf("wrong number of rows written: want=%d got=%d", len(batch), n)
+		}
+	}
+
+	manifest, err := writer.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(manifest) != 4 {
+		t.Fatalf("wrong number of files: want=4 got=%d", len(manifest))
+	}
+
+	offset := 0
+	for i, entry := range manifest {
+		wantRows := min(300, numRows-offset)
+		if entry.NumRows != int64(wantRows) {
+			t.Errorf("file %d: wrong number of rows: want=%d got=%d", i, wantRows, entry.NumRows)
+		}
+
+		s, err := os.Stat(entry.Path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.Size() != entry.Size {
+			t.Errorf("file %d: wrong size: want=%d got=%d", i, s.Size(), entry.Size)
+		}
+
+		wantMax, wantMin := rows[offset].ID, rows[offset+wantRows-1].ID
+		if entry.MinKey.Int64() != wantMin || entry.MaxKey.Int64() != wantMax {
+			t.Errorf("file %d: wrong key bounds: want=[%d,%d] got=[%v,%v]", i, wantMin, wantMax, entry.MinKey, entry.MaxKey)
+		}
+
+		f, err := os.Open(entry.Path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := parquet.Read[Row](f, s.Size())
+		f.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for j, row := range got {
+			if row != rows[offset+j] {
+				t.Fatalf("file %d: row %d mismatch: want=%+v got=%+v", i, j, rows[offset+j], row)
+			}
+		}
+		offset += wantRows
+	}
+}
+
+func TestMultiFileWriterMaxBytesPerFile(t *testing.T) {
+	type Row struct {
+		Payload string `parquet:"payload"`
+	}
+
+	var outputs []*bytesWriteCloser
+	writer := parquet.NewMultiFileWriter[Row](func(fileIndex int) (string, io.WriteCloser, error) {
+		output := new(bytesWriteCloser)
+		outputs = append(outputs, output)
+		return fmt.Sprintf("file-%d", fileIndex), output, nil
+	}, parquet.MaxBytesPerFile(64*1024))
+
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i] = Row{Payload: fmt.Sprintf("%0256d", i)}
+	}
+	if _, err := writer.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+
+	manifest, err := writer.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(manifest) < 2 {
+		t.Fatalf("expected the writer to roll over to multiple files, got %d", len(manifest))
+	}
+
+	numRows := int64(0)
+	for i, entry := range manifest {
+		if !outputs[i].closed {
+			t.Errorf("file %d was not closed", i)
+		}
+		if entry.Size != int64(outputs[i].Len()) {
+			t.Errorf("file %d: wrong size: want=%d got=%d", i, outputs[i].Len(), entry.Size)
+		}
+		if !entry.MinKey.IsNull() || !entry.MaxKey.IsNull() {
+			t.Errorf("file %d: expected null key bounds without a key column", i)
+		}
+		numRows += entry.NumRows
+	}
+	if numRows != int64(len(rows)) {
+		t.Errorf("wrong number of rows: want=%d got=%d", len(rows), numRows)
+	}
+}
+
+type bytesWriteCloser struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (w *bytesWriteCloser) Close() error {
+	w.closed = true
+	return nil
+}
@@ -101,10 +101,17 @@ type Field interface {
 
 // Encoded wraps the node passed as argument to use the given encoding.
 //
-// The function panics if it is called on a non-leaf node, or if the
-// encoding does not support the node type.
+// When node is a LIST, optional or not, the encoding applies to the leaf
+// column of its elements; the repetition and definition levels of the column
+// are encoded separately and are not affected by the encoding.
+//
+// The function panics if it is called on a non-leaf node which is not a list
+// of leaf columns, or if the encoding does not support the node type.
 func Encoded(node Node, encoding encoding.Encoding) Node {
 	if !node.Leaf() {
+		if isList(node) {
+			return encodedList(node, encoding)
+		}
 		panic("cannot add encoding to a non-leaf node")
 	}
 	if encoding != nil {
@@ -153,6 +160,74 @@ func (n *compressedNode) Compression() compress.Codec {
 	return n.codec
 }
 
+// encodedList returns a copy of the LIST node where the element node is
+// wrapped to use the given encoding.
+func encodedList(node Node, encoding encoding.Encoding) Node {
+	list := node.Fields()[0]
+	element := list.Fields()[0]
+	return &rewrittenGroup{
+		Node: node,
+		fields: []Field{&rewrittenField{
+			Node: &rewrittenGroup{
+				Node:   list,
+				fields: []Field{&rewrittenField{Node: Encoded(element, encoding), field: element}},
+			},
+			field: list,
+		}},
+	}
+}
+
+// compressedLeaves returns a copy of node where all the leaf columns which do
+// not already have a compression codec are set to use the given codec.
+func compressedLeaves(node Node, codec compress.Codec) Node {
+	if node.Leaf() {
+		if node.Compression() != nil {
+			return node
+		}
+		return Compressed(node, codec)
+	}
+
+	fields := node.Fields()
+	rewritten := make([]Field, len(fields))
+	for i, f := range fields {
+		rewritten[i] = &rewrittenField{Node: compressedLeaves(f, codec), field: f}
+	}
+	return &rewrittenGroup{Node: node, fields: rewritten}
+}
+
+// rewrittenGroup wraps a group node to replace its list of fields, retaining
+// all the other properties of the original node.
+type rewrittenGroup struct {
+	Node
+	fields []Field
+}
+
+func (g *rewrittenGroup) String() string { return sprint("", g) }
+
+func (g *rewrittenGroup) Fields() []Field { return g.fields }
+
+// rewrittenField associates a new node to the name and value accessor of a
+// field from the original group.
+type rewrittenField struct {
+	Node
+	field Field
+}
+
+func (f *rewrittenField) Name() string { return f.field.Name() }
+
+func (f *rewrittenField) Value(base reflect.Value) reflect.Value { return f.field.Value(base) }
+
+func (f *rewrittenField) requiredPointer() bool { return isRequiredPointer(f.field) }
+
+// columnOptions forwards the column options of the original field, which
+// would otherwise be lost when its node is rewritten.
+func (f *rewrittenField) options() *columnOptions {
+	if field, ok := f.field.(fieldWithColumnOptions); ok {
+		return field.options()
+	}
+	return nil
+}
+
 // Optional wraps the given node to make it optional.
 func Optional(node Node) Node { return &optionalNode{node} }
 
@@ -517,7 +592,7 @@ func findByPath(node Node, path []string) Node {
 // with the same fields, because MAP nodes have a specific logical type.
 //
 // Note that the encoding and compression of the nodes are not considered by this
-// function.
+// function, use EqualNodesStrict to compare them as well.
 func EqualNodes(node1, node2 Node) bool {
 	if node1.Leaf() {
 		return node2.Leaf() && leafNodesAreEqual(node1, node2)
@@ -542,6 +617,50 @@ func SameNodes(node1, node2 Node) bool {
 	}
 }
 
+// EqualNodesStrict returns true if node1 and node2 are equal, including the
+// configuration of their columns.
+//
+// In addition to the properties compared by EqualNodes (names and order of
+// fields, repetition types, data and logical types), the encodings and
+// compression codecs of the nodes must be the same. A node with no explicit
+// encoding or compression is not equal to a node configured with one, even if
+// it would be the default selected by writers.
+//
+// This function is useful to verify that a schema preserves the exact column
+// configuration of another, for example after rewriting a file.
+func EqualNodesStrict(node1, node2 Node) bool {
+	if !encodingsAreEqual(node1.Encoding(), node2.Encoding()) {
+		return false
+	}
+	if !compressionsAreEqual(node1.Compression(), node2.Compression()) {
+		return false
+	}
+	if node1.Leaf() {
+		return node2.Leaf() && leafNodesAreEqual(node1, node2)
+	}
+	if node2.Leaf() || !repetitionsAreEqual(node1, node2) {
+		return false
+	}
+	if !fieldsAreEqual(node1.Fields(), node2.Fields(), EqualNodesStrict) {
+		return false
+	}
+	return equalLogicalTypes(node1.Type(), node2.Type())
+}
+
+func encodingsAreEqual(encoding1, encoding2 encoding.Encoding) bool {
+	if encoding1 == nil || encoding2 == nil {
+		return encoding1 == encoding2
+	}
+	return encoding1.Encoding() == encoding2.Encoding()
+}
+
+func compressionsAreEqual(codec1, codec2 compress.Codec) bool {
+	if codec1 == nil || codec2 == nil {
+		return codec1 == codec2
+	}
+	return codec1.CompressionCodec() == codec2.CompressionCodec()
+}
+
 func repetitionsAreEqual(node1, node2 Node) bool {
 	return node1.Optional() == node2.Optional() && node1.Repeated() == node2.Repeated()
 }
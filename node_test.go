@@ -695,6 +695,114 @@ func TestSameNodesVsEqualNodes(t *testing.T) {
 	}
 }
 
+func TestEqualNodesStrict(t *testing.T) {
+	tests := []struct {
+		name     string
+		node1    Node
+		node2    Node
+		expected bool
+	}{
+		{
+			name:     "same leaf nodes",
+			node1:    String(),
+			node2:    String(),
+			expected: true,
+		},
+		{
+			name:     "same encoding and compression",
+			node1:    Compressed(Encoded(String(), &DeltaByteArray), &Snappy),
+			node2:    Compressed(Encoded(String(), &DeltaByteArray), &Snappy),
+			expected: true,
+		},
+		{
+			name:     "different encodings",
+			node1:    Encoded(String(), &DeltaByteArray),
+			node2:    Encoded(String(), &DeltaLengthByteArray),
+			expected: false,
+		},
+		{
+			name:     "encoding on one node only",
+			node1:    Encoded(String(), &Plain),
+			node2:    String(),
+			expected: false,
+		},
+		{
+			name:     "different compression codecs",
+			node1:    Compressed(Int(64), &Snappy),
+			node2:    Compressed(Int(64), &Zstd),
+			expected: false,
+		},
+		{
+			name:     "compression on one node only",
+			node1:    Int(64),
+			node2:    Compressed(Int(64), &Uncompressed),
+			expected: false,
+		},
+		{
+			name:     "different types",
+			node1:    Compressed(Int(32), &Snappy),
+			node2:    Compressed(Int(64), &Snappy),
+			expected: false,
+		},
+		{
+			name:     "different repetitions",
+			node1:    Optional(Encoded(Int(64), &DeltaBinaryPacked)),
+			node2:    Encoded(Int(64), &DeltaBinaryPacked),
+			expected: false,
+		},
+		{
+			name: "same nested groups",
+			node1: Group{
+				"id":   Compressed(Encoded(Int(64), &DeltaBinaryPacked), &Gzip),
+				"tags": List(Compressed(String(), &Lz4Raw)),
+			},
+			node2: Group{
+				"id":   Compressed(Encoded(Int(64), &DeltaBinaryPacked), &Gzip),
+				"tags": List(Compressed(String(), &Lz4Raw)),
+			},
+			expected: true,
+		},
+		{
+			name: "different nested compression codecs",
+			node1: Group{
+				"id":   Int(64),
+				"tags": List(Compressed(String(), &Lz4Raw)),
+			},
+			node2: Group{
+				"id":   Int(64),
+				"tags": List(Compressed(String(), &Brotli)),
+			},
+			expected: false,
+		},
+		{
+			name:     "different field names",
+			node1:    Group{"a": Int(64)},
+			node2:    Group{"b": Int(64)},
+			expected: false,
+		},
+		{
+			name:     "leaf and group",
+			node1:    Group{"a": Int(64)},
+			node2:    Int(64),
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if result := EqualNodesStrict(test.node1, test.node2); result != test.expected {
+				t.Errorf("EqualNodesStrict() = %v, want %v", result, test.expected)
+			}
+			if result := EqualNodesStrict(test.node2, test.node1); result != test.expected {
+				t.Errorf("EqualNodesStrict() is not symmetric: %v, want %v", result, test.expected)
+			}
+			if test.expected && !EqualNodes(test.node1, test.node2) {
+				t.Error("nodes equal with EqualNodesStrict must be equal with EqualNodes")
+			}
+		})
+	}
+}
+
 func TestEncodingOf(t *testing.T) {
 	testCases := []struct {
 		name             string
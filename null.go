@@ -36,6 +36,24 @@ func nullIndexStruct(bits []uint64, rows sparse.Array) {
 	bytealg.Broadcast(unsafecast.Slice[byte](bits), 0xFF)
 }
 
+// nullIndexFuncOfStruct returns a function reporting struct values as null when
+// all their fields are zero, consistently with the deconstruction of optional
+// groups (see deconstructFuncOfOptional).
+func nullIndexFuncOfStruct(t reflect.Type) nullIndexFunc {
+	if t.NumField() == 0 {
+		return nullIndexStruct
+	}
+	return func(bits []uint64, rows sparse.Array) {
+		for i := range rows.Len() {
+			if !reflect.NewAt(t, rows.Index(i)).Elem().IsZero() {
+				x := uint(i) / 64
+				y := uint(i) % 64
+				bits[x] |= 1 << y
+			}
+		}
+	}
+}
+
 func nullIndexTime(bits []uint64, rows sparse.Array) {
 	for i := range rows.Len() {
 		t := (*time.Time)(rows.Index(i))
@@ -106,7 +124,7 @@ func nullIndexFuncOf(t reflect.Type) nullIndexFunc {
 		return nullIndexPointer
 
 	case reflect.Struct:
-		return nullIndexStruct
+		return nullIndexFuncOfStruct(t)
 	}
 
 	panic("cannot convert Go values of type " + typeNameOf(t) + " to parquet value")
@@ -1193,14 +1193,36 @@ func (page *fixedLenByteArrayPage) Values() ValueReader {
 	return &fixedLenByteArrayPageValues{page: page}
 }
 
-func (page *fixedLenByteArrayPage) min() []byte { return minFixedLenByteArray(page.data, page.size) }
+func (page *fixedLenByteArrayPage) min() []byte {
+	if page.isFloat16() {
+		min, _ := boundsFloat16(page.data)
+		return min
+	}
+	return minFixedLenByteArray(page.data, page.size)
+}
 
-func (page *fixedLenByteArrayPage) max() []byte { return maxFixedLenByteArray(page.data, page.size) }
+func (page *fixedLenByteArrayPage) max() []byte {
+	if page.isFloat16() {
+		_, max := boundsFloat16(page.data)
+		return max
+	}
+	return maxFixedLenByteArray(page.data, page.size)
+}
 
 func (page *fixedLenByteArrayPage) bounds() (min, max []byte) {
+	if page.isFloat16() {
+		return boundsFloat16(page.data)
+	}
 	return boundsFixedLenByteArray(page.data, page.size)
 }
 
+// isFloat16 returns true if the page holds FLOAT16 values, which are not
+// ordered by their byte representation.
+func (page *fixedLenByteArrayPage) isFloat16() bool {
+	_, ok := page.typ.(*float16Type)
+	return ok
+}
+
 func (page *fixedLenByteArrayPage) Bounds() (min, max Value, ok bool) {
 	if ok = len(page.data) > 0; ok {
 		minBytes, maxBytes := page.bounds()
@@ -27,15 +27,15 @@ func Read[T any](r io.ReaderAt, size int64, options ...ReaderOption) (rows []T,
 	if err != nil {
 		return nil, err
 	}
-	file, err := OpenFile(r, size)
+	file, err := OpenFile(r, size, fileOption(func(c *FileConfig) { c.ColumnTransforms = config.ColumnTransforms }))
 	if err != nil {
 		return nil, err
 	}
 	rows = make([]T, file.NumRows())
 	reader := NewGenericReader[T](file, config)
 	n, err := reader.Read(rows)
-	if err == io.EOF {
-		err = nil
+	if err == nil || err == io.EOF {
+		err = reader.SkippedRowGroups()
 	}
 	reader.Close()
 	return rows[:n], err
@@ -60,7 +60,7 @@ func ReadFile[T any](path string, options ...ReaderOption) (rows []T, err error)
 	if err != nil {
 		return nil, err
 	}
-	return Read[T](f, s.Size())
+	return Read[T](f, s.Size(), options...)
 }
 
 // Write writes the given list of rows to a parquet file written to w.
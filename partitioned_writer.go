@@ -0,0 +1,233 @@
+package parquet
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// PartitionedWriter writes rows of type T to parquet files organized in
+// directories by the values of a set of partition columns.
+//
+// Partition directories follow the Hive convention, with one level of
+// directory per partition column named after the column and its value (e.g.
+// "country=FR/year=2024"). Partition files are opened lazily when the first
+// row of a partition is written, and are all closed when the writer is closed.
+// The partition columns remain present in the files.
+//
+// The number of files opened at the same time is bounded by the
+// MaxOpenPartitions option.
+//
+// PartitionedWriter values are not safe to use concurrently from multiple
+// goroutines.
+type PartitionedWriter[T any] struct {
+	baseDir    string
+	config     *PartitionConfig
+	schema     *Schema
+	columns    []LeafColumn
+	partitions map[string]*partition[T]
+	numOpen    int
+	clock      uint64
+	row        Row
+}
+
+type partition[T any] struct {
+	dir      string
+	numFiles int
+	lastUse  uint64
+	file     *os.File
+	writer   *GenericWriter[T]
+}
+
+// NewPartitionedWriter constructs a writer producing partitions of parquet
+// files under baseDir, split by the values of the partition columns.
+//
+// Partition columns are identified by their path in the schema, with the names
+// of nested fields separated by dots (e.g. "address.country"). They must be
+// leaf columns which are not repeated.
+//
+// The function panics if the options carry an invalid configuration, or if one
+// of the partition columns does not exist in the schema.
+func NewPartitionedWriter[T any](baseDir string, partitionColumns []string, options ...PartitionOption) *PartitionedWriter[T] {
+	config, err := NewPartitionConfig(options...)
+	if err != nil {
+		panic(err)
+	}
+
+	writerConfig, err := NewWriterConfig(config.WriterOptions...)
+	if err != nil {
+		panic(err)
+	}
+	schema := writerConfig.Schema
+	if schema == nil {
+		schema = schemaOf(dereference(typeOf[T]()))
+	}
+
+	if len(partitionColumns) == 0 {
+		panic("partitioned writer must be configured with at least one partition column")
+	}
+
+	columns := make([]LeafColumn, len(partitionColumns))
+	for i, name := range partitionColumns {
+		leaf, ok := schema.Lookup(strings.Split(name, ".")...)
+		if !ok {
+			panic(fmt.Errorf("partition column %q does not exist in the schema", name))
+		}
+		if leaf.MaxRepetitionLevel > 0 {
+			panic(fmt.Errorf("partition column %q cannot be repeated", name))
+		}
+		columns[i] = leaf
+	}
+
+	return &PartitionedWriter[T]{
+		baseDir:    baseDir,
+		config:     config,
+		schema:     schema,
+		columns:    columns,
+		partitions: make(map[string]*partition[T]),
+	}
+}
+
+// Write writes rows to the files of the partitions that they belong to.
+func (w *PartitionedWriter[T]) Write(rows []T) (int, error) {
+	if len(rows) == 0 {
+		return 0, nil
+	}
+
+	written := 0
+	dir := w.partitionOf(rows[0])
+
+	for written < len(rows) {
+		// Consecutive rows often belong to the same partition, group them to
+		// amortize the cost of writing them.
+		end, next := written+1, ""
+		for end < len(rows) {
+			if next = w.partitionOf(rows[end]); next != dir {
+				break
+			}
+			end++
+		}
+
+		p, err := w.open(dir)
+		if err != nil {
+			return written, err
+		}
+		n, err := p.writer.Write(rows[written:end])
+		written += n
+		if err != nil {
+			return written, fmt.Errorf("writing to partition %s: %w", dir, err)
+		}
+		dir = next
+	}
+
+	return written, nil
+}
+
+// Close closes all the partition files that are still open.
+func (w *PartitionedWriter[T]) Close() error {
+	var errs []error
+	for _, p := range w.partitions {
+		if p.writer != nil {
+			if err := w.close(p); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// partitionOf returns the path of the partition directory that row belongs
+// to, relative to the base directory.
+func (w *PartitionedWriter[T]) partitionOf(row T) string {
+	w.row = w.schema.Deconstruct(w.row[:0], row)
+
+	dir := new(strings.Builder)
+	for i, column := range w.columns {
+		if i != 0 {
+			dir.WriteByte(filepath.Separator)
+		}
+		dir.WriteString(url.PathEscape(columnPath(column.Path).String()))
+		dir.WriteByte('=')
+
+		for _, v := range w.row {
+			if v.Column() != column.ColumnIndex {
+				continue
+			}
+			if v.DefinitionLevel() < column.MaxDefinitionLevel {
+				dir.WriteString(hivePartitionNullValue)
+			} else {
+				dir.WriteString(url.PathEscape(v.String()))
+			}
+			break
+		}
+	}
+	return dir.String()
+}
+
+// hivePartitionNullValue is the directory name used by Hive to represent null
+// values of partition columns.
+const hivePartitionNullValue = "__HIVE_DEFAULT_PARTITION__"
+
+func (w *PartitionedWriter[T]) open(dir string) (*partition[T], error) {
+	w.clock++
+
+	p := w.partitions[dir]
+	if p == nil {
+		p = &partition[T]{dir: dir}
+		w.partitions[dir] = p
+	}
+	p.lastUse = w.clock
+
+	if p.writer != nil {
+		return p, nil
+	}
+
+	if w.numOpen >= w.config.MaxOpenPartitions {
+		if err := w.closeLeastRecentlyUsed(); err != nil {
+			return nil, err
+		}
+	}
+
+	path := filepath.Join(w.baseDir, dir)
+	if err := os.MkdirAll(path, 0755); err != nil {
+		return nil, fmt.Errorf("creating partition directory: %w", err)
+	}
+	f, err := os.Create(filepath.Join(path, fmt.Sprintf("part-%05d.parquet", p.numFiles)))
+	if err != nil {
+		return nil, fmt.Errorf("creating partition file: %w", err)
+	}
+
+	p.numFiles++
+	p.file = f
+	p.writer = NewGenericWriter[T](f, w.config.WriterOptions...)
+	w.numOpen++
+	return p, nil
+}
+
+func (w *PartitionedWriter[T]) closeLeastRecentlyUsed() error {
+	var lru *partition[T]
+	for _, p := range w.partitions {
+		if p.writer != nil && (lru == nil || p.lastUse < lru.lastUse) {
+			lru = p
+		}
+	}
+	return w.close(lru)
+}
+
+func (w *PartitionedWriter[T]) close(p *partition[T]) error {
+	writer, file := p.writer, p.file
+	p.writer, p.file = nil, nil
+	w.numOpen--
+
+	if err := writer.Close(); err != nil {
+		file.Close()
+		return fmt.Errorf("closing partition file %s: %w", file.Name(), err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("closing partition file %s: %w", file.Name(), err)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package parquet_test
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestPartitionedWriter(t *testing.T) {
+	type Row struct {
+		ID      int64  `parquet:"id"`
+		Country string `parquet:"country"`
+	}
+
+	countries := []string{"FR", "US", "JP"}
+	rows := make([]Row, 300)
+	for i := range rows {
+		rows[i] = Row{ID: int64(i), Country: countries[i%len(countries)]}
+	}
+
+	tests := []struct {
+		scenario          string
+		maxOpenPartitions int
+	}{
+		{scenario: "all partitions open", maxOpenPartitions: 0},
+		{scenario: "bounded open partitions", maxOpenPartitions: 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			dir := t.TempDir()
+
+			var options []parquet.PartitionOption
+			if test.maxOpenPartitions > 0 {
+				options = append(options, parquet.MaxOpenPartitions(test.maxOpenPartitions))
+			}
+			writer := parquet.NewPartitionedWriter[Row](dir, []string{"country"}, options...)
+
+			// Write in small batches so partitions are revisited after having
+			// been closed when the number of open partitions is bounded.
+			for i := 0; i < len(rows); i += 10 {
+				if _, err := writer.Write(rows[i : i+10]); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := writer.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, country := range countries {
+				files, err := filepath.Glob(filepath.Join(dir, "country="+country, "*.parquet"))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(files) == 0 {
+					t.Fatalf("no files found in partition country=%s", country)
+				}
+				if test.maxOpenPartitions == 0 && len(files) != 1 {
+					t.Errorf("partition country=%s: expected a single file, got %d", country, len(files))
+				}
+
+				var got []Row
+				for _, file := range files {
+					f, err := os.Open(file)
+					if err != nil {
+						t.Fatal(err)
+					}
+					s, err := f.Stat()
+					if err != nil {
+						t.Fatal(err)
+					}
+					fileRows, err := parquet.Read[Row](f, s.Size())
+					f.Close()
+					if err != nil {
+						t.Fatal(err)
+					}
+					got = append(got, fileRows...)
+				}
+
+				var want []Row
+				for _, row := range rows {
+					if row.Country == country {
+						want = append(want, row)
+					}
+				}
+				slices.SortFunc(got, func(a, b Row) int { return int(a.ID - b.ID) })
+				if !slices.Equal(got, want) {
+					t.Errorf("partition country=%s: rows mismatch\nwant = %+v\ngot  = %+v", country, want, got)
+				}
+			}
+
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, entry := range entries {
+				if !strings.HasPrefix(entry.Name(), "country=") {
+					t.Errorf("unexpected entry in base directory: %s", entry.Name())
+				}
+			}
+		})
+	}
+}
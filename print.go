@@ -7,6 +7,8 @@ import (
 	"strconv"
 	"strings"
 	"text/tabwriter"
+
+	"github.com/parquet-go/parquet-go/deprecated"
 )
 
 func PrintSchema(w io.Writer, name string, node Node) error {
@@ -137,6 +139,11 @@ func annotationOf(node Node) string {
 	if logicalType := node.Type().LogicalType(); logicalType != nil {
 		return logicalType.String()
 	}
+	// INTERVAL has no logical type equivalent, it is only represented by its
+	// converted type.
+	if convertedType := node.Type().ConvertedType(); convertedType != nil && *convertedType == deprecated.Interval {
+		return "INTERVAL"
+	}
 	return ""
 }
 
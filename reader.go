@@ -4,8 +4,14 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"iter"
+	"math"
 	"reflect"
+	"sort"
+	"strings"
 
+	"github.com/parquet-go/parquet-go/bloom/xxhash"
+	"github.com/parquet-go/parquet-go/encoding"
 	"github.com/parquet-go/parquet-go/format"
 )
 
@@ -34,7 +40,7 @@ func NewGenericReader[T any](input io.ReaderAt, options ...ReaderOption) *Generi
 		panic(err)
 	}
 
-	f, err := openFile(input)
+	f, err := openFile(input, c.ColumnTransforms)
 	if err != nil {
 		panic(err)
 	}
@@ -50,6 +56,12 @@ func NewGenericReader[T any](input io.ReaderAt, options ...ReaderOption) *Generi
 		}
 	}
 
+	if c.StrictSchema {
+		if err := checkStrictSchema(c.Schema, f.schema); err != nil {
+			panic(err)
+		}
+	}
+
 	r := &GenericReader[T]{
 		base: Reader{
 			file: reader{
@@ -57,10 +69,15 @@ func NewGenericReader[T any](input io.ReaderAt, options ...ReaderOption) *Generi
 				schema:   c.Schema,
 				rowGroup: rowGroup,
 			},
+			selection:    allRowGroups(f),
+			strictSchema: c.StrictSchema,
 		},
 	}
 
-	if !EqualNodes(c.Schema, f.schema) {
+	if c.SkipCorruptRowGroups {
+		r.base.skipCorruptRowGroups = true
+		r.base.file.rowGroup, _ = selectRowGroups(f, c.Schema, r.base.selection, true)
+	} else if !EqualNodes(c.Schema, f.schema) {
 		r.base.file.rowGroup = convertRowGroupTo(r.base.file.rowGroup, c.Schema)
 	}
 
@@ -84,12 +101,19 @@ func NewGenericRowGroupReader[T any](rowGroup RowGroup, options ...ReaderOption)
 		}
 	}
 
+	if c.StrictSchema {
+		if err := checkStrictSchema(c.Schema, rowGroup.Schema()); err != nil {
+			panic(err)
+		}
+	}
+
 	r := &GenericReader[T]{
 		base: Reader{
 			file: reader{
 				schema:   c.Schema,
 				rowGroup: rowGroup,
 			},
+			strictSchema: c.StrictSchema,
 		},
 	}
 
@@ -117,6 +141,55 @@ func (r *GenericReader[T]) Read(rows []T) (int, error) {
 	return r.read(r, rows)
 }
 
+// ReadMatching reads the next rows from the reader into batch, keeping only the
+// rows for which predicate returns true. The matching rows are compacted at the
+// front of batch, in the order they were read, and the method keeps reading
+// until batch is full or no more rows can be read.
+//
+// The method returns the number of matching rows in batch and the number of
+// rows that were scanned to find them, which includes the rows rejected by the
+// predicate. The error is io.EOF when no more rows can be read from the reader.
+//
+// The rows rejected by the predicate are swapped to the back of batch rather
+// than overwritten, so the memory they hold is reused by the next reads instead
+// of being shared with the matching rows.
+func (r *GenericReader[T]) ReadMatching(batch []T, predicate func(*T) bool) (matched, scanned int, err error) {
+	for matched < len(batch) && err == nil {
+		var n int
+		n, err = r.Read(batch[matched:])
+		scanned += n
+
+		rows := batch[matched : matched+n]
+		for i := range rows {
+			if predicate(&rows[i]) {
+				batch[matched], rows[i] = rows[i], batch[matched]
+				matched++
+			}
+		}
+
+		if n == 0 && err == nil {
+			err = io.ErrNoProgress
+		}
+	}
+	return matched, scanned, err
+}
+
+// ReadIntoFunc is like Read but calls fn after reconstructing each row of dst,
+// passing it the row of parquet values that the Go value was reconstructed
+// from. The function can use it to populate fields that need custom decoding,
+// and can leave the other fields as they were set by the default reconstruction
+// of the reader.
+//
+// The values of the row passed to fn may share memory with the reader and
+// are only valid until fn returns. Rows which need to be retained must be
+// cloned by the function.
+//
+// An error returned by fn stops the read and is returned by the method, along
+// with the number of rows that were completely read before it.
+func (r *GenericReader[T]) ReadIntoFunc(dst []T, fn func(Row, *T) error) (int, error) {
+	return r.readRowsFunc(dst, fn)
+}
+
 func (r *GenericReader[T]) ReadRows(rows []Row) (int, error) {
 	return r.base.ReadRows(rows)
 }
@@ -133,15 +206,75 @@ func (r *GenericReader[T]) SeekToRow(rowIndex int64) error {
 	return r.base.SeekToRow(rowIndex)
 }
 
+// ReadRowAt reads the row at the given index. See Reader.ReadRowAt for details.
+func (r *GenericReader[T]) ReadRowAt(rowIndex int64) (Row, error) {
+	return r.base.ReadRowAt(rowIndex)
+}
+
 func (r *GenericReader[T]) Close() error {
 	return r.base.Close()
 }
 
+// ReadRowGroups restricts r to reading the rows of the row groups at the given
+// indexes. See Reader.ReadRowGroups for details.
+func (r *GenericReader[T]) ReadRowGroups(indexes []int) error {
+	return r.base.ReadRowGroups(indexes)
+}
+
+// SetFilter sets a filter on the rows read by r. See Reader.SetFilter for
+// details.
+func (r *GenericReader[T]) SetFilter(filter RowFilter) error {
+	return r.base.SetFilter(filter)
+}
+
+// ColumnDictionary returns the distinct values found in the dictionary pages of
+// a column. See Reader.ColumnDictionary for details.
+func (r *GenericReader[T]) ColumnDictionary(fullScan bool, path ...string) (values []Value, ok bool, err error) {
+	return r.base.ColumnDictionary(fullScan, path...)
+}
+
+// EstimateCardinality returns an estimate of the number of distinct values of
+// a column. See Reader.EstimateCardinality for details.
+func (r *GenericReader[T]) EstimateCardinality(sampleRows int64, path ...string) (int64, error) {
+	return r.base.EstimateCardinality(sampleRows, path...)
+}
+
+// ScanColumn calls do with each value of a column. See Reader.ScanColumn for
+// details.
+func (r *GenericReader[T]) ScanColumn(do func(Value), path ...string) error {
+	return r.base.ScanColumn(do, path...)
+}
+
+// ColumnEncodings returns the encodings of the pages of a column chunk. See
+// Reader.ColumnEncodings for details.
+func (r *GenericReader[T]) ColumnEncodings(rowGroup int, path string) ([]encoding.Encoding, error) {
+	return r.base.ColumnEncodings(rowGroup, path)
+}
+
+// RowGroupOffsets returns the index of the first row of each row group. See
+// Reader.RowGroupOffsets for details.
+func (r *GenericReader[T]) RowGroupOffsets() []int64 {
+	return r.base.RowGroupOffsets()
+}
+
+// SkippedRowGroups returns an error describing the row groups that were
+// skipped because they could not be read. See Reader.SkippedRowGroups for
+// details.
+func (r *GenericReader[T]) SkippedRowGroups() error {
+	return r.base.SkippedRowGroups()
+}
+
 // File returns a FileView of the underlying parquet file.
 func (r *GenericReader[T]) File() FileView {
 	return r.base.File()
 }
 
+// RawMetadata returns the thrift metadata of the underlying parquet file. See
+// Reader.RawMetadata for details.
+func (r *GenericReader[T]) RawMetadata() *format.FileMetaData {
+	return r.base.RawMetadata()
+}
+
 // readRows reads the next rows from the reader into the given rows slice up to len(rows).
 //
 // The returned values are safe to reuse across readRows calls and do not share
@@ -150,6 +283,10 @@ func (r *GenericReader[T]) File() FileView {
 // The method returns the number of rows read and io.EOF when no more rows
 // can be read from the reader.
 func (r *GenericReader[T]) readRows(rows []T) (int, error) {
+	return r.readRowsFunc(rows, nil)
+}
+
+func (r *GenericReader[T]) readRowsFunc(rows []T, fn func(Row, *T) error) (int, error) {
 	nRequest := len(rows)
 	if cap(r.base.rowbuf) < nRequest {
 		r.base.rowbuf = make([]Row, nRequest)
@@ -173,6 +310,11 @@ func (r *GenericReader[T]) readRows(rows []T) (int, error) {
 				if err2 := schema.Reconstruct(&rows[nTotal+i], row); err2 != nil {
 					return nTotal + i, err2
 				}
+				if fn != nil {
+					if err2 := fn(row, &rows[nTotal+i]); err2 != nil {
+						return nTotal + i, err2
+					}
+				}
 			}
 		}
 		nTotal += n
@@ -193,6 +335,8 @@ var (
 
 	_ Rows                = (*GenericReader[map[struct{}]struct{}])(nil)
 	_ RowReaderWithSchema = (*GenericReader[map[struct{}]struct{}])(nil)
+
+	_ ColumnScanner = (*GenericReader[any])(nil)
 )
 
 type readFunc[T any] func(*GenericReader[T], []T) (int, error)
@@ -245,6 +389,17 @@ type Reader struct {
 	read     reader
 	rowIndex int64
 	rowbuf   []Row
+	// Indexes of the row groups of the file read by r, which are all the row
+	// groups unless a selection was made with ReadRowGroups.
+	selection []int
+	// Filter set with SetFilter, nil when all the rows are read.
+	filter *readerFilter
+	// Conversion of the rows of the file to the schema of the values passed
+	// to Read, nil if the schemas are the same.
+	conv Conversion
+
+	skipCorruptRowGroups bool
+	strictSchema         bool
 }
 
 // NewReader constructs a parquet reader reading rows from the given
@@ -275,7 +430,7 @@ func NewReader(input io.ReaderAt, options ...ReaderOption) *Reader {
 		panic(err)
 	}
 
-	f, err := openFile(input)
+	f, err := openFile(input, c.ColumnTransforms)
 	if err != nil {
 		panic(err)
 	}
@@ -286,18 +441,30 @@ func NewReader(input io.ReaderAt, options ...ReaderOption) *Reader {
 			schema:   f.schema,
 			rowGroup: fileRowGroupOf(f),
 		},
+		selection:    allRowGroups(f),
+		strictSchema: c.StrictSchema,
 	}
 
 	if c.Schema != nil {
+		if c.StrictSchema {
+			if err := checkStrictSchema(c.Schema, f.schema); err != nil {
+				panic(err)
+			}
+		}
 		r.file.schema = c.Schema
 		r.file.rowGroup = convertRowGroupTo(r.file.rowGroup, c.Schema)
 	}
 
+	if c.SkipCorruptRowGroups {
+		r.skipCorruptRowGroups = true
+		r.file.rowGroup, _ = selectRowGroups(f, r.file.schema, r.selection, true)
+	}
+
 	r.read.init(r.file.schema, r.file.rowGroup)
 	return r
 }
 
-func openFile(input io.ReaderAt) (*File, error) {
+func openFile(input io.ReaderAt, transforms []*ColumnTransform) (*File, error) {
 	f, _ := input.(*File)
 	if f != nil {
 		return f, nil
@@ -306,7 +473,7 @@ func openFile(input io.ReaderAt) (*File, error) {
 	if err != nil {
 		return nil, err
 	}
-	return OpenFile(input, n)
+	return OpenFile(input, n, fileOption(func(config *FileConfig) { config.ColumnTransforms = transforms }))
 }
 
 func fileRowGroupOf(f *File) RowGroup {
@@ -331,6 +498,11 @@ func NewRowGroupReader(rowGroup RowGroup, options ...ReaderOption) *Reader {
 	}
 
 	if c.Schema != nil {
+		if c.StrictSchema {
+			if err := checkStrictSchema(c.Schema, rowGroup.Schema()); err != nil {
+				panic(err)
+			}
+		}
 		rowGroup = convertRowGroupTo(rowGroup, c.Schema)
 	}
 
@@ -339,6 +511,7 @@ func NewRowGroupReader(rowGroup RowGroup, options ...ReaderOption) *Reader {
 			schema:   rowGroup.Schema(),
 			rowGroup: rowGroup,
 		},
+		strictSchema: c.StrictSchema,
 	}
 
 	r.read.init(r.file.schema, r.file.rowGroup)
@@ -359,6 +532,157 @@ func convertRowGroupTo(rowGroup RowGroup, schema *Schema) RowGroup {
 	return rowGroup
 }
 
+// checkStrictSchema returns an error wrapping ErrSchemaMismatch if schema and
+// fileSchema do not have the same leaf columns, implementing the StrictSchema
+// reader option.
+func checkStrictSchema(schema, fileSchema *Schema) error {
+	fileColumns := fileSchema.Columns()
+	missing := make(map[string]struct{}, len(fileColumns))
+	for _, path := range fileColumns {
+		missing[columnPath(path).String()] = struct{}{}
+	}
+	for _, path := range schema.Columns() {
+		name := columnPath(path).String()
+		if _, ok := missing[name]; !ok {
+			return fmt.Errorf("%w: column %q does not exist in the file", ErrSchemaMismatch, name)
+		}
+		delete(missing, name)
+	}
+	for _, path := range fileColumns {
+		name := columnPath(path).String()
+		if _, ok := missing[name]; ok {
+			return fmt.Errorf("%w: column %q of the file does not exist in the schema", ErrSchemaMismatch, name)
+		}
+	}
+	return nil
+}
+
+// selectRowGroups returns a view of the row groups of f at the given indexes,
+// converted to schema. When skipCorrupt is true, the row groups which fail to
+// be read are skipped, implementing the SkipCorruptRowGroups reader option.
+func selectRowGroups(f *File, schema *Schema, indexes []int, skipCorrupt bool) (RowGroup, error) {
+	fileRowGroups := f.RowGroups()
+	rowGroups := make([]RowGroup, len(indexes))
+	for i, index := range indexes {
+		if index < 0 || index >= len(fileRowGroups) {
+			return nil, fmt.Errorf("row group index out of bounds: %d/%d", index, len(fileRowGroups))
+		}
+		rowGroups[i] = convertRowGroupTo(fileRowGroups[index], schema)
+	}
+
+	switch {
+	case skipCorrupt:
+		m := new(skipCorruptRowGroup)
+		m.init(schema, nil, rowGroups)
+		return m, nil
+	case len(rowGroups) == 0:
+		return newEmptyRowGroup(schema), nil
+	case len(rowGroups) == 1:
+		return rowGroups[0], nil
+	default:
+		return newMultiRowGroup(schema, nil, rowGroups), nil
+	}
+}
+
+func allRowGroups(f *File) []int {
+	indexes := make([]int, len(f.RowGroups()))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	return indexes
+}
+
+type skipCorruptRowGroup struct {
+	multiRowGroup
+	// Errors that caused the row groups to be skipped, indexed like the row
+	// groups; nil for the row groups which were read successfully.
+	errs []error
+}
+
+func (m *skipCorruptRowGroup) Rows() Rows {
+	if m.errs == nil {
+		m.errs = make([]error, len(m.rowGroups))
+	}
+	return &skipCorruptRows{schema: m.schema, rowGroups: m.rowGroups, errs: m.errs}
+}
+
+// skipped returns an error joining the errors that caused row groups to be
+// skipped, or nil if no row groups were skipped.
+func (m *skipCorruptRowGroup) skipped() error {
+	var errs []error
+	for index, err := range m.errs {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("row group %d: %w", index, err))
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("skipped %d corrupt row groups: %w", len(errs), errors.Join(errs...))
+}
+
+// skipCorruptRows reads rows from a sequence of row groups, moving on to the
+// next row group when reading from one of them fails. The errors are recorded
+// in the row group that the rows were created from.
+type skipCorruptRows struct {
+	schema    *Schema
+	rowGroups []RowGroup
+	index     int
+	rows      Rows
+	errs      []error
+}
+
+func (r *skipCorruptRows) ReadRows(rows []Row) (int, error) {
+	for r.index < len(r.rowGroups) {
+		if r.rows == nil {
+			r.rows = r.rowGroups[r.index].Rows()
+		}
+
+		n, err := r.rows.ReadRows(rows)
+		switch {
+		case err == nil || (err == io.EOF && n > 0):
+			return n, nil
+		case err != io.EOF:
+			// The rows returned by the call which failed may be incomplete,
+			// they are discarded with the rest of the row group.
+			r.errs[r.index] = err
+		}
+
+		r.rows.Close()
+		r.rows = nil
+		r.index++
+	}
+	return 0, io.EOF
+}
+
+func (r *skipCorruptRows) SeekToRow(rowIndex int64) error {
+	if r.rows != nil {
+		r.rows.Close()
+		r.rows = nil
+	}
+
+	for r.index = 0; r.index < len(r.rowGroups); r.index++ {
+		numRows := r.rowGroups[r.index].NumRows()
+		if rowIndex < numRows {
+			r.rows = r.rowGroups[r.index].Rows()
+			return r.rows.SeekToRow(rowIndex)
+		}
+		rowIndex -= numRows
+	}
+	return nil
+}
+
+func (r *skipCorruptRows) Schema() *Schema { return r.schema }
+
+func (r *skipCorruptRows) Close() error {
+	if r.rows != nil {
+		r.rows.Close()
+		r.rows = nil
+	}
+	r.index = len(r.rowGroups)
+	return nil
+}
+
 func sizeOf(r io.ReaderAt) (int64, error) {
 	switch f := r.(type) {
 	case interface{ Size() int64 }:
@@ -400,6 +724,10 @@ func (r *Reader) Read(row any) error {
 		}
 	}
 
+	if r.filter != nil {
+		return r.readFiltered(row)
+	}
+
 	if err := r.read.SeekToRow(r.rowIndex); err != nil {
 		if errors.Is(err, io.ErrClosedPipe) {
 			return io.EOF
@@ -425,27 +753,85 @@ func (r *Reader) Read(row any) error {
 func (r *Reader) updateReadSchema(rowType reflect.Type) error {
 	schema := schemaOf(rowType)
 
+	if r.strictSchema {
+		if err := checkStrictSchema(schema, r.file.schema); err != nil {
+			return err
+		}
+	}
+
 	if EqualNodes(schema, r.file.schema) {
 		r.read.init(schema, r.file.rowGroup)
+		r.conv = nil
 	} else {
 		conv, err := Convert(schema, r.file.schema)
 		if err != nil {
 			return err
 		}
 		r.read.init(schema, ConvertRowGroup(r.file.rowGroup, conv))
+		r.conv = conv
 	}
 
 	r.seen = rowType
 	return nil
 }
 
+// ReadRowGroups restricts r to reading the rows of the row groups at the given
+// indexes in the underlying parquet file, which is useful to read only the row
+// groups selected after evaluating their statistics for example.
+//
+// Row groups are read in the order of indexes, which do not need to be
+// contiguous. Calling the method rewinds r to the first row of the selection,
+// and the row indexes of methods such as SeekToRow become relative to the
+// selected rows.
+//
+// The method returns an error if the reader does not read from a parquet file,
+// or if one of the indexes is not within the range of row groups of the file.
+func (r *Reader) ReadRowGroups(indexes []int) error {
+	f := r.file.file
+	if f == nil {
+		return errors.New("cannot select row groups of a reader which does not read from a parquet file")
+	}
+	rowGroup, err := selectRowGroups(f, r.file.schema, indexes, r.skipCorruptRowGroups)
+	if err != nil {
+		return err
+	}
+
+	for _, rd := range []*reader{&r.file, &r.read} {
+		if rd.rows != nil {
+			rd.rows.Close()
+			rd.rows = nil
+		}
+		rd.init(r.file.schema, rowGroup)
+	}
+
+	// The read schema is updated again on the next call to Read.
+	r.seen = nil
+	r.selection = append([]int{}, indexes...)
+	r.rowIndex = 0
+	if r.filter != nil {
+		r.filter.ranges, r.filter.loaded = nil, false
+		r.filter.discardMatches()
+	}
+	clearRows(r.rowbuf)
+	return nil
+}
+
 // ReadRows reads the next rows from r into the given Row buffer.
 //
 // The returned values are laid out in the order expected by the
 // parquet.(*Schema).Reconstruct method.
 //
+// When a filter was set with SetFilter, only the matching rows are returned.
+//
 // The method returns io.EOF when no more rows can be read from r.
 func (r *Reader) ReadRows(rows []Row) (int, error) {
+	if r.filter != nil {
+		return r.readFilteredRows(rows)
+	}
+	return r.readRows(rows)
+}
+
+func (r *Reader) readRows(rows []Row) (int, error) {
 	if err := r.file.SeekToRow(r.rowIndex); err != nil {
 		return 0, err
 	}
@@ -454,14 +840,205 @@ func (r *Reader) ReadRows(rows []Row) (int, error) {
 	return n, err
 }
 
+// SetFilter sets a filter on the rows read by r, subsequent calls to Read,
+// ReadRows, and Rows only return the rows matching the filter. Passing a nil
+// filter removes the filter that was previously set.
+//
+// The filter is evaluated against the statistics of the column chunks and the
+// column index of their pages first: row groups and pages which cannot contain
+// matching rows are skipped without being read, using the offset index to seek
+// to the next pages which may match. The rows of those pages are then checked
+// individually, since the statistics only give bounds on their values. When
+// the file has no page index, the rows of all the row groups which may match
+// are checked.
+//
+// The method does not reposition r, and does not change the results of the
+// NumRows, SeekToRow, and ReadRowAt methods, which still apply to all the rows.
+// The method returns an error if one of the columns of the filter does not
+// exist in the schema of r, or if its value cannot be converted to the column
+// type.
+func (r *Reader) SetFilter(filter RowFilter) error {
+	if filter == nil {
+		r.filter = nil
+		return nil
+	}
+	bound, err := filter.bind(r.file.schema)
+	if err != nil {
+		return fmt.Errorf("setting reader filter: %w", err)
+	}
+	r.filter = &readerFilter{filter: bound}
+	return nil
+}
+
+// readerFilter holds the state of a filter set on a Reader.
+type readerFilter struct {
+	filter boundRowFilter
+	// Ranges of rows which may match the filter, computed on the first read
+	// after the filter was set or the row groups were selected.
+	ranges []rowRange
+	loaded bool
+	// Indexes of the rows returned by the last read.
+	rows []int64
+	// Rows matching the filter which were read ahead by Read, with their
+	// indexes. The rows at matches[next:] are returned by the next calls to
+	// Read as long as the reader remains at position, which was the index of
+	// the row following the rows returned by the previous call. The index of
+	// the row following the last row scanned is end.
+	matches    []Row
+	matchRows  []int64
+	next       int
+	position   int64
+	end        int64
+	readBuffer []Row
+}
+
+func (f *readerFilter) discardMatches() {
+	f.matches, f.next = f.matches[:0], 0
+}
+
+// readFiltered reads the next row matching the filter into row. The rows are
+// read and matched in batches, the matching rows of a batch are retained to be
+// returned by the next calls instead of being read again.
+func (r *Reader) readFiltered(row any) error {
+	f := r.filter
+	if f.next == len(f.matches) || f.position != r.rowIndex {
+		if f.readBuffer == nil {
+			f.readBuffer = make([]Row, defaultRowBufferSize)
+		}
+		n, err := r.readFilteredRows(f.readBuffer)
+		f.matches, f.next = f.readBuffer[:n], 0
+		f.matchRows = append(f.matchRows[:0], f.rows...)
+		f.end = r.rowIndex
+		if n == 0 {
+			return err
+		}
+	}
+
+	match := f.matches[f.next]
+	f.next++
+	// The rows following the last match of the batch did not match the
+	// filter, they do not need to be read again.
+	if f.next == len(f.matches) {
+		r.rowIndex = f.end
+	} else {
+		r.rowIndex = f.matchRows[f.next-1] + 1
+	}
+	f.position = r.rowIndex
+
+	if r.conv != nil {
+		if _, err := r.conv.Convert(f.matches[f.next-1 : f.next]); err != nil {
+			return err
+		}
+		match = f.matches[f.next-1]
+	}
+	return r.read.schema.Reconstruct(row, match)
+}
+
+func (r *Reader) readFilteredRows(rows []Row) (int, error) {
+	f := r.filter
+	f.rows = f.rows[:0]
+	if r.file.rowGroup == nil || len(rows) == 0 {
+		return r.readRows(rows[:0])
+	}
+	if !f.loaded {
+		f.ranges, f.loaded = f.filter.rowRanges(r.file.rowGroup), true
+	}
+
+	for {
+		i := sort.Search(len(f.ranges), func(i int) bool {
+			return f.ranges[i].end > r.rowIndex
+		})
+		if i == len(f.ranges) {
+			return 0, io.EOF
+		}
+
+		rowIndex := max(r.rowIndex, f.ranges[i].start)
+		r.rowIndex = rowIndex
+		n, err := r.readRows(rows[:min(int64(len(rows)), f.ranges[i].end-rowIndex)])
+
+		matched := 0
+		for j, row := range rows[:n] {
+			if f.filter.match(row) {
+				rows[matched], rows[j] = rows[j], rows[matched]
+				f.rows = append(f.rows, rowIndex+int64(j))
+				matched++
+			}
+		}
+
+		if matched > 0 || err != nil {
+			return matched, err
+		}
+		if n == 0 {
+			return 0, io.ErrNoProgress
+		}
+	}
+}
+
+// Rows returns an iterator over the rows remaining to be read from r.
+//
+// The rows are read in batches into a buffer owned by the iterator, which
+// avoids allocating memory for each row: the yielded Row and its values are
+// only valid until the next iteration, programs that need to retain them must
+// use Row.Clone. For example:
+//
+//	for row, err := range reader.Rows() {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+//
+// Errors other than io.EOF are yielded with a nil row and end the iteration.
+// When the loop is exited early, r remains positioned after the last row that
+// was yielded, and can be used to resume reading.
+func (r *Reader) Rows() iter.Seq2[Row, error] {
+	return func(yield func(Row, error) bool) {
+		rows := make([]Row, defaultRowBufferSize)
+		for {
+			n, err := r.ReadRows(rows)
+			for i, row := range rows[:n] {
+				if !yield(row, nil) {
+					if r.filter != nil {
+						r.rowIndex = r.filter.rows[i] + 1
+					} else {
+						r.rowIndex -= int64(n - (i + 1))
+					}
+					return
+				}
+			}
+			if err != nil {
+				if err != io.EOF {
+					yield(nil, err)
+				}
+				return
+			}
+		}
+	}
+}
+
 // Schema returns the schema of rows read by r.
 func (r *Reader) Schema() *Schema { return r.file.schema }
 
 // NumRows returns the number of rows that can be read from r.
 func (r *Reader) NumRows() int64 { return r.file.rowGroup.NumRows() }
 
-// SeekToRow positions r at the given row index.
+// SeekToRow positions r at the given row index, the next call to Read or
+// ReadRows returns the row at this index.
+//
+// Row groups preceding the row are skipped using their row counts. Within the
+// row group, the offset index of each column is used to skip directly to the
+// page containing the row when it exists; otherwise, the pages are scanned
+// from the beginning of the column chunk.
+//
+// Seeking to NumRows positions r at the end of the rows. The method returns
+// an error wrapping ErrSeekOutOfRange if rowIndex is negative or greater than
+// the number of rows.
 func (r *Reader) SeekToRow(rowIndex int64) error {
+	if r.file.rowGroup != nil {
+		if numRows := r.NumRows(); rowIndex < 0 || rowIndex > numRows {
+			return fmt.Errorf("seeking to row %d of %d: %w", rowIndex, numRows, ErrSeekOutOfRange)
+		}
+	}
 	if err := r.file.SeekToRow(rowIndex); err != nil {
 		return err
 	}
@@ -469,6 +1046,185 @@ func (r *Reader) SeekToRow(rowIndex int64) error {
 	return nil
 }
 
+// ReadRowAt reads the row at the given index, decoding only the pages which
+// contain its values.
+//
+// When the file has offset indexes, locating the pages of the row is done with
+// a binary search on the page locations of each column; otherwise, the pages
+// of the row group are scanned until the row is reached. Row groups preceding
+// the row are always skipped using their row counts.
+//
+// After the method returns, the reader is positioned on the row following the
+// one that was read.
+func (r *Reader) ReadRowAt(rowIndex int64) (Row, error) {
+	if numRows := r.NumRows(); rowIndex < 0 || rowIndex >= numRows {
+		return nil, fmt.Errorf("reading row %d of %d: %w", rowIndex, numRows, ErrSeekOutOfRange)
+	}
+	if err := r.SeekToRow(rowIndex); err != nil {
+		return nil, fmt.Errorf("seeking reader to row %d: %w", rowIndex, err)
+	}
+	rows := [1]Row{}
+	n, err := r.readRows(rows[:])
+	if n == 1 {
+		return rows[0], nil
+	}
+	if err == nil || err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return nil, fmt.Errorf("reading row %d: %w", rowIndex, err)
+}
+
+// RowGroupOffsets returns the index of the first row of each row group read by
+// r, which programs building external indexes can use to map row numbers to
+// the row group holding them and to the offset of the row within the group.
+//
+// The offsets are computed from the row counts recorded in the metadata, no
+// pages are read. When the reader was restricted to a selection of row groups
+// with ReadRowGroups, the offsets are those of the selected row groups, and
+// are relative to the first selected row like the indexes of SeekToRow. Row
+// groups skipped because of the SkipCorruptRowGroups option keep their offsets
+// since they still count toward the row indexes of SeekToRow.
+func (r *Reader) RowGroupOffsets() []int64 {
+	var numRows []int64
+	if f := r.file.file; f != nil {
+		rowGroups := f.Metadata().RowGroups
+		for _, index := range r.selection {
+			numRows = append(numRows, rowGroups[index].NumRows)
+		}
+	} else {
+		for _, rowGroup := range rowGroupsOf(r.file.rowGroup) {
+			numRows = append(numRows, rowGroup.NumRows())
+		}
+	}
+
+	offsets := make([]int64, len(numRows))
+	rowIndex := int64(0)
+	for i, n := range numRows {
+		offsets[i] = rowIndex
+		rowIndex += n
+	}
+	return offsets
+}
+
+// SkippedRowGroups returns an error describing the row groups that r skipped
+// because they could not be read, when it was created with the
+// SkipCorruptRowGroups option. The returned error wraps the errors which caused
+// the row groups to be skipped, and is nil if no row groups were skipped.
+//
+// Readers return io.EOF when reaching the end of the rows whether or not row
+// groups were skipped; applications should call this method after reaching the
+// end of the rows to be notified of the data loss.
+func (r *Reader) SkippedRowGroups() error {
+	if m, ok := r.file.rowGroup.(*skipCorruptRowGroup); ok {
+		return m.skipped()
+	}
+	return nil
+}
+
+// rowGroupsOf returns the sequence of row groups that rowGroup reads from.
+func rowGroupsOf(rowGroup RowGroup) []RowGroup {
+	switch g := rowGroup.(type) {
+	case *emptyRowGroup:
+		return nil
+	case *multiRowGroup:
+		return g.rowGroups
+	default:
+		return []RowGroup{rowGroup}
+	}
+}
+
+// ColumnDictionary returns the distinct values found in the dictionary pages of
+// the column at path, across all the row groups of the file being read.
+//
+// The path identifies a leaf column by the names of the fields leading to it
+// (e.g. "address", "country"). Null values are never part of the result.
+//
+// Reading dictionaries is much cheaper than reading the column values, which
+// makes this method useful to enumerate the distinct values of low cardinality
+// columns (e.g. to build facet filters). When some of the column chunks are not
+// dictionary encoded, the method returns ok=false unless fullScan is true, in
+// which case the values of those column chunks are read to complete the result.
+//
+// The values are returned in the order they were first seen, and do not share
+// memory with the reader's underlying buffers.
+func (r *Reader) ColumnDictionary(fullScan bool, path ...string) (values []Value, ok bool, err error) {
+	return r.file.columnDictionary(fullScan, path)
+}
+
+// EstimateCardinality returns an estimate of the number of distinct values of
+// the column at path, across all the row groups of the file being read. The
+// path identifies a leaf column like in ColumnDictionary. Null values are not
+// counted.
+//
+// When all the column chunks are dictionary encoded, the estimate is computed
+// from the dictionaries only, without decoding the data pages. Otherwise, up
+// to sampleRows rows are read from the column, spread across row groups in
+// proportion of their sizes, and the number of distinct values of the column
+// is extrapolated from the sample. A sampleRows value of zero or less reads
+// all the rows. Note that the samples are taken from the beginning of each row
+// group, estimates may be less accurate on columns that are sorted.
+//
+// The result is intended to inform encoding decisions (e.g. whether to use
+// dictionary encoding when rewriting the column) and should not be relied on
+// as an exact count.
+func (r *Reader) EstimateCardinality(sampleRows int64, path ...string) (int64, error) {
+	return r.file.estimateCardinality(sampleRows, path)
+}
+
+// ScanColumn calls do with each value of the column at path, across all the
+// row groups of the file being read. The path identifies a leaf column like in
+// ColumnDictionary.
+//
+// Values are decoded page by page from the column chunks, without reading the
+// other columns nor reconstructing rows. Null values are passed to do as well,
+// their repetition and definition levels can be used to locate them in the
+// rows. Values may share memory with the reader's underlying buffers, they
+// must be cloned to be retained after do returns.
+func (r *Reader) ScanColumn(do func(Value), path ...string) error {
+	return r.file.scanColumn(do, path)
+}
+
+// ColumnEncodings returns the encodings used by the pages of the column at path
+// in the row group at index rowGroup of the file being read. The path is the
+// dotted path of a leaf column (e.g. "address.country").
+//
+// The encodings are read from the metadata of the column chunk, without reading
+// its pages: the encoding of the dictionary page comes first, followed by the
+// encodings of the data pages in the order they were first used. This is
+// useful to verify that the encodings requested when writing the file (e.g.
+// with the "dict" or "delta" struct tags) took effect. When the column chunk
+// metadata does not have page encoding stats, the list of encodings of the
+// column chunk is returned instead, which also contains the encodings of the
+// repetition and definition levels.
+//
+// An error is returned if the reader was not created from a File.
+func (r *Reader) ColumnEncodings(rowGroup int, path string) ([]encoding.Encoding, error) {
+	return r.file.columnEncodings(rowGroup, path)
+}
+
+// ColumnScanner is implemented by readers which can scan the values of their
+// columns, such as Reader and GenericReader.
+type ColumnScanner interface {
+	ScanColumn(do func(Value), path ...string) error
+}
+
+// ScanColumnFunc reads the values of the column at path from reader and
+// returns them converted to T by the convert function. See Reader.ScanColumn
+// for details on how the column is read.
+//
+// The function allows decoding columns into arbitrary Go types, for example:
+//
+//	seconds, err := parquet.ScanColumnFunc(reader, func(v parquet.Value) int64 {
+//		return time.UnixMilli(v.Int64()).Unix()
+//	}, "timestamp")
+func ScanColumnFunc[T any](reader ColumnScanner, convert func(Value) T, path ...string) ([]T, error) {
+	var values []T
+	err := reader.ScanColumn(func(v Value) {
+		values = append(values, convert(v))
+	}, path...)
+	return values, err
+}
+
 // Close closes the reader, preventing more rows from being read.
 func (r *Reader) Close() error {
 	if err := r.read.Close(); err != nil {
@@ -553,6 +1309,268 @@ func (r *reader) SeekToRow(rowIndex int64) error {
 	return nil
 }
 
+// lookupColumn returns the leaf column at path, and the row groups that its
+// column chunks are read from.
+func (r *reader) lookupColumn(path columnPath) (LeafColumn, []RowGroup, error) {
+	if r.rowGroup == nil {
+		return LeafColumn{}, nil, io.ErrClosedPipe
+	}
+	schema, rowGroups := r.schema, []RowGroup{r.rowGroup}
+	if r.file != nil {
+		schema, rowGroups = r.file.schema, r.file.RowGroups()
+	}
+
+	leaf, ok := schema.Lookup(path...)
+	if !ok {
+		return LeafColumn{}, nil, fmt.Errorf("column %q does not exist in the schema", path)
+	}
+	return leaf, rowGroups, nil
+}
+
+func (r *reader) columnDictionary(fullScan bool, path columnPath) ([]Value, bool, error) {
+	leaf, rowGroups, err := r.lookupColumn(path)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var values []Value
+	var buffer []byte
+	seen := make(map[string]struct{})
+
+	add := func(v Value) {
+		if v.IsNull() {
+			return
+		}
+		buffer = v.AppendBytes(buffer[:0])
+		if _, exists := seen[string(buffer)]; !exists {
+			seen[string(buffer)] = struct{}{}
+			values = append(values, v.Clone())
+		}
+	}
+
+	for _, rowGroup := range rowGroups {
+		chunk := rowGroup.ColumnChunks()[leaf.ColumnIndex]
+
+		dict, err := readColumnChunkDictionary(chunk)
+		if err != nil {
+			return nil, false, fmt.Errorf("reading dictionary of column %q: %w", path, err)
+		}
+
+		if dict != nil {
+			for i := range dict.Len() {
+				add(dict.Index(int32(i)))
+			}
+			continue
+		}
+
+		if !fullScan {
+			return nil, false, nil
+		}
+		if err := scanColumnChunkValues(chunk, add); err != nil {
+			return nil, false, fmt.Errorf("reading values of column %q: %w", path, err)
+		}
+	}
+
+	return values, true, nil
+}
+
+func (r *reader) scanColumn(do func(Value), path columnPath) error {
+	leaf, rowGroups, err := r.lookupColumn(path)
+	if err != nil {
+		return err
+	}
+
+	for _, rowGroup := range rowGroups {
+		if err := scanColumnChunkValues(rowGroup.ColumnChunks()[leaf.ColumnIndex], do); err != nil {
+			return fmt.Errorf("reading values of column %q: %w", path, err)
+		}
+	}
+	return nil
+}
+
+func (r *reader) columnEncodings(rowGroup int, path string) ([]encoding.Encoding, error) {
+	if r.rowGroup == nil {
+		return nil, io.ErrClosedPipe
+	}
+	if r.file == nil {
+		return nil, fmt.Errorf("cannot read encodings of column %q: reader was not created from a file", path)
+	}
+
+	leaf, ok := r.file.schema.Lookup(strings.Split(path, ".")...)
+	if !ok {
+		return nil, fmt.Errorf("column %q does not exist in the schema", path)
+	}
+	rowGroups := r.file.metadata.RowGroups
+	if rowGroup < 0 || rowGroup >= len(rowGroups) {
+		return nil, fmt.Errorf("row group index out of range: %d/%d", rowGroup, len(rowGroups))
+	}
+
+	metadata := &rowGroups[rowGroup].Columns[leaf.ColumnIndex].MetaData
+	encodings := make([]encoding.Encoding, 0, 2)
+	add := func(e format.Encoding) {
+		enc := LookupEncoding(e)
+		for _, seen := range encodings {
+			if seen.Encoding() == enc.Encoding() {
+				return
+			}
+		}
+		encodings = append(encodings, enc)
+	}
+
+	if len(metadata.EncodingStats) == 0 {
+		for _, e := range metadata.Encoding {
+			add(e)
+		}
+		return encodings, nil
+	}
+	for _, stats := range metadata.EncodingStats {
+		if stats.PageType == format.DictionaryPage {
+			add(stats.Encoding)
+		}
+	}
+	for _, stats := range metadata.EncodingStats {
+		if stats.PageType != format.DictionaryPage {
+			add(stats.Encoding)
+		}
+	}
+	return encodings, nil
+}
+
+func (r *reader) estimateCardinality(sampleRows int64, path columnPath) (int64, error) {
+	leaf, rowGroups, err := r.lookupColumn(path)
+	if err != nil {
+		return 0, err
+	}
+
+	dicts := make([]Dictionary, 0, len(rowGroups))
+	for _, rowGroup := range rowGroups {
+		dict, err := readColumnChunkDictionary(rowGroup.ColumnChunks()[leaf.ColumnIndex])
+		if err != nil {
+			return 0, fmt.Errorf("reading dictionary of column %q: %w", path, err)
+		}
+		if dict == nil {
+			break
+		}
+		dicts = append(dicts, dict)
+	}
+
+	if len(dicts) == len(rowGroups) {
+		h := new(hyperLogLog)
+		for _, dict := range dicts {
+			for i := range dict.Len() {
+				if v := dict.Index(int32(i)); !v.IsNull() {
+					h.add(v)
+				}
+			}
+		}
+		return h.estimate(), nil
+	}
+
+	totalRows := int64(0)
+	for _, rowGroup := range rowGroups {
+		totalRows += rowGroup.NumRows()
+	}
+	if sampleRows <= 0 || sampleRows > totalRows {
+		sampleRows = totalRows
+	}
+
+	var buffer []byte
+	var numValues, numRows int64
+	counts := make(map[uint64]int64)
+
+	for _, rowGroup := range rowGroups {
+		if totalRows == 0 {
+			break
+		}
+		limit := (sampleRows*rowGroup.NumRows() + totalRows - 1) / totalRows
+		n, err := sampleColumnChunkValues(rowGroup.ColumnChunks()[leaf.ColumnIndex], limit, func(v Value) {
+			if v.IsNull() {
+				return
+			}
+			buffer = v.AppendBytes(buffer[:0])
+			counts[xxhash.Sum64(buffer)]++
+			numValues++
+		})
+		if err != nil {
+			return 0, fmt.Errorf("reading values of column %q: %w", path, err)
+		}
+		numRows += n
+	}
+
+	distinct, singletons := int64(len(counts)), int64(0)
+	for _, count := range counts {
+		if count == 1 {
+			singletons++
+		}
+	}
+	if numRows >= totalRows {
+		return distinct, nil
+	}
+	// The total number of non-null values is extrapolated from the sample
+	// since the null counts of column chunks are not always available.
+	totalValues := int64(math.Round(float64(numValues) * float64(totalRows) / float64(numRows)))
+	return estimateDistinctCount(numValues, distinct, singletons, totalValues), nil
+}
+
+// readColumnChunkDictionary returns the dictionary of the column chunk, or nil
+// if the column chunk is not dictionary encoded.
+func readColumnChunkDictionary(chunk ColumnChunk) (Dictionary, error) {
+	pages := chunk.Pages()
+	defer pages.Close()
+
+	if p, ok := pages.(interface{ ReadDictionary() (Dictionary, error) }); ok {
+		return p.ReadDictionary()
+	}
+	return nil, nil
+}
+
+func scanColumnChunkValues(chunk ColumnChunk, do func(Value)) error {
+	r := NewColumnChunkValueReader(chunk)
+	defer r.Close()
+
+	values := make([]Value, defaultValueBufferSize)
+	for {
+		n, err := r.ReadValues(values)
+		for _, v := range values[:n] {
+			do(v)
+		}
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// sampleColumnChunkValues calls do with the values of the first rows of chunk,
+// up to limit rows, and returns the number of rows that were read.
+func sampleColumnChunkValues(chunk ColumnChunk, limit int64, do func(Value)) (int64, error) {
+	r := NewColumnChunkValueReader(chunk)
+	defer r.Close()
+
+	numRows := int64(0)
+	values := make([]Value, defaultValueBufferSize)
+	for {
+		n, err := r.ReadValues(values)
+		for _, v := range values[:n] {
+			if v.RepetitionLevel() == 0 {
+				if numRows == limit {
+					return numRows, nil
+				}
+				numRows++
+			}
+			do(v)
+		}
+		if err != nil {
+			if err == io.EOF {
+				return numRows, nil
+			}
+			return numRows, err
+		}
+	}
+}
+
 func (r *reader) Close() (err error) {
 	r.rowGroup = nil
 	if r.rows != nil {
@@ -564,6 +1582,7 @@ func (r *reader) Close() (err error) {
 var (
 	_ Rows                = (*Reader)(nil)
 	_ RowReaderWithSchema = (*Reader)(nil)
+	_ ColumnScanner       = (*Reader)(nil)
 
 	_ RowReader = (*reader)(nil)
 	_ RowSeeker = (*reader)(nil)
@@ -586,6 +1605,20 @@ func (r *Reader) File() FileView {
 	}
 }
 
+// RawMetadata returns the FileMetaData structure decoded from the footer of the
+// parquet file being read, exposing the schema elements, row groups, key/value
+// metadata and created_by fields as they were written in the file. This is
+// mostly useful to tooling inspecting the content of parquet files.
+//
+// The returned value is shared with the reader and must be treated as
+// read-only. The method returns nil if the Reader was not created with a File.
+func (r *Reader) RawMetadata() *format.FileMetaData {
+	if r.file.file == nil {
+		return nil
+	}
+	return r.file.file.Metadata()
+}
+
 func (r *readerFileView) Metadata() *format.FileMetaData {
 	if r.reader.file != nil {
 		return r.reader.file.Metadata()
@@ -13,9 +13,12 @@ import (
 	"reflect"
 	"slices"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/format"
 	"github.com/parquet-go/parquet-go/internal/quick"
 )
 
@@ -643,6 +646,738 @@ func TestReaderSeekToRow(t *testing.T) {
 	}
 }
 
+func TestReaderSeekToRowPages(t *testing.T) {
+	type rowType struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name"`
+	}
+
+	const numRows = 1000
+	rows := make([]rowType, numRows)
+	for i := range rows {
+		rows[i] = rowType{ID: int64(i), Name: fmt.Sprintf("name-%d", i)}
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[rowType](buf,
+		parquet.PageBufferSize(256),
+		parquet.MaxRowsPerRowGroup(300),
+	)
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, skipPageIndex := range []bool{false, true} {
+		t.Run(fmt.Sprintf("skipPageIndex=%t", skipPageIndex), func(t *testing.T) {
+			f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()), parquet.SkipPageIndex(skipPageIndex))
+			if err != nil {
+				t.Fatal(err)
+			}
+			reader := parquet.NewReader(f)
+			defer reader.Close()
+
+			buffer := make([]parquet.Row, 3)
+			for _, index := range []int64{500, 0, 299, 300, 997, 42, 42, 601} {
+				if err := reader.SeekToRow(index); err != nil {
+					t.Fatalf("seek to row %d: %v", index, err)
+				}
+				n, err := reader.ReadRows(buffer)
+				if n != len(buffer) {
+					t.Fatalf("reading rows at %d: n=%d err=%v", index, n, err)
+				}
+				for i, row := range buffer[:n] {
+					if id := row[0].Int64(); id != index+int64(i) {
+						t.Fatalf("row %d after seeking to %d: wrong id %d", i, index, id)
+					}
+				}
+			}
+
+			if err := reader.SeekToRow(numRows); err != nil {
+				t.Fatalf("seek to the end of the rows: %v", err)
+			}
+			if n, err := reader.ReadRows(buffer); n != 0 || err != io.EOF {
+				t.Errorf("reading rows at the end: n=%d err=%v", n, err)
+			}
+
+			for _, index := range []int64{-1, numRows + 1} {
+				if err := reader.SeekToRow(index); !errors.Is(err, parquet.ErrSeekOutOfRange) {
+					t.Errorf("seek to row %d: want=%v got=%v", index, parquet.ErrSeekOutOfRange, err)
+				}
+			}
+		})
+	}
+}
+
+func TestReaderReadRowAt(t *testing.T) {
+	type rowType struct {
+		ID   int64    `parquet:"id"`
+		Name string   `parquet:"name,dict"`
+		Tags []string `parquet:"tags,list"`
+	}
+
+	const numRows = 1000
+	rows := make([]rowType, numRows)
+	for i := range rows {
+		rows[i] = rowType{
+			ID:   int64(i),
+			Name: fmt.Sprintf("name-%d", i%7),
+			Tags: make([]string, i%4),
+		}
+		for j := range rows[i].Tags {
+			rows[i].Tags[j] = strconv.Itoa(i * j)
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[rowType](buf,
+		parquet.PageBufferSize(256),
+		parquet.MaxRowsPerRowGroup(300),
+	)
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, skipPageIndex := range []bool{false, true} {
+		t.Run(fmt.Sprintf("skipPageIndex=%t", skipPageIndex), func(t *testing.T) {
+			f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()), parquet.SkipPageIndex(skipPageIndex))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := make([]parquet.Row, numRows)
+			reader := parquet.NewReader(f)
+			defer reader.Close()
+			if n, err := reader.ReadRows(want); n != numRows {
+				t.Fatalf("reading all rows: n=%d err=%v", n, err)
+			}
+
+			prng := rand.New(rand.NewSource(0))
+			indexes := append([]int{numRows - 1, 0, 299, 300, 301, 599, 600}, prng.Perm(numRows)[:50]...)
+			for _, index := range indexes {
+				row, err := reader.ReadRowAt(int64(index))
+				if err != nil {
+					t.Fatalf("reading row %d: %v", index, err)
+				}
+				if !row.Equal(want[index]) {
+					t.Fatalf("row %d mismatch:\nwant: %v\ngot:  %v", index, want[index], row)
+				}
+			}
+
+			if _, err := reader.ReadRowAt(numRows); !errors.Is(err, parquet.ErrSeekOutOfRange) {
+				t.Errorf("reading row past the end: want=%v got=%v", parquet.ErrSeekOutOfRange, err)
+			}
+		})
+	}
+}
+
+func TestReaderColumnDictionary(t *testing.T) {
+	type rowType struct {
+		ID      int64  `parquet:"id"`
+		Country string `parquet:"country,dict"`
+		Name    string `parquet:"name"`
+	}
+
+	countries := []string{"FR", "US", "JP", "DE", "BR"}
+	rows := make([]rowType, 1000)
+	for i := range rows {
+		rows[i] = rowType{
+			ID:      int64(i),
+			Country: countries[(i*i)%len(countries)],
+			Name:    fmt.Sprintf("name-%d", i%3),
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[rowType](buf, parquet.MaxRowsPerRowGroup(300))
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	reader := parquet.NewGenericReader[rowType](bytes.NewReader(buf.Bytes()))
+	defer reader.Close()
+
+	if n := len(reader.File().RowGroups()); n < 2 {
+		t.Fatalf("expected multiple row groups, got %d", n)
+	}
+
+	distinct := func(values []parquet.Value) []string {
+		s := make([]string, len(values))
+		for i, v := range values {
+			s[i] = v.String()
+		}
+		slices.Sort(s)
+		return s
+	}
+
+	values, ok, err := reader.ColumnDictionary(false, "country")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatal("expected the dictionary of a dictionary encoded column to be available")
+	}
+	// Not all countries appear in the data, the dictionaries must cover exactly
+	// the distinct values that were written.
+	want := []string{"BR", "FR", "US"}
+	if got := distinct(values); !slices.Equal(got, want) {
+		t.Errorf("wrong dictionary values: want=%q got=%q", want, got)
+	}
+
+	if _, ok, err := reader.ColumnDictionary(false, "name"); err != nil {
+		t.Fatal(err)
+	} else if ok {
+		t.Error("expected ok=false for a column which is not dictionary encoded")
+	}
+
+	values, ok, err = reader.ColumnDictionary(true, "name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatal("expected ok=true when scanning columns without a dictionary")
+	}
+	if got, want := distinct(values), []string{"name-0", "name-1", "name-2"}; !slices.Equal(got, want) {
+		t.Errorf("wrong scanned values: want=%q got=%q", want, got)
+	}
+
+	if _, _, err := reader.ColumnDictionary(false, "missing"); err == nil {
+		t.Error("expected an error for a column which does not exist")
+	}
+}
+
+func TestScanColumnFunc(t *testing.T) {
+	type rowType struct {
+		ID        int64     `parquet:"id"`
+		Timestamp time.Time `parquet:"timestamp,timestamp(millisecond)"`
+		Tags      []string  `parquet:"tags,list"`
+	}
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	rows := make([]rowType, 1000)
+	for i := range rows {
+		rows[i] = rowType{
+			ID:        int64(i),
+			Timestamp: base.Add(time.Duration(i) * 1500 * time.Millisecond),
+			Tags:      []string{"a", "b"}[:i%3%2+1],
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[rowType](buf, parquet.MaxRowsPerRowGroup(300))
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	reader := parquet.NewGenericReader[rowType](bytes.NewReader(buf.Bytes()))
+	defer reader.Close()
+
+	seconds, err := parquet.ScanColumnFunc(reader, func(v parquet.Value) int64 {
+		return time.UnixMilli(v.Int64()).Unix()
+	}, "timestamp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(seconds) != len(rows) {
+		t.Fatalf("wrong number of values: want=%d got=%d", len(rows), len(seconds))
+	}
+	for i, row := range rows {
+		if seconds[i] != row.Timestamp.Unix() {
+			t.Fatalf("wrong value at index %d: want=%d got=%d", i, row.Timestamp.Unix(), seconds[i])
+		}
+	}
+
+	// Values of repeated columns are passed to the conversion function, so
+	// the result may have more values than there are rows.
+	tags, err := parquet.ScanColumnFunc(reader, func(v parquet.Value) string {
+		return v.String()
+	}, "tags", "list", "element")
+	if err != nil {
+		t.Fatal(err)
+	}
+	numTags := 0
+	for _, row := range rows {
+		numTags += len(row.Tags)
+	}
+	if len(tags) != numTags {
+		t.Errorf("wrong number of tags: want=%d got=%d", numTags, len(tags))
+	}
+
+	if _, err := parquet.ScanColumnFunc(reader, func(parquet.Value) int { return 0 }, "missing"); err == nil {
+		t.Error("expected an error for a column which does not exist")
+	}
+}
+
+func TestReaderEstimateCardinality(t *testing.T) {
+	type rowType struct {
+		ID       int64  `parquet:"id"`
+		Category string `parquet:"category,dict"`
+		Label    string `parquet:"label"`
+		Level    int32  `parquet:"level"`
+	}
+
+	prng := rand.New(rand.NewSource(0))
+	rows := make([]rowType, 100_000)
+	for i := range rows {
+		rows[i] = rowType{
+			ID:       int64(i),
+			Category: fmt.Sprintf("category-%d", prng.Intn(2000)),
+			Label:    fmt.Sprintf("label-%d", prng.Intn(5000)),
+			Level:    int32(prng.Intn(20)),
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[rowType](buf, parquet.MaxRowsPerRowGroup(30_000))
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	reader := parquet.NewGenericReader[rowType](bytes.NewReader(buf.Bytes()))
+	defer reader.Close()
+
+	tests := []struct {
+		path       string
+		sampleRows int64
+		want       int64
+		tolerance  float64
+	}{
+		{path: "category", sampleRows: 1000, want: 2000, tolerance: 0.02},
+		{path: "label", sampleRows: 0, want: 5000, tolerance: 0},
+		{path: "label", sampleRows: 50_000, want: 5000, tolerance: 0.05},
+		{path: "id", sampleRows: 10_000, want: 100_000, tolerance: 0.05},
+		{path: "level", sampleRows: 1000, want: 20, tolerance: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%s/%d", test.path, test.sampleRows), func(t *testing.T) {
+			got, err := reader.EstimateCardinality(test.sampleRows, test.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := math.Abs(float64(got-test.want)) / float64(test.want); diff > test.tolerance {
+				t.Errorf("estimate out of tolerance: want=%d got=%d (error=%.2f%%)", test.want, got, 100*diff)
+			}
+		})
+	}
+
+	if _, err := reader.EstimateCardinality(0, "missing"); err == nil {
+		t.Error("expected an error for a column which does not exist")
+	}
+}
+
+func TestReaderRawMetadata(t *testing.T) {
+	f, err := os.Open("testdata/alltypes_plain.parquet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	r := parquet.NewReader(f)
+	defer r.Close()
+
+	metadata := r.RawMetadata()
+	if metadata == nil {
+		t.Fatal("raw metadata is nil")
+	}
+	if metadata.Version != 1 {
+		t.Errorf("wrong version: want=1 got=%d", metadata.Version)
+	}
+	if metadata.NumRows != 8 {
+		t.Errorf("wrong number of rows: want=8 got=%d", metadata.NumRows)
+	}
+	if want := "impala version 1.3.0-INTERNAL (build 8a48ddb1eff84592b3fc06bc6f51ec120e1fffc9)"; metadata.CreatedBy != want {
+		t.Errorf("wrong created_by: want=%q got=%q", want, metadata.CreatedBy)
+	}
+	if len(metadata.Schema) != 12 {
+		t.Fatalf("wrong number of schema elements: want=12 got=%d", len(metadata.Schema))
+	}
+	if root, id := metadata.Schema[0], metadata.Schema[1]; root.Name != "schema" || root.NumChildren != 11 || id.Name != "id" {
+		t.Errorf("wrong schema elements: root=%+v id=%+v", root, id)
+	}
+	if len(metadata.RowGroups) != 1 {
+		t.Fatalf("wrong number of row groups: want=1 got=%d", len(metadata.RowGroups))
+	}
+	if rowGroup := metadata.RowGroups[0]; rowGroup.NumRows != 8 || len(rowGroup.Columns) != 11 {
+		t.Errorf("wrong row group: rows=%d columns=%d", rowGroup.NumRows, len(rowGroup.Columns))
+	} else if path := rowGroup.Columns[0].MetaData.PathInSchema; !slices.Equal(path, []string{"id"}) {
+		t.Errorf("wrong path of first column chunk: %q", path)
+	}
+
+	t.Run("key value metadata", func(t *testing.T) {
+		type Row struct {
+			ID int64 `parquet:"id"`
+		}
+		buf := new(bytes.Buffer)
+		w := parquet.NewGenericWriter[Row](buf, parquet.KeyValueMetadata("hello", "world"), parquet.CreatedBy("app", "1.2.3", "abc"))
+		if _, err := w.Write([]Row{{ID: 1}}); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		r := parquet.NewGenericReader[Row](bytes.NewReader(buf.Bytes()))
+		defer r.Close()
+
+		metadata := r.RawMetadata()
+		if !slices.ContainsFunc(metadata.KeyValueMetadata, func(kv format.KeyValue) bool {
+			return kv.Key == "hello" && kv.Value == "world"
+		}) {
+			t.Errorf("key/value metadata not found: %+v", metadata.KeyValueMetadata)
+		}
+		if want := "app version 1.2.3(build abc)"; metadata.CreatedBy != want {
+			t.Errorf("wrong created_by: want=%q got=%q", want, metadata.CreatedBy)
+		}
+	})
+
+	t.Run("row group reader", func(t *testing.T) {
+		buffer := parquet.NewBuffer(parquet.SchemaOf(struct{ ID int64 }{}))
+		if r := parquet.NewRowGroupReader(buffer); r.RawMetadata() != nil {
+			t.Error("raw metadata of row group reader is not nil")
+		}
+	})
+}
+
+func TestReaderRowGroupOffsets(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name"`
+	}
+
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i] = Row{ID: int64(i), Name: strconv.Itoa(i)}
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](buf, parquet.MaxRowsPerRowGroup(300))
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	file := bytes.NewReader(buf.Bytes())
+
+	r := parquet.NewGenericReader[Row](file)
+	defer r.Close()
+
+	metadata := r.RawMetadata()
+	want := make([]int64, len(metadata.RowGroups))
+	for i, offset := 1, int64(0); i < len(want); i++ {
+		offset += metadata.RowGroups[i-1].NumRows
+		want[i] = offset
+	}
+	if !slices.Equal(want, []int64{0, 300, 600, 900}) {
+		t.Fatalf("unexpected row group sizes: %+v", metadata.RowGroups)
+	}
+
+	offsets := r.RowGroupOffsets()
+	if !slices.Equal(offsets, want) {
+		t.Fatalf("wrong row group offsets: want=%d got=%d", want, offsets)
+	}
+
+	// Map a global row number to the row group holding it.
+	rowIndex := int64(742)
+	rowGroup, found := slices.BinarySearch(offsets, rowIndex)
+	if !found {
+		rowGroup--
+	}
+	if rowGroup != 2 || rowIndex-offsets[rowGroup] != 142 {
+		t.Errorf("wrong location of row %d: row group %d, offset %d", rowIndex, rowGroup, rowIndex-offsets[rowGroup])
+	}
+
+	t.Run("converted schema", func(t *testing.T) {
+		type ID struct {
+			ID int64 `parquet:"id"`
+		}
+		r := parquet.NewGenericReader[ID](file)
+		defer r.Close()
+		if got := r.RowGroupOffsets(); !slices.Equal(got, want) {
+			t.Errorf("wrong row group offsets: want=%d got=%d", want, got)
+		}
+	})
+
+	t.Run("selected row groups", func(t *testing.T) {
+		r := parquet.NewReader(file)
+		defer r.Close()
+		if err := r.ReadRowGroups([]int{3, 1}); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := r.RowGroupOffsets(), []int64{0, 100}; !slices.Equal(got, want) {
+			t.Errorf("wrong row group offsets: want=%d got=%d", want, got)
+		}
+	})
+
+	t.Run("skip corrupt row groups", func(t *testing.T) {
+		r := parquet.NewGenericReader[Row](file, parquet.SkipCorruptRowGroups(true))
+		defer r.Close()
+		if got := r.RowGroupOffsets(); !slices.Equal(got, want) {
+			t.Errorf("wrong row group offsets: want=%d got=%d", want, got)
+		}
+		if err := r.ReadRowGroups([]int{2, 0, 3}); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := r.RowGroupOffsets(), []int64{0, 300, 600}; !slices.Equal(got, want) {
+			t.Errorf("wrong row group offsets: want=%d got=%d", want, got)
+		}
+	})
+
+	t.Run("row group reader", func(t *testing.T) {
+		buffer := parquet.NewGenericBuffer[Row]()
+		buffer.Write(rows[:10])
+		r := parquet.NewRowGroupReader(buffer)
+		if got, want := r.RowGroupOffsets(), []int64{0}; !slices.Equal(got, want) {
+			t.Errorf("wrong row group offsets: want=%d got=%d", want, got)
+		}
+	})
+}
+
+func TestReaderSkipCorruptRowGroups(t *testing.T) {
+	type rowType struct {
+		ID int64 `parquet:"id"`
+	}
+
+	rows := make([]rowType, 300)
+	for i := range rows {
+		rows[i] = rowType{ID: int64(i)}
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[rowType](buf, parquet.MaxRowsPerRowGroup(100))
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	metadata := f.Metadata()
+	if n := len(metadata.RowGroups); n != 3 {
+		t.Fatalf("wrong number of row groups: want=3 got=%d", n)
+	}
+
+	// Flip the last byte of the column chunk in the second row group, which
+	// belongs to the data page and causes a checksum mismatch when reading it.
+	column := metadata.RowGroups[1].Columns[0].MetaData
+	data := bytes.Clone(buf.Bytes())
+	data[column.DataPageOffset+column.TotalCompressedSize-1] ^= 0xFF
+
+	if _, err := parquet.Read[rowType](bytes.NewReader(data), int64(len(data))); err == nil {
+		t.Fatal("expected reading the corrupted file to fail")
+	}
+	if _, err := parquet.Read[rowType](bytes.NewReader(buf.Bytes()), int64(buf.Len()), parquet.SkipCorruptRowGroups(true)); err != nil {
+		t.Fatalf("no row groups should be skipped when reading the original file: %v", err)
+	}
+
+	got, err := parquet.Read[rowType](bytes.NewReader(data), int64(len(data)), parquet.SkipCorruptRowGroups(true))
+	if !errors.Is(err, parquet.ErrCorrupted) {
+		t.Errorf("expected the error to wrap parquet.ErrCorrupted, got %v", err)
+	}
+	if want := append(slices.Clone(rows[:100]), rows[200:]...); !slices.Equal(got, want) {
+		t.Errorf("wrong rows recovered: want=%d rows got=%d rows", len(want), len(got))
+	}
+
+	reader := parquet.NewReader(bytes.NewReader(data), parquet.SkipCorruptRowGroups(true))
+	defer reader.Close()
+	numRows := 0
+	for {
+		n, err := reader.ReadRows(make([]parquet.Row, 10))
+		numRows += n
+		if err != nil {
+			if err != io.EOF {
+				t.Errorf("expected the reader to return io.EOF, got %v", err)
+			}
+			break
+		}
+	}
+	if numRows != 200 {
+		t.Errorf("wrong number of rows recovered: want=200 got=%d", numRows)
+	}
+	if err := reader.SkippedRowGroups(); !errors.Is(err, parquet.ErrCorrupted) {
+		t.Errorf("expected the skipped row groups to be reported, got %v", err)
+	}
+}
+
+func TestReaderReadRowGroups(t *testing.T) {
+	type rowType struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name"`
+	}
+
+	rows := make([]rowType, 300)
+	for i := range rows {
+		rows[i] = rowType{ID: int64(i), Name: fmt.Sprintf("row-%d", i)}
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[rowType](buf, parquet.MaxRowsPerRowGroup(100))
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	want := append(slices.Clone(rows[:100]), rows[200:]...)
+
+	t.Run("GenericReader", func(t *testing.T) {
+		reader := parquet.NewGenericReader[rowType](bytes.NewReader(buf.Bytes()))
+		defer reader.Close()
+
+		if n := len(reader.File().RowGroups()); n != 3 {
+			t.Fatalf("wrong number of row groups: want=3 got=%d", n)
+		}
+		if err := reader.ReadRowGroups([]int{0, 2}); err != nil {
+			t.Fatal(err)
+		}
+		if n := reader.NumRows(); n != int64(len(want)) {
+			t.Errorf("wrong number of rows: want=%d got=%d", len(want), n)
+		}
+
+		got := make([]rowType, len(rows))
+		n, err := reader.Read(got)
+		if err != nil && !errors.Is(err, io.EOF) {
+			t.Fatal(err)
+		}
+		if !slices.Equal(got[:n], want) {
+			t.Errorf("wrong rows read: want=%d rows got=%d rows", len(want), n)
+		}
+
+		if err := reader.ReadRowGroups([]int{1, 3}); err == nil {
+			t.Error("expected an error for a row group index out of bounds")
+		}
+	})
+
+	t.Run("Reader", func(t *testing.T) {
+		reader := parquet.NewReader(bytes.NewReader(buf.Bytes()))
+		defer reader.Close()
+
+		// Read a few rows first to verify that selecting row groups rewinds
+		// the reader.
+		var row rowType
+		if err := reader.Read(&row); err != nil {
+			t.Fatal(err)
+		}
+		if err := reader.ReadRowGroups([]int{2, 0}); err != nil {
+			t.Fatal(err)
+		}
+
+		var got []rowType
+		for {
+			if err := reader.Read(&row); err != nil {
+				if !errors.Is(err, io.EOF) {
+					t.Fatal(err)
+				}
+				break
+			}
+			got = append(got, row)
+		}
+		if want := append(slices.Clone(rows[200:]), rows[:100]...); !slices.Equal(got, want) {
+			t.Errorf("wrong rows read: want=%d rows got=%d rows", len(want), len(got))
+		}
+	})
+}
+
+func TestReaderStrictSchema(t *testing.T) {
+	type fileRow struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name"`
+	}
+	type extraField struct {
+		ID    int64  `parquet:"id"`
+		Name  string `parquet:"name"`
+		Email string `parquet:"email"`
+	}
+	type missingField struct {
+		ID int64 `parquet:"id"`
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, []fileRow{{ID: 1, Name: "one"}, {ID: 2, Name: "two"}}); err != nil {
+		t.Fatal(err)
+	}
+	input := bytes.NewReader(buf.Bytes())
+
+	expectSchemaMismatch := func(t *testing.T, newReader func()) {
+		t.Helper()
+		defer func() {
+			t.Helper()
+			err, _ := recover().(error)
+			if !errors.Is(err, parquet.ErrSchemaMismatch) {
+				t.Errorf("expected a panic with ErrSchemaMismatch, got %v", err)
+			}
+		}()
+		newReader()
+	}
+
+	t.Run("lenient", func(t *testing.T) {
+		got, err := parquet.Read[extraField](input, input.Size())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := []extraField{{ID: 1, Name: "one"}, {ID: 2, Name: "two"}}; !slices.Equal(got, want) {
+			t.Errorf("wrong rows read:\nwant = %+v\ngot  = %+v", want, got)
+		}
+
+		reader := parquet.NewGenericReader[missingField](input)
+		defer reader.Close()
+		rows := make([]missingField, 2)
+		if n, err := reader.Read(rows); n != 2 || (err != nil && !errors.Is(err, io.EOF)) {
+			t.Fatalf("reading rows: n=%d err=%v", n, err)
+		}
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		reader := parquet.NewGenericReader[fileRow](input, parquet.StrictSchema(true))
+		defer reader.Close()
+		rows := make([]fileRow, 2)
+		if n, err := reader.Read(rows); n != 2 || (err != nil && !errors.Is(err, io.EOF)) {
+			t.Fatalf("reading rows: n=%d err=%v", n, err)
+		}
+
+		expectSchemaMismatch(t, func() { parquet.NewGenericReader[extraField](input, parquet.StrictSchema(true)) })
+		expectSchemaMismatch(t, func() { parquet.NewGenericReader[missingField](input, parquet.StrictSchema(true)) })
+		expectSchemaMismatch(t, func() {
+			parquet.NewReader(input, parquet.SchemaOf(new(extraField)), parquet.StrictSchema(true))
+		})
+	})
+
+	t.Run("strict Reader.Read", func(t *testing.T) {
+		reader := parquet.NewReader(input, parquet.StrictSchema(true))
+		defer reader.Close()
+
+		if err := reader.Read(new(missingField)); !errors.Is(err, parquet.ErrSchemaMismatch) {
+			t.Errorf("expected ErrSchemaMismatch, got %v", err)
+		}
+		row := new(fileRow)
+		if err := reader.Read(row); err != nil {
+			t.Fatal(err)
+		}
+		if *row != (fileRow{ID: 1, Name: "one"}) {
+			t.Errorf("wrong row read: %+v", *row)
+		}
+	})
+}
+
 func TestSeekToRowNoDict(t *testing.T) {
 	type rowType struct {
 		Name utf8string `parquet:","` // no dictionary encoding
@@ -1030,3 +1765,280 @@ func printColumnLayout(t *testing.T, f *os.File) {
 		parquet.Release(p)
 	}
 }
+
+func TestReaderColumnEncodings(t *testing.T) {
+	type rowType struct {
+		ID    int64    `parquet:"id,delta"`
+		Name  string   `parquet:"name,dict"`
+		Tags  []string `parquet:"tags,list,dict"`
+		Score float64  `parquet:"score"`
+		Note  *string  `parquet:"note,optional,plain"`
+	}
+
+	rows := make([]rowType, 100)
+	for i := range rows {
+		rows[i] = rowType{
+			ID:    int64(i),
+			Name:  fmt.Sprintf("name-%d", i%5),
+			Tags:  []string{"a", "b"}[:i%2+1],
+			Score: float64(i),
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[rowType](buf)
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	reader := parquet.NewGenericReader[rowType](bytes.NewReader(buf.Bytes()))
+	defer reader.Close()
+
+	for _, test := range []struct {
+		path      string
+		encodings []format.Encoding
+	}{
+		{path: "id", encodings: []format.Encoding{format.DeltaBinaryPacked}},
+		{path: "name", encodings: []format.Encoding{format.Plain, format.RLEDictionary}},
+		{path: "tags.list.element", encodings: []format.Encoding{format.Plain, format.RLEDictionary}},
+		{path: "score", encodings: []format.Encoding{format.Plain}},
+		{path: "note", encodings: []format.Encoding{format.Plain}},
+	} {
+		encodings, err := reader.ColumnEncodings(0, test.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := make([]format.Encoding, len(encodings))
+		for i, enc := range encodings {
+			got[i] = enc.Encoding()
+		}
+		if !slices.Equal(got, test.encodings) {
+			t.Errorf("column %q: wrong encodings: want=%v got=%v", test.path, test.encodings, got)
+		}
+	}
+
+	if _, err := reader.ColumnEncodings(0, "missing"); err == nil {
+		t.Error("expected an error for a column which does not exist")
+	}
+	if _, err := reader.ColumnEncodings(1, "id"); err == nil {
+		t.Error("expected an error for a row group which does not exist")
+	}
+}
+
+func TestReaderRows(t *testing.T) {
+	type rowType struct {
+		ID   int64    `parquet:"id"`
+		Name string   `parquet:"name"`
+		Tags []string `parquet:"tags,list"`
+	}
+
+	rows := make([]rowType, 1000)
+	for i := range rows {
+		rows[i] = rowType{
+			ID:   int64(i),
+			Name: fmt.Sprintf("name-%d", i),
+			Tags: []string{"a", "b", "c"}[:i%3],
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[rowType](buf, parquet.MaxRowsPerRowGroup(300))
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	reader := parquet.NewReader(bytes.NewReader(buf.Bytes()))
+	defer reader.Close()
+	schema := parquet.SchemaOf(new(rowType))
+
+	check := func(t *testing.T, i int, row parquet.Row) {
+		t.Helper()
+		var got rowType
+		if err := schema.Reconstruct(&got, row); err != nil {
+			t.Fatal(err)
+		}
+		if got.ID != rows[i].ID || got.Name != rows[i].Name || !slices.Equal(got.Tags, rows[i].Tags) {
+			t.Fatalf("wrong row at index %d: want=%+v got=%+v", i, rows[i], got)
+		}
+	}
+
+	i := 0
+	for row, err := range reader.Rows() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		check(t, i, row)
+		i++
+	}
+	if i != len(rows) {
+		t.Fatalf("wrong number of rows: want=%d got=%d", len(rows), i)
+	}
+
+	// Exiting the loop early leaves the reader positioned after the last row
+	// that was yielded.
+	if err := reader.SeekToRow(0); err != nil {
+		t.Fatal(err)
+	}
+	i = 0
+	for row, err := range reader.Rows() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		check(t, i, row)
+		if i++; i == 10 {
+			break
+		}
+	}
+	next := make([]parquet.Row, 1)
+	if _, err := reader.ReadRows(next); err != nil {
+		t.Fatal(err)
+	}
+	check(t, 10, next[0])
+}
+
+func TestGenericReaderReadMatching(t *testing.T) {
+	type rowType struct {
+		ID       int64    `parquet:"id"`
+		Category string   `parquet:"category"`
+		Tags     []string `parquet:"tags,list"`
+	}
+
+	rows := make([]rowType, 1000)
+	for i := range rows {
+		rows[i] = rowType{
+			ID:       int64(i),
+			Category: fmt.Sprintf("category-%d", i%4),
+			Tags:     []string{"a", "b", "c"}[:i%3],
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[rowType](buf, parquet.MaxRowsPerRowGroup(300))
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	reader := parquet.NewGenericReader[rowType](bytes.NewReader(buf.Bytes()))
+	defer reader.Close()
+
+	predicate := func(row *rowType) bool { return row.Category == "category-1" }
+
+	var got []rowType
+	var totalScanned int
+	batch := make([]rowType, 64)
+	for {
+		matched, scanned, err := reader.ReadMatching(batch, predicate)
+		if matched > scanned {
+			t.Fatalf("more rows matched than scanned: matched=%d scanned=%d", matched, scanned)
+		}
+		for _, row := range batch[:matched] {
+			row.Tags = slices.Clone(row.Tags)
+			got = append(got, row)
+		}
+		totalScanned += scanned
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if matched != len(batch) {
+			t.Fatalf("batch not filled before the end of the rows: matched=%d", matched)
+		}
+	}
+
+	if totalScanned != len(rows) {
+		t.Errorf("wrong number of rows scanned: want=%d got=%d", len(rows), totalScanned)
+	}
+
+	var want []rowType
+	for _, row := range rows {
+		if predicate(&row) {
+			want = append(want, row)
+		}
+	}
+	if len(got) != len(want) {
+		t.Fatalf("wrong number of rows matched: want=%d got=%d", len(want), len(got))
+	}
+	for i := range want {
+		if got[i].ID != want[i].ID || got[i].Category != want[i].Category || !slices.Equal(got[i].Tags, want[i].Tags) {
+			t.Fatalf("wrong row at index %d: want=%+v got=%+v", i, want[i], got[i])
+		}
+	}
+}
+
+func TestGenericReaderReadIntoFunc(t *testing.T) {
+	type fileRow struct {
+		ID          int64  `parquet:"id"`
+		Temperature string `parquet:"temperature"`
+	}
+	type rowType struct {
+		ID          int64   `parquet:"id"`
+		Temperature string  `parquet:"temperature"`
+		Celsius     float64 `parquet:"-"`
+	}
+
+	rows := make([]fileRow, 100)
+	for i := range rows {
+		rows[i] = fileRow{ID: int64(i), Temperature: strconv.Itoa(i-50) + "C"}
+	}
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows); err != nil {
+		t.Fatal(err)
+	}
+
+	reader := parquet.NewGenericReader[rowType](bytes.NewReader(buf.Bytes()))
+	defer reader.Close()
+
+	column, _ := reader.Schema().Lookup("temperature")
+	parse := func(row parquet.Row, dst *rowType) error {
+		for _, v := range row {
+			if v.Column() == column.ColumnIndex {
+				c, err := strconv.ParseFloat(strings.TrimSuffix(v.String(), "C"), 64)
+				dst.Celsius = c
+				return err
+			}
+		}
+		return errors.New("missing temperature column")
+	}
+
+	got := make([]rowType, len(rows))
+	n, err := reader.ReadIntoFunc(got, parse)
+	if err != nil && err != io.EOF {
+		t.Fatal(err)
+	}
+	if n != len(rows) {
+		t.Fatalf("wrong number of rows read: want=%d got=%d", len(rows), n)
+	}
+	for i, row := range got {
+		want := rowType{ID: int64(i), Temperature: rows[i].Temperature, Celsius: float64(i - 50)}
+		if row != want {
+			t.Fatalf("wrong row at index %d: want=%+v got=%+v", i, want, row)
+		}
+	}
+
+	// Errors returned by the function stop the read.
+	reader.Reset()
+	failure := errors.New("failure")
+	n, err = reader.ReadIntoFunc(got, func(row parquet.Row, dst *rowType) error {
+		if dst.ID == 10 {
+			return failure
+		}
+		return nil
+	})
+	if err != failure {
+		t.Fatalf("wrong error: want=%v got=%v", failure, err)
+	}
+	if n != 10 {
+		t.Fatalf("wrong number of rows read before the error: want=10 got=%d", n)
+	}
+}
@@ -0,0 +1,145 @@
+package parquet
+
+import (
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/parquet-go/parquet-go/format"
+)
+
+// Rebalance writes to dst a copy of the parquet file src where the rows are
+// distributed in row groups of approximately targetRowGroupBytes bytes, which
+// gives files produced by heterogeneous sources predictable units of work for
+// programs reading their row groups in parallel.
+//
+// Sizes are measured with the compressed size of the column chunks recorded
+// in the metadata of src. Row groups larger than the target are split, and row
+// groups smaller than the target are merged with the following ones. Row
+// groups which are already within 25% of the target are copied as they are:
+// when their column chunks use the compression codecs and encodings of the
+// writer, the pages are copied without being decoded (see
+// Writer.WriteRowGroup). The sizes of the row groups assembled from the rows
+// of src are estimated from the average size of the rows of the row groups
+// they come from, the last row group of the output may be smaller than the
+// target.
+//
+// The schema and key/value metadata of src are preserved. The writer is
+// configured with the schema of src, which carries the compression codecs and
+// encodings of its columns, and the options passed to the function.
+func Rebalance(dst io.Writer, src *File, targetRowGroupBytes int64, options ...WriterOption) error {
+	if targetRowGroupBytes <= 0 {
+		return fmt.Errorf("invalid target row group size: %d", targetRowGroupBytes)
+	}
+
+	writerOptions := []WriterOption{src.Schema()}
+	for _, kv := range src.Metadata().KeyValueMetadata {
+		writerOptions = append(writerOptions, KeyValueMetadata(kv.Key, kv.Value))
+	}
+	w := NewWriter(dst, append(writerOptions, options...)...)
+
+	r := &rebalancer{
+		writer: w,
+		target: float64(targetRowGroupBytes),
+		buffer: make([]Row, defaultRowBufferSize),
+	}
+	minSize := r.target * 0.75
+	maxSize := r.target * 1.25
+
+	for i, rowGroup := range src.RowGroups() {
+		if rowGroup.NumRows() == 0 {
+			continue
+		}
+		size := float64(rowGroupCompressedSize(&src.Metadata().RowGroups[i]))
+
+		// Pending rows close enough to the target are flushed before a row
+		// group which would make them exceed it.
+		if r.pending >= minSize && r.pending+size > maxSize {
+			if err := r.flush(); err != nil {
+				return err
+			}
+		}
+		if r.pending == 0 && size >= minSize && size <= maxSize {
+			if _, err := w.WriteRowGroup(rowGroup); err != nil {
+				return fmt.Errorf("row group %d: %w", i, err)
+			}
+			continue
+		}
+		if err := r.writeRows(rowGroup, size); err != nil {
+			return fmt.Errorf("row group %d: %w", i, err)
+		}
+	}
+
+	return w.Close()
+}
+
+type rebalancer struct {
+	writer *Writer
+	target float64
+	// Estimated size of the rows buffered in the writer.
+	pending float64
+	buffer  []Row
+}
+
+func (r *rebalancer) flush() error {
+	r.pending = 0
+	return r.writer.Flush()
+}
+
+// writeRows writes the rows of rowGroup, flushing the row groups of the writer
+// when the estimated size of their rows reaches the target.
+func (r *rebalancer) writeRows(rowGroup RowGroup, size float64) error {
+	rows := rowGroup.Rows()
+	defer rows.Close()
+
+	numRows := rowGroup.NumRows()
+	rowSize := size / float64(numRows)
+
+	for remaining := numRows; remaining > 0; {
+		// Number of rows which fill the current row group up to the target.
+		n := int64(len(r.buffer))
+		if rowSize > 0 {
+			n = min(n, int64(math.Ceil((r.target-r.pending)/rowSize)))
+		}
+		n = max(min(n, remaining), 1)
+
+		read, err := rows.ReadRows(r.buffer[:n])
+		if read > 0 {
+			if _, err := r.writer.WriteRows(r.buffer[:read]); err != nil {
+				return err
+			}
+			remaining -= int64(read)
+			if r.pending += float64(read) * rowSize; r.pending >= r.target {
+				if err := r.flush(); err != nil {
+					return err
+				}
+			}
+		}
+		if err != nil {
+			if err == io.EOF && remaining == 0 {
+				break
+			}
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return err
+		}
+		if read == 0 {
+			return io.ErrNoProgress
+		}
+	}
+	return nil
+}
+
+// rowGroupCompressedSize returns the compressed size of the column chunks of
+// rowGroup, which some writers do not record in the row group metadata.
+func rowGroupCompressedSize(rowGroup *format.RowGroup) int64 {
+	if rowGroup.TotalCompressedSize > 0 {
+		return rowGroup.TotalCompressedSize
+	}
+	size := int64(0)
+	for i := range rowGroup.Columns {
+		size += rowGroup.Columns[i].MetaData.TotalCompressedSize
+	}
+	return size
+}
@@ -0,0 +1,99 @@
+package parquet_test
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestRebalance(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Data string `parquet:"data"`
+	}
+
+	// Row groups of heterogeneous sizes, as produced by writers flushing on
+	// different conditions.
+	prng := rand.New(rand.NewSource(0))
+	rowGroupSizes := []int{40, 2500, 10, 25, 380, 700, 5, 1200, 90}
+
+	var rows []Row
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](buf,
+		parquet.PageBufferSize(1024),
+		parquet.KeyValueMetadata("owner", "test"),
+	)
+	for _, size := range rowGroupSizes {
+		batch := make([]Row, size)
+		for i := range batch {
+			batch[i] = Row{ID: int64(len(rows) + i), Data: fmt.Sprintf("%016x", prng.Uint64())}
+		}
+		if _, err := w.Write(batch); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		rows = append(rows, batch...)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	src, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The size of the row group of 380 rows is used as target to exercise the
+	// copy of row groups which are already well sized.
+	target := src.Metadata().RowGroups[4].TotalCompressedSize
+
+	out := new(bytes.Buffer)
+	if err := parquet.Rebalance(out, src, target); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(out.Bytes()), int64(out.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rowGroups := f.Metadata().RowGroups
+	if len(rowGroups) < 2 {
+		t.Fatalf("too few row groups: %d", len(rowGroups))
+	}
+	// Sizes of the row groups assembled from rows are estimates, they must
+	// cluster around the target.
+	total := int64(0)
+	for i, rowGroup := range rowGroups[:len(rowGroups)-1] {
+		size := rowGroup.TotalCompressedSize
+		if size < target/2 || size > target*3/2 {
+			t.Errorf("row group %d: size too far from the target: want=%d got=%d (%d rows)", i, target, size, rowGroup.NumRows)
+		}
+		total += size
+	}
+	if mean := total / int64(len(rowGroups)-1); mean < target*9/10 || mean > target*11/10 {
+		t.Errorf("mean row group size too far from the target: want=%d got=%d", target, mean)
+	}
+	if size := rowGroups[len(rowGroups)-1].TotalCompressedSize; size > target*5/4 {
+		t.Errorf("last row group: size larger than the target: want<=%d got=%d", target*5/4, size)
+	}
+
+	if value, ok := f.Lookup("owner"); !ok || value != "test" {
+		t.Errorf("key/value metadata not preserved: %q", value)
+	}
+	got, err := parquet.Read[Row](bytes.NewReader(out.Bytes()), int64(out.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, rows) {
+		t.Error("rows of the rebalanced file do not match the source")
+	}
+
+	if err := parquet.Rebalance(new(bytes.Buffer), src, 0); err == nil {
+		t.Error("expected an error rebalancing with a target size of zero")
+	}
+}
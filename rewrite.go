@@ -0,0 +1,170 @@
+package parquet
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// RewriteColumn writes to dst a copy of the parquet file src where the values
+// of the column at path were passed through the transform function.
+//
+// The rewrite is done column by column: rows are never assembled, the row
+// groups, the schema and the key/value metadata of src are preserved. Only the
+// pages of the column at path are decoded and encoded again. The pages of the
+// other columns are copied as-is when their compression codec and encodings
+// match the writer options (see Writer.WriteRowGroup), and are re-encoded
+// otherwise. This makes the function well suited to redact or normalize the
+// values of a single column of large files.
+//
+// The transform function is only called with non-null values, and must return
+// values of the same kind. Returning a null value is only allowed if the column
+// is optional, the value is then written as a null at the same position. The
+// repetition and definition levels, and column index of the returned values
+// are ignored.
+func RewriteColumn(dst io.Writer, src *File, path []string, transform func(Value) Value, options ...WriterOption) error {
+	schema := src.Schema()
+	leaf, ok := schema.Lookup(path...)
+	if !ok {
+		return fmt.Errorf("column %q does not exist in the schema", columnPath(path))
+	}
+
+	writerOptions := []WriterOption{schema}
+	for _, kv := range src.Metadata().KeyValueMetadata {
+		writerOptions = append(writerOptions, KeyValueMetadata(kv.Key, kv.Value))
+	}
+	w := NewWriter(dst, append(writerOptions, options...)...)
+	columns := w.ColumnWriters()
+	// Like in WriteRowGroup, the values of all the columns must be seen when
+	// verifying the file, and files written with null bitmaps have more
+	// columns than src.
+	canCopy := !w.writer.verify && len(columns) == len(schema.Columns())
+
+	for i, rowGroup := range src.RowGroups() {
+		fileRowGroup := rowGroup.(*FileRowGroup)
+		chunks := make([]columnChunkCopy, len(fileRowGroup.columns))
+
+		for j, chunk := range fileRowGroup.columns {
+			if j != leaf.ColumnIndex && canCopy {
+				chunkCopy, ok, err := columns[j].prepareColumnChunkCopy(chunk.(*FileColumnChunk))
+				if err != nil {
+					return fmt.Errorf("row group %d: column %q: %w", i, columnPath(schema.Columns()[j]), err)
+				}
+				if ok {
+					chunks[j] = chunkCopy
+					continue
+				}
+			}
+			var fn func(Value) (Value, error)
+			if j == leaf.ColumnIndex {
+				fn = func(v Value) (Value, error) { return transformValue(leaf, v, transform) }
+			}
+			if err := rewriteColumnChunk(columns[j], chunk, fn); err != nil {
+				return fmt.Errorf("row group %d: column %q: %w", i, columnPath(schema.Columns()[j]), err)
+			}
+		}
+
+		if !canCopy {
+			if err := w.Flush(); err != nil {
+				return err
+			}
+			continue
+		}
+		// The transform may change the order of the values, the row groups do
+		// not declare the sorting columns of src.
+		if _, err := w.writer.copyRowGroup(fileRowGroup, nil, chunks); err != nil {
+			return fmt.Errorf("row group %d: %w", i, err)
+		}
+	}
+
+	return w.Close()
+}
+
+func transformValue(leaf LeafColumn, v Value, transform func(Value) Value) (Value, error) {
+	if v.IsNull() {
+		return v, nil
+	}
+	t := transform(v)
+	switch {
+	case t.IsNull():
+		if !leaf.Node.Optional() {
+			return t, fmt.Errorf("cannot write null value to a column which is not optional")
+		}
+		return t.Level(v.RepetitionLevel(), leaf.MaxDefinitionLevel-1, leaf.ColumnIndex), nil
+	case t.Kind() != v.Kind():
+		return t, fmt.Errorf("cannot write value of kind %s to a column of kind %s", t.Kind(), v.Kind())
+	default:
+		return t.Level(v.RepetitionLevel(), v.DefinitionLevel(), leaf.ColumnIndex), nil
+	}
+}
+
+// rewriteColumnChunk writes the values of chunk to w, passing them through
+// transform first if it is not nil.
+func rewriteColumnChunk(w *ColumnWriter, chunk ColumnChunk, transform func(Value) (Value, error)) error {
+	pages := chunk.Pages()
+	defer pages.Close()
+
+	buffer := make([]Value, defaultValueBufferSize)
+	values := make([]Value, 0, defaultValueBufferSize)
+
+	for {
+		p, err := pages.ReadPage()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return err
+		}
+
+		r := p.Values()
+		for {
+			n, err := r.ReadValues(buffer)
+			for _, v := range buffer[:n] {
+				if transform != nil {
+					tv, terr := transform(v)
+					if terr != nil {
+						return terr
+					}
+					v = tv
+				}
+				values = append(values, v)
+			}
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				return err
+			}
+		}
+
+		// Rows may span multiple pages, only the values of complete rows are
+		// written so the column writer does not flush pages in the middle of
+		// a row. The values of the last row are retained until the next page
+		// was read.
+		end := len(values)
+		for end > 0 && values[end-1].RepetitionLevel() != 0 {
+			end--
+		}
+		if end > 0 {
+			end--
+		}
+		if end > 0 {
+			if _, err := w.WriteRowValues(values[:end]); err != nil {
+				return err
+			}
+		}
+		n := copy(values, values[end:])
+		for i := range values[:n] {
+			values[i] = values[i].Clone()
+		}
+		clear(values[n:])
+		values = values[:n]
+		Release(p)
+	}
+
+	if len(values) > 0 {
+		_, err := w.WriteRowValues(values)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package parquet_test
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestRewriteColumn(t *testing.T) {
+	type Row struct {
+		ID    int64    `parquet:"id"`
+		Email *string  `parquet:"email,optional"`
+		Tags  []string `parquet:"tags"`
+	}
+
+	rows := make([]Row, 100)
+	for i := range rows {
+		rows[i].ID = int64(i)
+		rows[i].Tags = []string{}
+		if i%4 != 0 {
+			email := fmt.Sprintf("user%d@example.com", i)
+			rows[i].Email = &email
+		}
+		for j := range i % 5 {
+			rows[i].Tags = append(rows[i].Tags, fmt.Sprintf("tag%d", j))
+		}
+	}
+
+	// Rows are written one by one so the small page buffers produce multiple
+	// pages per column chunk.
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](buf,
+		parquet.MaxRowsPerRowGroup(30),
+		parquet.PageBufferSize(128),
+		parquet.KeyValueMetadata("owner", "test"),
+	)
+	for i := range rows {
+		if _, err := w.Write(rows[i : i+1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	src, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	redact := func(v parquet.Value) parquet.Value {
+		user, _, _ := strings.Cut(v.String(), "@")
+		if strings.HasSuffix(user, "7") {
+			return parquet.NullValue()
+		}
+		return parquet.ByteArrayValue([]byte("***@" + strings.Repeat("x", len(user))))
+	}
+
+	out := new(bytes.Buffer)
+	if err := parquet.RewriteColumn(out, src, []string{"email"}, redact); err != nil {
+		t.Fatal(err)
+	}
+
+	dst, err := parquet.OpenFile(bytes.NewReader(out.Bytes()), int64(out.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(dst.RowGroups()), len(src.RowGroups()); got != want {
+		t.Errorf("wrong number of row groups: want=%d got=%d", want, got)
+	}
+	if owner, _ := dst.Lookup("owner"); owner != "test" {
+		t.Errorf("key/value metadata not preserved: %q", owner)
+	}
+
+	// The pages of the columns which are not rewritten are copied as-is, they
+	// would otherwise be merged since the output uses larger page buffers.
+	for i := range src.Metadata().RowGroups {
+		for _, j := range []int{0, 2} {
+			a := src.Metadata().RowGroups[i].Columns[j].MetaData
+			b := dst.Metadata().RowGroups[i].Columns[j].MetaData
+			pagesA := buf.Bytes()[a.DataPageOffset : a.DataPageOffset+a.TotalCompressedSize]
+			pagesB := out.Bytes()[b.DataPageOffset : b.DataPageOffset+b.TotalCompressedSize]
+			if !bytes.Equal(pagesA, pagesB) {
+				t.Errorf("row group %d: pages of column %q were not copied", i, a.PathInSchema)
+			}
+		}
+	}
+
+	got, err := parquet.Read[Row](bytes.NewReader(out.Bytes()), int64(out.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := make([]Row, len(rows))
+	for i, row := range rows {
+		want[i] = row
+		if row.Email != nil {
+			if v := redact(parquet.ValueOf(*row.Email)); v.IsNull() {
+				want[i].Email = nil
+			} else {
+				email := v.String()
+				want[i].Email = &email
+			}
+		}
+	}
+	if len(got) != len(want) {
+		t.Fatalf("wrong number of rows: want=%d got=%d", len(want), len(got))
+	}
+	for i := range want {
+		if !reflect.DeepEqual(got[i], want[i]) {
+			t.Errorf("row %d mismatch:\nwant = %+v\ngot  = %+v", i, want[i], got[i])
+		}
+	}
+
+	t.Run("verify on close", func(t *testing.T) {
+		// Verifying the file requires all the columns to be re-encoded.
+		out := new(bytes.Buffer)
+		if err := parquet.RewriteColumn(out, src, []string{"email"}, redact, parquet.VerifyOnClose(true)); err != nil {
+			t.Fatal(err)
+		}
+		got, err := parquet.Read[Row](bytes.NewReader(out.Bytes()), int64(out.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Error("rows mismatch")
+		}
+	})
+
+	t.Run("missing column", func(t *testing.T) {
+		if err := parquet.RewriteColumn(new(bytes.Buffer), src, []string{"name"}, redact); err == nil {
+			t.Error("expected an error for a column which does not exist")
+		}
+	})
+
+	t.Run("wrong kind", func(t *testing.T) {
+		err := parquet.RewriteColumn(new(bytes.Buffer), src, []string{"id"}, func(parquet.Value) parquet.Value {
+			return parquet.ByteArrayValue([]byte("oops"))
+		})
+		if err == nil {
+			t.Error("expected an error when the transform changes the kind of values")
+		}
+	})
+
+	t.Run("null in required column", func(t *testing.T) {
+		err := parquet.RewriteColumn(new(bytes.Buffer), src, []string{"id"}, func(parquet.Value) parquet.Value {
+			return parquet.NullValue()
+		})
+		if err == nil {
+			t.Error("expected an error when writing null values to a required column")
+		}
+	})
+}
@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"slices"
+	"time"
+	"unsafe"
 )
 
 const (
@@ -455,7 +458,14 @@ func deconstructFuncOfOptional(columnIndex int16, node Node) (int16, deconstruct
 
 //go:noinline
 func deconstructFuncOfRepeated(columnIndex int16, node Node) (int16, deconstructFunc) {
-	columnIndex, deconstruct := deconstructFuncOf(columnIndex, Required(node))
+	return deconstructFuncOfRepeatedElem(columnIndex, Required(node))
+}
+
+// deconstructFuncOfRepeatedElem is like deconstructFuncOfRepeated but elem is
+// the node of the repeated elements, which may be optional when they are the
+// elements of a LIST.
+func deconstructFuncOfRepeatedElem(columnIndex int16, elem Node) (int16, deconstructFunc) {
+	columnIndex, deconstruct := deconstructFuncOf(columnIndex, elem)
 	return columnIndex, func(columns [][]Value, levels levels, value reflect.Value) {
 		if value.Kind() == reflect.Interface {
 			value = value.Elem()
@@ -485,8 +495,28 @@ func deconstructFuncOfRequired(columnIndex int16, node Node) (int16, deconstruct
 	}
 }
 
+// deconstructFuncOfRequiredPointer wraps deconstruct to dereference the Go
+// pointers mapped to required columns by the MakePointersRequired option.
+func deconstructFuncOfRequiredPointer(deconstruct deconstructFunc) deconstructFunc {
+	return func(columns [][]Value, levels levels, value reflect.Value) {
+		if value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				panic("cannot deconstruct nil pointer of type " + value.Type().String() + " into required parquet column")
+			}
+			value = value.Elem()
+		}
+		deconstruct(columns, levels, value)
+	}
+}
+
 func deconstructFuncOfList(columnIndex int16, node Node) (int16, deconstructFunc) {
-	return deconstructFuncOf(columnIndex, Repeated(listElementOf(node)))
+	elem := listElementOf(node)
+	if elem.Optional() {
+		// Wrapping the element in Repeated would discard the optional level,
+		// null elements must be written with the definition level of the list.
+		return deconstructFuncOfRepeatedElem(columnIndex, elem)
+	}
+	return deconstructFuncOf(columnIndex, Repeated(elem))
 }
 
 //go:noinline
@@ -497,6 +527,22 @@ func deconstructFuncOfMap(columnIndex int16, node Node) (int16, deconstructFunc)
 	keyType := keyValueElem.Field(0).Type
 	valueType := keyValueElem.Field(1).Type
 	nextColumnIndex, deconstruct := deconstructFuncOf(columnIndex, schemaOf(keyValueElem))
+
+	var compare func(reflect.Value, reflect.Value) int
+	var compareKeys func(reflect.Value, reflect.Value) int
+	sorted := isSortedMap(node)
+	switch {
+	case sorted:
+		compare = sortedMapKeyCompareFuncOf(keyType, fieldByName(keyValue, "key"))
+	case isOrderedMap(node):
+		compare = orderedMapKeyCompareFuncOf(keyType, fieldByName(keyValue, "key"))
+	}
+	if compare != nil {
+		compareKeys = func(a, b reflect.Value) int {
+			return compare(a.Convert(keyType), b.Convert(keyType))
+		}
+	}
+
 	return nextColumnIndex, func(columns [][]Value, levels levels, mapValue reflect.Value) {
 		if !mapValue.IsValid() || mapValue.Len() == 0 {
 			deconstruct(columns, levels, reflect.Value{})
@@ -510,7 +556,22 @@ func deconstructFuncOfMap(columnIndex int16, node Node) (int16, deconstructFunc)
 		k := elem.Field(0)
 		v := elem.Field(1)
 
-		for _, key := range mapValue.MapKeys() {
+		keys := mapValue.MapKeys()
+		if compareKeys != nil {
+			slices.SortFunc(keys, compareKeys)
+		}
+		if sorted {
+			for i := 1; i < len(keys); i++ {
+				if key := keys[i]; compareKeys(keys[i-1], key) == 0 {
+					panic(&deconstructError{columnIndex, func(path columnPath) error {
+						// The path of the key column ends with key_value.key.
+						return fmt.Errorf("cannot write map with duplicate key %v to sorted map column %q", key, path[:len(path)-2])
+					}})
+				}
+			}
+		}
+
+		for _, key := range keys {
 			k.Set(key.Convert(keyType))
 			v.Set(mapValue.MapIndex(key).Convert(valueType))
 			deconstruct(columns, levels, elem)
@@ -519,12 +580,73 @@ func deconstructFuncOfMap(columnIndex int16, node Node) (int16, deconstructFunc)
 	}
 }
 
-//go:noinline
+// orderedMapKeyCompareFuncOf is like sortedMapKeyCompareFuncOf but returns nil
+// when keys of type t cannot be ordered, which are then written in the
+// iteration order of Go maps.
+func orderedMapKeyCompareFuncOf(t reflect.Type, keyNode Node) (compare func(reflect.Value, reflect.Value) int) {
+	defer func() {
+		if recover() != nil {
+			compare = nil
+		}
+	}()
+	return sortedMapKeyCompareFuncOf(t, keyNode)
+}
+
+// sortedMapKeyCompareFuncOf returns a function comparing Go map keys of type t
+// according to the ordering rules of the parquet type of keyNode.
+func sortedMapKeyCompareFuncOf(t reflect.Type, keyNode Node) func(reflect.Value, reflect.Value) int {
+	if keyNode == nil || !keyNode.Leaf() {
+		panic("cannot sort map keys of type " + t.String() + " which are not parquet leaf columns")
+	}
+	keyType := keyNode.Type()
+	if keyType.Kind() == ByteArray || keyType.Kind() == FixedLenByteArray || keyType.LogicalType() != nil {
+		// Go types may not order values the same way as their parquet
+		// representation (e.g. unsigned integers, decimals), the keys are
+		// compared after being converted to parquet values.
+		kind, lt := keyType.Kind(), keyType.LogicalType()
+		valueOf := func(v reflect.Value) Value { return makeValue(kind, lt, v) }
+		if c := lookupConverter(t); c != nil {
+			valueOf = c.makeValue
+		}
+		return func(a, b reflect.Value) int {
+			return keyType.Compare(valueOf(a), valueOf(b))
+		}
+	}
+	if compare := compareFuncOf(t); compare != nil {
+		return compare
+	}
+	if t.Kind() == reflect.Bool {
+		return func(a, b reflect.Value) int {
+			switch {
+			case a.Bool() == b.Bool():
+				return 0
+			case a.Bool():
+				return +1
+			default:
+				return -1
+			}
+		}
+	}
+	panic("cannot sort map keys of type " + t.String())
+}
+
 func deconstructFuncOfGroup(columnIndex int16, node Node) (int16, deconstructFunc) {
+	nextColumnIndex, deconstruct := deconstructFuncOfFields(columnIndex, node)
+	if fields, ok := flatStructFieldsOf(columnIndex, node); ok {
+		return nextColumnIndex, deconstructFuncOfFlatStruct(node.GoType(), fields, deconstruct)
+	}
+	return nextColumnIndex, deconstruct
+}
+
+//go:noinline
+func deconstructFuncOfFields(columnIndex int16, node Node) (int16, deconstructFunc) {
 	fields := node.Fields()
 	funcs := make([]deconstructFunc, len(fields))
 	for i, field := range fields {
 		columnIndex, funcs[i] = deconstructFuncOf(columnIndex, field)
+		if isRequiredPointer(field) {
+			funcs[i] = deconstructFuncOfRequiredPointer(funcs[i])
+		}
 	}
 	return columnIndex, func(columns [][]Value, levels levels, value reflect.Value) {
 		if value.IsValid() {
@@ -539,6 +661,178 @@ func deconstructFuncOfGroup(columnIndex int16, node Node) (int16, deconstructFun
 	}
 }
 
+// flatStructField describes a field of a flat struct, which can be converted
+// to a parquet value by reading the memory of the struct at the field offset.
+type flatStructField struct {
+	offset      uintptr
+	index       int
+	kind        Kind
+	goKind      reflect.Kind
+	columnIndex int16
+}
+
+// flatStructFieldsOf returns the fields of node if it represents a Go struct
+// made only of required leaf columns of scalar types, which can be
+// deconstructed without the indirection of calling a function for each field.
+func flatStructFieldsOf(columnIndex int16, node Node) ([]flatStructField, bool) {
+	fields := node.Fields()
+	if len(fields) == 0 {
+		return nil, false
+	}
+	for _, field := range fields {
+		// Only nodes generated from Go structs have fields of this type, the
+		// Go type of other nodes may not be computable.
+		f, ok := field.(*structField)
+		if !ok || len(f.index) != 1 || !f.Leaf() || f.Optional() || f.Repeated() {
+			return nil, false
+		}
+	}
+
+	t := node.GoType()
+	if t.Kind() != reflect.Struct {
+		return nil, false
+	}
+	flat := make([]flatStructField, len(fields))
+
+	for i, field := range fields {
+		f := field.(*structField)
+		sf := t.Field(f.index[0])
+		if lookupConverter(sf.Type) != nil {
+			return nil, false
+		}
+		kind := f.Type().Kind()
+		if !isFlatStructFieldKind(kind, sf.Type.Kind()) {
+			return nil, false
+		}
+		if lt := f.Type().LogicalType(); lt != nil && lt.Decimal != nil && (sf.Type.Kind() == reflect.Uint32 || sf.Type.Kind() == reflect.Uint64) {
+			// The range of unsigned decimal values must be validated.
+			return nil, false
+		}
+		if _, scaled := f.Type().(goUnitScaler); scaled {
+			// The values must be converted to the unit of the column.
+			return nil, false
+		}
+		flat[i] = flatStructField{
+			offset:      sf.Offset,
+			index:       f.index[0],
+			kind:        kind,
+			goKind:      sf.Type.Kind(),
+			columnIndex: columnIndex + int16(i),
+		}
+	}
+
+	return flat, true
+}
+
+func isFlatStructFieldKind(kind Kind, goKind reflect.Kind) bool {
+	switch kind {
+	case Boolean:
+		return goKind == reflect.Bool
+	case Int32:
+		switch goKind {
+		case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+			return true
+		}
+	case Int64:
+		switch goKind {
+		case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Int,
+			reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uint, reflect.Uintptr:
+			return true
+		}
+	case Float:
+		return goKind == reflect.Float32
+	case Double:
+		return goKind == reflect.Float32 || goKind == reflect.Float64
+	case ByteArray:
+		return goKind == reflect.String
+	}
+	return false
+}
+
+//go:noinline
+func deconstructFuncOfFlatStruct(t reflect.Type, fields []flatStructField, deconstruct deconstructFunc) deconstructFunc {
+	return func(columns [][]Value, levels levels, value reflect.Value) {
+		if !value.IsValid() || value.Type() != t {
+			deconstruct(columns, levels, value)
+			return
+		}
+
+		var base unsafe.Pointer
+		if value.CanAddr() {
+			base = value.Addr().UnsafePointer()
+		}
+
+		for i := range fields {
+			f := &fields[i]
+			var v Value
+			if base != nil {
+				v = f.makeValue(unsafe.Add(base, f.offset))
+			} else {
+				v = makeValue(f.kind, nil, value.Field(f.index))
+			}
+			v.repetitionLevel = levels.repetitionLevel
+			v.definitionLevel = levels.definitionLevel
+			v.columnIndex = ^f.columnIndex
+			columns[f.columnIndex] = append(columns[f.columnIndex], v)
+		}
+	}
+}
+
+func (f *flatStructField) makeValue(p unsafe.Pointer) Value {
+	switch f.goKind {
+	case reflect.Bool:
+		return makeValueBoolean(*(*bool)(p))
+	case reflect.Float32:
+		if f.kind == Float {
+			return makeValueFloat(*(*float32)(p))
+		}
+		return makeValueDouble(float64(*(*float32)(p)))
+	case reflect.Float64:
+		return makeValueDouble(*(*float64)(p))
+	case reflect.String:
+		return makeValueString(f.kind, *(*string)(p))
+	}
+
+	var i int64
+	var u uint64
+	var unsigned bool
+	switch f.goKind {
+	case reflect.Int8:
+		i = int64(*(*int8)(p))
+	case reflect.Int16:
+		i = int64(*(*int16)(p))
+	case reflect.Int32:
+		i = int64(*(*int32)(p))
+	case reflect.Int64:
+		i = *(*int64)(p)
+	case reflect.Int:
+		i = int64(*(*int)(p))
+	case reflect.Uint8:
+		u, unsigned = uint64(*(*uint8)(p)), true
+	case reflect.Uint16:
+		u, unsigned = uint64(*(*uint16)(p)), true
+	case reflect.Uint32:
+		u, unsigned = uint64(*(*uint32)(p)), true
+	case reflect.Uint64:
+		u, unsigned = *(*uint64)(p), true
+	case reflect.Uint:
+		u, unsigned = uint64(*(*uint)(p)), true
+	case reflect.Uintptr:
+		u, unsigned = uint64(*(*uintptr)(p)), true
+	}
+
+	switch {
+	case f.kind == Int32 && unsigned:
+		return makeValueInt32(int32(u))
+	case f.kind == Int32:
+		return makeValueInt32(int32(i))
+	case unsigned:
+		return makeValueUint64(u)
+	default:
+		return makeValueInt64(i)
+	}
+}
+
 //go:noinline
 func deconstructFuncOfLeaf(columnIndex int16, node Node) (int16, deconstructFunc) {
 	if columnIndex > MaxColumnIndex {
@@ -547,12 +841,47 @@ func deconstructFuncOfLeaf(columnIndex int16, node Node) (int16, deconstructFunc
 	typ := node.Type()
 	kind := typ.Kind()
 	lt := typ.LogicalType()
+	epochType, hasEpoch := typ.(*epochTimestampType)
+	scaledType, isScaled := typ.(goUnitScaler)
+	decimalFloat, isFloatDecimal := typ.(*floatDecimalType)
+	maxDecimal := ^uint64(0)
+	if lt != nil && lt.Decimal != nil {
+		maxDecimal = decimalMaxUnsignedValue(int(lt.Decimal.Precision))
+	}
 	valueColumnIndex := ^columnIndex
+	converters := new(converterCache)
 	return columnIndex + 1, func(columns [][]Value, levels levels, value reflect.Value) {
 		v := Value{}
 
 		if value.IsValid() {
-			v = makeValue(kind, lt, value)
+			if c := converters.lookup(value.Type()); c != nil {
+				v = c.makeValue(value)
+			} else if hasEpoch && value.Type() == reflect.TypeOf(time.Time{}) {
+				v = makeValueInt64(epochType.unitsSinceEpoch(value.Interface().(time.Time)))
+			} else if isScaled && value.Kind() == reflect.Int64 {
+				if kind == Int32 {
+					v = makeValueInt32(int32(scaledType.fromGoUnit(value.Int())))
+				} else {
+					v = makeValueInt64(scaledType.fromGoUnit(value.Int()))
+				}
+			} else if isFloatDecimal && (value.Kind() == reflect.Float32 || value.Kind() == reflect.Float64) {
+				var err error
+				if v, err = decimalFloat.fromFloat(value.Float(), value.Type().Bits()); err != nil {
+					panic(&deconstructError{columnIndex, func(path columnPath) error {
+						return fmt.Errorf("writing value of column %s: %w", path, err)
+					}})
+				}
+			} else {
+				switch value.Kind() {
+				case reflect.Uint32, reflect.Uint64:
+					if u := value.Uint(); u > maxDecimal {
+						panic(&deconstructError{columnIndex, func(path columnPath) error {
+							return fmt.Errorf("value %d of column %s exceeds the precision of the decimal type", u, path)
+						}})
+					}
+				}
+				v = makeValue(kind, lt, value)
+			}
 		}
 
 		v.repetitionLevel = levels.repetitionLevel
@@ -616,6 +945,11 @@ func reconstructFuncOfOptional(columnIndex int16, node Node) (int16, reconstruct
 
 func setMakeSlice(v reflect.Value, n int) reflect.Value {
 	t := v.Type()
+	if t.Kind() == reflect.Array {
+		// Arrays cannot be resized, the elements past n are left zero.
+		v.SetZero()
+		return v
+	}
 	if t.Kind() == reflect.Interface {
 		t = reflect.TypeOf(([]any)(nil))
 	}
@@ -636,7 +970,13 @@ func setNullSlice(v reflect.Value) reflect.Value {
 
 //go:noinline
 func reconstructFuncOfRepeated(columnIndex int16, node Node) (int16, reconstructFunc) {
-	nextColumnIndex, reconstruct := reconstructFuncOf(columnIndex, Required(node))
+	return reconstructFuncOfRepeatedElem(columnIndex, Required(node))
+}
+
+// reconstructFuncOfRepeatedElem is the counterpart of
+// deconstructFuncOfRepeatedElem.
+func reconstructFuncOfRepeatedElem(columnIndex int16, elem Node) (int16, reconstructFunc) {
+	nextColumnIndex, reconstruct := reconstructFuncOf(columnIndex, elem)
 	return nextColumnIndex, func(value reflect.Value, levels levels, columns [][]Value) error {
 		levels.repetitionDepth++
 		levels.definitionLevel++
@@ -669,6 +1009,9 @@ func reconstructFuncOfRepeated(columnIndex int16, node Node) (int16, reconstruct
 			}
 		}
 
+		if value.Kind() == reflect.Array && n > value.Len() {
+			return fmt.Errorf("cannot reconstruct %d values into Go array of type %s", n, value.Type())
+		}
 		value = setMakeSlice(value, n)
 
 		for i := range n {
@@ -710,8 +1053,30 @@ func reconstructFuncOfRequired(columnIndex int16, node Node) (int16, reconstruct
 	}
 }
 
+// reconstructFuncOfRequiredPointer wraps reconstruct to allocate the Go
+// pointers mapped to required columns by the MakePointersRequired option.
+func reconstructFuncOfRequiredPointer(node Node, reconstruct reconstructFunc) reconstructFunc {
+	if lt := node.Type().LogicalType(); node.Leaf() && lt != nil && lt.Json != nil {
+		// JSON values are unmarshaled into pointers directly.
+		return reconstruct
+	}
+	return func(value reflect.Value, levels levels, columns [][]Value) error {
+		if value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				value.Set(reflect.New(value.Type().Elem()))
+			}
+			value = value.Elem()
+		}
+		return reconstruct(value, levels, columns)
+	}
+}
+
 func reconstructFuncOfList(columnIndex int16, node Node) (int16, reconstructFunc) {
-	return reconstructFuncOf(columnIndex, Repeated(listElementOf(node)))
+	elem := listElementOf(node)
+	if elem.Optional() {
+		return reconstructFuncOfRepeatedElem(columnIndex, elem)
+	}
+	return reconstructFuncOf(columnIndex, Repeated(elem))
 }
 
 //go:noinline
@@ -720,6 +1085,14 @@ func reconstructFuncOfMap(columnIndex int16, node Node) (int16, reconstructFunc)
 	keyValueType := keyValue.GoType()
 	keyValueElem := keyValueType.Elem()
 	nextColumnIndex, reconstruct := reconstructFuncOf(columnIndex, schemaOf(keyValueElem))
+
+	// The keys of sorted maps are validated when they are the first column of
+	// the key/value group, which is always the case for maps of Go types.
+	var keyType Type
+	if fields := keyValue.Fields(); isSortedMap(node) && fields[0].Name() == "key" && fields[0].Leaf() {
+		keyType = fields[0].Type()
+	}
+
 	return nextColumnIndex, func(value reflect.Value, levels levels, columns [][]Value) error {
 		levels.repetitionDepth++
 		levels.definitionLevel++
@@ -734,6 +1107,12 @@ func reconstructFuncOfMap(columnIndex int16, node Node) (int16, reconstructFunc)
 			return nil
 		}
 
+		if keyType != nil {
+			if err := checkSortedMapKeys(keyType, columns[0], levels.repetitionDepth); err != nil {
+				return err
+			}
+		}
+
 		values := make([][]Value, len(columns))
 		column := columns[0]
 		t := value.Type()
@@ -793,6 +1172,23 @@ func reconstructFuncOfMap(columnIndex int16, node Node) (int16, reconstructFunc)
 	}
 }
 
+// checkSortedMapKeys returns an error if the keys of a sorted map, which are
+// the values of the column starting a new repetition, are not sorted in
+// ascending order or contain duplicates.
+func checkSortedMapKeys(keyType Type, column []Value, repetitionDepth byte) error {
+	prev := -1
+	for i, key := range column {
+		if key.repetitionLevel > repetitionDepth {
+			continue
+		}
+		if prev >= 0 && keyType.Compare(column[prev], key) >= 0 {
+			return fmt.Errorf("keys of sorted map are not unique and in ascending order: %v is followed by %v", column[prev], key)
+		}
+		prev = i
+	}
+	return nil
+}
+
 //go:noinline
 func reconstructFuncOfGroup(columnIndex int16, node Node) (int16, reconstructFunc) {
 	fields := node.Fields()
@@ -803,6 +1199,9 @@ func reconstructFuncOfGroup(columnIndex int16, node Node) (int16, reconstructFun
 	for i, field := range fields {
 		columnIndex, funcs[i] = reconstructFuncOf(columnIndex, field)
 		columnOffsets[i] = columnIndex - firstColumnIndex
+		if isRequiredPointer(field) {
+			funcs[i] = reconstructFuncOfRequiredPointer(field, funcs[i])
+		}
 	}
 
 	return columnIndex, func(value reflect.Value, levels levels, columns [][]Value) error {
@@ -853,11 +1252,15 @@ func reconstructFuncOfGroup(columnIndex int16, node Node) (int16, reconstructFun
 //go:noinline
 func reconstructFuncOfLeaf(columnIndex int16, node Node) (int16, reconstructFunc) {
 	typ := node.Type()
+	converters := new(converterCache)
 	return columnIndex + 1, func(value reflect.Value, _ levels, columns [][]Value) error {
 		column := columns[0]
 		if len(column) == 0 {
 			return fmt.Errorf("no values found in parquet row for column %d", columnIndex)
 		}
+		if c := converters.lookup(value.Type()); c != nil {
+			return c.assignValue(value, column[0])
+		}
 		return typ.AssignValue(value, column[0])
 	}
 }
@@ -1,5 +1,10 @@
 package parquet
 
+import (
+	"fmt"
+	"reflect"
+)
+
 // RowBuilder is a type which helps build parquet rows incrementally by adding
 // values to columns.
 type RowBuilder struct {
@@ -7,6 +12,9 @@ type RowBuilder struct {
 	models  []Value
 	levels  []columnLevel
 	groups  []*columnGroup
+	schema  Node
+	leaves  map[string]leafColumn
+	pool    *ValuePool
 }
 
 type columnLevel struct {
@@ -35,6 +43,7 @@ func NewRowBuilder(schema Node) *RowBuilder {
 		columns: make([][]Value, n),
 		models:  make([]Value, n),
 		levels:  make([]columnLevel, n),
+		schema:  schema,
 	}
 	buffers := make([]Value, len(b.columns))
 	for i := range b.columns {
@@ -139,6 +148,111 @@ func (b *RowBuilder) Next(columnIndex int) {
 	}
 }
 
+// SetByName sets the value of the leaf column at the given path, replacing any
+// values previously added to the column.
+//
+// The name is the path of the column in the schema, with the names of nested
+// fields separated by dots (e.g. "address.city"). The Go value is converted
+// to a parquet value using the type of the column, and the definition and
+// repetition levels are assigned according to the position of the column in
+// the schema.
+//
+// A nil value leaves optional and repeated columns empty. For columns nested
+// in a repeated group, the value must be a slice or array holding the values
+// of each repetition.
+//
+// The method returns an error if the column does not exist in the schema, or
+// if the Go value cannot be converted to the type of the column.
+func (b *RowBuilder) SetByName(name string, value any) error {
+	if b.leaves == nil {
+		b.leaves = make(map[string]leafColumn)
+		forEachLeafColumnOf(b.schema, func(leaf leafColumn) {
+			b.leaves[leaf.path.String()] = leaf
+		})
+	}
+
+	leaf, ok := b.leaves[name]
+	if !ok {
+		return fmt.Errorf("cannot set value of column %q: no such column in the schema", name)
+	}
+	columnIndex := int(leaf.columnIndex)
+
+	v := reflect.ValueOf(value)
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		v = v.Elem()
+	}
+
+	var values []Value
+	switch {
+	case !v.IsValid():
+		if leaf.maxDefinitionLevel == 0 {
+			return fmt.Errorf("cannot set value of required column %q to nil", name)
+		}
+
+	case leaf.maxRepetitionLevel > 0:
+		if leaf.maxRepetitionLevel > 1 {
+			return fmt.Errorf("cannot set value of column %q: columns nested in multiple repeated groups must be built with Add and Next", name)
+		}
+		if (v.Kind() != reflect.Slice && v.Kind() != reflect.Array) || isByteSequence(v.Type()) {
+			return fmt.Errorf("cannot set value of repeated column %q from go value of type %s", name, v.Type())
+		}
+		values = make([]Value, v.Len())
+		for i := range values {
+			elem := v.Index(i)
+			for elem.Kind() == reflect.Pointer || elem.Kind() == reflect.Interface {
+				if elem.IsNil() {
+					return fmt.Errorf("cannot set value of repeated column %q: element %d is nil", name, i)
+				}
+				elem = elem.Elem()
+			}
+			var err error
+			if values[i], err = makeLeafValue(leaf.node, elem); err != nil {
+				return fmt.Errorf("cannot set value of column %q: %w", name, err)
+			}
+		}
+
+	default:
+		v, err := makeLeafValue(leaf.node, v)
+		if err != nil {
+			return fmt.Errorf("cannot set value of column %q: %w", name, err)
+		}
+		values = []Value{v}
+	}
+
+	clearValues(b.columns[columnIndex])
+	b.columns[columnIndex] = b.columns[columnIndex][:0]
+	b.levels[columnIndex].repetitionLevel = 0
+
+	for _, v := range values {
+		b.Add(columnIndex, v)
+	}
+	return nil
+}
+
+// makeLeafValue converts the Go value v to a parquet value of the leaf node's
+// type, returning an error if the conversion is not possible.
+func makeLeafValue(node Node, v reflect.Value) (value Value, err error) {
+	typ := node.Type()
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: go value of type %s to parquet %s", ErrInvalidConversion, v.Type(), typ)
+		}
+	}()
+	return makeValue(typ.Kind(), typ.LogicalType(), v), nil
+}
+
+func isByteSequence(t reflect.Type) bool {
+	return (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && t.Elem().Kind() == reflect.Uint8
+}
+
+// Build materializes the current state of b into a parquet row, and resets the
+// builder so it can be used to build the next row.
+func (b *RowBuilder) Build() Row {
+	row := b.Row()
+	b.Reset()
+	return row
+}
+
 // Reset clears the internal state of b, making it possible to reuse while
 // retaining the internal buffers.
 func (b *RowBuilder) Reset() {
@@ -151,12 +265,26 @@ func (b *RowBuilder) Reset() {
 	}
 }
 
+// SetValuePool configures b to allocate the rows returned by Row and Build
+// from pool, the rows then remain valid until the pool is reset. Passing nil
+// restores the default behavior of allocating rows on the heap.
+//
+// The byte arrays of values added to the builder are not copied to the pool,
+// programs may use ValuePool.Clone or ValuePool.ByteArrayValue to construct
+// values which share the lifetime of the rows.
+func (b *RowBuilder) SetValuePool(pool *ValuePool) {
+	b.pool = pool
+}
+
 // Row materializes the current state of b into a parquet row.
 func (b *RowBuilder) Row() Row {
 	numValues := 0
 	for _, column := range b.columns {
 		numValues += len(column)
 	}
+	if b.pool != nil {
+		return b.AppendRow(b.pool.AllocRow(numValues))
+	}
 	return b.AppendRow(make(Row, 0, numValues))
 }
 
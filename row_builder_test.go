@@ -1,7 +1,10 @@
 package parquet_test
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/parquet-go/parquet-go"
@@ -271,6 +274,100 @@ func TestRowBuilder(t *testing.T) {
 	}
 }
 
+func TestRowBuilderSetByName(t *testing.T) {
+	type Address struct {
+		City    string  `parquet:"city"`
+		Country *string `parquet:"country,optional"`
+	}
+	type Person struct {
+		Name    string   `parquet:"name"`
+		Age     int32    `parquet:"age"`
+		Email   *string  `parquet:"email,optional"`
+		Tags    []string `parquet:"tags"`
+		Address Address  `parquet:"address"`
+	}
+
+	schema := parquet.SchemaOf(Person{})
+	builder := parquet.NewRowBuilder(schema)
+
+	country := "France"
+	email := "luke@example.com"
+	want := []Person{
+		{
+			Name:    "Luke",
+			Age:     19,
+			Email:   &email,
+			Tags:    []string{"jedi", "pilot"},
+			Address: Address{City: "Paris", Country: &country},
+		},
+		{
+			Name:    "Leia",
+			Age:     19,
+			Tags:    []string{},
+			Address: Address{City: "Alderaan"},
+		},
+	}
+
+	set := func(name string, value any) {
+		t.Helper()
+		if err := builder.SetByName(name, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	set("name", "Luke")
+	set("age", int32(19))
+	set("email", &email)
+	set("tags", []string{"jedi", "pilot"})
+	set("address.city", "Paris")
+	set("address.country", country)
+	rows := []parquet.Row{builder.Build()}
+
+	set("name", "Anakin")
+	set("name", "Leia") // replaces the previous value
+	set("age", int32(19))
+	set("email", nil)
+	set("address.city", "Alderaan")
+	rows = append(rows, builder.Build())
+
+	buffer := new(bytes.Buffer)
+	writer := parquet.NewGenericWriter[Person](buffer, schema)
+	if _, err := writer.WriteRows(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := parquet.Read[Person](bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("rows mismatch\nwant = %+v\ngot  = %+v", want, got)
+	}
+
+	for _, test := range []struct {
+		name  string
+		value any
+	}{
+		{name: "unknown", value: "value"},
+		{name: "address", value: "value"},
+		{name: "name", value: nil},
+		{name: "age", value: "19"},
+		{name: "tags", value: "jedi"},
+		{name: "tags", value: []int{1, 2}},
+	} {
+		if err := builder.SetByName(test.name, test.value); err == nil {
+			t.Errorf("setting %q to %#v: expected an error", test.name, test.value)
+		}
+	}
+
+	if err := builder.SetByName("age", 19.5); !errors.Is(err, parquet.ErrInvalidConversion) {
+		t.Errorf("expected invalid conversion error, got %v", err)
+	}
+}
+
 func BenchmarkRowBuilderAdd(b *testing.B) {
 	builder := parquet.NewRowBuilder(parquet.Group{
 		"ids": parquet.Repeated(parquet.Int(64)),
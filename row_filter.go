@@ -0,0 +1,334 @@
+package parquet
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RowFilter is a predicate on the values of the columns of rows, which readers
+// use to skip the row groups and pages that cannot contain matching rows.
+//
+// Filters are constructed with the comparison functions EqualTo, Less,
+// LessOrEqual, Greater, and GreaterOrEqual, and combined with And and Or.
+// See Reader.SetFilter for details on how they are evaluated.
+type RowFilter interface {
+	// Binds the filter to the schema of the rows that it applies to.
+	bind(schema *Schema) (boundRowFilter, error)
+}
+
+// EqualTo returns a RowFilter matching the rows where the column at path is
+// equal to value.
+//
+// The path identifies a leaf column, with the names of nested fields separated
+// by dots (e.g. "address.country"). The value is converted to the type of the
+// column when the filter is set on a reader. Null values never match, and
+// rows of repeated columns match when any of their values does.
+func EqualTo(path string, value Value) RowFilter {
+	return &comparisonFilter{op: equalOp, path: path, value: value}
+}
+
+// Less returns a RowFilter matching the rows where the column at path is less
+// than value. See EqualTo for details.
+func Less(path string, value Value) RowFilter {
+	return &comparisonFilter{op: lessOp, path: path, value: value}
+}
+
+// LessOrEqual returns a RowFilter matching the rows where the column at path is
+// less than or equal to value. See EqualTo for details.
+func LessOrEqual(path string, value Value) RowFilter {
+	return &comparisonFilter{op: lessOrEqualOp, path: path, value: value}
+}
+
+// Greater returns a RowFilter matching the rows where the column at path is
+// greater than value. See EqualTo for details.
+func Greater(path string, value Value) RowFilter {
+	return &comparisonFilter{op: greaterOp, path: path, value: value}
+}
+
+// GreaterOrEqual returns a RowFilter matching the rows where the column at path
+// is greater than or equal to value. See EqualTo for details.
+func GreaterOrEqual(path string, value Value) RowFilter {
+	return &comparisonFilter{op: greaterOrEqualOp, path: path, value: value}
+}
+
+// And returns a RowFilter matching the rows which match all the filters passed
+// as arguments. With no arguments, the filter matches all rows.
+func And(filters ...RowFilter) RowFilter { return andFilter(filters) }
+
+// Or returns a RowFilter matching the rows which match at least one of the
+// filters passed as arguments. With no arguments, the filter matches no rows.
+func Or(filters ...RowFilter) RowFilter { return orFilter(filters) }
+
+// boundRowFilter is the representation of a RowFilter resolved against a
+// schema, with column indexes and values of the column types.
+type boundRowFilter interface {
+	// Returns the sorted ranges of rows of rowGroup which may match the
+	// filter according to the statistics and page index of its columns.
+	rowRanges(rowGroup RowGroup) []rowRange
+
+	// Returns true if the row matches the filter.
+	match(row Row) bool
+}
+
+type comparisonOp int
+
+const (
+	equalOp comparisonOp = iota
+	lessOp
+	lessOrEqualOp
+	greaterOp
+	greaterOrEqualOp
+)
+
+type comparisonFilter struct {
+	op    comparisonOp
+	path  string
+	value Value
+}
+
+func (f *comparisonFilter) bind(schema *Schema) (boundRowFilter, error) {
+	leaf, ok := schema.Lookup(strings.Split(f.path, ".")...)
+	if !ok {
+		return nil, fmt.Errorf("column %q does not exist in the schema", f.path)
+	}
+	if f.value.IsNull() {
+		return nil, fmt.Errorf("cannot compare column %q to a null value", f.path)
+	}
+	typ := leaf.Node.Type()
+	value, err := convertFilterValue(f.value, typ)
+	if err != nil {
+		return nil, fmt.Errorf("cannot compare column %q to %v: %w", f.path, f.value, err)
+	}
+	return &columnComparison{
+		op:     f.op,
+		column: leaf.ColumnIndex,
+		typ:    typ,
+		value:  value,
+	}, nil
+}
+
+func convertFilterValue(value Value, typ Type) (Value, error) {
+	if value.Kind() == typ.Kind() {
+		return value, nil
+	}
+	return typ.ConvertValue(value, extraColumnTypeOf(value))
+}
+
+type columnComparison struct {
+	op     comparisonOp
+	column int
+	typ    Type
+	value  Value
+}
+
+// compare returns true if the result of comparing a value to the filter value
+// satisfies the comparison.
+func (f *columnComparison) compare(cmp int) bool {
+	switch f.op {
+	case equalOp:
+		return cmp == 0
+	case lessOp:
+		return cmp < 0
+	case lessOrEqualOp:
+		return cmp <= 0
+	case greaterOp:
+		return cmp > 0
+	default:
+		return cmp >= 0
+	}
+}
+
+// mayMatch returns true if a sequence of values bounded by min and max may
+// contain values matching the comparison. Missing bounds are unknown, in which
+// case the values may match.
+func (f *columnComparison) mayMatch(typ Type, value, min, max Value) bool {
+	if min.IsNull() || max.IsNull() {
+		return true
+	}
+	switch f.op {
+	case equalOp:
+		return typ.Compare(min, value) <= 0 && typ.Compare(max, value) >= 0
+	case lessOp, lessOrEqualOp:
+		return f.compare(typ.Compare(min, value))
+	default:
+		return f.compare(typ.Compare(max, value))
+	}
+}
+
+func (f *columnComparison) match(row Row) bool {
+	for _, v := range row {
+		if v.Column() == f.column && !v.IsNull() && f.compare(f.typ.Compare(v, f.value)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *columnComparison) rowRanges(rowGroup RowGroup) []rowRange {
+	return f.appendRowRanges(nil, rowGroup.ColumnChunks()[f.column], 0, rowGroup.NumRows())
+}
+
+func (f *columnComparison) appendRowRanges(ranges []rowRange, chunk ColumnChunk, offset, numRows int64) []rowRange {
+	if numRows == 0 {
+		return ranges
+	}
+
+	if multi, ok := chunk.(*multiColumnChunk); ok {
+		for i, c := range multi.chunks {
+			n := multi.rowGroup.rowGroups[i].NumRows()
+			ranges = f.appendRowRanges(ranges, c, offset, n)
+			offset += n
+		}
+		return ranges
+	}
+
+	// The column chunk may not have the type of the schema the filter was
+	// bound to when the rows are converted, its statistics are compared to a
+	// value of the chunk type. The rows are kept when the conversion fails.
+	typ := chunk.Type()
+	value, err := convertFilterValue(f.value, typ)
+	if err != nil {
+		return appendRowRange(ranges, offset, offset+numRows)
+	}
+
+	if c, ok := chunk.(interface {
+		Bounds() (min, max Value, ok bool)
+	}); ok {
+		if min, max, ok := c.Bounds(); ok && !f.mayMatch(typ, value, min, max) {
+			return ranges
+		}
+	}
+
+	columnIndex, _ := chunk.ColumnIndex()
+	offsetIndex, _ := chunk.OffsetIndex()
+	if columnIndex == nil || offsetIndex == nil || columnIndex.NumPages() != offsetIndex.NumPages() {
+		return appendRowRange(ranges, offset, offset+numRows)
+	}
+
+	numPages := columnIndex.NumPages()
+	for i := 0; i < numPages; i++ {
+		if columnIndex.NullPage(i) || !f.mayMatch(typ, value, columnIndex.MinValue(i), columnIndex.MaxValue(i)) {
+			continue
+		}
+		end := numRows
+		if i+1 < numPages {
+			end = offsetIndex.FirstRowIndex(i + 1)
+		}
+		ranges = appendRowRange(ranges, offset+offsetIndex.FirstRowIndex(i), offset+end)
+	}
+	return ranges
+}
+
+type andFilter []RowFilter
+
+func (f andFilter) bind(schema *Schema) (boundRowFilter, error) {
+	filters, err := bindRowFilters(schema, f)
+	return boundAndFilter(filters), err
+}
+
+type boundAndFilter []boundRowFilter
+
+func (f boundAndFilter) rowRanges(rowGroup RowGroup) []rowRange {
+	ranges := []rowRange{{start: 0, end: rowGroup.NumRows()}}
+	for _, filter := range f {
+		ranges = intersectRowRanges(ranges, filter.rowRanges(rowGroup))
+	}
+	return ranges
+}
+
+func (f boundAndFilter) match(row Row) bool {
+	for _, filter := range f {
+		if !filter.match(row) {
+			return false
+		}
+	}
+	return true
+}
+
+type orFilter []RowFilter
+
+func (f orFilter) bind(schema *Schema) (boundRowFilter, error) {
+	filters, err := bindRowFilters(schema, f)
+	return boundOrFilter(filters), err
+}
+
+type boundOrFilter []boundRowFilter
+
+func (f boundOrFilter) rowRanges(rowGroup RowGroup) []rowRange {
+	var ranges []rowRange
+	for _, filter := range f {
+		ranges = unionRowRanges(ranges, filter.rowRanges(rowGroup))
+	}
+	return ranges
+}
+
+func (f boundOrFilter) match(row Row) bool {
+	for _, filter := range f {
+		if filter.match(row) {
+			return true
+		}
+	}
+	return false
+}
+
+func bindRowFilters(schema *Schema, filters []RowFilter) ([]boundRowFilter, error) {
+	bound := make([]boundRowFilter, len(filters))
+	for i, filter := range filters {
+		if filter == nil {
+			return nil, fmt.Errorf("filter %d of %d is nil", i, len(filters))
+		}
+		b, err := filter.bind(schema)
+		if err != nil {
+			return nil, err
+		}
+		bound[i] = b
+	}
+	return bound, nil
+}
+
+// rowRange is a range of row indexes, from start (inclusive) to end
+// (exclusive).
+type rowRange struct {
+	start int64
+	end   int64
+}
+
+// appendRowRange appends the range of rows from start to end to ranges, which
+// must be sorted, merging it with the last range when they are adjacent.
+func appendRowRange(ranges []rowRange, start, end int64) []rowRange {
+	if start >= end {
+		return ranges
+	}
+	if n := len(ranges); n > 0 && ranges[n-1].end >= start {
+		ranges[n-1].end = max(ranges[n-1].end, end)
+		return ranges
+	}
+	return append(ranges, rowRange{start: start, end: end})
+}
+
+func intersectRowRanges(a, b []rowRange) []rowRange {
+	var ranges []rowRange
+	for len(a) > 0 && len(b) > 0 {
+		ranges = appendRowRange(ranges, max(a[0].start, b[0].start), min(a[0].end, b[0].end))
+		if a[0].end < b[0].end {
+			a = a[1:]
+		} else {
+			b = b[1:]
+		}
+	}
+	return ranges
+}
+
+func unionRowRanges(a, b []rowRange) []rowRange {
+	ranges := make([]rowRange, 0, len(a)+len(b))
+	for len(a) > 0 || len(b) > 0 {
+		var r rowRange
+		if len(b) == 0 || (len(a) > 0 && a[0].start <= b[0].start) {
+			r, a = a[0], a[1:]
+		} else {
+			r, b = b[0], b[1:]
+		}
+		ranges = appendRowRange(ranges, r.start, r.end)
+	}
+	return ranges
+}
@@ -0,0 +1,329 @@
+package parquet_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestReaderSetFilter(t *testing.T) {
+	type Row struct {
+		ID   int64    `parquet:"id"`
+		Age  int32    `parquet:"age"`
+		Name string   `parquet:"name"`
+		Tags []string `parquet:"tags,list"`
+	}
+
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i] = Row{
+			ID:   int64(i),
+			Age:  int32(i / 10),
+			Name: "n" + strconv.Itoa(i),
+			Tags: []string{},
+		}
+		if i%100 == 7 {
+			rows[i].Tags = []string{"a", "z"}
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](buf, parquet.MaxRowsPerRowGroup(250), parquet.PageBufferSize(256))
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	idsOf := func(rows []Row) []int64 {
+		ids := make([]int64, len(rows))
+		for i, row := range rows {
+			ids[i] = row.ID
+		}
+		return ids
+	}
+	idsWhere := func(predicate func(Row) bool) []int64 {
+		var ids []int64
+		for _, row := range rows {
+			if predicate(row) {
+				ids = append(ids, row.ID)
+			}
+		}
+		return ids
+	}
+
+	tests := []struct {
+		scenario string
+		filter   parquet.RowFilter
+		want     []int64
+	}{
+		{
+			scenario: "greater",
+			filter:   parquet.Greater("age", parquet.ValueOf(30)),
+			want:     idsWhere(func(r Row) bool { return r.Age > 30 }),
+		},
+		{
+			scenario: "less or equal",
+			filter:   parquet.LessOrEqual("id", parquet.ValueOf(int64(12))),
+			want:     idsWhere(func(r Row) bool { return r.ID <= 12 }),
+		},
+		{
+			scenario: "equal to",
+			filter:   parquet.EqualTo("name", parquet.ValueOf("n642")),
+			want:     []int64{642},
+		},
+		{
+			scenario: "and",
+			filter: parquet.And(
+				parquet.GreaterOrEqual("age", parquet.ValueOf(20)),
+				parquet.Less("age", parquet.ValueOf(25)),
+			),
+			want: idsWhere(func(r Row) bool { return r.Age >= 20 && r.Age < 25 }),
+		},
+		{
+			scenario: "or",
+			filter: parquet.Or(
+				parquet.Less("age", parquet.ValueOf(2)),
+				parquet.EqualTo("id", parquet.ValueOf(int64(995))),
+			),
+			want: idsWhere(func(r Row) bool { return r.Age < 2 || r.ID == 995 }),
+		},
+		{
+			scenario: "repeated",
+			filter:   parquet.EqualTo("tags.list.element", parquet.ValueOf("z")),
+			want:     idsWhere(func(r Row) bool { return slices.Contains(r.Tags, "z") }),
+		},
+		{
+			scenario: "no match",
+			filter:   parquet.Greater("age", parquet.ValueOf(1000)),
+			want:     nil,
+		},
+	}
+
+	for _, skipPageIndex := range []bool{false, true} {
+		f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()), parquet.SkipPageIndex(skipPageIndex))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(f.RowGroups()) != 4 {
+			t.Fatalf("wrong number of row groups: want=4 got=%d", len(f.RowGroups()))
+		}
+
+		for _, test := range tests {
+			t.Run(test.scenario, func(t *testing.T) {
+				t.Run("GenericReader", func(t *testing.T) {
+					reader := parquet.NewGenericReader[Row](f)
+					defer reader.Close()
+					if err := reader.SetFilter(test.filter); err != nil {
+						t.Fatal(err)
+					}
+					got := make([]Row, 0, len(rows))
+					batch := make([]Row, 7)
+					for {
+						n, err := reader.Read(batch)
+						got = append(got, batch[:n]...)
+						if err != nil {
+							if err != io.EOF {
+								t.Fatal(err)
+							}
+							break
+						}
+					}
+					if ids := idsOf(got); !slices.Equal(ids, test.want) {
+						t.Errorf("wrong rows:\nwant: %v\ngot:  %v", test.want, ids)
+					}
+					for _, row := range got {
+						if !reflect.DeepEqual(row, rows[row.ID]) {
+							t.Errorf("wrong row: want=%+v got=%+v", rows[row.ID], row)
+						}
+					}
+				})
+
+				t.Run("Reader", func(t *testing.T) {
+					reader := parquet.NewReader(f)
+					defer reader.Close()
+					if err := reader.SetFilter(test.filter); err != nil {
+						t.Fatal(err)
+					}
+					var ids []int64
+					for {
+						var row Row
+						if err := reader.Read(&row); err != nil {
+							if err != io.EOF {
+								t.Fatal(err)
+							}
+							break
+						}
+						ids = append(ids, row.ID)
+					}
+					if !slices.Equal(ids, test.want) {
+						t.Errorf("wrong rows:\nwant: %v\ngot:  %v", test.want, ids)
+					}
+				})
+			})
+		}
+	}
+
+	t.Run("Rows", func(t *testing.T) {
+		reader := parquet.NewReader(bytes.NewReader(buf.Bytes()))
+		defer reader.Close()
+		if err := reader.SetFilter(parquet.Greater("age", parquet.ValueOf(50))); err != nil {
+			t.Fatal(err)
+		}
+		var ids []int64
+		for row, err := range reader.Rows() {
+			if err != nil {
+				t.Fatal(err)
+			}
+			ids = append(ids, row[0].Int64())
+			if len(ids) == 3 {
+				break
+			}
+		}
+		// The reader resumes after the last row yielded by the iterator.
+		rowbuf := make([]parquet.Row, 1)
+		if _, err := reader.ReadRows(rowbuf); err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, rowbuf[0][0].Int64())
+		if want := []int64{510, 511, 512, 513}; !slices.Equal(ids, want) {
+			t.Errorf("wrong rows: want=%v got=%v", want, ids)
+		}
+
+		// Removing the filter reads all the remaining rows.
+		if err := reader.SetFilter(nil); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := reader.ReadRows(rowbuf); err != nil {
+			t.Fatal(err)
+		}
+		if id := rowbuf[0][0].Int64(); id != 514 {
+			t.Errorf("wrong row after removing the filter: want=514 got=%d", id)
+		}
+	})
+
+	t.Run("SkipPages", func(t *testing.T) {
+		readAll := func(filter parquet.RowFilter) int64 {
+			counter := &countingReaderAt{ra: bytes.NewReader(buf.Bytes())}
+			f, err := parquet.OpenFile(counter, int64(buf.Len()), parquet.ReadBufferSize(64))
+			if err != nil {
+				t.Fatal(err)
+			}
+			reader := parquet.NewReader(f)
+			defer reader.Close()
+			if filter != nil {
+				if err := reader.SetFilter(filter); err != nil {
+					t.Fatal(err)
+				}
+			}
+			counter.reads = 0
+			for _, err := range reader.Rows() {
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			return counter.reads
+		}
+		all := readAll(nil)
+		filtered := readAll(parquet.Less("age", parquet.ValueOf(3)))
+		if filtered*4 > all {
+			t.Errorf("too many reads with a filter skipping most pages: all=%d filtered=%d", all, filtered)
+		}
+	})
+
+	t.Run("Read", func(t *testing.T) {
+		filter := parquet.Greater("age", parquet.ValueOf(30))
+		want := idsWhere(func(r Row) bool { return r.Age > 30 })
+
+		// Matching rows are read in batches which are retained between calls,
+		// reading them one by one costs as many reads as iterating over them.
+		readAll := func(read func(*parquet.Reader) []int64) ([]int64, int64) {
+			counter := &countingReaderAt{ra: bytes.NewReader(buf.Bytes())}
+			f, err := parquet.OpenFile(counter, int64(buf.Len()), parquet.ReadBufferSize(64))
+			if err != nil {
+				t.Fatal(err)
+			}
+			reader := parquet.NewReader(f)
+			defer reader.Close()
+			if err := reader.SetFilter(filter); err != nil {
+				t.Fatal(err)
+			}
+			counter.reads = 0
+			return read(reader), counter.reads
+		}
+
+		// The values are read into a type which differs from the schema of
+		// the file, the matching rows are converted.
+		type ID struct {
+			ID int64 `parquet:"id"`
+		}
+		ids, reads := readAll(func(reader *parquet.Reader) (ids []int64) {
+			for {
+				var row ID
+				if err := reader.Read(&row); err != nil {
+					if err != io.EOF {
+						t.Fatal(err)
+					}
+					return ids
+				}
+				ids = append(ids, row.ID)
+			}
+		})
+		if !slices.Equal(ids, want) {
+			t.Errorf("wrong rows:\nwant: %v\ngot:  %v", want, ids)
+		}
+		_, iterReads := readAll(func(reader *parquet.Reader) []int64 {
+			for _, err := range reader.Rows() {
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			return nil
+		})
+		if reads > iterReads {
+			t.Errorf("too many reads reading rows one by one: rows=%d read=%d", iterReads, reads)
+		}
+
+		// Repositioning the reader discards the rows read ahead.
+		reader := parquet.NewReader(bytes.NewReader(buf.Bytes()))
+		defer reader.Close()
+		if err := reader.SetFilter(filter); err != nil {
+			t.Fatal(err)
+		}
+		for _, step := range []struct{ seek, id int64 }{{-1, 310}, {900, 900}, {400, 400}, {-1, 401}} {
+			if step.seek >= 0 {
+				if err := reader.SeekToRow(step.seek); err != nil {
+					t.Fatal(err)
+				}
+			}
+			var row ID
+			if err := reader.Read(&row); err != nil {
+				t.Fatal(err)
+			}
+			if row.ID != step.id {
+				t.Errorf("wrong row after seeking to %d: want=%d got=%d", step.seek, step.id, row.ID)
+			}
+		}
+	})
+
+	t.Run("InvalidColumn", func(t *testing.T) {
+		reader := parquet.NewReader(bytes.NewReader(buf.Bytes()))
+		defer reader.Close()
+		if err := reader.SetFilter(parquet.EqualTo("missing", parquet.ValueOf(1))); err == nil {
+			t.Error("expected an error setting a filter on a missing column")
+		}
+		if err := reader.SetFilter(parquet.And(parquet.EqualTo("age", parquet.Value{}))); err == nil {
+			t.Error("expected an error setting a filter comparing to a null value")
+		}
+		var row Row
+		if err := reader.Read(&row); err != nil || row.ID != 0 {
+			t.Errorf("reader should not be filtered after an error: row=%+v err=%v", row, err)
+		}
+	})
+}
@@ -208,12 +208,35 @@ func NewRowGroupRowReader(rowGroup RowGroup) Rows {
 }
 
 func newRowGroupRows(schema *Schema, columns []ColumnChunk, bufferSize int) *rowGroupRows {
-	r := &rowGroupRows{
-		schema:   schema,
-		bufsize:  bufferSize,
-		buffers:  make([]Value, len(columns)*bufferSize),
-		columns:  make([]columnChunkRows, len(columns)),
-		rowIndex: -1,
+	r := &rowGroupRows{bufsize: bufferSize}
+	r.init(schema, columns)
+
+	// This finalizer is used to ensure that the goroutines started by calling
+	// init on the underlying page readers will be shutdown in the event that
+	// Close isn't called and the rowGroupRows object is garbage collected.
+	debug.SetFinalizer(r, func(r *rowGroupRows) { r.Close() })
+	return r
+}
+
+// init prepares r to read the given columns, reusing the buffers allocated for
+// previous column chunks when they are large enough. The method must only be
+// called on rowGroupRows which were never used, or which were closed.
+func (r *rowGroupRows) init(schema *Schema, columns []ColumnChunk) {
+	r.schema = schema
+	r.closed = false
+	r.rowIndex = -1
+
+	if size := len(columns) * r.bufsize; cap(r.buffers) < size {
+		r.buffers = make([]Value, size)
+	} else {
+		r.buffers = r.buffers[:size]
+		clear(r.buffers)
+	}
+	if cap(r.columns) < len(columns) {
+		r.columns = make([]columnChunkRows, len(columns))
+	} else {
+		r.columns = r.columns[:len(columns)]
+		clear(r.columns)
 	}
 
 	for i, column := range columns {
@@ -230,12 +253,6 @@ func newRowGroupRows(schema *Schema, columns []ColumnChunk, bufferSize int) *row
 		r.columns[i].reader.release = release
 		r.columns[i].reader.pages = column.Pages()
 	}
-
-	// This finalizer is used to ensure that the goroutines started by calling
-	// init on the underlying page readers will be shutdown in the event that
-	// Close isn't called and the rowGroupRows object is garbage collected.
-	debug.SetFinalizer(r, func(r *rowGroupRows) { r.Close() })
-	return r
 }
 
 func (r *rowGroupRows) clear() {
@@ -0,0 +1,160 @@
+package parquet
+
+import "io"
+
+// ShardRowGroup splits rowGroup into contiguous ranges of at most maxRows rows,
+// returning a row group for each range. The shards collectively cover all the
+// rows of rowGroup exactly once, in order.
+//
+// The function is intended for programs processing files that have few large
+// row groups, the shards can be read concurrently to increase the parallelism
+// of the processing. Shards share the underlying column chunks of rowGroup:
+// the pages of each shard are located with the offset index of the column
+// chunks when it is available, so the reads are bounded to the pages holding
+// the rows of the shard rather than the whole column chunks. Reading pages of
+// multiple shards concurrently requires that the column chunks support it,
+// which is the case for the row groups of parquet files opened with OpenFile.
+//
+// The column indexes, offset indexes, and bloom filters of the shards are
+// those of the column chunks of rowGroup. The number of values of repeated
+// columns in each shard is an estimate based on the proportion of rows.
+//
+// If maxRows is zero or negative, or if rowGroup has no more than maxRows
+// rows, the function returns a slice containing only rowGroup.
+func ShardRowGroup(rowGroup RowGroup, maxRows int64) []RowGroup {
+	numRows := rowGroup.NumRows()
+	if maxRows <= 0 || numRows <= maxRows {
+		return []RowGroup{rowGroup}
+	}
+
+	repeated := make([]bool, len(rowGroup.ColumnChunks()))
+	forEachLeafColumnOf(rowGroup.Schema(), func(leaf leafColumn) {
+		if int(leaf.columnIndex) < len(repeated) {
+			repeated[leaf.columnIndex] = leaf.maxRepetitionLevel > 0
+		}
+	})
+
+	numShards := (numRows + maxRows - 1) / maxRows
+	shards := make([]RowGroup, numShards)
+
+	for i := range shards {
+		// Distribute the rows evenly so the last shard is not much smaller
+		// than the others.
+		rowIndex := numRows * int64(i) / numShards
+		shard := &shardRowGroup{
+			base:     rowGroup,
+			rowIndex: rowIndex,
+			numRows:  numRows*int64(i+1)/numShards - rowIndex,
+		}
+		baseColumns := rowGroup.ColumnChunks()
+		columns := make([]shardColumnChunk, len(baseColumns))
+		shard.columns = make([]ColumnChunk, len(baseColumns))
+		for j, column := range baseColumns {
+			columns[j] = shardColumnChunk{
+				base:     column,
+				shard:    shard,
+				repeated: repeated[j],
+			}
+			shard.columns[j] = &columns[j]
+		}
+		shards[i] = shard
+	}
+
+	return shards
+}
+
+type shardRowGroup struct {
+	base     RowGroup
+	rowIndex int64
+	numRows  int64
+	columns  []ColumnChunk
+}
+
+func (g *shardRowGroup) NumRows() int64 { return g.numRows }
+
+func (g *shardRowGroup) ColumnChunks() []ColumnChunk { return g.columns }
+
+func (g *shardRowGroup) Schema() *Schema { return g.base.Schema() }
+
+func (g *shardRowGroup) SortingColumns() []SortingColumn { return g.base.SortingColumns() }
+
+func (g *shardRowGroup) Rows() Rows { return NewRowGroupRowReader(g) }
+
+type shardColumnChunk struct {
+	base     ColumnChunk
+	shard    *shardRowGroup
+	repeated bool
+}
+
+func (c *shardColumnChunk) Type() Type { return c.base.Type() }
+
+func (c *shardColumnChunk) Column() int { return c.base.Column() }
+
+func (c *shardColumnChunk) Pages() Pages {
+	pages := &shardPages{
+		base:  c.base.Pages(),
+		shard: c.shard,
+	}
+	pages.err = pages.SeekToRow(0)
+	return pages
+}
+
+func (c *shardColumnChunk) ColumnIndex() (ColumnIndex, error) { return c.base.ColumnIndex() }
+
+func (c *shardColumnChunk) OffsetIndex() (OffsetIndex, error) { return c.base.OffsetIndex() }
+
+func (c *shardColumnChunk) BloomFilter() BloomFilter { return c.base.BloomFilter() }
+
+func (c *shardColumnChunk) NumValues() int64 {
+	if !c.repeated {
+		return c.shard.numRows
+	}
+	baseRows := c.shard.base.NumRows()
+	if baseRows == 0 {
+		return 0
+	}
+	return c.base.NumValues() * c.shard.numRows / baseRows
+}
+
+// shardPages reads the pages of a column chunk holding the rows of a shard,
+// the last page is truncated to the end of the shard and no pages are read
+// beyond it.
+type shardPages struct {
+	base      Pages
+	shard     *shardRowGroup
+	remaining int64
+	err       error
+}
+
+func (p *shardPages) ReadPage() (Page, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	if p.remaining <= 0 {
+		return nil, io.EOF
+	}
+	page, err := p.base.ReadPage()
+	if err != nil {
+		return nil, err
+	}
+	if numRows := page.NumRows(); numRows > p.remaining {
+		page = page.Slice(0, p.remaining)
+	}
+	p.remaining -= page.NumRows()
+	return page, nil
+}
+
+func (p *shardPages) SeekToRow(rowIndex int64) error {
+	if rowIndex < 0 || rowIndex > p.shard.numRows {
+		return ErrSeekOutOfRange
+	}
+	p.remaining = p.shard.numRows - rowIndex
+	if p.remaining == 0 {
+		p.err = nil
+		return nil
+	}
+	p.err = p.base.SeekToRow(p.shard.rowIndex + rowIndex)
+	return p.err
+}
+
+func (p *shardPages) Close() error { return p.base.Close() }
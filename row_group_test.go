@@ -2,10 +2,13 @@ package parquet_test
 
 import (
 	"bytes"
+	"fmt"
 	"io"
 	"os"
 	"reflect"
 	"sort"
+	"strconv"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -258,3 +261,86 @@ func BenchmarkReadModeAsync(b *testing.B) {
 	b.ReportMetric(float64(r.reads.Load()), "reads")
 	b.ReportMetric(float64(r.bytes.Load()), "bytes")
 }
+
+func TestShardRowGroup(t *testing.T) {
+	type Row struct {
+		ID   int64    `parquet:"id"`
+		Name *string  `parquet:"name,optional"`
+		Tags []string `parquet:"tags,list"`
+	}
+
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i].ID = int64(i)
+		if i%3 != 0 {
+			name := strconv.Itoa(i)
+			rows[i].Name = &name
+		}
+		rows[i].Tags = []string{}
+		for j := range i % 4 {
+			rows[i].Tags = append(rows[i].Tags, strconv.Itoa(i*10+j))
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows, parquet.PageBufferSize(256)); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(f.RowGroups()); n != 1 {
+		t.Fatalf("wrong number of row groups: want=1 got=%d", n)
+	}
+
+	for _, maxRows := range []int64{0, 1, 99, 170, 999, 1000, 5000} {
+		t.Run(fmt.Sprintf("maxRows=%d", maxRows), func(t *testing.T) {
+			shards := parquet.ShardRowGroup(f.RowGroups()[0], maxRows)
+
+			var numRows int64
+			for _, shard := range shards {
+				if maxRows > 0 && shard.NumRows() > maxRows {
+					t.Errorf("shard has too many rows: max=%d got=%d", maxRows, shard.NumRows())
+				}
+				numRows += shard.NumRows()
+			}
+			if numRows != int64(len(rows)) {
+				t.Fatalf("wrong number of rows in shards: want=%d got=%d", len(rows), numRows)
+			}
+
+			results := make([][]Row, len(shards))
+			errs := make([]error, len(shards))
+			var wg sync.WaitGroup
+			for i, shard := range shards {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					r := parquet.NewGenericRowGroupReader[Row](shard)
+					defer r.Close()
+					results[i] = make([]Row, shard.NumRows())
+					n, err := r.Read(results[i])
+					if err != nil && err != io.EOF {
+						errs[i] = err
+					}
+					results[i] = results[i][:n]
+				}()
+			}
+			wg.Wait()
+
+			var got []Row
+			for i, err := range errs {
+				if err != nil {
+					t.Fatalf("shard %d: %v", i, err)
+				}
+				if int64(len(results[i])) != shards[i].NumRows() {
+					t.Errorf("shard %d: wrong number of rows read: want=%d got=%d", i, shards[i].NumRows(), len(results[i]))
+				}
+				got = append(got, results[i]...)
+			}
+			if !reflect.DeepEqual(got, rows) {
+				t.Error("rows read from the shards do not match the rows of the file")
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package parquet
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type flatStruct struct {
+	F00 bool
+	F01 int8
+	F02 int16
+	F03 int32
+	F04 int64
+	F05 int
+	F06 uint8
+	F07 uint16
+	F08 uint32
+	F09 uint64
+	F10 uint
+	F11 float32
+	F12 float64
+	F13 string
+	F14 time.Duration
+	F15 bool    `parquet:"f15"`
+	F16 int32   `parquet:"f16,delta"`
+	F17 int64   `parquet:"f17,timestamp"`
+	F18 string  `parquet:"f18,dict"`
+	F19 string  `parquet:"f19,enum"`
+	F20 float32 `parquet:"f20"`
+	F21 float64 `parquet:"f21"`
+	F22 uint32  `parquet:"f22"`
+	F23 uint64  `parquet:"f23"`
+	F24 int64   `parquet:"f24,decimal(2:18)"`
+	F25 string  `parquet:"f25,json"`
+	F26 int16   `parquet:"f26"`
+	F27 uint16  `parquet:"f27"`
+	F28 int     `parquet:"f28,plain"`
+	F29 string  `parquet:"f29,zstd"`
+}
+
+func makeFlatStruct(prng *rand.Rand) flatStruct {
+	s := func() string { return fmt.Sprintf("s%d", prng.Intn(1000)) }
+	return flatStruct{
+		F00: prng.Intn(2) == 0,
+		F01: int8(prng.Int()),
+		F02: int16(prng.Int()),
+		F03: int32(prng.Int()),
+		F04: prng.Int63() - prng.Int63(),
+		F05: prng.Int(),
+		F06: uint8(prng.Int()),
+		F07: uint16(prng.Int()),
+		F08: prng.Uint32(),
+		F09: prng.Uint64(),
+		F10: uint(prng.Uint64()),
+		F11: prng.Float32(),
+		F12: prng.NormFloat64(),
+		F13: s(),
+		F14: time.Duration(prng.Int63()),
+		F15: prng.Intn(2) == 0,
+		F16: prng.Int31(),
+		F17: prng.Int63(),
+		F18: s(),
+		F19: s(),
+		F20: -prng.Float32(),
+		F21: -prng.Float64(),
+		F22: prng.Uint32(),
+		F23: prng.Uint64(),
+		F24: prng.Int63n(1e6),
+		F25: `"` + s() + `"`,
+		F26: int16(prng.Int()),
+		F27: uint16(prng.Int()),
+		F28: -prng.Int(),
+		F29: s(),
+	}
+}
+
+func TestDeconstructFlatStruct(t *testing.T) {
+	schema := SchemaOf(flatStruct{})
+	if _, ok := flatStructFieldsOf(0, schema.root); !ok {
+		t.Fatal("expected the struct to be deconstructed with the flat struct fast path")
+	}
+	_, deconstruct := deconstructFuncOfFields(0, schema.root)
+	numColumns := len(schema.Columns())
+
+	prng := rand.New(rand.NewSource(0))
+	for i := 0; i < 100; i++ {
+		value := makeFlatStruct(prng)
+
+		columns := make([][]Value, numColumns)
+		deconstruct(columns, levels{}, reflect.ValueOf(value))
+		want := appendRow(nil, columns)
+
+		if got := schema.Deconstruct(nil, value); !got.Equal(want) {
+			t.Fatalf("rows mismatch when deconstructing a struct value:\nwant = %+v\ngot  = %+v", want, got)
+		}
+		if got := schema.Deconstruct(nil, &value); !got.Equal(want) {
+			t.Fatalf("rows mismatch when deconstructing a struct pointer:\nwant = %+v\ngot  = %+v", want, got)
+		}
+	}
+}
+
+func TestDeconstructFlatStructFallback(t *testing.T) {
+	type nested struct {
+		A int64
+		B string
+	}
+	tests := []struct {
+		scenario string
+		value    any
+		flat     bool
+	}{
+		{scenario: "optional field", value: struct {
+			A int64
+			B *string `parquet:",optional"`
+		}{}},
+		{scenario: "repeated field", value: struct {
+			A int64
+			B []int32
+		}{}},
+		{scenario: "nested group", value: struct {
+			A int64
+			B nested
+		}{}},
+		{scenario: "time field", value: struct {
+			A int64
+			B time.Time
+		}{}},
+		{scenario: "uuid field", value: struct {
+			A string `parquet:",uuid"`
+		}{}},
+		{scenario: "flat", value: nested{}, flat: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			schema := SchemaOf(test.value)
+			if _, flat := flatStructFieldsOf(0, schema.root); flat != test.flat {
+				t.Errorf("wrong fast path selection: want=%t got=%t", test.flat, flat)
+			}
+		})
+	}
+}
+
+func BenchmarkDeconstructFlatStruct(b *testing.B) {
+	schema := SchemaOf(flatStruct{})
+	value := makeFlatStruct(rand.New(rand.NewSource(0)))
+
+	b.Run("generic", func(b *testing.B) {
+		_, deconstruct := deconstructFuncOfFields(0, schema.root)
+		columns := make([][]Value, len(schema.Columns()))
+		v := reflect.ValueOf(&value).Elem()
+		for i := 0; i < b.N; i++ {
+			for j := range columns {
+				columns[j] = columns[j][:0]
+			}
+			deconstruct(columns, levels{}, v)
+		}
+	})
+
+	b.Run("flat", func(b *testing.B) {
+		_, deconstruct := deconstructFuncOfGroup(0, schema.root)
+		columns := make([][]Value, len(schema.Columns()))
+		v := reflect.ValueOf(&value).Elem()
+		for i := 0; i < b.N; i++ {
+			for j := range columns {
+				columns[j] = columns[j][:0]
+			}
+			deconstruct(columns, levels{}, v)
+		}
+	})
+
+	b.Run("Schema.Deconstruct", func(b *testing.B) {
+		row := Row{}
+		for i := 0; i < b.N; i++ {
+			row = schema.Deconstruct(row[:0], &value)
+		}
+	})
+}
@@ -1,9 +1,12 @@
 package parquet
 
 import (
+	"cmp"
+	"encoding/json"
 	"fmt"
 	"math"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -11,8 +14,12 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/parquet-go/parquet-go/compress"
+	"github.com/parquet-go/parquet-go/compress/brotli"
+	"github.com/parquet-go/parquet-go/compress/gzip"
+	"github.com/parquet-go/parquet-go/compress/zstd"
 	"github.com/parquet-go/parquet-go/deprecated"
 	"github.com/parquet-go/parquet-go/encoding"
+	"golang.org/x/sys/cpu"
 )
 
 // Schema represents a parquet schema created from a Go value.
@@ -66,24 +73,36 @@ func (v *onceValue[T]) load(f func() *T) *T {
 //
 //	optional  | make the parquet column optional
 //	snappy    | sets the parquet column compression codec to snappy
-//	gzip      | sets the parquet column compression codec to gzip
-//	brotli    | sets the parquet column compression codec to brotli
+//	gzip      | sets the parquet column compression codec to gzip, gzip(n) sets the level from 0 to 9
+//	brotli    | sets the parquet column compression codec to brotli, brotli(n) sets the quality from 0 to 11
 //	lz4       | sets the parquet column compression codec to lz4
-//	zstd      | sets the parquet column compression codec to zstd
+//	zstd      | sets the parquet column compression codec to zstd, zstd(n) selects the encoder level from 1 to 22
 //	plain     | enables the plain encoding (no-op default)
 //	dict      | enables dictionary encoding on the parquet column
-//	delta     | enables delta encoding on the parquet column
+//	delta     | enables delta encoding on the parquet column, DELTA_BINARY_PACKED for integers and DELTA_BYTE_ARRAY for strings and byte arrays
+//	deltalength | for string and []byte types, use the DELTA_LENGTH_BYTE_ARRAY encoding
 //	list      | for slice types, use the parquet LIST logical type
 //	enum      | for string types, use the parquet ENUM logical type
 //	bytes     | for string types, use no parquet logical type
 //	string    | for []byte types, use the parquet STRING logical type
 //	uuid      | for string and [16]byte types, use the parquet UUID logical type
-//	decimal   | for int32, int64 and [n]byte types, use the parquet DECIMAL logical type
+//	float16   | for float32 types, use the parquet FLOAT16 logical type, storing half precision values
+//	decimal   | for int32, int64, uint32, uint64, float32, float64 and [n]byte types, use the parquet DECIMAL logical type
 //	date      | for int32 types use the DATE logical type
-//	time      | for int32 and int64 types use the TIME logical type
+//	time      | for int32, int64 and TimeOfDay types use the TIME logical type
 //	timestamp | for int64 types use the TIMESTAMP logical type with, by default, millisecond precision
+//	duration  | for time.Duration types, store INT64 values in the unit given as argument (nanosecond by default)
 //	split     | for float32/float64, use the BYTE_STREAM_SPLIT encoding
+//	bitpacked | for bool types, use the bit-packed PLAIN encoding, even when another default encoding is configured for booleans
 //	id(n)     | where n is int denoting a column field id. Example id(2) for a column with field id of 2
+//	fieldid(n)| alias of id(n)
+//	fixed     | for arrays of numbers, pack the values in little-endian order in a FIXED_LEN_BYTE_ARRAY instead of a repeated column (always the case for [n]byte)
+//	nostats   | disables statistics on the parquet column (or all the columns of a group)
+//	index     | writes page indexes only for the columns declared with this option (or the columns of a group)
+//	noindex   | disables the column and offset indexes of the parquet column (or all the columns of a group)
+//	group     | for embedded structs, write the struct as a group instead of flattening its fields into the parent
+//	bloom     | writes a split block bloom filter for the parquet column (or all the columns of a group)
+//	ndv       | counts the exact number of distinct values of the parquet column (or all the columns of a group), see File.DistinctCount
 //
 // # The date logical type is an int32 value of the number of days since the unix epoch
 //
@@ -105,6 +124,34 @@ func (v *onceValue[T]) load(f func() *T) *T {
 //	  TimestampMicrosNotAdjusted int64 `parquet:"timestamp_micros_not_adjusted,timestamp(microsecond:local)"
 //	}
 //
+// Timestamps of time.Time fields may be stored relative to an epoch other
+// than the Unix epoch by adding an epoch argument, expressed as a date, after
+// the other arguments of the timestamp tag. Readers must use the same epoch to
+// get the original values back; the epoch is recorded in the key/value metadata
+// of the column chunks under the "parquet-go.timestamp.epoch" key. Example:
+//
+//	type Message struct {
+//	  Created time.Time `parquet:"created,timestamp(millisecond:epoch=2001-01-01)"`
+//	}
+//
+// Fields of type TimeOfDay are stored as columns of the TIME logical type. The
+// time tag accepts the same arguments as the timestamp tag to select the unit
+// of the column, values are stored in nanoseconds by default.
+//
+// Fields of type time.Duration are stored as plain INT64 columns holding a
+// number of nanoseconds. The duration tag accepts a time unit argument to store
+// durations in a coarser unit, values are then truncated to the unit when
+// written. The columns remain plain INT64 columns, the unit is recorded in the
+// key/value metadata of their column chunks. Example:
+//
+//	type Request struct {
+//	  Latency time.Duration `parquet:"latency,duration(microsecond)"`
+//	}
+//
+// Fields of type json.RawMessage are stored as columns of the JSON logical type
+// holding the documents as-is, without having to use the json tag. Other byte
+// slices remain plain BYTE_ARRAY columns.
+//
 // The decimal tag must be followed by two integer parameters, the first integer
 // representing the scale and the second the precision; for example:
 //
@@ -112,6 +159,20 @@ func (v *onceValue[T]) load(f func() *T) *T {
 //		Cost int64 `parquet:"cost,decimal(0:3)"`
 //	}
 //
+// Decimals are signed, unsigned integer fields are stored as INT32 or INT64
+// depending on the precision, which cannot exceed 18. Writing values greater
+// than the largest value allowed by the precision results in an error.
+//
+// Float fields are also stored as INT32 or INT64 depending on the precision,
+// the values are rounded to the scale of the decimal when written. The rounding
+// mode can be set with a third argument, which is one of "round=half-even"
+// (the default), "round=half-up" (ties are rounded away from zero) or
+// "round=truncate"; for example:
+//
+//	type Item struct {
+//		Price float64 `parquet:"price,decimal(2:10:round=half-up)"`
+//	}
+//
 // Invalid combination of struct tags and Go types, or repeating options will
 // cause the function to panic.
 //
@@ -124,6 +185,11 @@ func (v *onceValue[T]) load(f func() *T) *T {
 //   - The `parquet-value` tag allows users to configure a map's values, for example to declare their native Parquet types.
 //
 // When configuring a Parquet map, the `parquet` tag will configure the map itself.
+// Go maps are always written as groups annotated with the MAP logical type,
+// holding a repeated key_value group of key and value columns; the `map` option
+// may be set on the `parquet` tag to make it explicit. Nil and empty maps can
+// only be distinguished when the map is optional, in which case nil maps are
+// written as null values and empty maps are read back as empty non-nil maps.
 //
 // For example, the following will set the int64 key of the map to be a timestamp:
 //
@@ -140,15 +206,74 @@ func (v *onceValue[T]) load(f func() *T) *T {
 //
 // Note that the name of the element cannot be changed.
 //
+// The level of the gzip, brotli, and zstd codecs may be passed as argument, in
+// which case the column uses its own codec instead of the package default. The
+// level may also be given as a level=N pair. Levels out of the range supported
+// by the codec cause the function to panic. The zstd encoder only implements
+// four levels, the zstd levels are mapped to the closest one: 1 and 2 select
+// zstd.SpeedFastest, 3 to 5 zstd.SpeedDefault, 6 to 9
+// zstd.SpeedBetterCompression, and 10 to 22 zstd.SpeedBestCompression.
+// Example:
+//
+//	type Archive struct {
+//	  Cold []byte `parquet:"cold,zstd(level=19)"`
+//	  Hot  []byte `parquet:"hot,zstd(1)"`
+//	}
+//
+// Compression tags (snappy, gzip, brotli, lz4, zstd, uncompressed) may also be
+// set on fields of struct types, in which case the codec applies to all leaf
+// columns of the nested struct which do not declare a codec of their own:
+//
+//	type Event struct {
+//	  Payload struct {
+//	    Body    string
+//	    Headers string `parquet:",snappy"`
+//	  } `parquet:",zstd"`
+//	}
+//
+// Types implementing both encoding.BinaryMarshaler and
+// encoding.BinaryUnmarshaler are stored as BYTE_ARRAY columns holding the
+// output of MarshalBinary, instead of being mapped according to their
+// structure. Converters registered with RegisterConverter take precedence, and
+// types with a native parquet representation such as time.Time and uuid.UUID
+// are not affected.
+//
+// Fields of type IntervalValue are mapped to INTERVAL columns, see Interval.
+//
 // The schema name is the Go type name of the value.
-func SchemaOf(model any) *Schema {
-	return schemaOf(dereference(reflect.TypeOf(model)))
+//
+// Options may be passed to alter how Go types are mapped to parquet columns,
+// for example:
+//
+//	schema := parquet.SchemaOf(row, parquet.MakePointersRequired())
+//
+// The function panics if the options carry an invalid configuration.
+func SchemaOf(model any, options ...SchemaOption) *Schema {
+	t := dereference(reflect.TypeOf(model))
+	if len(options) == 0 {
+		return schemaOf(t)
+	}
+	config, err := NewSchemaConfig(options...)
+	if err != nil {
+		panic(err)
+	}
+	return schemaOfConfig(t, config)
+}
+
+type schemaCacheKey struct {
+	model  reflect.Type
+	config SchemaConfig
 }
 
-var cachedSchemas sync.Map // map[reflect.Type]*Schema
+var cachedSchemas sync.Map // map[schemaCacheKey]*Schema
 
 func schemaOf(model reflect.Type) *Schema {
-	cached, _ := cachedSchemas.Load(model)
+	return schemaOfConfig(model, DefaultSchemaConfig())
+}
+
+func schemaOfConfig(model reflect.Type, config *SchemaConfig) *Schema {
+	key := schemaCacheKey{model: model, config: *config}
+	cached, _ := cachedSchemas.Load(key)
 	schema, _ := cached.(*Schema)
 	if schema != nil {
 		return schema
@@ -156,8 +281,8 @@ func schemaOf(model reflect.Type) *Schema {
 	if model.Kind() != reflect.Struct {
 		panic("cannot construct parquet schema from value of type " + model.String())
 	}
-	schema = NewSchema(model.Name(), nodeOf(model, noTags))
-	if actual, loaded := cachedSchemas.LoadOrStore(model, schema); loaded {
+	schema = NewSchema(model.Name(), nodeOf(model, noTags, config))
+	if actual, loaded := cachedSchemas.LoadOrStore(key, schema); loaded {
 		schema = actual.(*Schema)
 	}
 	return schema
@@ -269,11 +394,31 @@ func (s *Schema) Compression() compress.Codec { return s.root.Compression() }
 // GoType returns the Go type that best represents the schema.
 func (s *Schema) GoType() reflect.Type { return s.root.GoType() }
 
+// deconstructError is raised by the functions deconstructing Go values which
+// cannot be represented by the parquet schema (e.g. floats out of the range of
+// decimal columns), writers return the error instead of panicking. The error
+// is made from the path of the column at columnIndex, which the functions do
+// not know about.
+type deconstructError struct {
+	columnIndex int16
+	makeError   func(path columnPath) error
+}
+
 // Deconstruct deconstructs a Go value and appends it to a row.
 //
 // The method panics is the structure of the go value does not match the
 // parquet schema.
 func (s *Schema) Deconstruct(row Row, value any) Row {
+	row, err := s.deconstruct(row, value)
+	if err != nil {
+		panic(err)
+	}
+	return row
+}
+
+// deconstruct is like Deconstruct but returns an error if value cannot be
+// represented by the schema.
+func (s *Schema) deconstruct(row Row, value any) (_ Row, err error) {
 	state := s.lazyLoadState()
 	funcs := s.lazyLoadFuncs()
 	columns := make([][]Value, len(state.columns))
@@ -291,8 +436,18 @@ func (s *Schema) Deconstruct(row Row, value any) Row {
 		}
 		v = v.Elem()
 	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			e, ok := r.(*deconstructError)
+			if !ok {
+				panic(r)
+			}
+			err = e.makeError(state.columns[e.columnIndex])
+		}
+	}()
 	funcs.deconstruct(columns, levels{}, v)
-	return appendRow(row, columns)
+	return appendRow(row, columns), nil
 }
 
 // Reconstruct reconstructs a Go value from a row.
@@ -403,12 +558,156 @@ func (s *Schema) forEachNode(do func(name string, node Node)) {
 	forEachNodeOf(s.Name(), s, do)
 }
 
+// orderMapKeys returns a schema equivalent to s where the keys of maps are
+// written in ascending order, or s itself if it has no maps to order.
+func orderMapKeys(s *Schema) *Schema {
+	if root := orderedMapsOf(s.root); root != s.root {
+		return NewSchema(s.Name(), root)
+	}
+	return s
+}
+
+func orderedMapsOf(node Node) Node {
+	if node.Leaf() {
+		return node
+	}
+
+	fields := node.Fields()
+	rewritten := make([]Field, len(fields))
+	changed := false
+	for i, f := range fields {
+		if n := orderedMapsOf(f); n != Node(f) {
+			rewritten[i] = &rewrittenField{Node: n, field: f}
+			changed = true
+		} else {
+			rewritten[i] = f
+		}
+	}
+	if changed {
+		node = &rewrittenGroup{Node: node, fields: rewritten}
+	}
+	if isMap(node) && !isSortedMap(node) {
+		node = orderedMapNode{node}
+	}
+	return node
+}
+
+// reorderColumns returns a schema equivalent to s but with fields arranged so
+// that leaf columns are assigned column indexes in the order of the columns
+// passed as argument.
+//
+// The columns must be a permutation of the leaf columns of s, and the leaves of
+// each group must be contiguous in the requested order since column indexes are
+// assigned by walking the schema depth-first.
+func reorderColumns(s *Schema, columns [][]string) (*Schema, error) {
+	leaves := s.Columns()
+	if len(columns) != len(leaves) {
+		return nil, fmt.Errorf("column order has %d columns but the schema has %d leaf columns", len(columns), len(leaves))
+	}
+
+	ranks := make(map[string]int, len(columns))
+	for i, path := range columns {
+		if _, ok := s.Lookup(path...); !ok {
+			return nil, fmt.Errorf("column order contains unknown column %q", columnPath(path))
+		}
+		key := strings.Join(path, "\x00")
+		if _, dup := ranks[key]; dup {
+			return nil, fmt.Errorf("column order contains duplicate column %q", columnPath(path))
+		}
+		ranks[key] = i
+	}
+
+	root, _, _, err := reorderNode(s.root, nil, ranks)
+	if err != nil {
+		return nil, err
+	}
+	return NewSchema(s.name, root), nil
+}
+
+func reorderNode(node Node, path columnPath, ranks map[string]int) (Node, int, int, error) {
+	if node.Leaf() {
+		rank := ranks[strings.Join(path, "\x00")]
+		return node, rank, rank, nil
+	}
+
+	type rankedField struct {
+		field    Field
+		min, max int
+	}
+
+	fields := node.Fields()
+	ranked := make([]rankedField, len(fields))
+	for i, f := range fields {
+		n, lo, hi, err := reorderNode(f, path.append(f.Name()), ranks)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		if n != Node(f) {
+			f = &rewrittenField{Node: n, field: f}
+		}
+		ranked[i] = rankedField{field: f, min: lo, max: hi}
+	}
+
+	if len(ranked) == 0 {
+		return node, -1, -1, nil
+	}
+
+	// Groups without leaf columns have no rank, they are moved after the
+	// other fields since they do not influence the column indexes.
+	slices.SortStableFunc(ranked, func(a, b rankedField) int {
+		switch {
+		case a.min < 0 && b.min < 0:
+			return 0
+		case a.min < 0:
+			return +1
+		case b.min < 0:
+			return -1
+		}
+		return cmp.Compare(a.min, b.min)
+	})
+
+	numRanked := len(ranked)
+	for numRanked > 0 && ranked[numRanked-1].min < 0 {
+		numRanked--
+	}
+	if numRanked == 0 {
+		return node, -1, -1, nil
+	}
+	for i := 1; i < numRanked; i++ {
+		if ranked[i].min != ranked[i-1].max+1 {
+			return nil, 0, 0, fmt.Errorf("column order splits the leaf columns of group %q", path)
+		}
+	}
+
+	reordered := make([]Field, len(ranked))
+	for i, f := range ranked {
+		reordered[i] = f.field
+	}
+
+	changed := false
+	for i, f := range reordered {
+		changed = changed || f != fields[i]
+	}
+
+	lo, hi := ranked[0].min, ranked[numRanked-1].max
+	if !changed {
+		return node, lo, hi, nil
+	}
+	return &rewrittenGroup{Node: node, fields: reordered}, lo, hi, nil
+}
+
 type structNode struct {
 	gotype reflect.Type
 	fields []structField
 }
 
-func structNodeOf(t reflect.Type) *structNode {
+func structNodeOf(t reflect.Type, config *SchemaConfig) *structNode {
+	// Parquet schemas have a fixed depth, self-referential types would make
+	// the construction of the schema recurse until the stack overflows.
+	if path := recursiveFieldsOf(t); path != nil {
+		panic("cannot create parquet schema of recursive type " + t.String() + ": field " + t.Name() + "." + strings.Join(path, ".") + " refers back to " + t.String())
+	}
+
 	// Collect struct fields first so we can order them before generating the
 	// column indexes.
 	fields := structFieldsOf(t)
@@ -419,9 +718,30 @@ func structNodeOf(t reflect.Type) *structNode {
 	}
 
 	for i := range fields {
-		field := structField{name: fields[i].Name, index: fields[i].Index}
 		tags := fromStructTag(fields[i].Tag)
-		field.Node = makeNodeOf(fields[i].Type, fields[i].Name, tags)
+		field := structField{
+			name:  fields[i].Name,
+			index: fields[i].Index,
+			columnOptions: columnOptions{
+				noStats: hasTagOption(tags.parquet, "nostats"),
+				indexed: hasTagOption(tags.parquet, "index"),
+				noIndex: hasTagOption(tags.parquet, "noindex"),
+				ndv:     hasTagOption(tags.parquet, "ndv"),
+			},
+		}
+		field.Node = makeNodeOf(fields[i].Type, fields[i].Name, tags, config)
+
+		forEachTagOption([]string{tags.parquet}, func(option, args string) {
+			if option == "bloom" {
+				// The arguments were validated when making the node.
+				field.bloomFilterBitsPerValue, _ = parseBloomFilterArgs(args)
+			}
+		})
+
+		if config.PointersRequired && fields[i].Type.Kind() == reflect.Ptr && field.Node.Optional() && !hasTagOption(tags.parquet, "optional") {
+			field.Node = Required(field.Node)
+			field.pointerRequired = true
+		}
 
 		s.fields[i] = field
 	}
@@ -429,6 +749,55 @@ func structNodeOf(t reflect.Type) *structNode {
 	return s
 }
 
+// recursiveFieldsOf returns the names of the struct fields through which the
+// struct type t refers back to itself, or nil if t is not recursive. Fields are
+// followed the same way nodeOf does when constructing the schema of t, except
+// for fields stored as JSON documents, which are not broken down into columns.
+func recursiveFieldsOf(t reflect.Type) []string {
+	var path []string
+	visited := make(map[reflect.Type]struct{})
+
+	var walk func(reflect.Type) bool
+	walk = func(typ reflect.Type) bool {
+		if lookupConverter(typ) != nil {
+			return false
+		}
+		switch typ.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Array:
+			return walk(typ.Elem())
+		case reflect.Map:
+			return walk(typ.Key()) || walk(typ.Elem())
+		case reflect.Struct:
+			if typ == t && path != nil {
+				return true
+			}
+			// Types seen before cannot lead back to t, or the walk would
+			// already have returned. Cycles which do not go through t are
+			// reported when constructing the nodes of their own types.
+			if _, seen := visited[typ]; seen {
+				return false
+			}
+			visited[typ] = struct{}{}
+			for _, f := range structFieldsOf(typ) {
+				if hasTagOption(f.Tag.Get("parquet"), "json") {
+					continue
+				}
+				path = append(path, f.Name)
+				if walk(f.Type) {
+					return true
+				}
+				path = path[:len(path)-1]
+			}
+		}
+		return false
+	}
+
+	if walk(t) {
+		return path
+	}
+	return nil
+}
+
 func structFieldsOf(t reflect.Type) []reflect.StructField {
 	fields := appendStructFields(t, nil, nil, 0)
 
@@ -461,7 +830,9 @@ func appendStructFields(t reflect.Type, fields []reflect.StructField, index []in
 
 		f.Offset += offset
 
-		if f.Anonymous {
+		// Embedded structs are flattened into the parent unless they have the
+		// "group" tag, in which case they are regular fields of the struct.
+		if f.Anonymous && !hasTagOption(f.Tag.Get("parquet"), "group") {
 			fields = appendStructFields(f.Type, fields, fieldIndex, f.Offset)
 		} else if f.IsExported() {
 			f.Index = fieldIndex
@@ -520,6 +891,46 @@ type structField struct {
 	Node
 	name  string
 	index []int
+	// Set when the field is a Go pointer made required by the
+	// MakePointersRequired option.
+	pointerRequired bool
+	columnOptions
+}
+
+func (f *structField) requiredPointer() bool { return f.pointerRequired }
+
+// fieldWithRequiredPointer is implemented by the fields which may hold Go
+// pointers mapped to required columns.
+type fieldWithRequiredPointer interface {
+	requiredPointer() bool
+}
+
+// isRequiredPointer reports whether field holds a Go pointer mapped to a
+// required column, which must be dereferenced to read or write its value.
+func isRequiredPointer(field Field) bool {
+	f, ok := field.(fieldWithRequiredPointer)
+	return ok && f.requiredPointer()
+}
+
+// columnOptions are the options declared with the "parquet" tag of a struct
+// field which apply to the columns of the field, rather than being part of its
+// node.
+type columnOptions struct {
+	noStats bool
+	indexed bool
+	noIndex bool
+	ndv     bool
+	// Number of bits per value of the bloom filter declared with the "bloom"
+	// tag, zero if the field has no bloom filter.
+	bloomFilterBitsPerValue uint
+}
+
+func (opts *columnOptions) options() *columnOptions { return opts }
+
+// fieldWithColumnOptions is implemented by the fields carrying column options,
+// including the fields rewritten from them (see rewrittenField).
+type fieldWithColumnOptions interface {
+	options() *columnOptions
 }
 
 func (f *structField) Name() string { return f.name }
@@ -571,6 +982,17 @@ func throwInvalidNode(t reflect.Type, msg, name string, parquetTags parquetTags)
 	panic(msg + ": " + nodeString(t, name, tags...))
 }
 
+// decimalMaxUnsignedValue returns the largest unscaled value of a decimal with
+// the given precision, used to validate the unsigned values written to decimal
+// columns since they are stored in signed physical types.
+func decimalMaxUnsignedValue(precision int) uint64 {
+	maxValue := uint64(1)
+	for range precision {
+		maxValue *= 10
+	}
+	return maxValue - 1
+}
+
 // FixedLenByteArray decimals are sized based on precision
 // this function calculates the necessary byte array size.
 func decimalFixedLenByteArraySize(precision int) int {
@@ -594,14 +1016,25 @@ func forEachStructTagOption(sf reflect.StructField, do func(t reflect.Type, opti
 	}
 }
 
-func nodeOf(t reflect.Type, tags parquetTags) Node {
+func nodeOf(t reflect.Type, tags parquetTags, config *SchemaConfig) Node {
+	if c := lookupConverter(t); c != nil {
+		return c.node
+	}
+
 	switch t {
 	case reflect.TypeOf(deprecated.Int96{}):
 		return Leaf(Int96Type)
 	case reflect.TypeOf(uuid.UUID{}):
 		return UUID()
 	case reflect.TypeOf(time.Time{}):
+		if config.TimestampUnit != nil {
+			return Timestamp(config.TimestampUnit)
+		}
 		return Timestamp(Nanosecond)
+	case timeOfDayValueType:
+		return timeOfDayNodeOf(Nanosecond, true)
+	case reflect.TypeOf(json.RawMessage(nil)):
+		return JSON()
 	}
 
 	var n Node
@@ -631,18 +1064,20 @@ func nodeOf(t reflect.Type, tags parquetTags) Node {
 		n = String()
 
 	case reflect.Ptr:
-		n = Optional(nodeOf(t.Elem(), noTags))
+		n = Optional(nodeOf(t.Elem(), noTags, config))
 
 	case reflect.Slice:
 		if elem := t.Elem(); elem.Kind() == reflect.Uint8 { // []byte?
 			n = Leaf(ByteArrayType)
 		} else {
-			n = Repeated(nodeOf(elem, noTags))
+			n = Repeated(nodeOf(elem, noTags, config))
 		}
 
 	case reflect.Array:
-		if t.Elem().Kind() == reflect.Uint8 {
+		if elem := t.Elem(); elem.Kind() == reflect.Uint8 { // [N]byte?
 			n = Leaf(FixedLenByteArrayType(t.Len()))
+		} else {
+			n = Repeated(nodeOf(elem, noTags, config))
 		}
 
 	case reflect.Map:
@@ -650,19 +1085,27 @@ func nodeOf(t reflect.Type, tags parquetTags) Node {
 		if strings.Contains(mapTag, "json") {
 			n = JSON()
 		} else {
-			n = Map(
-				makeNodeOf(t.Key(), t.Name(), tags.getMapKeyNodeTags()),
-				makeNodeOf(t.Elem(), t.Name(), tags.getMapValueNodeTags()),
+			newMap := Map
+			if config.SortMapKeys {
+				newMap = SortedMap
+			}
+			n = newMap(
+				makeNodeOf(t.Key(), t.Name(), tags.getMapKeyNodeTags(), config),
+				makeNodeOf(t.Elem(), t.Name(), tags.getMapValueNodeTags(), config),
 			)
 		}
 
 		forEachTagOption([]string{mapTag}, func(option, args string) {
 			switch option {
-			case "", "json":
+			case "", "json", "map":
 				return
 			case "optional":
 				n = Optional(n)
-			case "id":
+			case "bloom":
+				if _, err := parseBloomFilterArgs(args); err != nil {
+					throwInvalidTag(t, "map", option+args)
+				}
+			case "id", "fieldid":
 				id, err := parseIDArgs(args)
 				if err != nil {
 					throwInvalidTag(t, "map", option)
@@ -674,7 +1117,7 @@ func nodeOf(t reflect.Type, tags parquetTags) Node {
 		})
 
 	case reflect.Struct:
-		return structNodeOf(t)
+		return structNodeOf(t, config)
 	}
 
 	if n == nil {
@@ -704,6 +1147,28 @@ func splitOptionArgs(s string) (option, args string) {
 	return
 }
 
+// parseCompressionLevel parses the optional argument of compression tags,
+// which is a level expressed either as a number (e.g. "zstd(19)") or as a
+// level=N pair (e.g. "zstd(level=19)"). The function returns ok=false if the
+// tag had no argument, and an error if the level is not within [min, max].
+func parseCompressionLevel(args string, min, max int) (level int, ok bool, err error) {
+	if args == "" || args == "()" {
+		return 0, false, nil
+	}
+	if !strings.HasPrefix(args, "(") || !strings.HasSuffix(args, ")") {
+		return 0, false, fmt.Errorf("malformed compression args: %s", args)
+	}
+	arg := strings.TrimPrefix(args[1:len(args)-1], "level=")
+	level, err = strconv.Atoi(arg)
+	if err != nil {
+		return 0, false, fmt.Errorf("malformed compression level: %s", args)
+	}
+	if level < min || level > max {
+		return 0, false, fmt.Errorf("compression level out of range [%d:%d]: %d", min, max, level)
+	}
+	return level, true, nil
+}
+
 func parseDecimalArgs(args string) (scale, precision int, err error) {
 	if !strings.HasPrefix(args, "(") || !strings.HasSuffix(args, ")") {
 		return 0, 0, fmt.Errorf("malformed decimal args: %s", args)
@@ -725,6 +1190,51 @@ func parseDecimalArgs(args string) (scale, precision int, err error) {
 	return int(s), int(p), nil
 }
 
+// parseDecimalRoundingArg extracts the rounding argument of decimal tags, which
+// must be the last argument (e.g. "round=half-up"). The remaining arguments are
+// returned.
+func parseDecimalRoundingArg(args string) (rest string, rounding decimalRounding, hasRounding bool, err error) {
+	if !strings.HasPrefix(args, "(") || !strings.HasSuffix(args, ")") {
+		return args, rounding, false, nil
+	}
+	parts := strings.Split(args[1:len(args)-1], ":")
+	value, hasRounding := strings.CutPrefix(parts[len(parts)-1], "round=")
+	if !hasRounding {
+		return args, rounding, false, nil
+	}
+	rounding, err = parseDecimalRounding(value)
+	if err != nil {
+		return args, rounding, false, err
+	}
+	return "(" + strings.Join(parts[:len(parts)-1], ":") + ")", rounding, true, nil
+}
+
+// parseBloomFilterArgs parses the arguments of bloom tags, which may set the
+// target false positive probability of the filter (e.g. "(fpp=0.01)"), and
+// returns the number of bits per value of the filter.
+func parseBloomFilterArgs(args string) (bitsPerValue uint, err error) {
+	if !strings.HasPrefix(args, "(") || !strings.HasSuffix(args, ")") {
+		return 0, fmt.Errorf("malformed bloom args: %s", args)
+	}
+	args = strings.TrimPrefix(args, "(")
+	args = strings.TrimSuffix(args, ")")
+	if args == "" {
+		return defaultBloomFilterBitsPerValue, nil
+	}
+	value, ok := strings.CutPrefix(args, "fpp=")
+	if !ok {
+		return 0, fmt.Errorf("malformed bloom args: %s", args)
+	}
+	fpp, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, err
+	}
+	if !(fpp > 0 && fpp < 1) {
+		return 0, fmt.Errorf("bloom filter false positive probability must be between 0 and 1: %s", value)
+	}
+	return bloomFilterBitsPerValue(fpp), nil
+}
+
 func parseIDArgs(args string) (int, error) {
 	if !strings.HasPrefix(args, "(") || !strings.HasSuffix(args, ")") {
 		return 0, fmt.Errorf("malformed id args: %s", args)
@@ -767,6 +1277,68 @@ func parseTimestampArgs(args string) (unit TimeUnit, isUTCNormalized bool, err e
 	return unit, adjusted, nil
 }
 
+// parseTimestampEpoch extracts the epoch argument of timestamp tags, which must
+// be the last argument and is expressed as a date (e.g. "epoch=2000-01-01").
+// The remaining arguments are returned.
+func parseTimestampEpoch(args string) (rest string, epoch time.Time, hasEpoch bool, err error) {
+	if !strings.HasPrefix(args, "(") || !strings.HasSuffix(args, ")") {
+		return args, epoch, false, nil
+	}
+	parts := strings.Split(args[1:len(args)-1], ":")
+	value, hasEpoch := strings.CutPrefix(parts[len(parts)-1], "epoch=")
+	if !hasEpoch {
+		return args, epoch, false, nil
+	}
+	epoch, err = time.Parse(time.DateOnly, value)
+	if err != nil {
+		return args, epoch, false, fmt.Errorf("malformed timestamp epoch: %s", value)
+	}
+	return "(" + strings.Join(parts[:len(parts)-1], ":") + ")", epoch, true, nil
+}
+
+func timestampNodeOf(unit TimeUnit, isAdjustedToUTC bool, epoch time.Time, hasEpoch bool, config *SchemaConfig) Node {
+	if config.TimestampUnit != nil {
+		unit = config.TimestampUnit
+	}
+	if hasEpoch {
+		return TimestampEpoch(unit, isAdjustedToUTC, epoch)
+	}
+	return TimestampAdjusted(unit, isAdjustedToUTC)
+}
+
+// int64TimestampNodeOf returns the node of int64 fields holding timestamps in
+// the given unit. When the configuration normalizes timestamps to a different
+// unit, the values are scaled to that unit when written, and back to the unit
+// of the field when read.
+func int64TimestampNodeOf(unit TimeUnit, isAdjustedToUTC bool, config *SchemaConfig) Node {
+	if config.TimestampUnit == nil || config.TimestampUnit == unit {
+		return TimestampAdjusted(unit, isAdjustedToUTC)
+	}
+	return Leaf(&scaledTimestampType{
+		timestampType: timestampType{IsAdjustedToUTC: isAdjustedToUTC, Unit: config.TimestampUnit.TimeUnit()},
+		goUnit:        unit.TimeUnit(),
+	})
+}
+
+// durationNodeOf returns the node of time.Duration fields stored in the given
+// unit. Durations are plain INT64 columns in all units, values are scaled from
+// nanoseconds when written, and back to nanoseconds when read.
+func durationNodeOf(unit TimeUnit) Node {
+	return Leaf(&durationType{Type: Int64Type, unit: unit.TimeUnit()})
+}
+
+// parseDurationArgs parses the arguments of the duration tag, which is an
+// optional time unit defaulting to nanoseconds.
+func parseDurationArgs(args string) (TimeUnit, error) {
+	if args == "" || args == "()" {
+		return Nanosecond, nil
+	}
+	if !strings.HasPrefix(args, "(") || !strings.HasSuffix(args, ")") {
+		return nil, fmt.Errorf("malformed duration args: %s", args)
+	}
+	return parseTimeUnit(args[1 : len(args)-1])
+}
+
 func parseTimeUnit(arg string) (TimeUnit, error) {
 	switch arg {
 	case "millisecond":
@@ -805,7 +1377,7 @@ var (
 	_ WriterOption   = (*Schema)(nil)
 )
 
-func makeNodeOf(t reflect.Type, name string, tags parquetTags) Node {
+func makeNodeOf(t reflect.Type, name string, tags parquetTags, config *SchemaConfig) Node {
 	var (
 		node       Node
 		optional   bool
@@ -850,8 +1422,22 @@ func makeNodeOf(t reflect.Type, name string, tags parquetTags) Node {
 		compressed = c
 	}
 
+	// Logical type tags declared on pointer fields apply to the type of the
+	// elements, the pointer makes the column optional so nil pointers are
+	// written as null values.
+	elem := t
+	if t.Kind() == reflect.Pointer {
+		elem = t.Elem()
+	}
+	setElemNode := func(n Node) {
+		if t.Kind() == reflect.Pointer {
+			n = Optional(n)
+		}
+		setNode(n)
+	}
+
 	if t.Kind() == reflect.Map {
-		node = nodeOf(t, tags)
+		node = nodeOf(t, tags, config)
 	} else {
 		forEachTagOption([]string{tags.parquet}, func(option, args string) {
 			switch option {
@@ -864,49 +1450,87 @@ func makeNodeOf(t reflect.Type, name string, tags parquetTags) Node {
 				setCompression(&Snappy)
 
 			case "gzip":
-				setCompression(&Gzip)
+				level, ok, err := parseCompressionLevel(args, gzip.NoCompression, gzip.BestCompression)
+				switch {
+				case err != nil:
+					throwInvalidTag(t, name, option+args)
+				case ok:
+					setCompression(&gzip.Codec{Level: level})
+				default:
+					setCompression(&Gzip)
+				}
 
 			case "brotli":
-				setCompression(&Brotli)
+				quality, ok, err := parseCompressionLevel(args, 0, 11)
+				switch {
+				case err != nil:
+					throwInvalidTag(t, name, option+args)
+				case ok:
+					setCompression(&brotli.Codec{Quality: quality, LGWin: brotli.DefaultLGWin})
+				default:
+					setCompression(&Brotli)
+				}
 
 			case "lz4":
 				setCompression(&Lz4Raw)
 
 			case "zstd":
-				setCompression(&Zstd)
+				level, ok, err := parseCompressionLevel(args, 1, 22)
+				switch {
+				case err != nil:
+					throwInvalidTag(t, name, option+args)
+				case ok:
+					setCompression(&zstd.Codec{Level: zstd.LevelFromZstd(level)})
+				default:
+					setCompression(&Zstd)
+				}
 
 			case "uncompressed":
 				setCompression(&Uncompressed)
 
-			case "plain":
+			case "plain", "bitpacked":
+				// The PLAIN encoding of booleans packs the values in bits,
+				// which is smaller than RLE for short runs of values. The
+				// bitpacked tag is the name of the encoding for booleans.
+				if option == "bitpacked" && elem.Kind() != reflect.Bool {
+					throwInvalidTag(t, name, option)
+				}
 				setEncoding(&Plain)
 
 			case "dict":
 				setEncoding(&RLEDictionary)
 
 			case "json":
-				setNode(JSON())
+				switch {
+				case elem.Kind() == reflect.String, elem.Kind() == reflect.Slice && elem.Elem().Kind() == reflect.Uint8:
+					// Strings and byte slices hold JSON documents which are
+					// written as-is, other types are marshaled to JSON, which
+					// already represents nil pointers as null documents.
+					setElemNode(JSON())
+				default:
+					setNode(JSON())
+				}
 
 			case "delta":
-				switch t.Kind() {
+				switch elem.Kind() {
 				case reflect.Int, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
 					setEncoding(&DeltaBinaryPacked)
 				case reflect.String:
 					setEncoding(&DeltaByteArray)
 				case reflect.Slice:
-					if t.Elem().Kind() == reflect.Uint8 { // []byte?
+					if elem.Elem().Kind() == reflect.Uint8 { // []byte?
 						setEncoding(&DeltaByteArray)
 					} else {
 						throwInvalidTag(t, name, option)
 					}
 				case reflect.Array:
-					if t.Elem().Kind() == reflect.Uint8 { // [N]byte?
+					if elem.Elem().Kind() == reflect.Uint8 { // [N]byte?
 						setEncoding(&DeltaByteArray)
 					} else {
 						throwInvalidTag(t, name, option)
 					}
 				default:
-					switch t {
+					switch elem {
 					case reflect.TypeOf(time.Time{}):
 						setEncoding(&DeltaBinaryPacked)
 					default:
@@ -914,6 +1538,16 @@ func makeNodeOf(t reflect.Type, name string, tags parquetTags) Node {
 					}
 				}
 
+			case "deltalength":
+				switch {
+				case elem.Kind() == reflect.String:
+					setEncoding(&DeltaLengthByteArray)
+				case elem.Kind() == reflect.Slice && elem.Elem().Kind() == reflect.Uint8: // []byte?
+					setEncoding(&DeltaLengthByteArray)
+				default:
+					throwInvalidTag(t, name, option)
+				}
+
 			case "split":
 				switch t.Kind() {
 				case reflect.Float32, reflect.Float64:
@@ -924,53 +1558,107 @@ func makeNodeOf(t reflect.Type, name string, tags parquetTags) Node {
 
 			case "list":
 				switch t.Kind() {
-				case reflect.Slice:
-					element := makeNodeOf(t.Elem(), t.Name(), tags.getListElementNodeTags())
+				case reflect.Slice, reflect.Array:
+					element := makeNodeOf(t.Elem(), t.Name(), tags.getListElementNodeTags(), config)
 					setNode(element)
 					setList()
 				default:
 					throwInvalidTag(t, name, option)
 				}
 
+			case "map":
+				// Map fields are handled by nodeOf, the option is only valid
+				// on fields of map types.
+				throwInvalidTag(t, name, option)
+
+			case "fixed":
+				if !isPackedArray(t) {
+					throwInvalidTag(t, name, option)
+				}
+				setNode(Leaf(FixedLenByteArrayType(int(t.Size()))))
+
 			case "enum":
-				switch t.Kind() {
+				switch elem.Kind() {
 				case reflect.String:
-					setNode(Enum())
+					setElemNode(Enum())
 				default:
 					throwInvalidTag(t, name, option)
 				}
 
 			case "uuid":
-				switch t.Kind() {
+				switch elem.Kind() {
 				case reflect.Array:
-					if t.Elem().Kind() != reflect.Uint8 || t.Len() != 16 {
+					if elem.Elem().Kind() != reflect.Uint8 || elem.Len() != 16 {
 						throwInvalidTag(t, name, option)
 					}
-					setNode(UUID())
+					setElemNode(UUID())
 				case reflect.String:
-					setNode(UUID())
+					setElemNode(UUID())
+				default:
+					throwInvalidTag(t, name, option)
+				}
+
+			case "float16":
+				switch elem.Kind() {
+				case reflect.Float32:
+					setElemNode(Float16())
 				default:
 					throwInvalidTag(t, name, option)
 				}
 
 			case "decimal":
-				scale, precision, err := parseDecimalArgs(args)
+				decimalArgs, rounding, hasRounding, err := parseDecimalRoundingArg(args)
 				if err != nil {
 					throwInvalidTag(t, name, option+args)
 				}
+				scale, precision, err := parseDecimalArgs(decimalArgs)
+				if err != nil {
+					throwInvalidTag(t, name, option+args)
+				}
+				if hasRounding && t.Kind() != reflect.Float32 && t.Kind() != reflect.Float64 {
+					throwInvalidTag(t, name, option+args)
+				}
 				var baseType Type
 				switch t.Kind() {
+				case reflect.Float32, reflect.Float64:
+					if scale < 0 || scale > precision {
+						throwInvalidTag(t, name, option+args)
+					}
+					switch {
+					case precision <= 9:
+						baseType = Int32Type
+					case precision <= 18:
+						baseType = Int64Type
+					default:
+						throwInvalidTag(t, name, option+args)
+					}
 				case reflect.Int32:
 					baseType = Int32Type
 				case reflect.Int64:
 					baseType = Int64Type
+				case reflect.Uint32, reflect.Uint64:
+					// Decimals are signed, unsigned values are stored in the
+					// smallest signed physical type which can hold all the
+					// values allowed by the precision.
+					switch {
+					case precision <= 9:
+						baseType = Int32Type
+					case precision <= 18:
+						baseType = Int64Type
+					default:
+						throwInvalidTag(t, name, option+args)
+					}
 				case reflect.Array, reflect.Slice:
 					baseType = FixedLenByteArrayType(decimalFixedLenByteArraySize(precision))
 				default:
 					throwInvalidTag(t, name, option)
 				}
 
-				setNode(Decimal(scale, precision, baseType))
+				if t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64 {
+					setNode(floatDecimal(scale, precision, baseType, rounding))
+				} else {
+					setNode(Decimal(scale, precision, baseType))
+				}
 			case "string":
 				switch {
 				case t.Kind() == reflect.String:
@@ -995,6 +1683,17 @@ func makeNodeOf(t reflect.Type, name string, tags parquetTags) Node {
 					throwInvalidTag(t, name, option)
 				}
 			case "time":
+				if elem == timeOfDayValueType {
+					timeUnit, adjusted := Nanosecond, true
+					if args != "" {
+						var err error
+						if timeUnit, adjusted, err = parseTimestampArgs(args); err != nil {
+							throwInvalidTag(t, name, option+args)
+						}
+					}
+					setElemNode(timeOfDayNodeOf(timeUnit, adjusted))
+					break
+				}
 				switch t.Kind() {
 				case reflect.Int32:
 					timeUnit, adjusted, err := parseTimestampArgs(args)
@@ -1018,39 +1717,62 @@ func makeNodeOf(t reflect.Type, name string, tags parquetTags) Node {
 				default:
 					throwInvalidTag(t, name, option)
 				}
+			case "duration":
+				if elem != reflect.TypeOf(time.Duration(0)) {
+					throwInvalidTag(t, name, option)
+				}
+				timeUnit, err := parseDurationArgs(args)
+				if err != nil {
+					throwInvalidTag(t, name, option+args)
+				}
+				setElemNode(durationNodeOf(timeUnit))
 			case "timestamp":
+				timestampArgs, epoch, hasEpoch, err := parseTimestampEpoch(args)
+				if err != nil {
+					throwInvalidTag(t, name, option+args)
+				}
 				switch t.Kind() {
 				case reflect.Int64:
-					timeUnit, adjusted, err := parseTimestampArgs(args)
-					if err != nil {
+					timeUnit, adjusted, err := parseTimestampArgs(timestampArgs)
+					if err != nil || hasEpoch {
 						throwInvalidTag(t, name, option+args)
 					}
-					setNode(TimestampAdjusted(timeUnit, adjusted))
+					setNode(int64TimestampNodeOf(timeUnit, adjusted, config))
 				case reflect.Ptr:
 					// Support *time.Time with timestamp tags
 					if t.Elem() == reflect.TypeOf(time.Time{}) {
-						timeUnit, adjusted, err := parseTimestampArgs(args)
+						timeUnit, adjusted, err := parseTimestampArgs(timestampArgs)
 						if err != nil {
 							throwInvalidTag(t, name, option+args)
 						}
 						// Wrap in Optional for schema correctness (nil pointers = NULL values)
-						setNode(Optional(TimestampAdjusted(timeUnit, adjusted)))
+						setNode(Optional(timestampNodeOf(timeUnit, adjusted, epoch, hasEpoch, config)))
 					} else {
 						throwInvalidTag(t, name, option)
 					}
 				default:
 					switch t {
 					case reflect.TypeOf(time.Time{}):
-						timeUnit, adjusted, err := parseTimestampArgs(args)
+						timeUnit, adjusted, err := parseTimestampArgs(timestampArgs)
 						if err != nil {
 							throwInvalidTag(t, name, option+args)
 						}
-						setNode(TimestampAdjusted(timeUnit, adjusted))
+						setNode(timestampNodeOf(timeUnit, adjusted, epoch, hasEpoch, config))
 					default:
 						throwInvalidTag(t, name, option)
 					}
 				}
-			case "id":
+			case "group":
+				if elem.Kind() != reflect.Struct {
+					throwInvalidTag(t, name, option)
+				}
+
+			case "bloom":
+				if _, err := parseBloomFilterArgs(args); err != nil {
+					throwInvalidTag(t, name, option+args)
+				}
+
+			case "id", "fieldid":
 				id, err := parseIDArgs(args)
 				if err != nil {
 					throwInvalidNode(t, "struct field has field id that is not a valid int", name, tags)
@@ -1070,18 +1792,24 @@ func makeNodeOf(t reflect.Type, name string, tags parquetTags) Node {
 		// Note for strings "optional" applies only to the entire BYTE_ARRAY and
 		// not each individual byte.
 		if optional && !isUint8 {
-			node = Repeated(Optional(nodeOf(t.Elem(), tags)))
+			node = Repeated(Optional(nodeOf(t.Elem(), tags, config)))
 			// Don't also apply "optional" to the whole list.
 			optional = false
 		}
 	}
 
 	if node == nil {
-		node = nodeOf(t, tags)
+		node = nodeOf(t, tags, config)
 	}
 
 	if compressed != nil {
-		node = Compressed(node, compressed)
+		if node.Leaf() {
+			node = Compressed(node, compressed)
+		} else {
+			// Compression codecs declared on groups apply to all the leaf
+			// columns beneath them, unless they declared their own codec.
+			node = compressedLeaves(node, compressed)
+		}
 	}
 
 	if encoded != nil {
@@ -1111,6 +1839,99 @@ func makeNodeOf(t reflect.Type, name string, tags parquetTags) Node {
 	return node
 }
 
+// forEachColumnOptions calls do with the column options of each field on the
+// path from node to the column at path, starting with the outermost field.
+func forEachColumnOptions(node Node, path columnPath, do func(*columnOptions)) {
+	for _, name := range path {
+		field := fieldByName(node, name)
+		if field == nil {
+			return
+		}
+		if f, ok := field.(fieldWithColumnOptions); ok {
+			if opts := f.options(); opts != nil {
+				do(opts)
+			}
+		}
+		node = field
+	}
+}
+
+// skipStatisticsOf reports whether the column at path was declared with the
+// "nostats" tag, either on its own field or on one of the groups containing it.
+func skipStatisticsOf(node Node, path columnPath) (noStats bool) {
+	forEachColumnOptions(node, path, func(opts *columnOptions) { noStats = noStats || opts.noStats })
+	return noStats
+}
+
+// distinctCountOf reports whether the column at path was declared with the
+// "ndv" tag, either on its own field or on one of the groups containing it.
+func distinctCountOf(node Node, path columnPath) (ndv bool) {
+	forEachColumnOptions(node, path, func(opts *columnOptions) { ndv = ndv || opts.ndv })
+	return ndv
+}
+
+// pageIndexOf reports whether the column at path was declared with the "index"
+// or "noindex" tags, either on its own field or on one of the groups containing
+// it.
+func pageIndexOf(node Node, path columnPath) (indexed, noIndex bool) {
+	forEachColumnOptions(node, path, func(opts *columnOptions) {
+		indexed = indexed || opts.indexed
+		noIndex = noIndex || opts.noIndex
+	})
+	return indexed, noIndex
+}
+
+// bloomFilterOf returns the bloom filter of the column at path declared with
+// the "bloom" tag, either on its own field or on one of the groups containing
+// it, or nil if the column has no bloom filter. The tag of the innermost field
+// takes precedence.
+func bloomFilterOf(node Node, path columnPath) BloomFilterColumn {
+	var bitsPerValue uint
+	forEachColumnOptions(node, path, func(opts *columnOptions) {
+		if opts.bloomFilterBitsPerValue != 0 {
+			bitsPerValue = opts.bloomFilterBitsPerValue
+		}
+	})
+	if bitsPerValue == 0 {
+		return nil
+	}
+	return SplitBlockFilter(bitsPerValue, path...)
+}
+
+// isPackedArray returns true if t is an array of fixed-size numbers, which can
+// be packed into a FIXED_LEN_BYTE_ARRAY value with the "fixed" tag.
+func isPackedArray(t reflect.Type) bool {
+	if t.Kind() != reflect.Array {
+		return false
+	}
+	switch t.Elem().Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// swapPackedArray converts the elements of size elemSize of a packed array
+// between the byte order of the CPU and the little-endian order that the values
+// of FIXED_LEN_BYTE_ARRAY columns are stored in. The function is a no-op on
+// little-endian CPUs, where both orders are the same.
+func swapPackedArray(data []byte, elemSize int) {
+	if !cpu.IsBigEndian || elemSize == 1 {
+		return
+	}
+	for i := 0; i+elemSize <= len(data); i += elemSize {
+		slices.Reverse(data[i : i+elemSize])
+	}
+}
+
+func hasTagOption(tag, option string) (found bool) {
+	forEachTagOption([]string{tag}, func(opt, _ string) { found = found || opt == option })
+	return found
+}
+
 func forEachTagOption(tags []string, do func(option, args string)) {
 	for _, tag := range tags {
 		_, tag = split(tag) // skip the field name
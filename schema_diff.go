@@ -0,0 +1,157 @@
+package parquet
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// SchemaDiffKind is an enumeration of the kinds of differences reported by
+// Schema.Compare.
+type SchemaDiffKind int
+
+const (
+	// ColumnAdded indicates that a column exists in the other schema only.
+	ColumnAdded SchemaDiffKind = iota
+	// ColumnRemoved indicates that a column exists in the compared schema
+	// only.
+	ColumnRemoved
+	// TypeChanged indicates that a column has different types in the two
+	// schemas, including the change of a leaf column to a group.
+	TypeChanged
+	// RepetitionChanged indicates that a column has different repetition types
+	// (required, optional, or repeated) in the two schemas.
+	RepetitionChanged
+)
+
+// String returns a human-readable representation of k.
+func (k SchemaDiffKind) String() string {
+	switch k {
+	case ColumnAdded:
+		return "added"
+	case ColumnRemoved:
+		return "removed"
+	case TypeChanged:
+		return "type changed"
+	case RepetitionChanged:
+		return "repetition changed"
+	default:
+		return fmt.Sprintf("SchemaDiffKind(%d)", int(k))
+	}
+}
+
+// SchemaDiff represents a difference between two schemas, see Schema.Compare.
+type SchemaDiff struct {
+	// The kind of difference.
+	Kind SchemaDiffKind
+	// The path to the column, which may be a leaf column or a group.
+	Path []string
+	// The node of the column in the compared schema, nil if it was added.
+	Old Node
+	// The node of the column in the other schema, nil if it was removed.
+	New Node
+}
+
+// String returns a human-readable representation of d.
+func (d SchemaDiff) String() string {
+	path := strings.Join(d.Path, ".")
+	switch d.Kind {
+	case ColumnAdded:
+		return fmt.Sprintf("%s: added %s %s", path, repetitionString(d.New), typeString(d.New))
+	case ColumnRemoved:
+		return fmt.Sprintf("%s: removed %s %s", path, repetitionString(d.Old), typeString(d.Old))
+	case TypeChanged:
+		return fmt.Sprintf("%s: type changed from %s to %s", path, typeString(d.Old), typeString(d.New))
+	case RepetitionChanged:
+		return fmt.Sprintf("%s: repetition changed from %s to %s", path, repetitionString(d.Old), repetitionString(d.New))
+	default:
+		return fmt.Sprintf("%s: %s", path, d.Kind)
+	}
+}
+
+// Compare returns the list of differences between s and other, describing the
+// changes that turn s into other.
+//
+// Columns present in only one of the schemas are reported as added or removed,
+// without reporting the columns nested in them when they are groups. Columns
+// present in both schemas are compared by data and logical types, and by
+// repetition types; the fields of groups present in both schemas are compared
+// recursively. Fields are matched by name, the order of fields in groups does
+// not produce differences.
+//
+// The differences are sorted by column path, then by kind, so the output is
+// stable across calls. Compare returns nil if the schemas have no differences.
+func (s *Schema) Compare(other *Schema) []SchemaDiff {
+	diffs := appendSchemaDiffs(nil, nil, s.root, other.root)
+	slices.SortStableFunc(diffs, func(a, b SchemaDiff) int {
+		if c := slices.Compare(a.Path, b.Path); c != 0 {
+			return c
+		}
+		return int(a.Kind) - int(b.Kind)
+	})
+	return diffs
+}
+
+func appendSchemaDiffs(diffs []SchemaDiff, path []string, from, to Node) []SchemaDiff {
+	names := make([]string, 0, len(from.Fields())+len(to.Fields()))
+	for _, f := range from.Fields() {
+		names = append(names, f.Name())
+	}
+	for _, f := range to.Fields() {
+		names = append(names, f.Name())
+	}
+	slices.Sort(names)
+	names = slices.Compact(names)
+
+	for _, name := range names {
+		columnPath := append(slices.Clip(path), name)
+		oldField := fieldByName(from, name)
+		newField := fieldByName(to, name)
+
+		switch {
+		case oldField == nil:
+			diffs = append(diffs, SchemaDiff{Kind: ColumnAdded, Path: columnPath, New: newField})
+		case newField == nil:
+			diffs = append(diffs, SchemaDiff{Kind: ColumnRemoved, Path: columnPath, Old: oldField})
+		default:
+			if !repetitionsAreEqual(oldField, newField) {
+				diffs = append(diffs, SchemaDiff{Kind: RepetitionChanged, Path: columnPath, Old: oldField, New: newField})
+			}
+			switch {
+			case oldField.Leaf() != newField.Leaf():
+				diffs = append(diffs, SchemaDiff{Kind: TypeChanged, Path: columnPath, Old: oldField, New: newField})
+			case oldField.Leaf():
+				if !EqualTypes(oldField.Type(), newField.Type()) {
+					diffs = append(diffs, SchemaDiff{Kind: TypeChanged, Path: columnPath, Old: oldField, New: newField})
+				}
+			default:
+				if !equalLogicalTypes(oldField.Type(), newField.Type()) {
+					diffs = append(diffs, SchemaDiff{Kind: TypeChanged, Path: columnPath, Old: oldField, New: newField})
+				}
+				diffs = appendSchemaDiffs(diffs, columnPath, oldField, newField)
+			}
+		}
+	}
+	return diffs
+}
+
+func repetitionString(node Node) string {
+	switch {
+	case node.Optional():
+		return "optional"
+	case node.Repeated():
+		return "repeated"
+	default:
+		return "required"
+	}
+}
+
+func typeString(node Node) string {
+	if node.Leaf() {
+		return node.Type().String()
+	}
+	if lt := node.Type().LogicalType(); lt != nil {
+		return "group (" + lt.String() + ")"
+	}
+	return "group"
+}
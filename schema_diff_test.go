@@ -0,0 +1,81 @@
+package parquet_test
+
+import (
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestSchemaCompare(t *testing.T) {
+	type Address struct {
+		City    string `parquet:"city"`
+		Country string `parquet:"country"`
+	}
+	type V1 struct {
+		ID      int64    `parquet:"id"`
+		Name    string   `parquet:"name"`
+		Age     int32    `parquet:"age"`
+		Address Address  `parquet:"address"`
+		Tags    []string `parquet:"tags"`
+		Score   float64  `parquet:"score"`
+	}
+
+	type AddressV2 struct {
+		City     string  `parquet:"city"`
+		Zip      *string `parquet:"zip,optional"`
+		Location struct {
+			Lat float64 `parquet:"lat"`
+			Lon float64 `parquet:"lon"`
+		} `parquet:"location"`
+	}
+	type V2 struct {
+		ID      int64     `parquet:"id"`
+		Name    *string   `parquet:"name,optional"`
+		Age     int64     `parquet:"age"`
+		Address AddressV2 `parquet:"address"`
+		Tags    []string  `parquet:"tags,list"`
+		Email   string    `parquet:"email"`
+	}
+
+	v1 := parquet.SchemaOf(new(V1))
+	v2 := parquet.SchemaOf(new(V2))
+
+	want := []string{
+		"address.country: removed required STRING",
+		"address.location: added required group",
+		"address.zip: added optional STRING",
+		"age: type changed from INT(32,true) to INT(64,true)",
+		"email: added required STRING",
+		"name: repetition changed from required to optional",
+		"score: removed required DOUBLE",
+		"tags: type changed from STRING to group (LIST)",
+		"tags: repetition changed from repeated to required",
+	}
+	diffs := v1.Compare(v2)
+	if len(diffs) != len(want) {
+		t.Fatalf("wrong number of differences: want=%d got=%d\n%v", len(want), len(diffs), diffs)
+	}
+	for i, diff := range diffs {
+		if got := diff.String(); got != want[i] {
+			t.Errorf("difference %d: want=%q got=%q", i, want[i], got)
+		}
+	}
+
+	// Reversing the comparison swaps the additions and removals.
+	for _, diff := range v2.Compare(v1) {
+		switch diff.Kind {
+		case parquet.ColumnAdded:
+			if diff.Old != nil || diff.New == nil {
+				t.Errorf("%v: added columns must only have a new node", diff)
+			}
+		case parquet.ColumnRemoved:
+			if diff.Old == nil || diff.New != nil {
+				t.Errorf("%v: removed columns must only have an old node", diff)
+			}
+		}
+	}
+
+	if diffs := v1.Compare(parquet.SchemaOf(new(V1))); diffs != nil {
+		t.Errorf("identical schemas must have no differences: %v", diffs)
+	}
+}
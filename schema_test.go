@@ -2,11 +2,19 @@ package parquet_test
 
 import (
 	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
 	"reflect"
 	"testing"
 	"time"
 
 	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/compress"
+	"github.com/parquet-go/parquet-go/compress/brotli"
+	"github.com/parquet-go/parquet-go/compress/gzip"
+	"github.com/parquet-go/parquet-go/compress/zstd"
 )
 
 func TestSchemaOf(t *testing.T) {
@@ -334,6 +342,49 @@ func TestInvalidSchemaOf(t *testing.T) {
 		value any
 		panic string
 	}{
+		// Group tags must be on structs
+		{
+			value: new(struct {
+				Name string `parquet:",group"`
+			}),
+			panic: `group is an invalid parquet tag: Name string [group]`,
+		},
+
+		// Decimal rounding modes only apply to floats, which are limited to
+		// the precision of INT64 columns.
+		{
+			value: new(struct {
+				Price int64 `parquet:",decimal(2:10:round=half-up)"`
+			}),
+			panic: `decimal(2:10:round=half-up) is an invalid parquet tag: Price int64 [decimal(2:10:round=half-up)]`,
+		},
+		{
+			value: new(struct {
+				Price float64 `parquet:",decimal(2:10:round=ceil)"`
+			}),
+			panic: `decimal(2:10:round=ceil) is an invalid parquet tag: Price float64 [decimal(2:10:round=ceil)]`,
+		},
+		{
+			value: new(struct {
+				Price float64 `parquet:",decimal(2:20)"`
+			}),
+			panic: `decimal(2:20) is an invalid parquet tag: Price float64 [decimal(2:20)]`,
+		},
+
+		// Bloom filter tags accept a false positive probability.
+		{
+			value: new(struct {
+				Name string `parquet:",bloom(fpp=2)"`
+			}),
+			panic: `bloom(fpp=2) is an invalid parquet tag: Name string [bloom(fpp=2)]`,
+		},
+		{
+			value: new(struct {
+				Name string `parquet:",bloom(10)"`
+			}),
+			panic: `bloom(10) is an invalid parquet tag: Name string [bloom(10)]`,
+		},
+
 		// Date tags must be int32
 		{
 			value: new(struct {
@@ -440,6 +491,48 @@ func TestInvalidSchemaOf(t *testing.T) {
 			}),
 			panic: `timestamp(millisecond:utc:local) is an invalid parquet tag: Timestamp time.Time [timestamp(millisecond:utc:local)]`,
 		},
+		{
+			value: new(struct {
+				Labels []string `parquet:",map"`
+			}),
+			panic: `map is an invalid parquet tag: Labels []string [map]`,
+		},
+		{
+			value: new(struct {
+				Data []byte `parquet:",zstd(23)"`
+			}),
+			panic: `zstd(23) is an invalid parquet tag: Data []uint8 [zstd(23)]`,
+		},
+		{
+			value: new(struct {
+				Data []byte `parquet:",gzip(level=fast)"`
+			}),
+			panic: `gzip(level=fast) is an invalid parquet tag: Data []uint8 [gzip(level=fast)]`,
+		},
+		{
+			value: new(struct {
+				Elapsed int64 `parquet:",duration"`
+			}),
+			panic: `duration is an invalid parquet tag: Elapsed int64 [duration]`,
+		},
+		{
+			value: new(struct {
+				Elapsed time.Duration `parquet:",duration(hour)"`
+			}),
+			panic: `duration(hour) is an invalid parquet tag: Elapsed time.Duration [duration(hour)]`,
+		},
+		{
+			value: new(struct {
+				Count int32 `parquet:",bitpacked"`
+			}),
+			panic: `bitpacked is an invalid parquet tag: Count int32 [bitpacked]`,
+		},
+		{
+			value: new(struct {
+				ID [16]byte `parquet:",deltalength"`
+			}),
+			panic: `deltalength is an invalid parquet tag: ID [16]uint8 [deltalength]`,
+		},
 	}
 
 	for _, test := range tests {
@@ -772,3 +865,981 @@ func TestSchemaRoundTrip(t *testing.T) {
 		})
 	}
 }
+
+func TestSchemaOfGroupCompression(t *testing.T) {
+	type Headers struct {
+		ContentType string `parquet:"content_type"`
+		Encoding    string `parquet:"encoding"`
+	}
+	type Payload struct {
+		Body     string   `parquet:"body"`
+		Checksum string   `parquet:"checksum,snappy"`
+		Headers  Headers  `parquet:"headers"`
+		Labels   []string `parquet:"labels,list"`
+	}
+	type Event struct {
+		ID      int64    `parquet:"id"`
+		Payload Payload  `parquet:"payload,zstd"`
+		Extra   *Headers `parquet:"extra,gzip"`
+	}
+
+	schema := parquet.SchemaOf(new(Event))
+
+	tests := []struct {
+		path  []string
+		codec string
+	}{
+		{path: []string{"id"}, codec: ""},
+		{path: []string{"payload", "body"}, codec: "ZSTD"},
+		{path: []string{"payload", "checksum"}, codec: "SNAPPY"},
+		{path: []string{"payload", "headers", "content_type"}, codec: "ZSTD"},
+		{path: []string{"payload", "headers", "encoding"}, codec: "ZSTD"},
+		{path: []string{"payload", "labels", "list", "element"}, codec: "ZSTD"},
+		{path: []string{"extra", "content_type"}, codec: "GZIP"},
+		{path: []string{"extra", "encoding"}, codec: "GZIP"},
+	}
+
+	for _, test := range tests {
+		leaf, ok := schema.Lookup(test.path...)
+		if !ok {
+			t.Fatalf("column %q not found in schema", test.path)
+		}
+		codec := ""
+		if c := leaf.Node.Compression(); c != nil {
+			codec = c.CompressionCodec().String()
+		}
+		if codec != test.codec {
+			t.Errorf("wrong codec for column %q: want=%q got=%q", test.path, test.codec, codec)
+		}
+	}
+
+	rows := []Event{
+		{ID: 1, Payload: Payload{Body: "hello", Checksum: "abc", Headers: Headers{ContentType: "text/plain"}, Labels: []string{"a", "b"}}},
+		{ID: 2, Payload: Payload{Labels: []string{}}, Extra: &Headers{ContentType: "application/json", Encoding: "utf-8"}},
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, column := range f.Metadata().RowGroups[0].Columns {
+		codec := column.MetaData.Codec.String()
+		want := tests[i].codec
+		if want == "" {
+			want = "UNCOMPRESSED"
+		}
+		if codec != want {
+			t.Errorf("wrong codec for column chunk %q: want=%q got=%q", column.MetaData.PathInSchema, want, codec)
+		}
+	}
+
+	got, err := parquet.Read[Event](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, rows) {
+		t.Errorf("rows mismatch:\nwant: %+v\ngot:  %+v", rows, got)
+	}
+}
+
+func TestSchemaOfMakePointersRequired(t *testing.T) {
+	type Inner struct {
+		Value int64 `parquet:"value"`
+	}
+	type Row struct {
+		ID    *int64  `parquet:"id"`
+		Note  *string `parquet:"note,optional"`
+		Inner *Inner  `parquet:"inner"`
+	}
+
+	schema := parquet.SchemaOf(Row{}, parquet.MakePointersRequired())
+
+	const want = `message Row {
+	required int64 id (INT(64,true));
+	optional binary note (STRING);
+	required group inner {
+		required int64 value (INT(64,true));
+	}
+}`
+	if got := schema.String(); got != want {
+		t.Fatalf("schema mismatch\nwant:\n%s\ngot:\n%s", want, got)
+	}
+
+	if leaf, _ := parquet.SchemaOf(Row{}).Lookup("id"); !leaf.Node.Optional() {
+		t.Error("schemas created without the option must keep pointers optional")
+	}
+
+	id, note := int64(1), "hello"
+	rows := []Row{
+		{ID: &id, Note: &note, Inner: &Inner{Value: 3}},
+		{ID: &id, Inner: &Inner{Value: 4}},
+	}
+
+	buffer := new(bytes.Buffer)
+	writer := parquet.NewGenericWriter[Row](buffer, schema)
+	if _, err := writer.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := parquet.Read[Row](bytes.NewReader(buffer.Bytes()), int64(buffer.Len()), schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, rows) {
+		t.Errorf("rows mismatch\nwant = %+v\ngot  = %+v", rows, got)
+	}
+
+	writer = parquet.NewGenericWriter[Row](new(bytes.Buffer), schema)
+	if _, err := writer.Write([]Row{{Inner: &Inner{}}}); err == nil {
+		t.Error("expected an error writing a nil pointer to a required column")
+	}
+}
+
+func TestSchemaOfPointerLogicalTypes(t *testing.T) {
+	type Inner struct {
+		Value string `json:"value"`
+	}
+	type Row struct {
+		Enum       *string   `parquet:"enum,enum"`
+		UUIDString *string   `parquet:"uuid_string,uuid"`
+		UUIDArray  *[16]byte `parquet:"uuid_array,uuid"`
+		JSONString *string   `parquet:"json_string,json"`
+		JSONBytes  *[]byte   `parquet:"json_bytes,json"`
+		JSONObject *Inner    `parquet:"json_object,json"`
+	}
+
+	const want = `message Row {
+	optional binary enum (ENUM);
+	optional fixed_len_byte_array(16) uuid_string (UUID);
+	optional fixed_len_byte_array(16) uuid_array (UUID);
+	optional binary json_string (JSON);
+	optional binary json_bytes (JSON);
+	required binary json_object (JSON);
+}`
+	if got := parquet.SchemaOf(Row{}).String(); got != want {
+		t.Fatalf("schema mismatch\nwant:\n%s\ngot:\n%s", want, got)
+	}
+
+	enum := "RED"
+	uuidString := "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	uuidArray := [16]byte{0: 1, 15: 2}
+	jsonString := `{"hello":"world"}`
+	jsonBytes := []byte(`[1,2,3]`)
+	rows := []Row{
+		{
+			Enum:       &enum,
+			UUIDString: &uuidString,
+			UUIDArray:  &uuidArray,
+			JSONString: &jsonString,
+			JSONBytes:  &jsonBytes,
+			JSONObject: &Inner{Value: "answer"},
+		},
+		{},
+	}
+
+	t.Run("generic", func(t *testing.T) {
+		buffer := new(bytes.Buffer)
+		if err := parquet.Write(buffer, rows); err != nil {
+			t.Fatal(err)
+		}
+		got, err := parquet.Read[Row](bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, rows) {
+			t.Errorf("rows mismatch\nwant = %+v\ngot  = %+v", rows, got)
+		}
+	})
+
+	t.Run("rows", func(t *testing.T) {
+		// JSON documents marshaled from Go values are only supported by the
+		// generic writers, the field is omitted from the rows deconstructed by
+		// the schema.
+		type RowWithoutObject struct {
+			Enum       *string   `parquet:"enum,enum"`
+			UUIDString *string   `parquet:"uuid_string,uuid"`
+			UUIDArray  *[16]byte `parquet:"uuid_array,uuid"`
+			JSONString *string   `parquet:"json_string,json"`
+			JSONBytes  *[]byte   `parquet:"json_bytes,json"`
+		}
+		schema := parquet.SchemaOf(RowWithoutObject{})
+
+		for _, row := range rows {
+			want := RowWithoutObject{row.Enum, row.UUIDString, row.UUIDArray, row.JSONString, row.JSONBytes}
+			var got RowWithoutObject
+			if err := schema.Reconstruct(&got, schema.Deconstruct(nil, want)); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("row mismatch\nwant = %+v\ngot  = %+v", want, got)
+			}
+		}
+
+		for i, v := range schema.Deconstruct(nil, RowWithoutObject{}) {
+			if !v.IsNull() {
+				t.Errorf("value of column %d is not null: %v", i, v)
+			}
+		}
+	})
+}
+
+func TestSchemaOfNormalizeTimestamps(t *testing.T) {
+	type Row struct {
+		Millis    time.Time  `parquet:"millis,timestamp(millisecond)"`
+		Nanos     time.Time  `parquet:"nanos,timestamp(nanosecond)"`
+		Default   time.Time  `parquet:"default"`
+		Pointer   *time.Time `parquet:"pointer,timestamp(millisecond)"`
+		IntMillis int64      `parquet:"int_millis,timestamp(millisecond)"`
+		IntNanos  int64      `parquet:"int_nanos,timestamp(nanosecond:local)"`
+	}
+
+	schema := parquet.SchemaOf(Row{}, parquet.NormalizeTimestamps(parquet.Microsecond))
+
+	const want = `message Row {
+	required int64 millis (TIMESTAMP(isAdjustedToUTC=true,unit=MICROS));
+	required int64 nanos (TIMESTAMP(isAdjustedToUTC=true,unit=MICROS));
+	required int64 default (TIMESTAMP(isAdjustedToUTC=true,unit=MICROS));
+	optional int64 pointer (TIMESTAMP(isAdjustedToUTC=true,unit=MICROS));
+	required int64 int_millis (TIMESTAMP(isAdjustedToUTC=true,unit=MICROS));
+	required int64 int_nanos (TIMESTAMP(isAdjustedToUTC=false,unit=MICROS));
+}`
+	if got := schema.String(); got != want {
+		t.Fatalf("schema mismatch\nwant:\n%s\ngot:\n%s", want, got)
+	}
+
+	ts := time.Date(2024, 5, 6, 7, 8, 9, 123456789, time.UTC)
+	row := Row{
+		Millis:    ts.Truncate(time.Millisecond),
+		Nanos:     ts,
+		Default:   ts,
+		Pointer:   &ts,
+		IntMillis: ts.UnixMilli(),
+		IntNanos:  ts.UnixNano(),
+	}
+	// Values are truncated to microseconds when written, int64 fields are
+	// converted back to the unit of their struct tag when read.
+	micros := ts.Truncate(time.Microsecond)
+	wantRow := Row{
+		Millis:    ts.Truncate(time.Millisecond),
+		Nanos:     micros,
+		Default:   micros,
+		Pointer:   &micros,
+		IntMillis: ts.UnixMilli(),
+		IntNanos:  micros.UnixNano(),
+	}
+	wantValues := []int64{
+		ts.Truncate(time.Millisecond).UnixMicro(),
+		ts.UnixMicro(),
+		ts.UnixMicro(),
+		ts.UnixMicro(),
+		ts.Truncate(time.Millisecond).UnixMicro(),
+		ts.UnixMicro(),
+	}
+
+	for _, test := range []struct {
+		scenario string
+		write    func(io.Writer) error
+	}{
+		{
+			scenario: "generic writer",
+			write: func(w io.Writer) error {
+				writer := parquet.NewGenericWriter[Row](w, schema)
+				if _, err := writer.Write([]Row{row}); err != nil {
+					return err
+				}
+				return writer.Close()
+			},
+		},
+		{
+			scenario: "writer",
+			write: func(w io.Writer) error {
+				writer := parquet.NewWriter(w, schema)
+				if err := writer.Write(&row); err != nil {
+					return err
+				}
+				return writer.Close()
+			},
+		},
+	} {
+		t.Run(test.scenario, func(t *testing.T) {
+			buffer := new(bytes.Buffer)
+			if err := test.write(buffer); err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			rows := make([]parquet.Row, 1)
+			reader := parquet.NewReader(f)
+			if n, _ := reader.ReadRows(rows); n != 1 {
+				t.Fatalf("wrong number of rows: %d", n)
+			}
+			reader.Close()
+			for i, v := range rows[0] {
+				if v.Int64() != wantValues[i] {
+					t.Errorf("wrong value in column %d: want=%d got=%d", i, wantValues[i], v.Int64())
+				}
+			}
+
+			got, err := parquet.Read[Row](bytes.NewReader(buffer.Bytes()), int64(buffer.Len()), schema)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != 1 || !reflect.DeepEqual(got[0], wantRow) {
+				t.Errorf("rows mismatch\nwant = %+v\ngot  = %+v", wantRow, got)
+			}
+		})
+	}
+}
+
+func TestSchemaOfSortMapKeys(t *testing.T) {
+	type Row struct {
+		Labels map[string]int64  `parquet:"labels"`
+		IDs    map[uint32]string `parquet:"ids"`
+		Scores map[float64]bool  `parquet:"scores"`
+	}
+
+	schema := parquet.SchemaOf(Row{}, parquet.SortMapKeys())
+
+	rows := make([]Row, 10)
+	for i := range rows {
+		rows[i] = Row{
+			Labels: map[string]int64{},
+			IDs:    map[uint32]string{},
+			Scores: map[float64]bool{},
+		}
+		for j := range 20 {
+			rows[i].Labels[fmt.Sprintf("label-%02d", (j*7)%20)] = int64(j)
+			rows[i].IDs[uint32(19-j)*200_000_000] = fmt.Sprint(j)
+			rows[i].Scores[float64(j)-9.5] = j%2 == 0
+		}
+	}
+
+	writeRows := map[string]func(io.Writer) error{
+		"generic writer": func(w io.Writer) error {
+			writer := parquet.NewGenericWriter[Row](w, schema)
+			if _, err := writer.Write(rows); err != nil {
+				return err
+			}
+			return writer.Close()
+		},
+		"writer": func(w io.Writer) error {
+			writer := parquet.NewWriter(w, schema)
+			for i := range rows {
+				if err := writer.Write(&rows[i]); err != nil {
+					return err
+				}
+			}
+			return writer.Close()
+		},
+	}
+
+	for scenario, write := range writeRows {
+		t.Run(scenario, func(t *testing.T) {
+			buffer := new(bytes.Buffer)
+			if err := write(buffer); err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, path := range [][]string{
+				{"labels", "key_value", "key"},
+				{"ids", "key_value", "key"},
+				{"scores", "key_value", "key"},
+			} {
+				leaf, _ := f.Schema().Lookup(path...)
+				values := make([]parquet.Value, 0, 20*len(rows))
+				chunk := f.RowGroups()[0].ColumnChunks()[leaf.ColumnIndex]
+				r := parquet.NewColumnChunkValueReader(chunk)
+				buf := make([]parquet.Value, 50)
+				for {
+					n, err := r.ReadValues(buf)
+					for _, v := range buf[:n] {
+						values = append(values, v.Clone())
+					}
+					if err != nil {
+						break
+					}
+				}
+				r.Close()
+
+				if len(values) != 20*len(rows) {
+					t.Fatalf("%s: wrong number of keys: %d", path[0], len(values))
+				}
+				for i := 1; i < len(values); i++ {
+					if values[i].RepetitionLevel() == 0 {
+						continue
+					}
+					if leaf.Node.Type().Compare(values[i-1], values[i]) >= 0 {
+						t.Fatalf("%s: keys are not in ascending order: %v >= %v", path[0], values[i-1], values[i])
+					}
+				}
+			}
+
+			got, err := parquet.Read[Row](bytes.NewReader(buffer.Bytes()), int64(buffer.Len()), schema)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, rows) {
+				t.Error("rows mismatch after reading back the file")
+			}
+		})
+	}
+
+	t.Run("duplicate keys", func(t *testing.T) {
+		writer := parquet.NewGenericWriter[Row](new(bytes.Buffer), schema)
+		row := Row{Scores: map[float64]bool{math.NaN(): true, math.NaN(): false}}
+		_, err := writer.Write([]Row{row})
+		if err == nil {
+			t.Fatal("expected an error writing duplicate keys to a sorted map")
+		}
+
+		// Writing the row through the parquet.Row deconstruction returns the
+		// same error.
+		w := parquet.NewWriter(new(bytes.Buffer), schema)
+		if rowErr := w.Write(&row); rowErr == nil || rowErr.Error() != err.Error() {
+			t.Errorf("wrong error writing duplicate keys with Writer.Write:\nwant: %v\ngot:  %v", err, rowErr)
+		}
+	})
+
+	t.Run("unsorted keys", func(t *testing.T) {
+		type Labels struct {
+			Labels map[string]int64 `parquet:"labels"`
+		}
+		buffer := new(bytes.Buffer)
+		writer := parquet.NewWriter(buffer, parquet.SchemaOf(Labels{}))
+		if _, err := writer.WriteRows([]parquet.Row{{
+			parquet.ByteArrayValue([]byte("b")).Level(0, 1, 0),
+			parquet.ByteArrayValue([]byte("a")).Level(1, 1, 0),
+			parquet.Int64Value(1).Level(0, 1, 1),
+			parquet.Int64Value(2).Level(1, 1, 1),
+		}}); err != nil {
+			t.Fatal(err)
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		reader := bytes.NewReader(buffer.Bytes())
+		if _, err := parquet.Read[Labels](reader, reader.Size()); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := parquet.Read[Labels](reader, reader.Size(), parquet.SchemaOf(Labels{}, parquet.SortMapKeys())); err == nil {
+			t.Error("expected an error reading unsorted keys into a sorted map")
+		}
+	})
+}
+
+func TestSchemaOfMapTag(t *testing.T) {
+	type Row struct {
+		Labels   map[string]int64 `parquet:"labels,map"`
+		Optional map[string]int64 `parquet:"optional,map,optional"`
+	}
+
+	schema := parquet.SchemaOf(Row{})
+	want := `message Row {
+	required group labels (MAP) {
+		repeated group key_value {
+			required binary key (STRING);
+			required int64 value (INT(64,true));
+		}
+	}
+	optional group optional (MAP) {
+		repeated group key_value {
+			required binary key (STRING);
+			required int64 value (INT(64,true));
+		}
+	}
+}`
+	if got := schema.String(); got != want {
+		t.Fatalf("wrong schema:\nwant:\n%s\ngot:\n%s", want, got)
+	}
+
+	rows := []Row{
+		{Labels: nil, Optional: nil},
+		{Labels: map[string]int64{}, Optional: map[string]int64{}},
+		{Labels: map[string]int64{"a": 1}, Optional: map[string]int64{"b": 2, "c": 3}},
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, _ := f.Schema().Lookup("optional", "key_value", "key")
+	values := make([]parquet.Value, 10)
+	n, _ := parquet.NewColumnChunkValueReader(f.RowGroups()[0].ColumnChunks()[leaf.ColumnIndex]).ReadValues(values)
+	// The nil map is null, the empty map is defined but has no key_value
+	// entries, the last map has two entries.
+	wantLevels := [][2]int{{0, 0}, {0, 1}, {0, 2}, {1, 2}}
+	if n != len(wantLevels) {
+		t.Fatalf("wrong number of values: want=%d got=%d", len(wantLevels), n)
+	}
+	for i, v := range values[:n] {
+		if got := [2]int{v.RepetitionLevel(), v.DefinitionLevel()}; got != wantLevels[i] {
+			t.Errorf("wrong levels of value %d: want=%v got=%v", i, wantLevels[i], got)
+		}
+	}
+
+	got, err := parquet.Read[Row](bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got[0].Optional != nil {
+		t.Errorf("nil map must be read back as nil, got %#v", got[0].Optional)
+	}
+	if got[1].Optional == nil || len(got[1].Optional) != 0 {
+		t.Errorf("empty map must be read back as an empty non-nil map, got %#v", got[1].Optional)
+	}
+	if !reflect.DeepEqual(got[2], rows[2]) {
+		t.Errorf("wrong row: want=%+v got=%+v", rows[2], got[2])
+	}
+}
+
+func TestSchemaFieldIDRoundTrip(t *testing.T) {
+	type Address struct {
+		City    string `parquet:"city,fieldid(11)"`
+		Country string `parquet:"country,id(12)"`
+	}
+	type Row struct {
+		ID      int64             `parquet:"id,fieldid(1)"`
+		Address *Address          `parquet:"address,optional,fieldid(2)"`
+		Tags    []string          `parquet:"tags,list,fieldid(3)" parquet-element:",fieldid(4)"`
+		Attrs   map[string]string `parquet:"attrs,fieldid(5)" parquet-key:",fieldid(6)" parquet-value:",fieldid(7)"`
+	}
+
+	want := map[string]int{
+		"id":                    1,
+		"address":               2,
+		"address.city":          11,
+		"address.country":       12,
+		"tags":                  3,
+		"tags.list":             0,
+		"tags.list.element":     4,
+		"attrs":                 5,
+		"attrs.key_value":       0,
+		"attrs.key_value.key":   6,
+		"attrs.key_value.value": 7,
+	}
+
+	fieldIDs := func(root parquet.Node) map[string]int {
+		ids := make(map[string]int)
+		var walk func(parquet.Node, string)
+		walk = func(node parquet.Node, prefix string) {
+			for _, field := range node.Fields() {
+				path := prefix + field.Name()
+				ids[path] = field.ID()
+				walk(field, path+".")
+			}
+		}
+		walk(root, "")
+		return ids
+	}
+
+	if got := fieldIDs(parquet.SchemaOf(new(Row))); !reflect.DeepEqual(got, want) {
+		t.Fatalf("wrong field ids in schema:\nwant = %v\ngot  = %v", want, got)
+	}
+
+	rows := []Row{{
+		ID:      42,
+		Address: &Address{City: "Paris", Country: "FR"},
+		Tags:    []string{"a", "b"},
+		Attrs:   map[string]string{"k": "v"},
+	}}
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fieldIDs(f.Schema()); !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong field ids read from the file:\nwant = %v\ngot  = %v", want, got)
+	}
+
+	// Writing the schema read from the file must preserve the field ids.
+	out := new(bytes.Buffer)
+	w := parquet.NewWriter(out, f.Schema())
+	if _, err := parquet.CopyRows(w, f.RowGroups()[0].Rows()); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	f, err = parquet.OpenFile(bytes.NewReader(out.Bytes()), int64(out.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fieldIDs(f.Schema()); !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong field ids after rewriting the file:\nwant = %v\ngot  = %v", want, got)
+	}
+
+	got, err := parquet.Read[Row](bytes.NewReader(out.Bytes()), int64(out.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, rows) {
+		t.Errorf("rows mismatch:\nwant = %+v\ngot  = %+v", rows, got)
+	}
+}
+
+func TestSchemaOfDuration(t *testing.T) {
+	type Row struct {
+		Default time.Duration  `parquet:"default"`
+		Nanos   time.Duration  `parquet:"nanos,duration"`
+		Micros  time.Duration  `parquet:"micros,duration(microsecond)"`
+		Millis  time.Duration  `parquet:"millis,duration(millisecond)"`
+		Pointer *time.Duration `parquet:"pointer,duration(microsecond)"`
+	}
+
+	schema := parquet.SchemaOf(Row{})
+	want := `message Row {
+	required int64 default (INT(64,true));
+	required int64 nanos (INT(64,true));
+	required int64 micros (INT(64,true));
+	required int64 millis (INT(64,true));
+	optional int64 pointer (INT(64,true));
+}`
+	if got := schema.String(); got != want {
+		t.Fatalf("wrong schema:\nwant:\n%s\ngot:\n%s", want, got)
+	}
+
+	d := 36*time.Hour + 1234567891*time.Nanosecond
+	rows := []Row{
+		{Default: d, Nanos: -d, Micros: d, Millis: d, Pointer: &d},
+		{Default: 1, Nanos: math.MaxInt64, Micros: -d, Millis: -d},
+	}
+
+	buffer := new(bytes.Buffer)
+	writer := parquet.NewGenericWriter[Row](buffer)
+	if _, err := writer.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := make([]parquet.Row, 1)
+	if _, err := f.RowGroups()[0].Rows().ReadRows(values); err != nil && err != io.EOF {
+		t.Fatal(err)
+	}
+	stored := []int64{int64(d), int64(-d), d.Microseconds(), d.Milliseconds(), d.Microseconds()}
+	for i, v := range values[0] {
+		if v.Int64() != stored[i] {
+			t.Errorf("wrong value stored in column %d: want=%d got=%d", i, stored[i], v.Int64())
+		}
+	}
+	units := []string{"", "NANOS", "MICROS", "MILLIS", "MICROS"}
+	for i, chunk := range f.Metadata().RowGroups[0].Columns {
+		unit := ""
+		for _, kv := range chunk.MetaData.KeyValueMetadata {
+			if kv.Key == "parquet-go.duration.unit" {
+				unit = kv.Value
+			}
+		}
+		if unit != units[i] {
+			t.Errorf("wrong duration unit recorded for column %d: want=%q got=%q", i, units[i], unit)
+		}
+	}
+
+	got := make([]Row, len(rows))
+	n, err := parquet.NewGenericReader[Row](f).Read(got)
+	if n != len(rows) {
+		t.Fatalf("wrong number of rows: want=%d got=%d (%v)", len(rows), n, err)
+	}
+	micros := d.Truncate(time.Microsecond)
+	want0 := Row{Default: d, Nanos: -d, Micros: micros, Millis: d.Truncate(time.Millisecond), Pointer: &micros}
+	want1 := Row{Default: 1, Nanos: math.MaxInt64, Micros: -micros, Millis: -d.Truncate(time.Millisecond)}
+	if !reflect.DeepEqual(got[0], want0) {
+		t.Errorf("wrong first row:\nwant: %+v\ngot:  %+v", want0, got[0])
+	}
+	if !reflect.DeepEqual(got[1], want1) {
+		t.Errorf("wrong second row:\nwant: %+v\ngot:  %+v", want1, got[1])
+	}
+}
+
+func TestSchemaOfCompressionLevel(t *testing.T) {
+	type Row struct {
+		Cold    []byte `parquet:"cold,zstd(level=19)"`
+		Hot     []byte `parquet:"hot,zstd(1)"`
+		Warm    []byte `parquet:"warm,zstd(7)"`
+		Default []byte `parquet:"default,zstd"`
+		Gzip    []byte `parquet:"gzip,gzip(3)"`
+		Brotli  []byte `parquet:"brotli,brotli(level=11)"`
+	}
+
+	schema := parquet.SchemaOf(Row{})
+	codecOf := func(name string) compress.Codec {
+		leaf, ok := schema.Lookup(name)
+		if !ok {
+			t.Fatalf("column %q not found", name)
+		}
+		return leaf.Node.Compression()
+	}
+
+	if codec, ok := codecOf("cold").(*zstd.Codec); !ok || codec.Level != zstd.SpeedBestCompression {
+		t.Errorf("wrong codec of cold column: %#v", codecOf("cold"))
+	}
+	if codec, ok := codecOf("hot").(*zstd.Codec); !ok || codec.Level != zstd.SpeedFastest {
+		t.Errorf("wrong codec of hot column: %#v", codecOf("hot"))
+	}
+	if codec, ok := codecOf("warm").(*zstd.Codec); !ok || codec.Level != zstd.SpeedBetterCompression {
+		t.Errorf("wrong codec of warm column: %#v", codecOf("warm"))
+	}
+	if codec := codecOf("default"); codec != &parquet.Zstd {
+		t.Errorf("columns without a level must use the default codec: %#v", codec)
+	}
+	if codec, ok := codecOf("gzip").(*gzip.Codec); !ok || codec.Level != 3 {
+		t.Errorf("wrong codec of gzip column: %#v", codecOf("gzip"))
+	}
+	if codec, ok := codecOf("brotli").(*brotli.Codec); !ok || codec.Quality != 11 {
+		t.Errorf("wrong codec of brotli column: %#v", codecOf("brotli"))
+	}
+
+	rows := []Row{{
+		Cold:    bytes.Repeat([]byte("cold"), 100),
+		Hot:     bytes.Repeat([]byte("hot"), 100),
+		Warm:    bytes.Repeat([]byte("warm"), 100),
+		Default: []byte("default"),
+		Gzip:    bytes.Repeat([]byte("gzip"), 100),
+		Brotli:  bytes.Repeat([]byte("brotli"), 100),
+	}}
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows); err != nil {
+		t.Fatal(err)
+	}
+	got, err := parquet.Read[Row](bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, rows) {
+		t.Errorf("rows mismatch:\nwant: %+v\ngot:  %+v", rows, got)
+	}
+}
+
+type EmbeddedMeta struct {
+	Source  string `parquet:"source"`
+	Version int32  `parquet:"version"`
+}
+
+func TestSchemaOfEmbeddedGroup(t *testing.T) {
+	type Flattened struct {
+		ID int64 `parquet:"id"`
+		EmbeddedMeta
+	}
+
+	type Nested struct {
+		ID           int64 `parquet:"id"`
+		EmbeddedMeta `parquet:"meta,group"`
+	}
+
+	type Optional struct {
+		ID            int64 `parquet:"id"`
+		*EmbeddedMeta `parquet:",group"`
+	}
+
+	for _, test := range []struct {
+		value any
+		want  string
+	}{
+		{
+			value: Flattened{},
+			want: `message Flattened {
+	required int64 id (INT(64,true));
+	required binary source (STRING);
+	required int32 version (INT(32,true));
+}`,
+		},
+		{
+			value: Nested{},
+			want: `message Nested {
+	required int64 id (INT(64,true));
+	required group meta {
+		required binary source (STRING);
+		required int32 version (INT(32,true));
+	}
+}`,
+		},
+		{
+			value: Optional{},
+			want: `message Optional {
+	required int64 id (INT(64,true));
+	optional group EmbeddedMeta {
+		required binary source (STRING);
+		required int32 version (INT(32,true));
+	}
+}`,
+		},
+	} {
+		if got := parquet.SchemaOf(test.value).String(); got != test.want {
+			t.Errorf("wrong schema:\nwant:\n%s\ngot:\n%s", test.want, got)
+		}
+	}
+
+	rows := []Nested{
+		{ID: 1, EmbeddedMeta: EmbeddedMeta{Source: "a", Version: 1}},
+		{ID: 2, EmbeddedMeta: EmbeddedMeta{Source: "b", Version: 2}},
+	}
+	buffer := new(bytes.Buffer)
+	writer := parquet.NewGenericWriter[Nested](buffer)
+	if _, err := writer.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	got := make([]Nested, len(rows))
+	n, err := parquet.NewGenericReader[Nested](bytes.NewReader(buffer.Bytes())).Read(got)
+	if err != nil && err != io.EOF {
+		t.Fatal(err)
+	}
+	if n != len(rows) || !reflect.DeepEqual(got, rows) {
+		t.Errorf("wrong rows:\nwant: %+v\ngot:  %+v", rows, got[:n])
+	}
+}
+
+type recursiveTree struct {
+	Value    int64            `parquet:"value"`
+	Children []*recursiveTree `parquet:"children"`
+}
+
+type recursiveA struct {
+	B *recursiveB `parquet:"b"`
+}
+
+type recursiveB struct {
+	Name string        `parquet:"name"`
+	A    []*recursiveA `parquet:"a,list"`
+}
+
+func TestSchemaOfRecursiveType(t *testing.T) {
+	for _, test := range []struct {
+		value any
+		panic string
+	}{
+		{
+			value: recursiveTree{},
+			panic: "cannot create parquet schema of recursive type parquet_test.recursiveTree: field recursiveTree.children refers back to parquet_test.recursiveTree",
+		},
+		{
+			value: recursiveA{},
+			panic: "cannot create parquet schema of recursive type parquet_test.recursiveA: field recursiveA.b.a refers back to parquet_test.recursiveA",
+		},
+		{
+			value: struct {
+				ID   int64         `parquet:"id"`
+				Tree recursiveTree `parquet:"tree"`
+			}{},
+			panic: "cannot create parquet schema of recursive type parquet_test.recursiveTree: field recursiveTree.children refers back to parquet_test.recursiveTree",
+		},
+	} {
+		t.Run(fmt.Sprintf("%T", test.value), func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != test.panic {
+					t.Errorf("wrong panic:\nwant: %v\ngot:  %v", test.panic, r)
+				}
+			}()
+			parquet.SchemaOf(test.value)
+		})
+	}
+
+	// Recursive fields stored as JSON documents do not need to be broken
+	// down into columns.
+	type Document struct {
+		ID   int64          `parquet:"id"`
+		Tree *recursiveTree `parquet:"tree,json"`
+	}
+	want := `message Document {
+	required int64 id (INT(64,true));
+	required binary tree (JSON);
+}`
+	if got := parquet.SchemaOf(Document{}).String(); got != want {
+		t.Errorf("wrong schema:\nwant:\n%s\ngot:\n%s", want, got)
+	}
+
+	rows := []Document{{
+		ID:   1,
+		Tree: &recursiveTree{Value: 1, Children: []*recursiveTree{{Value: 2}, {Value: 3}}},
+	}}
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows); err != nil {
+		t.Fatal(err)
+	}
+	got, err := parquet.Read[Document](bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, rows) {
+		t.Errorf("wrong rows:\nwant: %+v\ngot:  %+v", rows, got)
+	}
+}
+
+func TestSchemaOfJSONRawMessage(t *testing.T) {
+	type Row struct {
+		Document json.RawMessage   `parquet:"document"`
+		Optional *json.RawMessage  `parquet:"optional"`
+		Repeated []json.RawMessage `parquet:"repeated"`
+		Bytes    []byte            `parquet:"bytes"`
+	}
+
+	const want = `message Row {
+	required binary document (JSON);
+	optional binary optional (JSON);
+	repeated binary repeated (JSON);
+	required binary bytes;
+}`
+	if got := parquet.SchemaOf(new(Row)).String(); got != want {
+		t.Fatalf("wrong schema:\nwant:\n%s\ngot:\n%s", want, got)
+	}
+
+	doc := json.RawMessage(`{"a":[1,2]}`)
+	rows := []Row{
+		{
+			Document: json.RawMessage(`{"name":"bob"}`),
+			Optional: &doc,
+			Repeated: []json.RawMessage{json.RawMessage(`1`), json.RawMessage(`"two"`)},
+			Bytes:    []byte(`{"not":"json"}`),
+		},
+		{
+			Document: json.RawMessage(`null`),
+			Repeated: []json.RawMessage{},
+			Bytes:    []byte{},
+		},
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows); err != nil {
+		t.Fatal(err)
+	}
+	got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, rows) {
+		t.Errorf("wrong rows:\nwant: %+v\ngot:  %+v", rows, got)
+	}
+}
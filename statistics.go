@@ -0,0 +1,61 @@
+package parquet
+
+import (
+	"fmt"
+
+	"github.com/parquet-go/parquet-go/format"
+)
+
+// MergeStatistics merges the statistics of pages of a column of type typ into
+// the statistics of a column chunk holding all those pages.
+//
+// The function is intended for programs assembling parquet files from pages
+// that were encoded elsewhere (e.g. when copying raw pages from other files),
+// which must produce column chunk statistics matching the pages they contain.
+// It may also be used to merge the statistics of column chunks into those of
+// a larger chunk.
+//
+// Null counts are summed, and the minimum and maximum values are selected
+// based on the ordering rules of typ. Statistics which carry no minimum or
+// maximum value, for example because the page only holds null values, do not
+// contribute to the bounds of the result. The deprecated min and max fields and
+// the distinct count, which cannot be derived from the input, are left unset.
+//
+// An error is returned if the minimum or maximum values of one of the
+// statistics cannot be decoded as values of typ.
+func MergeStatistics(typ Type, stats ...format.Statistics) (format.Statistics, error) {
+	kind := typ.Kind()
+	merged := format.Statistics{}
+
+	var minValue, maxValue Value
+	for i := range stats {
+		s := &stats[i]
+		merged.NullCount += s.NullCount
+
+		if s.MinValue == nil || s.MaxValue == nil {
+			continue
+		}
+		pageMinValue, err := parseValue(kind, s.MinValue)
+		if err != nil {
+			return format.Statistics{}, fmt.Errorf("statistics %d: min value: %w", i, err)
+		}
+		pageMaxValue, err := parseValue(kind, s.MaxValue)
+		if err != nil {
+			return format.Statistics{}, fmt.Errorf("statistics %d: max value: %w", i, err)
+		}
+		if minValue.isNull() || typ.Compare(pageMinValue, minValue) < 0 {
+			minValue = pageMinValue
+		}
+		if maxValue.isNull() || typ.Compare(pageMaxValue, maxValue) > 0 {
+			maxValue = pageMaxValue
+		}
+	}
+
+	if !minValue.isNull() {
+		// The buffers are never nil so empty byte arrays remain distinguishable
+		// from missing bounds.
+		merged.MinValue = minValue.AppendBytes([]byte{})
+		merged.MaxValue = maxValue.AppendBytes([]byte{})
+	}
+	return merged, nil
+}
@@ -0,0 +1,116 @@
+package parquet_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/format"
+)
+
+func TestMergeStatistics(t *testing.T) {
+	type Row struct {
+		ID    int64   `parquet:"id"`
+		Name  string  `parquet:"name"`
+		Score *int32  `parquet:"score,optional"`
+		Ratio float64 `parquet:"ratio"`
+	}
+
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i] = Row{
+			ID:    int64(500 - i),
+			Name:  string(rune('a' + i%26)),
+			Ratio: float64(i%37) - 18.5,
+		}
+		if i%26 == 0 {
+			rows[i].Name = ""
+		}
+		if i%3 == 0 && i < 900 {
+			score := int32(i * 7 % 101)
+			rows[i].Score = &score
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](buf, parquet.PageBufferSize(256))
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, chunk := range f.RowGroups()[0].ColumnChunks() {
+		columnType := chunk.Type()
+
+		var stats []format.Statistics
+		pages := chunk.Pages()
+		for {
+			p, err := pages.ReadPage()
+			if err != nil {
+				if err != io.EOF {
+					t.Fatal(err)
+				}
+				break
+			}
+			s := format.Statistics{NullCount: p.NumNulls()}
+			if minValue, maxValue, ok := p.Bounds(); ok {
+				s.MinValue = minValue.AppendBytes([]byte{})
+				s.MaxValue = maxValue.AppendBytes([]byte{})
+			}
+			stats = append(stats, s)
+			parquet.Release(p)
+		}
+		pages.Close()
+
+		if len(stats) < 2 {
+			t.Fatalf("column %d: expected multiple pages, got %d", i, len(stats))
+		}
+
+		merged, err := parquet.MergeStatistics(columnType, stats...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := f.Metadata().RowGroups[0].Columns[i].MetaData.Statistics
+		if merged.NullCount != want.NullCount {
+			t.Errorf("column %d: wrong null count: want=%d got=%d", i, want.NullCount, merged.NullCount)
+		}
+		if !bytes.Equal(merged.MinValue, want.MinValue) || (merged.MinValue == nil) != (want.MinValue == nil) {
+			t.Errorf("column %d: wrong min value: want=%q got=%q", i, want.MinValue, merged.MinValue)
+		}
+		if !bytes.Equal(merged.MaxValue, want.MaxValue) || (merged.MaxValue == nil) != (want.MaxValue == nil) {
+			t.Errorf("column %d: wrong max value: want=%q got=%q", i, want.MaxValue, merged.MaxValue)
+		}
+	}
+
+	t.Run("no bounds", func(t *testing.T) {
+		merged, err := parquet.MergeStatistics(parquet.Int32Type,
+			format.Statistics{NullCount: 3},
+			format.Statistics{NullCount: 4},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if merged.NullCount != 7 || merged.MinValue != nil || merged.MaxValue != nil {
+			t.Errorf("wrong statistics merged from null pages: %+v", merged)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := parquet.MergeStatistics(parquet.Int64Type, format.Statistics{
+			MinValue: []byte{1, 2, 3},
+			MaxValue: []byte{1, 2, 3},
+		})
+		if err == nil {
+			t.Error("expected an error for values of the wrong size")
+		}
+	})
+}
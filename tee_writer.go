@@ -0,0 +1,44 @@
+package parquet
+
+import (
+	"fmt"
+	"io"
+)
+
+// TeeWriter returns an io.Writer which writes the same bytes to all the given
+// outputs, for example to produce redundant copies of a parquet file written by
+// a GenericWriter:
+//
+//	output := parquet.TeeWriter(file, upload)
+//	writer := parquet.NewGenericWriter[RowType](output)
+//
+// Each write is applied to the outputs in order. The first error returned by
+// an output, including short writes, aborts the write and is returned by all
+// the following writes, which are not applied to any output. The outputs hold
+// identical bytes up to the write which failed, which the outputs preceding
+// the failing one may have received.
+func TeeWriter(outputs ...io.Writer) io.Writer {
+	return &teeWriter{outputs: outputs}
+}
+
+type teeWriter struct {
+	outputs []io.Writer
+	err     error
+}
+
+func (w *teeWriter) Write(b []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	for i, output := range w.outputs {
+		n, err := output.Write(b)
+		if err == nil && n != len(b) {
+			err = io.ErrShortWrite
+		}
+		if err != nil {
+			w.err = fmt.Errorf("writing to output %d of %d: %w", i+1, len(w.outputs), err)
+			return 0, w.err
+		}
+	}
+	return len(b), nil
+}
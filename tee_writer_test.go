@@ -0,0 +1,88 @@
+package parquet_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestTeeWriter(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name"`
+	}
+
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i] = Row{ID: int64(i), Name: "row"}
+	}
+
+	buf1 := new(bytes.Buffer)
+	buf2 := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](parquet.TeeWriter(buf1, buf2), parquet.PageBufferSize(256))
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if buf1.Len() == 0 || !bytes.Equal(buf1.Bytes(), buf2.Bytes()) {
+		t.Fatalf("outputs differ: %d and %d bytes", buf1.Len(), buf2.Len())
+	}
+	got, err := parquet.Read[Row](bytes.NewReader(buf2.Bytes()), int64(buf2.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(rows) {
+		t.Errorf("wrong number of rows: want=%d got=%d", len(rows), len(got))
+	}
+}
+
+func TestTeeWriterError(t *testing.T) {
+	errFailed := errors.New("failed")
+	buf1 := new(bytes.Buffer)
+	buf2 := new(bytes.Buffer)
+	output := parquet.TeeWriter(buf1, &failingWriter{limit: 4, err: errFailed}, buf2)
+
+	if _, err := output.Write([]byte("abc")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := output.Write([]byte("def")); !errors.Is(err, errFailed) {
+		t.Fatalf("wrong error: %v", err)
+	}
+	if _, err := output.Write([]byte("ghi")); !errors.Is(err, errFailed) {
+		t.Fatalf("wrong error after failure: %v", err)
+	}
+	if buf2.String() != "abc" {
+		t.Errorf("the outputs after the failing one must not be written: %q", buf2.String())
+	}
+	if buf1.String() != "abcdef" {
+		t.Errorf("wrong content of the first output: %q", buf1.String())
+	}
+
+	short := parquet.TeeWriter(&failingWriter{limit: 2})
+	if _, err := short.Write([]byte("abc")); !errors.Is(err, io.ErrShortWrite) {
+		t.Errorf("wrong error on short write: %v", err)
+	}
+}
+
+// failingWriter accepts up to limit bytes, then returns err, or reports a short
+// write if err is nil.
+type failingWriter struct {
+	limit int
+	err   error
+}
+
+func (w *failingWriter) Write(b []byte) (int, error) {
+	if len(b) <= w.limit {
+		w.limit -= len(b)
+		return len(b), nil
+	}
+	n := w.limit
+	w.limit = 0
+	return n, w.err
+}
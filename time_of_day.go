@@ -0,0 +1,95 @@
+package parquet
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/parquet-go/parquet-go/format"
+)
+
+// TimeOfDay is the Go representation of values of the TIME logical type, which
+// is a civil time of day without a date or a time zone. Values are durations
+// elapsed since midnight, which avoids the artificial date that time.Time
+// values would carry.
+//
+// Fields of this type are mapped to TIME columns holding nanoseconds in schemas
+// generated from Go types. The time tag selects a different unit, in which case
+// values are truncated to the unit when written. Example:
+//
+//	type Store struct {
+//	  Opening parquet.TimeOfDay `parquet:"opening,time(millisecond)"`
+//	}
+type TimeOfDay time.Duration
+
+// NewTimeOfDay returns the time of day at the given hour, minute, second, and
+// nanosecond. Values outside of their usual range are normalized the same way
+// as durations (e.g. 90 minutes is 1:30).
+func NewTimeOfDay(hour, minute, second, nanosecond int) TimeOfDay {
+	return TimeOfDay(time.Duration(hour)*time.Hour +
+		time.Duration(minute)*time.Minute +
+		time.Duration(second)*time.Second +
+		time.Duration(nanosecond))
+}
+
+// TimeOfDayOf returns the time of day of t in its location.
+func TimeOfDayOf(t time.Time) TimeOfDay {
+	hour, minute, second := t.Clock()
+	return NewTimeOfDay(hour, minute, second, t.Nanosecond())
+}
+
+// Hour returns the hour of t, in the range [0, 23] for valid times of day.
+func (t TimeOfDay) Hour() int { return int(time.Duration(t) / time.Hour) }
+
+// Minute returns the minute of the hour of t, in the range [0, 59].
+func (t TimeOfDay) Minute() int { return int(time.Duration(t) % time.Hour / time.Minute) }
+
+// Second returns the second of the minute of t, in the range [0, 59].
+func (t TimeOfDay) Second() int { return int(time.Duration(t) % time.Minute / time.Second) }
+
+// Nanosecond returns the nanosecond of the second of t, in the range
+// [0, 999999999].
+func (t TimeOfDay) Nanosecond() int { return int(time.Duration(t) % time.Second) }
+
+// String returns t formatted as hh:mm:ss, followed by the fraction of seconds
+// when it is not zero.
+func (t TimeOfDay) String() string {
+	s := fmt.Sprintf("%02d:%02d:%02d", t.Hour(), t.Minute(), t.Second())
+	if ns := t.Nanosecond(); ns != 0 {
+		s += fmt.Sprintf(".%09d", ns)
+	}
+	return s
+}
+
+// timeOfDayNodeOf returns the node of TimeOfDay fields stored in the given
+// unit. Values are scaled from nanoseconds when written, and back to
+// nanoseconds when read.
+func timeOfDayNodeOf(unit TimeUnit, isAdjustedToUTC bool) Node {
+	return Leaf(&timeOfDayType{timeType{IsAdjustedToUTC: isAdjustedToUTC, Unit: unit.TimeUnit()}})
+}
+
+type timeOfDayType struct{ timeType }
+
+func (t *timeOfDayType) fromGoUnit(n int64) int64 {
+	return scaleTimestamp(n, Nanosecond.TimeUnit(), t.Unit)
+}
+
+func (t *timeOfDayType) toGoUnit(n int64) int64 {
+	return scaleTimestamp(n, t.Unit, Nanosecond.TimeUnit())
+}
+
+var (
+	_ goUnitScaler = (*timeOfDayType)(nil)
+
+	timeOfDayValueType = reflect.TypeFor[TimeOfDay]()
+)
+
+// assignTimeOfDay assigns the value of a TIME column expressed in unit to dst,
+// which is of type TimeOfDay.
+func assignTimeOfDay(dst reflect.Value, src Value, unit format.TimeUnit) {
+	if src.IsNull() {
+		dst.SetInt(0)
+	} else {
+		dst.SetInt(scaleTimestamp(src.int64(), unit, Nanosecond.TimeUnit()))
+	}
+}
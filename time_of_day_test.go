@@ -0,0 +1,175 @@
+package parquet_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestTimeOfDay(t *testing.T) {
+	type Row struct {
+		Nanos    parquet.TimeOfDay   `parquet:"nanos"`
+		Micros   parquet.TimeOfDay   `parquet:"micros,time(microsecond)"`
+		Millis   parquet.TimeOfDay   `parquet:"millis,time(millisecond:local)"`
+		Optional *parquet.TimeOfDay  `parquet:"optional,optional,time(microsecond)"`
+		Repeated []parquet.TimeOfDay `parquet:"repeated,list" parquet-element:",time(millisecond)"`
+	}
+
+	const want = `message Row {
+	required int64 nanos (TIME(isAdjustedToUTC=true,unit=NANOS));
+	required int64 micros (TIME(isAdjustedToUTC=true,unit=MICROS));
+	required int32 millis (TIME(isAdjustedToUTC=false,unit=MILLIS));
+	optional int64 optional (TIME(isAdjustedToUTC=true,unit=MICROS));
+	required group repeated (LIST) {
+		repeated group list {
+			required int32 element (TIME(isAdjustedToUTC=true,unit=MILLIS));
+		}
+	}
+}`
+	if got := parquet.SchemaOf(new(Row)).String(); got != want {
+		t.Fatalf("wrong schema:\nwant:\n%s\ngot:\n%s", want, got)
+	}
+
+	noon := parquet.NewTimeOfDay(12, 0, 0, 0)
+	rows := []Row{
+		{Repeated: []parquet.TimeOfDay{}},
+		{
+			Nanos:    parquet.NewTimeOfDay(23, 59, 59, 999_999_999),
+			Micros:   parquet.NewTimeOfDay(8, 30, 15, 123_456_000),
+			Millis:   parquet.NewTimeOfDay(17, 45, 0, 250_000_000),
+			Optional: &noon,
+			Repeated: []parquet.TimeOfDay{noon, parquet.NewTimeOfDay(0, 0, 1, 0)},
+		},
+	}
+
+	check := func(t *testing.T, buf *bytes.Buffer) {
+		f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := f.Schema().String(); got != want {
+			t.Errorf("wrong schema read from the file:\nwant:\n%s\ngot:\n%s", want, got)
+		}
+
+		// The values are stored in the unit of the columns.
+		values := make([]parquet.Value, 2)
+		for i, want := range []int64{int64(rows[1].Nanos), 30615123456, 63900250} {
+			pages := f.RowGroups()[0].ColumnChunks()[i].Pages()
+			p, err := pages.ReadPage()
+			if err != nil {
+				t.Fatal(err)
+			}
+			p.Values().ReadValues(values)
+			pages.Close()
+			if got := values[1].Int64(); got != want {
+				t.Errorf("column %d: wrong value: want=%d got=%d", i, want, got)
+			}
+		}
+
+		got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, rows) {
+			t.Errorf("wrong rows:\nwant: %+v\ngot:  %+v", rows, got)
+		}
+	}
+
+	t.Run("GenericWriter", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		if err := parquet.Write(buf, rows); err != nil {
+			t.Fatal(err)
+		}
+		check(t, buf)
+	})
+
+	t.Run("Writer", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		w := parquet.NewWriter(buf, parquet.SchemaOf(new(Row)))
+		for i := range rows {
+			if err := w.Write(&rows[i]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		check(t, buf)
+	})
+
+	t.Run("ConvertUnit", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		if err := parquet.Write(buf, rows); err != nil {
+			t.Fatal(err)
+		}
+		// Reading the columns in a different unit truncates the values.
+		type NanosRow struct {
+			Nanos  parquet.TimeOfDay `parquet:"nanos,time(millisecond)"`
+			Micros parquet.TimeOfDay `parquet:"micros"`
+			Millis parquet.TimeOfDay `parquet:"millis,time(microsecond)"`
+		}
+		got, err := parquet.Read[NanosRow](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []NanosRow{{}, {
+			Nanos:  parquet.NewTimeOfDay(23, 59, 59, 999_000_000),
+			Micros: rows[1].Micros,
+			Millis: rows[1].Millis,
+		}}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("wrong rows:\nwant: %+v\ngot:  %+v", want, got)
+		}
+	})
+}
+
+func TestTimeOfDayNode(t *testing.T) {
+	// Files written with other types than TimeOfDay are read into TimeOfDay
+	// values from the unit of their columns.
+	schema := parquet.NewSchema("test", parquet.Group{
+		"millis": parquet.Time(parquet.Millisecond),
+		"micros": parquet.Time(parquet.Microsecond),
+	})
+	buf := new(bytes.Buffer)
+	w := parquet.NewWriter(buf, schema)
+	if _, err := w.WriteRows([]parquet.Row{{
+		// Columns of groups are ordered by name.
+		parquet.Int64Value(1_500).Level(0, 0, 0),
+		parquet.Int32Value(3_600_000).Level(0, 0, 1),
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	type Row struct {
+		Millis parquet.TimeOfDay `parquet:"millis,time(millisecond)"`
+		Micros parquet.TimeOfDay `parquet:"micros,time(microsecond)"`
+	}
+	got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Row{{Millis: parquet.NewTimeOfDay(1, 0, 0, 0), Micros: parquet.NewTimeOfDay(0, 0, 0, 1_500_000)}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong rows: want=%+v got=%+v", want, got)
+	}
+}
+
+func TestTimeOfDayValue(t *testing.T) {
+	at := time.Date(2024, 6, 1, 7, 5, 3, 42, time.UTC)
+	tod := parquet.TimeOfDayOf(at)
+	if tod.Hour() != 7 || tod.Minute() != 5 || tod.Second() != 3 || tod.Nanosecond() != 42 {
+		t.Errorf("wrong time of day: %d:%d:%d.%d", tod.Hour(), tod.Minute(), tod.Second(), tod.Nanosecond())
+	}
+	if s := tod.String(); s != "07:05:03.000000042" {
+		t.Errorf("wrong string: %s", s)
+	}
+	if s := parquet.NewTimeOfDay(0, 90, 0, 0).String(); s != "01:30:00" {
+		t.Errorf("wrong string: %s", s)
+	}
+}
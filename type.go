@@ -2,6 +2,7 @@ package parquet
 
 import (
 	"bytes"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	"math/bits"
@@ -426,7 +427,7 @@ func (t int32Type) AssignValue(dst reflect.Value, src Value) error {
 	switch dst.Kind() {
 	case reflect.Int8, reflect.Int16, reflect.Int32:
 		dst.SetInt(int64(v))
-	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		dst.SetUint(uint64(v))
 	default:
 		dst.Set(reflect.ValueOf(v))
@@ -682,6 +683,8 @@ func (t floatType) AssignValue(dst reflect.Value, src Value) error {
 
 func (t floatType) ConvertValue(val Value, typ Type) (Value, error) {
 	switch typ.(type) {
+	case *float16Type:
+		return convertFloat16ToFloat(val)
 	case *stringType:
 		return convertStringToFloat(val)
 	}
@@ -763,6 +766,8 @@ func (t doubleType) AssignValue(dst reflect.Value, src Value) error {
 
 func (t doubleType) ConvertValue(val Value, typ Type) (Value, error) {
 	switch typ.(type) {
+	case *float16Type:
+		return convertFloat16ToDouble(val)
 	case *stringType:
 		return convertStringToDouble(val)
 	}
@@ -931,7 +936,7 @@ func (t fixedLenByteArrayType) AssignValue(dst reflect.Value, src Value) error {
 	v := src.byteArray()
 	switch dst.Kind() {
 	case reflect.Array:
-		if dst.Type().Elem().Kind() == reflect.Uint8 && dst.Len() == len(v) {
+		if isPackedArray(dst.Type()) && int(dst.Type().Size()) == len(v) {
 			// This code could be implemented as a call to reflect.Copy but
 			// it would require creating a reflect.Value from v which causes
 			// the heap allocation to pack the []byte value. To avoid this
@@ -940,6 +945,7 @@ func (t fixedLenByteArrayType) AssignValue(dst reflect.Value, src Value) error {
 			// a more efficient call to copy.
 			d := unsafe.Slice((*byte)(reflectValueData(dst)), len(v))
 			copy(d, v)
+			swapPackedArray(d, int(dst.Type().Elem().Size()))
 			return nil
 		}
 	case reflect.Slice:
@@ -1312,6 +1318,145 @@ func (t *decimalType) ConvertedType() *deprecated.ConvertedType {
 	return &convertedTypes[deprecated.Decimal]
 }
 
+// Compare orders the unscaled values of decimals stored as byte arrays, which
+// are big-endian two's complement integers, by comparing them as signed
+// numbers.
+func (t *decimalType) Compare(a, b Value) int {
+	if t.storedAsBytes() {
+		return compareDecimalBytes(a.byteArray(), b.byteArray())
+	}
+	return t.Type.Compare(a, b)
+}
+
+// storedAsBytes returns true if the unscaled values of t are stored in byte
+// arrays, which is the case of FIXED_LEN_BYTE_ARRAY decimals, and of BYTE_ARRAY
+// decimals found in files written by legacy applications.
+func (t *decimalType) storedAsBytes() bool {
+	switch t.Type.Kind() {
+	case ByteArray, FixedLenByteArray:
+		return true
+	default:
+		return false
+	}
+}
+
+func (t *decimalType) AssignValue(dst reflect.Value, src Value) error {
+	if t.storedAsBytes() && !src.IsNull() {
+		switch dst.Kind() {
+		case reflect.Int, reflect.Int32, reflect.Int64:
+			n, ok := decimalBytesToInt64(src.byteArray())
+			if !ok || dst.OverflowInt(n) {
+				return fmt.Errorf("decimal value %s overflows go value of type %s", formatDecimal(src, 0), dst.Type())
+			}
+			dst.SetInt(n)
+			return nil
+		}
+	}
+	return t.Type.AssignValue(dst, src)
+}
+
+// ConvertValue converts the unscaled values of decimals between physical types,
+// decimals stored as fixed length byte arrays are sign-extended or truncated to
+// the length of the target type. An error is returned if the value cannot be
+// represented by the target type.
+func (t *decimalType) ConvertValue(val Value, typ Type) (Value, error) {
+	if lt := typ.LogicalType(); lt == nil || lt.Decimal == nil || val.IsNull() {
+		return t.Type.ConvertValue(val, typ)
+	}
+
+	var unscaled []byte
+	switch typ.Kind() {
+	case Int32:
+		unscaled = appendDecimalBytes(nil, int64(val.int32()))
+	case Int64:
+		unscaled = appendDecimalBytes(nil, val.int64())
+	case ByteArray, FixedLenByteArray:
+		unscaled = val.byteArray()
+	default:
+		return t.Type.ConvertValue(val, typ)
+	}
+
+	switch kind := t.Type.Kind(); kind {
+	case Int32, Int64:
+		n, ok := decimalBytesToInt64(unscaled)
+		if ok && kind == Int32 && int64(int32(n)) != n {
+			ok = false
+		}
+		if !ok {
+			return val, decimalOverflowError(val, typ, t)
+		}
+		if kind == Int32 {
+			return val.convertToInt32(int32(n)), nil
+		}
+		return val.convertToInt64(n), nil
+	case FixedLenByteArray:
+		b, ok := resizeDecimalBytes(unscaled, t.Type.Length())
+		if !ok {
+			return val, decimalOverflowError(val, typ, t)
+		}
+		return val.convertToFixedLenByteArray(b), nil
+	default:
+		return t.Type.ConvertValue(val, typ)
+	}
+}
+
+// floatDecimalType is the type of decimal columns holding the values of float32
+// or float64 fields of Go programs. Values are converted to their unscaled
+// representation with the rounding mode of the type when they are written, and
+// converted back to floats when read.
+type floatDecimalType struct {
+	decimalType
+	rounding decimalRounding
+}
+
+func floatDecimal(scale, precision int, typ Type, rounding decimalRounding) Node {
+	return Leaf(&floatDecimalType{
+		decimalType: decimalType{
+			decimal: format.DecimalType{
+				Scale:     int32(scale),
+				Precision: int32(precision),
+			},
+			Type: typ,
+		},
+		rounding: rounding,
+	})
+}
+
+// fromFloat converts f, which is a float of bitSize bits, to the value of the
+// column.
+func (t *floatDecimalType) fromFloat(f float64, bitSize int) (Value, error) {
+	n, err := decimalFromFloat(f, bitSize, int(t.decimal.Scale), int(t.decimal.Precision), t.rounding)
+	if err != nil {
+		return Value{}, err
+	}
+	if t.Type.Kind() == Int32 {
+		return makeValueInt32(int32(n)), nil
+	}
+	return makeValueInt64(n), nil
+}
+
+func (t *floatDecimalType) AssignValue(dst reflect.Value, src Value) error {
+	if src.IsNull() {
+		return t.decimalType.AssignValue(dst, src)
+	}
+	var n int64
+	switch t.Type.Kind() {
+	case Int32:
+		n = int64(src.int32())
+	default:
+		n = src.int64()
+	}
+	switch dst.Kind() {
+	case reflect.Float32:
+		dst.SetFloat(decimalToFloat(n, 32, int(t.decimal.Scale)))
+	case reflect.Float64:
+		dst.SetFloat(decimalToFloat(n, 64, int(t.decimal.Scale)))
+	default:
+		return t.decimalType.AssignValue(dst, src)
+	}
+	return nil
+}
+
 // String constructs a leaf node of UTF8 logical type.
 //
 // https://github.com/apache/parquet-format/blob/master/LogicalTypes.md#string
@@ -1488,6 +1633,97 @@ func (t *uuidType) ConvertValue(val Value, typ Type) (Value, error) {
 	return be128Type{isUUID: true}.ConvertValue(val, typ)
 }
 
+// Float16 constructs a leaf node of FLOAT16 logical type, which holds half
+// precision floating point values in two bytes fixed length byte arrays.
+//
+// The values are read and written from Go float32 values.
+//
+// https://github.com/apache/parquet-format/blob/master/LogicalTypes.md#float16
+func Float16() Node { return Leaf(&float16Type{}) }
+
+type float16Type format.Float16Type
+
+func (t *float16Type) String() string { return (*format.Float16Type)(t).String() }
+
+func (t *float16Type) Kind() Kind { return FixedLenByteArray }
+
+func (t *float16Type) Length() int { return 2 }
+
+func (t *float16Type) EstimateSize(n int) int { return 2 * n }
+
+func (t *float16Type) EstimateNumValues(n int) int { return n / 2 }
+
+func (t *float16Type) Compare(a, b Value) int { return compareFloat16(a.byteArray(), b.byteArray()) }
+
+func (t *float16Type) ColumnOrder() *format.ColumnOrder { return &typeDefinedColumnOrder }
+
+func (t *float16Type) PhysicalType() *format.Type { return &physicalTypes[FixedLenByteArray] }
+
+func (t *float16Type) LogicalType() *format.LogicalType {
+	return &format.LogicalType{Float16: (*format.Float16Type)(t)}
+}
+
+func (t *float16Type) ConvertedType() *deprecated.ConvertedType { return nil }
+
+func (t *float16Type) NewColumnIndexer(sizeLimit int) ColumnIndexer {
+	return newFloat16ColumnIndexer()
+}
+
+func (t *float16Type) NewDictionary(columnIndex, numValues int, data encoding.Values) Dictionary {
+	return newFixedLenByteArrayDictionary(t, makeColumnIndex(columnIndex), makeNumValues(numValues), data)
+}
+
+func (t *float16Type) NewColumnBuffer(columnIndex, numValues int) ColumnBuffer {
+	return newFixedLenByteArrayColumnBuffer(t, makeColumnIndex(columnIndex), makeNumValues(numValues))
+}
+
+func (t *float16Type) NewPage(columnIndex, numValues int, data encoding.Values) Page {
+	return newFixedLenByteArrayPage(t, makeColumnIndex(columnIndex), makeNumValues(numValues), data)
+}
+
+func (t *float16Type) NewValues(values []byte, offsets []uint32) encoding.Values {
+	return fixedLenByteArrayType{length: 2}.NewValues(values, offsets)
+}
+
+func (t *float16Type) Encode(dst []byte, src encoding.Values, enc encoding.Encoding) ([]byte, error) {
+	return fixedLenByteArrayType{length: 2}.Encode(dst, src, enc)
+}
+
+func (t *float16Type) Decode(dst encoding.Values, src []byte, enc encoding.Encoding) (encoding.Values, error) {
+	return fixedLenByteArrayType{length: 2}.Decode(dst, src, enc)
+}
+
+func (t *float16Type) EstimateDecodeSize(numValues int, src []byte, enc encoding.Encoding) int {
+	return fixedLenByteArrayType{length: 2}.EstimateDecodeSize(numValues, src, enc)
+}
+
+func (t *float16Type) AssignValue(dst reflect.Value, src Value) error {
+	switch dst.Kind() {
+	case reflect.Float32, reflect.Float64:
+		if src.IsNull() {
+			dst.SetFloat(0)
+		} else {
+			dst.SetFloat(float64(float16ToFloat32(binary.LittleEndian.Uint16(src.byteArray()))))
+		}
+		return nil
+	}
+	return fixedLenByteArrayType{length: 2}.AssignValue(dst, src)
+}
+
+func (t *float16Type) ConvertValue(val Value, typ Type) (Value, error) {
+	switch typ.(type) {
+	case *float16Type:
+		return val, nil
+	}
+	switch typ.Kind() {
+	case Float:
+		return convertFloatToFloat16(val)
+	case Double:
+		return convertDoubleToFloat16(val)
+	}
+	return fixedLenByteArrayType{length: 2}.ConvertValue(val, typ)
+}
+
 // Enum constructs a leaf node with a logical type representing enumerations.
 //
 // https://github.com/apache/parquet-format/blob/master/LogicalTypes.md#enum
@@ -1949,6 +2185,10 @@ func (t *timeType) EstimateDecodeSize(numValues int, src []byte, enc encoding.En
 }
 
 func (t *timeType) AssignValue(dst reflect.Value, src Value) error {
+	if dst.Type() == timeOfDayValueType {
+		assignTimeOfDay(dst, src, t.Unit)
+		return nil
+	}
 	return t.baseType().AssignValue(dst, src)
 }
 
@@ -1968,6 +2208,10 @@ func (t *timeType) ConvertValue(val Value, typ Type) (Value, error) {
 		} else {
 			return convertTimestampToTimeMillis(val, src.Unit, src.tz(), tz)
 		}
+	case *timeType:
+		return convertTimeToTime(val, src.Unit, t.Unit)
+	case *timeOfDayType:
+		return convertTimeToTime(val, src.Unit, t.Unit)
 	}
 	return t.baseType().ConvertValue(val, typ)
 }
@@ -2128,6 +2372,157 @@ func (t *timestampType) ConvertValue(val Value, typ Type) (Value, error) {
 	return int64Type{}.ConvertValue(val, typ)
 }
 
+// TimestampEpoch constructs a leaf node of TIMESTAMP logical type storing
+// time.Time values relative to the given epoch instead of the Unix epoch.
+//
+// The logical type written to the file is a regular TIMESTAMP, applications
+// reading the values must apply the same epoch, which is the case of programs
+// using Go types with the same struct tags. Writers record the epoch in the
+// key/value metadata of the column chunks under the "parquet-go.timestamp.epoch"
+// key, formatted as RFC 3339, so other readers can tell that the values are not
+// relative to the Unix epoch. This is intended for interoperability with
+// systems which use a different epoch, for example 2000-01-01 or 2001-01-01.
+func TimestampEpoch(unit TimeUnit, isAdjustedToUTC bool, epoch time.Time) Node {
+	return Leaf(&epochTimestampType{
+		timestampType: timestampType{IsAdjustedToUTC: isAdjustedToUTC, Unit: unit.TimeUnit()},
+		epoch:         epoch,
+	})
+}
+
+// Key of the key/value metadata of column chunks holding the epoch that the
+// values of TimestampEpoch columns are relative to.
+const timestampEpochKey = "parquet-go.timestamp.epoch"
+
+type epochTimestampType struct {
+	timestampType
+	epoch time.Time
+}
+
+// unitsSinceEpoch returns the number of time units between the epoch and v.
+func (t *epochTimestampType) unitsSinceEpoch(v time.Time) int64 {
+	switch {
+	case t.Unit.Millis != nil:
+		return v.UnixMilli() - t.epoch.UnixMilli()
+	case t.Unit.Micros != nil:
+		return v.UnixMicro() - t.epoch.UnixMicro()
+	default:
+		return (v.Unix()-t.epoch.Unix())*1e9 + int64(v.Nanosecond()-t.epoch.Nanosecond())
+	}
+}
+
+// timeAt returns the time at n time units from the epoch.
+func (t *epochTimestampType) timeAt(n int64) time.Time {
+	switch {
+	case t.Unit.Millis != nil:
+		return time.UnixMilli(n + t.epoch.UnixMilli()).UTC()
+	case t.Unit.Micros != nil:
+		return time.UnixMicro(n + t.epoch.UnixMicro()).UTC()
+	default:
+		return time.Unix(t.epoch.Unix(), int64(t.epoch.Nanosecond())+n).UTC()
+	}
+}
+
+func (t *epochTimestampType) AssignValue(dst reflect.Value, src Value) error {
+	switch dst.Type() {
+	case reflect.TypeOf(time.Time{}):
+		if src.IsNull() {
+			dst.Set(reflect.ValueOf(time.Time{}))
+		} else {
+			dst.Set(reflect.ValueOf(t.timeAt(src.int64())))
+		}
+		return nil
+	case reflect.TypeOf((*time.Time)(nil)):
+		if src.IsNull() {
+			dst.Set(reflect.Zero(dst.Type()))
+		} else {
+			val := t.timeAt(src.int64())
+			dst.Set(reflect.ValueOf(&val))
+		}
+		return nil
+	default:
+		return t.timestampType.AssignValue(dst, src)
+	}
+}
+
+// scaledTimestampType is the type of TIMESTAMP columns holding int64 values
+// of Go programs which are expressed in a different time unit than the unit of
+// the column. Values are scaled when they are written and read.
+type scaledTimestampType struct {
+	timestampType
+	goUnit format.TimeUnit
+}
+
+// fromGoUnit converts a value expressed in the Go unit to the column unit.
+func (t *scaledTimestampType) fromGoUnit(n int64) int64 {
+	return scaleTimestamp(n, t.goUnit, t.Unit)
+}
+
+// toGoUnit converts a value expressed in the column unit to the Go unit.
+func (t *scaledTimestampType) toGoUnit(n int64) int64 {
+	return scaleTimestamp(n, t.Unit, t.goUnit)
+}
+
+func (t *scaledTimestampType) AssignValue(dst reflect.Value, src Value) error {
+	if dst.Kind() == reflect.Int64 && !src.IsNull() {
+		dst.SetInt(t.toGoUnit(src.int64()))
+		return nil
+	}
+	return t.timestampType.AssignValue(dst, src)
+}
+
+// Key of the key/value metadata of column chunks holding the time unit of the
+// values of duration columns.
+const durationUnitKey = "parquet-go.duration.unit"
+
+// durationType is the type of columns holding time.Duration values in the
+// unit of the duration tag. Values are truncated to the unit of the column
+// when they are written, and scaled back to nanoseconds when read.
+type durationType struct {
+	Type
+	unit format.TimeUnit
+}
+
+func (t *durationType) fromGoUnit(n int64) int64 {
+	return scaleTimestamp(n, Nanosecond.TimeUnit(), t.unit)
+}
+
+func (t *durationType) toGoUnit(n int64) int64 {
+	return scaleTimestamp(n, t.unit, Nanosecond.TimeUnit())
+}
+
+func (t *durationType) AssignValue(dst reflect.Value, src Value) error {
+	if dst.Kind() == reflect.Int64 && !src.IsNull() {
+		dst.SetInt(t.toGoUnit(src.int64()))
+		return nil
+	}
+	return t.Type.AssignValue(dst, src)
+}
+
+// goUnitScaler is implemented by the types of int64 columns holding values
+// which are expressed in a different unit in Go programs.
+type goUnitScaler interface {
+	// Converts a value expressed in the Go unit to the column unit.
+	fromGoUnit(int64) int64
+	// Converts a value expressed in the column unit to the Go unit.
+	toGoUnit(int64) int64
+}
+
+var (
+	_ goUnitScaler = (*scaledTimestampType)(nil)
+	_ goUnitScaler = (*durationType)(nil)
+)
+
+// scaleTimestamp converts n from the source to the target time unit. Values
+// are truncated when converting to a coarser unit.
+func scaleTimestamp(n int64, source, target format.TimeUnit) int64 {
+	sourceScale := timeUnitDuration(source).Nanoseconds()
+	targetScale := timeUnitDuration(target).Nanoseconds()
+	if sourceScale >= targetScale {
+		return n * (sourceScale / targetScale)
+	}
+	return n / (targetScale / sourceScale)
+}
+
 // List constructs a node of LIST logical type.
 //
 // https://github.com/apache/parquet-format/blob/master/LogicalTypes.md#lists
@@ -2219,6 +2614,48 @@ func Map(key, value Node) Node {
 
 type mapNode struct{ Group }
 
+// SortedMap constructs a node of MAP logical type, similar to Map, with the
+// guarantee that the keys of each map are unique and written in ascending
+// order.
+//
+// Keys are ordered by the rules of the parquet type of the key node, which
+// produces a canonical representation of maps: writing equal Go maps always
+// results in the same sequence of values. Sorted keys also tend to compress
+// better. Writing a map with keys that are equal once converted to parquet
+// values (e.g. NaN floating point values) results in an error, and so does
+// reading rows where the keys of maps are not sorted and unique.
+func SortedMap(key, value Node) Node {
+	return sortedMapNode{Map(key, value).(mapNode)}
+}
+
+type sortedMapNode struct{ mapNode }
+
+func (sortedMapNode) Type() Type { return &sortedMapType{} }
+
+// sortedMapType is the type of sorted map nodes, it allows identifying those
+// nodes through the wrappers altering their repetition type or field name.
+type sortedMapType struct{ mapType }
+
+func isSortedMap(node Node) bool {
+	_, sorted := node.Type().(*sortedMapType)
+	return sorted
+}
+
+// orderedMapNode wraps the MAP nodes of schemas used by writers configured with
+// DeterministicOutput, the keys of maps are written in ascending order so the
+// output does not depend on the iteration order of Go maps. Unlike sorted maps,
+// keys which are equal once converted to parquet values are not an error.
+type orderedMapNode struct{ Node }
+
+func (orderedMapNode) Type() Type { return &orderedMapType{} }
+
+type orderedMapType struct{ mapType }
+
+func isOrderedMap(node Node) bool {
+	_, ordered := node.Type().(*orderedMapType)
+	return ordered
+}
+
 func (mapNode) Type() Type { return &mapType{} }
 
 type mapType format.MapType
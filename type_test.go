@@ -3,6 +3,7 @@ package parquet_test
 import (
 	"bytes"
 	"io"
+	"reflect"
 	"testing"
 	"time"
 
@@ -509,6 +510,105 @@ func TestOptionalTimeWithNanosecond(t *testing.T) {
 	}
 }
 
+func TestTimestampEpoch(t *testing.T) {
+	type Record struct {
+		Millis   time.Time  `parquet:"millis,timestamp(millisecond:epoch=2001-01-01)"`
+		Micros   *time.Time `parquet:"micros,timestamp(microsecond:local:epoch=2000-01-01)"`
+		Nanos    time.Time  `parquet:"nanos,timestamp(nanosecond:epoch=1900-01-01)"`
+		Standard time.Time  `parquet:"standard,timestamp(millisecond)"`
+	}
+
+	micros := time.Date(1999, 12, 31, 23, 59, 59, 999999000, time.UTC)
+	records := []Record{
+		{
+			Millis:   time.Date(2001, 1, 1, 0, 0, 1, 0, time.UTC),
+			Micros:   &micros,
+			Nanos:    time.Date(1800, 6, 15, 8, 30, 0, 1, time.UTC),
+			Standard: time.Date(2001, 1, 1, 0, 0, 1, 0, time.UTC),
+		},
+		{
+			Millis:   time.Date(2024, 3, 1, 12, 30, 45, 123e6, time.UTC),
+			Nanos:    time.Date(2024, 3, 1, 12, 30, 45, 123456789, time.UTC),
+			Standard: time.Unix(0, 0).UTC(),
+		},
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, records); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := parquet.Read[Record](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(records) {
+		t.Fatalf("wrong number of records: want=%d got=%d", len(records), len(got))
+	}
+	for i := range records {
+		want := records[i]
+		if !got[i].Millis.Equal(want.Millis) || !got[i].Nanos.Equal(want.Nanos) || !got[i].Standard.Equal(want.Standard) {
+			t.Errorf("record %d: wrong times:\nwant = %+v\ngot  = %+v", i, want, got[i])
+		}
+		if (want.Micros == nil) != (got[i].Micros == nil) || (want.Micros != nil && !got[i].Micros.Equal(*want.Micros)) {
+			t.Errorf("record %d: wrong micros: want=%v got=%v", i, want.Micros, got[i].Micros)
+		}
+	}
+
+	// The values stored in the file are offset from the epoch.
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := make([]parquet.Row, 1)
+	reader := parquet.NewReader(f)
+	defer reader.Close()
+	if _, err := reader.ReadRows(rows); err != nil {
+		t.Fatal(err)
+	}
+	if millis := rows[0][0].Int64(); millis != 1000 {
+		t.Errorf("wrong millisecond value stored relative to the epoch: %d", millis)
+	}
+	if micros := rows[0][1].Int64(); micros != -1 {
+		t.Errorf("wrong microsecond value stored relative to the epoch: %d", micros)
+	}
+	if row := parquet.SchemaOf(Record{}).Deconstruct(nil, records[0]); !row.Equal(rows[0]) {
+		t.Errorf("wrong deconstructed row:\nwant = %+v\ngot  = %+v", rows[0], row)
+	}
+
+	// The epoch is recorded in the metadata of the column chunks.
+	for i, want := range []string{"2001-01-01T00:00:00Z", "2000-01-01T00:00:00Z", "1900-01-01T00:00:00Z", ""} {
+		columnChunk := f.Metadata().RowGroups[0].Columns[i].MetaData
+		got := ""
+		for _, kv := range columnChunk.KeyValueMetadata {
+			if kv.Key == "parquet-go.timestamp.epoch" {
+				got = kv.Value
+			}
+		}
+		if got != want {
+			t.Errorf("%s: wrong epoch in the column chunk metadata: want=%q got=%q", columnChunk.PathInSchema, want, got)
+		}
+	}
+
+	for _, tag := range []string{
+		"timestamp(millisecond:epoch=2001-13-01)",
+		"timestamp(epoch=yesterday)",
+	} {
+		t.Run(tag, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected a panic for an invalid epoch")
+				}
+			}()
+			parquet.SchemaOf(reflect.New(reflect.StructOf([]reflect.StructField{{
+				Name: "T",
+				Type: reflect.TypeOf(time.Time{}),
+				Tag:  reflect.StructTag(`parquet:"t,` + tag + `"`),
+			}})).Interface())
+		})
+	}
+}
+
 // TestIssue155 verifies the fix for https://github.com/parquet-go/parquet-go/issues/155
 // The issue reported that empty time.Time{} values were being serialized as "1754-08-30"
 // instead of being preserved as zero values (NULL) when using optional timestamp fields.
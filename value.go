@@ -14,6 +14,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/parquet-go/parquet-go/deprecated"
 	"github.com/parquet-go/parquet-go/format"
+	"golang.org/x/sys/cpu"
 )
 
 const (
@@ -286,6 +287,15 @@ func makeValue(k Kind, lt *format.LogicalType, v reflect.Value) Value {
 		return makeValueInt64(val)
 	}
 
+	if lt != nil && lt.Decimal != nil {
+		switch v.Kind() {
+		case reflect.Uint32, reflect.Uint64:
+			if v.Uint() > decimalMaxUnsignedValue(int(lt.Decimal.Precision)) {
+				panic(fmt.Errorf("value %d exceeds the precision of the decimal type", v.Uint()))
+			}
+		}
+	}
+
 	switch k {
 	case Boolean:
 		return makeValueBoolean(v.Bool())
@@ -296,6 +306,10 @@ func makeValue(k Kind, lt *format.LogicalType, v reflect.Value) Value {
 			return makeValueInt32(int32(v.Int()))
 		case reflect.Uint8, reflect.Uint16, reflect.Uint32:
 			return makeValueInt32(int32(v.Uint()))
+		case reflect.Uint64:
+			if lt != nil && lt.Decimal != nil {
+				return makeValueInt32(int32(v.Uint()))
+			}
 		}
 
 	case Int64:
@@ -336,6 +350,11 @@ func makeValue(k Kind, lt *format.LogicalType, v reflect.Value) Value {
 
 	case FixedLenByteArray:
 		switch v.Kind() {
+		case reflect.Float32:
+			if lt != nil && lt.Float16 != nil {
+				b := binary.LittleEndian.AppendUint16(nil, float32ToFloat16(float32(v.Float())))
+				return makeValueBytes(k, b)
+			}
 		case reflect.String:
 			if lt.UUID != nil { // uuid
 				uuidStr := v.String()
@@ -347,7 +366,7 @@ func makeValue(k Kind, lt *format.LogicalType, v reflect.Value) Value {
 			}
 			return makeValueString(k, v.String())
 		case reflect.Array:
-			if v.Type().Elem().Kind() == reflect.Uint8 {
+			if isPackedArray(v.Type()) {
 				return makeValueFixedLenByteArray(v)
 			}
 		case reflect.Slice:
@@ -450,7 +469,13 @@ func makeValueFixedLenByteArray(v reflect.Value) Value {
 		u.Elem().Set(v)
 		v = u
 	}
-	return makeValueByteArray(FixedLenByteArray, (*byte)(v.UnsafePointer()), t.Len())
+	if elemSize := int(t.Elem().Size()); cpu.IsBigEndian && elemSize > 1 {
+		// The elements of packed arrays are stored in little-endian order.
+		data := bytes.Clone(unsafe.Slice((*byte)(v.UnsafePointer()), t.Size()))
+		swapPackedArray(data, elemSize)
+		return makeValueBytes(FixedLenByteArray, data)
+	}
+	return makeValueByteArray(FixedLenByteArray, (*byte)(v.UnsafePointer()), int(t.Size()))
 }
 
 func makeValueByteArray(kind Kind, data *byte, size int) Value {
@@ -623,6 +648,15 @@ func (v Value) Bytes() []byte {
 
 // AppendBytes appends the binary representation of v to b.
 //
+// The representation is the same as the one returned by Bytes: booleans are
+// appended as a single byte holding 0 or 1 (unlike the PLAIN encoding, which
+// packs booleans into bits), integers and floating point numbers in the
+// little-endian layout of their PLAIN encoding, INT96 values as twelve bytes,
+// and byte arrays without length prefix. Unlike Bytes, which may allocate a
+// new slice for each value, the method does not allocate when b has enough
+// capacity, which allows programs to reuse a buffer when scanning large
+// numbers of values (e.g. to hash them).
+//
 // If v is the null value, b is returned unchanged.
 func (v Value) AppendBytes(b []byte) []byte {
 	buf := [8]byte{}
@@ -0,0 +1,124 @@
+package parquet
+
+import "unsafe"
+
+const (
+	// Number of values and bytes in each of the memory blocks that a ValuePool
+	// allocates from. Allocations larger than a block get a dedicated block.
+	valuePoolValuesSize = 4096
+	valuePoolBytesSize  = 64 * 1024
+)
+
+// ValuePool is an arena allocating the memory of rows and of byte array values
+// in bulk, which reduces the number of heap allocations and the pressure on
+// the garbage collector of programs constructing large numbers of rows, for
+// example to write them with WriteRows.
+//
+// Memory obtained from the pool remains valid until Reset is called, after
+// which the rows and values that were allocated must not be used anymore. The
+// typical use is to allocate the rows of a batch, write them, and reset the
+// pool before building the next batch:
+//
+//	pool := new(parquet.ValuePool)
+//	builder.SetValuePool(pool)
+//
+//	for {
+//		for i := range rows {
+//			...
+//			rows[i] = builder.Build()
+//		}
+//		if _, err := writer.WriteRows(rows); err != nil {
+//			...
+//		}
+//		pool.Reset()
+//	}
+//
+// The zero-value is a valid, empty pool. ValuePool instances are not safe to
+// use concurrently from multiple goroutines.
+type ValuePool struct {
+	values blockAllocator[Value]
+	bytes  blockAllocator[byte]
+}
+
+// AllocRow returns an empty row with capacity for n values.
+//
+// Appending more than n values to the row reallocates it on the heap rather
+// than overwriting memory of the pool.
+func (p *ValuePool) AllocRow(n int) Row {
+	return p.values.alloc(n, valuePoolValuesSize)[:0:n]
+}
+
+// AllocBytes returns a byte slice of length n.
+//
+// The content of the returned slice is undefined, the program is expected to
+// overwrite it.
+func (p *ValuePool) AllocBytes(n int) []byte {
+	return p.bytes.alloc(n, valuePoolBytesSize)
+}
+
+// Clone returns a copy of v where the bytes of BYTE_ARRAY and
+// FIXED_LEN_BYTE_ARRAY values are allocated from the pool.
+//
+// The method is similar to Value.Clone but does not allocate new memory for
+// each value.
+func (p *ValuePool) Clone(v Value) Value {
+	switch v.Kind() {
+	case ByteArray, FixedLenByteArray:
+		b := p.AllocBytes(len(v.byteArray()))
+		copy(b, v.byteArray())
+		v.ptr = unsafe.SliceData(b)
+	}
+	return v
+}
+
+// ByteArrayValue constructs a BYTE_ARRAY value holding a copy of value
+// allocated from the pool.
+func (p *ValuePool) ByteArrayValue(value []byte) Value {
+	b := p.AllocBytes(len(value))
+	copy(b, value)
+	return makeValueBytes(ByteArray, b)
+}
+
+// Reset releases all the memory allocated from the pool, retaining the
+// underlying blocks to serve future allocations.
+func (p *ValuePool) Reset() {
+	for _, block := range p.values.blocks {
+		// Clear the values so they do not retain the memory they referenced.
+		clearValues(block)
+	}
+	p.values.reset()
+	p.bytes.reset()
+}
+
+// blockAllocator allocates slices of T from a list of memory blocks that are
+// reused after a reset.
+type blockAllocator[T any] struct {
+	blocks [][]T
+	index  int
+}
+
+func (a *blockAllocator[T]) alloc(n, blockSize int) []T {
+	if n > blockSize {
+		// Large allocations are not retained, the memory is reclaimed by the
+		// garbage collector when the program is done with it.
+		return make([]T, n)
+	}
+	for ; a.index < len(a.blocks); a.index++ {
+		block := a.blocks[a.index]
+		if i := len(block); cap(block)-i >= n {
+			a.blocks[a.index] = block[:i+n]
+			return block[i : i+n : i+n]
+		}
+	}
+	block := make([]T, n, blockSize)
+	a.blocks = append(a.blocks, block)
+	a.index = len(a.blocks) - 1
+	return block[:n:n]
+}
+
+func (a *blockAllocator[T]) reset() {
+	for i, block := range a.blocks {
+		a.blocks[i] = block[:0]
+	}
+	a.index = 0
+}
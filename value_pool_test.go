@@ -0,0 +1,134 @@
+package parquet_test
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestValuePool(t *testing.T) {
+	pool := new(parquet.ValuePool)
+
+	row1 := pool.AllocRow(2)
+	row2 := pool.AllocRow(3)
+	if len(row1) != 0 || cap(row1) != 2 || len(row2) != 0 || cap(row2) != 3 {
+		t.Fatalf("wrong row sizes: len=%d cap=%d, len=%d cap=%d", len(row1), cap(row1), len(row2), cap(row2))
+	}
+
+	buffer := []byte("hello")
+	value := pool.Clone(parquet.ByteArrayValue(buffer))
+	copy(buffer, "world")
+
+	row1 = append(row1, parquet.Int64Value(1), value)
+	row2 = append(row2, parquet.Int64Value(2), pool.ByteArrayValue(buffer), parquet.BooleanValue(true))
+	// Appending beyond the capacity of the row must not overwrite the
+	// memory of other rows allocated from the pool.
+	row1 = append(row1, parquet.Int64Value(3))
+
+	if want := (parquet.Row{parquet.Int64Value(1), parquet.ByteArrayValue([]byte("hello")), parquet.Int64Value(3)}); !row1.Equal(want) {
+		t.Errorf("wrong first row: want=%v got=%v", want, row1)
+	}
+	if want := (parquet.Row{parquet.Int64Value(2), parquet.ByteArrayValue([]byte("world")), parquet.BooleanValue(true)}); !row2.Equal(want) {
+		t.Errorf("wrong second row: want=%v got=%v", want, row2)
+	}
+
+	if b := pool.AllocBytes(1 << 20); len(b) != 1<<20 {
+		t.Errorf("wrong size of large allocation: %d", len(b))
+	}
+	if r := pool.AllocRow(1 << 20); cap(r) != 1<<20 {
+		t.Errorf("wrong capacity of large row: %d", cap(r))
+	}
+
+	pool.Reset()
+	allocs := testing.AllocsPerRun(10, func() {
+		for range 100 {
+			_ = pool.AllocRow(10)
+			_ = pool.AllocBytes(100)
+		}
+		pool.Reset()
+	})
+	if allocs != 0 {
+		t.Errorf("allocating from a pool after a reset should not allocate, got %g allocations", allocs)
+	}
+
+	t.Run("row builder", func(t *testing.T) {
+		builder := parquet.NewRowBuilder(parquet.Group{
+			"id":   parquet.Int(64),
+			"name": parquet.String(),
+		})
+		builder.SetValuePool(pool)
+
+		rows := make([]parquet.Row, 3)
+		for i := range rows {
+			builder.Add(0, parquet.Int64Value(int64(i)))
+			builder.Add(1, pool.ByteArrayValue([]byte(fmt.Sprintf("name-%d", i))))
+			rows[i] = builder.Build()
+		}
+		for i, row := range rows {
+			want := parquet.Row{
+				parquet.Int64Value(int64(i)).Level(0, 0, 0),
+				parquet.ByteArrayValue([]byte(fmt.Sprintf("name-%d", i))).Level(0, 0, 1),
+			}
+			if !row.Equal(want) {
+				t.Errorf("wrong row %d: want=%v got=%v", i, want, row)
+			}
+		}
+	})
+}
+
+func BenchmarkValuePool(b *testing.B) {
+	const numRows = 1000
+
+	schema := parquet.Group{
+		"id":    parquet.Int(64),
+		"name":  parquet.String(),
+		"tags":  parquet.Repeated(parquet.String()),
+		"score": parquet.Optional(parquet.Leaf(parquet.DoubleType)),
+	}
+	names := make([][]byte, numRows)
+	for i := range names {
+		names[i] = fmt.Appendf(nil, "name-%d", i)
+	}
+
+	benchmark := func(b *testing.B, pool *parquet.ValuePool) {
+		builder := parquet.NewRowBuilder(schema)
+		if pool != nil {
+			builder.SetValuePool(pool)
+		}
+		writer := parquet.NewWriter(io.Discard, parquet.NewSchema("bench", schema))
+		rows := make([]parquet.Row, numRows)
+		name := make([]byte, 0, 16)
+
+		b.ReportAllocs()
+		for range b.N {
+			for i := range rows {
+				// The name is built in a buffer reused across rows, so its
+				// value has to be copied to be retained by the row.
+				name = append(name[:0], names[i]...)
+				nameValue := parquet.ByteArrayValue(name)
+				if pool != nil {
+					nameValue = pool.Clone(nameValue)
+				} else {
+					nameValue = nameValue.Clone()
+				}
+				// Columns of groups are ordered by name: id, name, score, tags.
+				builder.Add(0, parquet.Int64Value(int64(i)))
+				builder.Add(1, nameValue)
+				builder.Add(2, parquet.DoubleValue(float64(i)))
+				builder.Add(3, parquet.ByteArrayValue(names[i%10]))
+				rows[i] = builder.Build()
+			}
+			if _, err := writer.WriteRows(rows); err != nil {
+				b.Fatal(err)
+			}
+			if pool != nil {
+				pool.Reset()
+			}
+		}
+	}
+
+	b.Run("without pool", func(b *testing.B) { benchmark(b, nil) })
+	b.Run("with pool", func(b *testing.B) { benchmark(b, new(parquet.ValuePool)) })
+}
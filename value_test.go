@@ -9,6 +9,7 @@ import (
 
 	"github.com/parquet-go/parquet-go"
 	"github.com/parquet-go/parquet-go/deprecated"
+	"github.com/parquet-go/parquet-go/encoding/plain"
 )
 
 func TestSizeOfValue(t *testing.T) {
@@ -102,6 +103,52 @@ func TestValueClone(t *testing.T) {
 	}
 }
 
+func TestValueAppendBytes(t *testing.T) {
+	enc := new(plain.Encoding)
+	mustEncode := func(b []byte, err error) []byte {
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+	int96 := deprecated.Int96{0x01020304, 0x05060708, 0x090a0b0c}
+
+	tests := []struct {
+		scenario string
+		value    parquet.Value
+		want     []byte
+	}{
+		{"null", parquet.NullValue(), nil},
+		{"boolean true", parquet.BooleanValue(true), []byte{1}},
+		{"boolean false", parquet.BooleanValue(false), []byte{0}},
+		{"int32", parquet.Int32Value(-2), mustEncode(enc.EncodeInt32(nil, []int32{-2}))},
+		{"int64", parquet.Int64Value(1 << 40), mustEncode(enc.EncodeInt64(nil, []int64{1 << 40}))},
+		{"int96", parquet.Int96Value(int96), mustEncode(enc.EncodeInt96(nil, []deprecated.Int96{int96}))},
+		{"float", parquet.FloatValue(1.5), mustEncode(enc.EncodeFloat(nil, []float32{1.5}))},
+		{"double", parquet.DoubleValue(-0.25), mustEncode(enc.EncodeDouble(nil, []float64{-0.25}))},
+		{"byte array", parquet.ByteArrayValue([]byte("hello")), []byte("hello")},
+		{"fixed len byte array", parquet.FixedLenByteArrayValue([]byte{1, 2, 3}), []byte{1, 2, 3}},
+	}
+
+	scratch := make([]byte, 0, 64)
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			// Numeric values have the layout of their PLAIN encoding, booleans
+			// take a byte each, and byte arrays have no length prefix.
+			scratch = test.value.AppendBytes(append(scratch[:0], "prefix"...))
+			if got := scratch[len("prefix"):]; !bytes.Equal(got, test.want) {
+				t.Errorf("wrong bytes: want=%x got=%x", test.want, got)
+			}
+			if got := test.value.Bytes(); !bytes.Equal(got, test.want) {
+				t.Errorf("AppendBytes and Bytes differ: want=%x got=%x", test.want, got)
+			}
+			if allocs := testing.AllocsPerRun(10, func() { scratch = test.value.AppendBytes(scratch[:0]) }); allocs != 0 {
+				t.Errorf("appending to a buffer with enough capacity allocated: %g", allocs)
+			}
+		})
+	}
+}
+
 func TestZeroValue(t *testing.T) {
 	var v parquet.Value
 	if !v.IsNull() {
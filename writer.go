@@ -6,13 +6,17 @@ import (
 	"cmp"
 	"encoding/binary"
 	"fmt"
+	"hash"
 	"hash/crc32"
+	"hash/fnv"
 	"io"
 	"math"
 	"math/bits"
 	"os"
 	"reflect"
 	"slices"
+	"strconv"
+	"time"
 
 	"github.com/parquet-go/parquet-go/compress"
 	"github.com/parquet-go/parquet-go/encoding"
@@ -83,7 +87,16 @@ type GenericWriter[T any] struct {
 // similar to using a Writer.
 //
 // If the option list may explicitly declare a schema, it must be compatible
-// with the schema generated from T.
+// with the schema generated from T. The schema overrides the one generated from
+// T, which lets programs control the optionality, encodings, compression, and
+// order of the columns beyond what struct tags express: the values of T are
+// written to the columns of the schema that their fields map to, and fields
+// mapped to optional columns have their zero values written as nulls. The
+// compatibility is verified when the writer is created; the physical types of
+// the columns must match the Go types of the fields (e.g. an int32 field cannot
+// be written to an INT64 column), unless the write path converts them (e.g.
+// float32 fields written to FLOAT16 columns). An incompatible schema causes all
+// calls to Write to return an error.
 //
 // Sorting columns may be set on the writer to configure the generated row
 // groups metadata. However, rows are always written in the order they were
@@ -98,6 +111,7 @@ func NewGenericWriter[T any](output io.Writer, options ...WriterOption) *Generic
 	}
 
 	schema := config.Schema
+	schemaOverride := schema != nil
 	t := typeOf[T]()
 
 	var genWriteErr error
@@ -121,11 +135,25 @@ func NewGenericWriter[T any](output io.Writer, options ...WriterOption) *Generic
 		panic("generic writer must be instantiated with schema or concrete type.")
 	}
 
+	if len(config.ColumnIndexOrder) > 0 {
+		schema = mustReorderColumns(config.Schema, config.ColumnIndexOrder)
+		config.Schema = schema
+	}
+	if config.DeterministicOutput {
+		schema = orderMapKeys(config.Schema)
+		config.Schema = schema
+	}
+
 	var writeFn writeFunc[T]
+	if genWriteErr == nil {
+		if schemaOverride {
+			writeFn, genWriteErr = writeFuncWithSchema[T](t, config.Schema)
+		} else {
+			writeFn = writeFuncOf[T](t, config.Schema)
+		}
+	}
 	if genWriteErr != nil {
 		writeFn = func(*GenericWriter[T], []T) (int, error) { return 0, genWriteErr }
-	} else {
-		writeFn = writeFuncOf[T](t, config.Schema)
 	}
 
 	return &GenericWriter[T]{
@@ -141,6 +169,18 @@ func NewGenericWriter[T any](output io.Writer, options ...WriterOption) *Generic
 
 type writeFunc[T any] func(*GenericWriter[T], []T) (int, error)
 
+// writeFuncWithSchema is like writeFuncOf but for schemas which were not
+// generated from t, the incompatibilities between t and the schema detected
+// while building the write function are returned as errors.
+func writeFuncWithSchema[T any](t reflect.Type, schema *Schema) (writeFn writeFunc[T], err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("cannot write %v with schema %s: %v", t, schema.Name(), r)
+		}
+	}()
+	return writeFuncOf[T](t, schema), nil
+}
+
 func writeFuncOf[T any](t reflect.Type, schema *Schema) writeFunc[T] {
 	if t == nil {
 		return (*GenericWriter[T]).writeAny
@@ -165,12 +205,19 @@ func makeWriteFunc[T any](t reflect.Type, schema *Schema) writeFunc[T] {
 	return func(w *GenericWriter[T], rows []T) (n int, err error) {
 		if w.columns == nil {
 			w.columns = make([]ColumnBuffer, len(w.base.writer.columns))
-			for i, c := range w.base.writer.columns {
-				// These fields are usually lazily initialized when writing rows,
-				// we need them to exist now tho.
+		}
+		for i, c := range w.base.writer.columns {
+			// These fields are usually lazily initialized when writing rows,
+			// we need them to exist now tho. The buffers are looked up on each
+			// call because column writers swap them when their dictionary
+			// grows beyond the MaxDictionarySize limit.
+			if c.columnBuffer == nil {
 				c.columnBuffer = c.newColumnBuffer()
-				w.columns[i] = c.columnBuffer
 			}
+			w.columns[i] = c.columnBuffer
+		}
+		if check := w.base.writer.sortingCheck; check != nil {
+			return w.writeCheckedRows(check, rows)
 		}
 		err = writeRows(w.columns, makeArrayOf(rows), columnLevels{})
 		if err == nil {
@@ -180,6 +227,30 @@ func makeWriteFunc[T any](t reflect.Type, schema *Schema) writeFunc[T] {
 	}
 }
 
+// writeCheckedRows writes rows after verifying their order with check. The rows
+// are deconstructed to be compared, and the values of the rows are written to
+// the columns instead of deconstructing the rows again. Only the rows preceding
+// the first row out of order are written.
+func (w *GenericWriter[T]) writeCheckedRows(check *sortingCheck, rows []T) (int, error) {
+	schema := w.base.Schema()
+	buf := check.buffer(len(rows))
+	defer clearRows(buf)
+
+	for i := range rows {
+		row, err := schema.deconstruct(buf[i][:0], &rows[i])
+		if err != nil {
+			return 0, err
+		}
+		buf[i] = row
+	}
+
+	n, sortingErr := check.check(buf)
+	if err := w.base.writer.writeRowValues(buf[:n]); err != nil {
+		return 0, err
+	}
+	return n, sortingErr
+}
+
 func (w *GenericWriter[T]) Close() error {
 	return w.base.Close()
 }
@@ -200,7 +271,7 @@ func (w *GenericWriter[T]) Write(rows []T) (int, error) {
 		}
 
 		for _, c := range w.base.writer.columns {
-			if c.columnBuffer.Size() >= int64(c.bufferSize) {
+			if c.columnBuffer.Size() >= int64(c.bufferSize) || c.dictionaryFull() {
 				if err := c.Flush(); err != nil {
 					return n, err
 				}
@@ -215,6 +286,8 @@ func (w *GenericWriter[T]) WriteRows(rows []Row) (int, error) {
 	return w.base.WriteRows(rows)
 }
 
+// WriteRowGroup writes a row group to the parquet file, without converting its
+// rows to values of type T. See Writer.WriteRowGroup for details.
 func (w *GenericWriter[T]) WriteRowGroup(rowGroup RowGroup) (int64, error) {
 	return w.base.WriteRowGroup(rowGroup)
 }
@@ -254,7 +327,11 @@ func (w *GenericWriter[T]) writeRows(rows []T) (int, error) {
 
 	schema := w.base.Schema()
 	for i := range rows {
-		w.base.rowbuf[i] = schema.Deconstruct(w.base.rowbuf[i], &rows[i])
+		row, err := schema.deconstruct(w.base.rowbuf[i], &rows[i])
+		if err != nil {
+			return 0, err
+		}
+		w.base.rowbuf[i] = row
 	}
 
 	return w.base.WriteRows(w.base.rowbuf)
@@ -357,12 +434,81 @@ func NewWriter(output io.Writer, options ...WriterOption) *Writer {
 
 func (w *Writer) configure(schema *Schema) {
 	if schema != nil {
+		if len(w.config.ColumnIndexOrder) > 0 {
+			schema = mustReorderColumns(schema, w.config.ColumnIndexOrder)
+		}
+		if w.config.DeterministicOutput {
+			schema = orderMapKeys(schema)
+		}
 		w.config.Schema = schema
 		w.schema = schema
 		w.writer = newWriter(w.output, w.config)
 	}
 }
 
+func mustReorderColumns(schema *Schema, columns [][]string) *Schema {
+	reordered, err := reorderColumns(schema, columns)
+	if err != nil {
+		panic(fmt.Errorf("invalid column index order: %w", err))
+	}
+	return reordered
+}
+
+// mustOrderColumnChunks returns the indexes of columns in the order that their
+// chunks are written to row groups: the columns selected by each group first,
+// in the order of the groups, followed by the columns which are not part of any
+// group, in the order of the schema. A path selects the leaf column that it
+// designates, or all the leaf columns of the group that it designates.
+func mustOrderColumnChunks(columns []*ColumnWriter, groups [][][]string) []int {
+	order := make([]int, 0, len(columns))
+	seen := make([]bool, len(columns))
+
+	for _, group := range groups {
+		for _, path := range group {
+			found := false
+			for i, c := range columns {
+				if len(path) == 0 || !c.columnPath.startsWith(path) {
+					continue
+				}
+				if seen[i] {
+					panic(fmt.Errorf("invalid column chunk groups: column %q is part of more than one group", c.columnPath))
+				}
+				seen[i], found = true, true
+				order = append(order, i)
+			}
+			if !found {
+				panic(fmt.Errorf("invalid column chunk groups: column %q does not exist in the schema", columnPath(path)))
+			}
+		}
+	}
+
+	for i := range columns {
+		if !seen[i] {
+			order = append(order, i)
+		}
+	}
+	return order
+}
+
+// mustAddNullBitmapColumns returns a copy of schema with the boolean columns of
+// the null bitmaps added at the root.
+func mustAddNullBitmapColumns(schema *Schema, columns []NullBitmapColumn) *Schema {
+	fields := slices.Clone(schema.Fields())
+	for _, column := range columns {
+		leaf, ok := schema.Lookup(column.Path...)
+		switch {
+		case !ok:
+			panic(fmt.Errorf("invalid null bitmap: column %q does not exist in the schema", columnPath(column.Path)))
+		case leaf.MaxRepetitionLevel > 0 || !leaf.Node.Optional():
+			panic(fmt.Errorf("invalid null bitmap: column %q is not an optional column outside of repeated fields", columnPath(column.Path)))
+		case column.Name == "" || slices.ContainsFunc(fields, func(f Field) bool { return f.Name() == column.Name }):
+			panic(fmt.Errorf("invalid null bitmap: column name %q is empty or already used in the schema", column.Name))
+		}
+		fields = append(fields, &groupField{Node: Leaf(BooleanType), name: column.Name})
+	}
+	return NewSchema(schema.Name(), &rewrittenGroup{Node: schema.root, fields: fields})
+}
+
 // Close must be called after all values were produced to the writer in order to
 // flush all buffers and write the parquet footer.
 func (w *Writer) Close() error {
@@ -414,8 +560,12 @@ func (w *Writer) Write(row any) error {
 		w.rowbuf = w.rowbuf[:1]
 	}
 	defer clearRows(w.rowbuf)
-	w.rowbuf[0] = w.schema.Deconstruct(w.rowbuf[0][:0], row)
-	_, err := w.WriteRows(w.rowbuf)
+	r, err := w.schema.deconstruct(w.rowbuf[0][:0], row)
+	if err != nil {
+		return err
+	}
+	w.rowbuf[0] = r
+	_, err = w.WriteRows(w.rowbuf)
 	return err
 }
 
@@ -430,6 +580,27 @@ func (w *Writer) WriteRows(rows []Row) (int, error) {
 	return w.writer.WriteRows(rows)
 }
 
+// WriteColumns writes rows to the parquet file from the values of each of their
+// columns, returning the number of rows written.
+//
+// This lower level API is intended for applications which compute the
+// repetition and definition levels of values themselves, and is the inverse of
+// reading the values of the pages of column chunks. The columns slice must have
+// one element per leaf column of the writer's schema, in the order of
+// Schema.Columns, each holding the values of the column for the same rows.
+// Values with a repetition level of zero start a new row. The column index of
+// values is ignored, it is set by the writer from their position in columns.
+//
+// The levels of all values are validated against the schema before any rows
+// are written; the method returns ErrMisalignedColumns if the columns do not
+// have values for the same number of rows.
+func (w *Writer) WriteColumns(columns [][]Value) (int, error) {
+	if w.schema == nil {
+		return 0, ErrRowGroupSchemaMissing
+	}
+	return w.writer.writeColumns(columns)
+}
+
 // WriteRowGroup writes a row group to the parquet file.
 //
 // Buffered rows will be flushed prior to writing rows from the group, unless
@@ -437,6 +608,20 @@ func (w *Writer) WriteRows(rows []Row) (int, error) {
 //
 // The content of the row group is flushed to the writer; after the method
 // returns successfully, the row group will be empty and in ready to be reused.
+//
+// When the row group was read from a parquet file and its column chunks use the
+// compression codecs and encodings that the writer is configured with, the
+// pages are copied to the output as-is, without being decoded and re-encoded.
+// The statistics and page index of the column chunks are then copied from the
+// file as well. Otherwise, the rows are copied one by one.
+//
+// The schema of the writer may widen the one of the row group by adding
+// optional fields to it, which are written as null values for the rows of the
+// row group. This allows programs to append the row groups of an existing file
+// to a file written with a superset of its schema, for example after adding a
+// field to the Go type of the rows. Other differences between the schemas,
+// such as changes of column types or added required fields, cause the method
+// to return ErrRowGroupSchemaMismatch.
 func (w *Writer) WriteRowGroup(rowGroup RowGroup) (int64, error) {
 	rowGroupSchema := rowGroup.Schema()
 	switch {
@@ -445,11 +630,32 @@ func (w *Writer) WriteRowGroup(rowGroup RowGroup) (int64, error) {
 	case w.schema == nil:
 		w.configure(rowGroupSchema)
 	case !EqualNodes(w.schema, rowGroupSchema):
-		return 0, ErrRowGroupSchemaMismatch
+		if !widensNode(w.schema, rowGroupSchema) {
+			return 0, ErrRowGroupSchemaMismatch
+		}
+		conv, err := Convert(w.schema, rowGroupSchema)
+		if err != nil {
+			return 0, err
+		}
+		rowGroup = ConvertRowGroup(rowGroup, conv)
 	}
 	if err := w.writer.flush(); err != nil {
 		return 0, err
 	}
+	if fileRowGroup, ok := rowGroup.(*FileRowGroup); ok {
+		chunks, err := w.writer.prepareRowGroupCopy(fileRowGroup)
+		if err != nil {
+			return 0, err
+		}
+		if chunks != nil {
+			if check := w.writer.sortingCheck; check != nil {
+				if err := check.checkRowGroup(fileRowGroup); err != nil {
+					return 0, err
+				}
+			}
+			return w.writer.copyRowGroup(fileRowGroup, fileRowGroup.SortingColumns(), chunks)
+		}
+	}
 	w.writer.configureBloomFilters(rowGroup.ColumnChunks())
 	rows := rowGroup.Rows()
 	defer rows.Close()
@@ -460,6 +666,35 @@ func (w *Writer) WriteRowGroup(rowGroup RowGroup) (int64, error) {
 	return w.writer.writeRowGroup(rowGroup.Schema(), rowGroup.SortingColumns())
 }
 
+// widensNode returns true if node has all the fields of base with the same
+// types and repetitions, and only adds optional fields to them, in which case
+// the rows of base can be written with node by leaving the added fields null.
+func widensNode(node, base Node) bool {
+	if node.Leaf() || base.Leaf() {
+		return EqualNodes(node, base)
+	}
+	if !repetitionsAreEqual(node, base) || !equalLogicalTypes(node.Type(), base.Type()) {
+		return false
+	}
+	for _, field := range base.Fields() {
+		if fieldByName(node, field.Name()) == nil {
+			return false
+		}
+	}
+	for _, field := range node.Fields() {
+		baseField := fieldByName(base, field.Name())
+		switch {
+		case baseField == nil:
+			if !field.Optional() {
+				return false
+			}
+		case !widensNode(field, baseField):
+			return false
+		}
+	}
+	return true
+}
+
 // ReadRowsFrom reads rows from the reader passed as arguments and writes them
 // to w.
 //
@@ -494,14 +729,18 @@ func (w *Writer) Schema() *Schema { return w.schema }
 // cause some key/value pairs to be lost when open parquet files written with
 // repeated keys. We can revisit this decision if it ever becomes a blocker.
 func (w *Writer) SetKeyValueMetadata(key, value string) {
-	for i, kv := range w.writer.metadata {
+	setKeyValueMetadata(&w.writer.metadata, key, value)
+}
+
+func setKeyValueMetadata(metadata *[]format.KeyValue, key, value string) {
+	for i, kv := range *metadata {
 		if kv.Key == key {
 			kv.Value = value
-			w.writer.metadata[i] = kv
+			(*metadata)[i] = kv
 			return
 		}
 	}
-	w.writer.metadata = append(w.writer.metadata, format.KeyValue{
+	*metadata = append(*metadata, format.KeyValue{
 		Key:   key,
 		Value: value,
 	})
@@ -525,7 +764,7 @@ func (w *Writer) File() FileView {
 	}
 	return &writerFileView{
 		w.writer,
-		w.schema,
+		w.writer.schema,
 	}
 }
 
@@ -582,20 +821,41 @@ func (w *writerFileView) RowGroups() []RowGroup {
 }
 
 type writer struct {
+	// Schema of the file, which includes the null bitmap columns.
+	schema *Schema
+
 	buffer  *bufio.Writer
 	writer  offsetTrackingWriter
 	values  [][]Value
 	numRows int64
 	maxRows int64
 
-	createdBy string
-	metadata  []format.KeyValue
+	maxBufferedBytes int64
+	spillBuffers     BufferPool
+
+	// When verifying files on close, output is the writer that the file is
+	// written to and outputOffset the position in output where the file
+	// starts, and written retains a copy of the file if the output cannot be
+	// read back.
+	verify       bool
+	output       io.Writer
+	outputOffset int64
+	written      *bytes.Buffer
+
+	createdBy         string
+	metadata          []format.KeyValue
+	deterministic     bool
+	metadataChecksums bool
 
 	columns     []*ColumnWriter
 	columnChunk []format.ColumnChunk
 	columnIndex []format.ColumnIndex
 	offsetIndex []format.OffsetIndex
 
+	// Indexes of the columns in the order their chunks are laid out in row
+	// groups, see ColumnChunkGroups.
+	chunkOrder []int
+
 	columnOrders   []format.ColumnOrder
 	schemaElements []format.SchemaElement
 	rowGroups      []format.RowGroup
@@ -603,11 +863,17 @@ type writer struct {
 	offsetIndexes  [][]format.OffsetIndex
 	sortingColumns []format.SortingColumn
 
+	// Verifies the order of the rows written when the writer was configured
+	// with SortingColumnsChecked, nil otherwise.
+	sortingCheck *sortingCheck
+
 	fileMetaData *format.FileMetaData
 }
 
 func newWriter(output io.Writer, config *WriterConfig) *writer {
 	w := new(writer)
+	w.verify = config.VerifyOnClose
+	output = w.setOutput(output)
 	if config.WriteBufferSize <= 0 {
 		w.writer.Reset(output)
 	} else {
@@ -615,19 +881,43 @@ func newWriter(output io.Writer, config *WriterConfig) *writer {
 		w.writer.Reset(w.buffer)
 	}
 	w.maxRows = config.MaxRowsPerRowGroup
+	w.maxBufferedBytes = config.MaxBufferedBytes
+	w.spillBuffers = config.SpillBuffers
 	w.createdBy = config.CreatedBy
+	w.deterministic = config.DeterministicOutput
+	w.metadataChecksums = config.MetadataChecksums
+	if w.deterministic && w.createdBy == defaultCreatedBy() {
+		w.createdBy = parquetGoModulePath
+	}
 	w.metadata = make([]format.KeyValue, 0, len(config.KeyValueMetadata))
 	for k, v := range config.KeyValueMetadata {
 		w.metadata = append(w.metadata, format.KeyValue{Key: k, Value: v})
 	}
 	sortKeyValueMetadata(w.metadata)
 	w.sortingColumns = make([]format.SortingColumn, len(config.Sorting.SortingColumns))
+	if config.CheckSortingOrder && len(config.Sorting.SortingColumns) > 0 {
+		w.sortingCheck = &sortingCheck{
+			columns: config.Sorting.SortingColumns,
+			compare: compareRowsFuncOf(config.Schema, config.Sorting.SortingColumns),
+		}
+	}
+
+	// The schema of the file differs from the schema of rows when the writer
+	// emits null bitmap columns, which are appended after the other columns.
+	schema := config.Schema
+	if len(config.NullBitmaps) > 0 {
+		schema = mustAddNullBitmapColumns(schema, config.NullBitmaps)
+	}
+	if config.SchemaName != "" && config.SchemaName != schema.Name() {
+		schema = NewSchema(config.SchemaName, schema.root)
+	}
+	w.schema = schema
 
-	config.Schema.forEachNode(func(name string, node Node) {
+	schema.forEachNode(func(name string, node Node) {
 		nodeType := node.Type()
 
 		repetitionType := (*format.FieldRepetitionType)(nil)
-		if node != config.Schema { // the root has no repetition type
+		if node != schema { // the root has no repetition type
 			repetitionType = fieldRepetitionTypePtrOf(node)
 		}
 		// For backward compatibility with older readers, the parquet specification
@@ -669,15 +959,25 @@ func newWriter(output io.Writer, config *WriterConfig) *writer {
 		defaultCompression = &Uncompressed
 	}
 
-	forEachLeafColumnOf(config.Schema, func(leaf leafColumn) {
+	// When some columns were declared with the "index" tag, only those have
+	// page indexes.
+	indexedColumns := false
+	forEachLeafColumnOf(schema, func(leaf leafColumn) {
+		indexed, _ := pageIndexOf(schema, leaf.path)
+		indexedColumns = indexedColumns || indexed
+	})
+
+	forEachLeafColumnOf(schema, func(leaf leafColumn) {
 		encoding := encodingOf(leaf.node, config.Encodings)
 		dictionary := Dictionary(nil)
 		columnType := leaf.node.Type()
 		columnIndex := int(leaf.columnIndex)
 		compression := leaf.node.Compression()
+		compressionCandidates := []compress.Codec(nil)
 
 		if compression == nil {
 			compression = defaultCompression
+			compressionCandidates = config.AdaptiveCompression
 		}
 
 		if isDictionaryEncoding(encoding) {
@@ -689,25 +989,41 @@ func newWriter(output io.Writer, config *WriterConfig) *writer {
 			columnType = dictionary.Type()
 		}
 
+		skipStatistics := skipStatisticsOf(schema, leaf.path)
+		indexed, noIndex := pageIndexOf(schema, leaf.path)
+
+		// Filters configured on the writer take precedence over the filters
+		// declared with struct tags.
+		columnFilter := searchBloomFilterColumn(config.BloomFilters, leaf.path)
+		if columnFilter == nil {
+			columnFilter = bloomFilterOf(schema, leaf.path)
+		}
+
 		c := &ColumnWriter{
 			buffers:            new(writerBuffers),
 			pool:               config.ColumnPageBuffers,
 			columnPath:         leaf.path,
 			columnType:         columnType,
 			columnIndex:        columnType.NewColumnIndexer(config.ColumnIndexSizeLimit),
-			columnFilter:       searchBloomFilterColumn(config.BloomFilters, leaf.path),
+			columnFilter:       columnFilter,
 			compression:        compression,
+			compressionChoices: compressionCandidates,
 			dictionary:         dictionary,
 			dataPageType:       dataPageType,
 			maxRepetitionLevel: leaf.maxRepetitionLevel,
 			maxDefinitionLevel: leaf.maxDefinitionLevel,
+			lowerNulls:         leaf.node.Optional() && dictionary != nil,
 			bufferIndex:        int32(leaf.columnIndex),
 			bufferSize:         int32(float64(config.PageBufferSize) * 0.98),
-			writePageStats:     config.DataPageStatistics,
-			writePageBounds: !slices.ContainsFunc(config.SkipPageBounds, func(skip []string) bool {
+			writePageStats:     config.DataPageStatistics && !skipStatistics,
+			writePageBounds: !skipStatistics && !slices.ContainsFunc(config.SkipPageBounds, func(skip []string) bool {
 				return columnPath(skip).equal(leaf.path)
 			}),
-			encodings: make([]format.Encoding, 0, 3),
+			skipStatistics:  skipStatistics,
+			skipPageIndex:   noIndex || (indexedColumns && !indexed),
+			canonicalFloats: config.CanonicalizeFloats && (leaf.node.Type().Kind() == Float || leaf.node.Type().Kind() == Double),
+			encodings:       make([]format.Encoding, 0, 3),
+			transform:       lookupColumnTransform(config.ColumnTransforms, leaf.path),
 			// Data pages in version 2 can omit compression when dictionary
 			// encoding is employed; only the dictionary page needs to be
 			// compressed, the data pages are encoded with the hybrid
@@ -718,6 +1034,30 @@ func newWriter(output io.Writer, config *WriterConfig) *writer {
 
 		c.header.encoder.Reset(c.header.protocol.NewWriter(&c.buffers.header))
 
+		if config.ConstantColumns && dictionary == nil {
+			switch columnType.Kind() {
+			case Float, Double:
+			default:
+				c.constantDictionary = columnType.NewDictionary(columnIndex, 0, columnType.NewValues(nil, nil))
+			}
+		}
+
+		if config.VerifyOnClose {
+			c.valueHash = newValueHash()
+		}
+
+		if distinctCountOf(schema, leaf.path) {
+			c.distinctValues = newDistinctValues()
+		}
+
+		if t, ok := leaf.node.Type().(*epochTimestampType); ok {
+			c.timestampEpoch = t.epoch.Format(time.RFC3339Nano)
+		}
+
+		if t, ok := leaf.node.Type().(*durationType); ok {
+			c.durationUnit = t.unit.String()
+		}
+
 		if leaf.maxDefinitionLevel > 0 {
 			c.encodings = addEncoding(c.encodings, format.RLE)
 		}
@@ -726,6 +1066,17 @@ func newWriter(output io.Writer, config *WriterConfig) *writer {
 			c.encodings = addEncoding(c.encodings, format.Plain)
 		}
 
+		if dictionary != nil && config.MaxDictionarySize > 0 {
+			c.maxDictionarySize = int64(config.MaxDictionarySize)
+			c.fallbackType = leaf.node.Type()
+		}
+
+		if config.DataPageSize > 0 {
+			c.dataPageSize = int64(config.DataPageSize)
+			c.maxBufferSize = maxBufferSizeFactor * max(c.dataPageSize, int64(config.PageBufferSize))
+			c.resetBufferSize()
+		}
+
 		c.encoding = encoding
 		c.encodings = addEncoding(c.encodings, c.encoding.Encoding())
 		sortPageEncodings(c.encodings)
@@ -741,6 +1092,13 @@ func newWriter(output io.Writer, config *WriterConfig) *writer {
 		}
 	})
 
+	for i, bitmap := range config.NullBitmaps {
+		leaf, _ := config.Schema.Lookup(bitmap.Path...)
+		w.columns[leaf.ColumnIndex].nullBitmap = w.columns[len(w.columns)-len(config.NullBitmaps)+i]
+	}
+
+	w.chunkOrder = mustOrderColumnChunks(w.columns, config.ColumnChunkGroups)
+
 	// Pre-allocate the backing array so that in most cases where the rows
 	// contain a single value we will hit collocated memory areas when writing
 	// rows to the writer. This won't benefit repeated columns much but in that
@@ -759,13 +1117,23 @@ func newWriter(output io.Writer, config *WriterConfig) *writer {
 	for i, c := range w.columns {
 		w.columnChunk[i] = format.ColumnChunk{
 			MetaData: format.ColumnMetaData{
-				Type:             format.Type(c.columnType.Kind()),
-				Encoding:         c.encodings,
-				PathInSchema:     c.columnPath,
-				Codec:            c.compression.CompressionCodec(),
-				KeyValueMetadata: nil, // TODO
+				Type:         format.Type(c.columnType.Kind()),
+				Encoding:     c.encodings,
+				PathInSchema: c.columnPath,
+				Codec:        c.compression.CompressionCodec(),
 			},
 		}
+		if c.transform != nil {
+			w.columnChunk[i].MetaData.KeyValueMetadata = []format.KeyValue{{Key: columnTransformKey, Value: c.transform.Name}}
+		}
+		if c.timestampEpoch != "" {
+			w.columnChunk[i].MetaData.KeyValueMetadata = append(w.columnChunk[i].MetaData.KeyValueMetadata,
+				format.KeyValue{Key: timestampEpochKey, Value: c.timestampEpoch})
+		}
+		if c.durationUnit != "" {
+			w.columnChunk[i].MetaData.KeyValueMetadata = append(w.columnChunk[i].MetaData.KeyValueMetadata,
+				format.KeyValue{Key: durationUnitKey, Value: c.durationUnit})
+		}
 	}
 
 	for i, c := range w.columns {
@@ -781,6 +1149,7 @@ func newWriter(output io.Writer, config *WriterConfig) *writer {
 }
 
 func (w *writer) reset(writer io.Writer) {
+	writer = w.setOutput(writer)
 	if w.buffer == nil {
 		w.writer.Reset(writer)
 	} else {
@@ -789,6 +1158,15 @@ func (w *writer) reset(writer io.Writer) {
 	}
 	for _, c := range w.columns {
 		c.reset()
+		if c.valueHash != nil {
+			c.valueHash.reset()
+		}
+		if c.distinctValues != nil {
+			c.distinctValues.reset()
+		}
+	}
+	if w.sortingCheck != nil {
+		w.sortingCheck.reset()
 	}
 	for i := range w.rowGroups {
 		w.rowGroups[i] = format.RowGroup{}
@@ -816,7 +1194,73 @@ func (w *writer) close() error {
 		return err
 	}
 	if w.buffer != nil {
-		return w.buffer.Flush()
+		if err := w.buffer.Flush(); err != nil {
+			return err
+		}
+	}
+	if w.verify {
+		return w.verifyOutput()
+	}
+	return nil
+}
+
+// setOutput records the output of the writer and returns the io.Writer that
+// the file should be written to. When files are verified on close and the
+// output cannot be read back, the file is also copied to an in-memory buffer.
+func (w *writer) setOutput(output io.Writer) io.Writer {
+	w.output = output
+	if !w.verify {
+		return output
+	}
+	// The output may already hold data when the writer is created, the
+	// position where the file starts is only known if the output is seekable.
+	if _, ok := output.(io.ReaderAt); ok {
+		if seeker, ok := output.(io.Seeker); ok {
+			if offset, err := seeker.Seek(0, io.SeekCurrent); err == nil {
+				w.outputOffset = offset
+				w.written = nil
+				return output
+			}
+		}
+	}
+	if w.written == nil {
+		w.written = new(bytes.Buffer)
+	} else {
+		w.written.Reset()
+	}
+	return io.MultiWriter(output, w.written)
+}
+
+// verifyOutput reads back the file that was just written and compares its
+// number of rows and the hashes of its column values with the ones computed
+// when the pages were written.
+func (w *writer) verifyOutput() error {
+	var input io.ReaderAt
+	if w.written != nil {
+		input = bytes.NewReader(w.written.Bytes())
+	} else {
+		input = io.NewSectionReader(w.output.(io.ReaderAt), w.outputOffset, w.writer.offset)
+	}
+
+	f, err := OpenFile(input, w.writer.offset, SkipBloomFilters(true))
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrVerificationFailed, err)
+	}
+	if numRows := f.NumRows(); numRows != w.fileMetaData.NumRows {
+		return fmt.Errorf("%w: wrote %d rows but read back %d", ErrVerificationFailed, w.fileMetaData.NumRows, numRows)
+	}
+
+	rowGroups := f.RowGroups()
+	for i, c := range w.columns {
+		h := newValueHash()
+		for _, rowGroup := range rowGroups {
+			if err := h.writeColumnChunk(rowGroup.ColumnChunks()[i]); err != nil {
+				return fmt.Errorf("%w: reading column %q: %w", ErrVerificationFailed, c.columnPath, err)
+			}
+		}
+		if h.sum() != c.valueHash.sum() {
+			return fmt.Errorf("%w: values of column %q differ from the values written", ErrVerificationFailed, c.columnPath)
+		}
 	}
 	return nil
 }
@@ -858,12 +1302,17 @@ func (w *writer) writeFileFooter() error {
 	// because the parquet format is backward compatible in this case. Older
 	// readers will simply ignore this section since they do not know how to
 	// decode its content, nor have loaded any metadata to reference it.
+	// Columns declared with the "noindex" tag, or without the "index" tag when
+	// other columns have it, are omitted from the page index.
 	protocol := new(thrift.CompactProtocol)
 	encoder := thrift.NewEncoder(protocol.NewWriter(&w.writer))
 
 	for i, columnIndexes := range w.columnIndexes {
 		rowGroup := &w.rowGroups[i]
 		for j := range columnIndexes {
+			if w.columns[j].skipPageIndex {
+				continue
+			}
 			column := &rowGroup.Columns[j]
 			column.ColumnIndexOffset = w.writer.offset
 			if err := encoder.Encode(&columnIndexes[j]); err != nil {
@@ -876,6 +1325,9 @@ func (w *writer) writeFileFooter() error {
 	for i, offsetIndexes := range w.offsetIndexes {
 		rowGroup := &w.rowGroups[i]
 		for j := range offsetIndexes {
+			if w.columns[j].skipPageIndex {
+				continue
+			}
 			column := &rowGroup.Columns[j]
 			column.OffsetIndexOffset = w.writer.offset
 			if err := encoder.Encode(&offsetIndexes[j]); err != nil {
@@ -897,6 +1349,18 @@ func (w *writer) writeFileFooter() error {
 	// https://github.com/apache/arrow/blob/70b9ef5/go/parquet/metadata/file.go#L122-L127
 	const parquetFileFormatVersion = 2
 
+	for _, c := range w.columns {
+		if c.distinctValues != nil {
+			setKeyValueMetadata(&w.metadata, distinctCountKey(c.columnPath), strconv.FormatInt(c.distinctValues.count(), 10))
+		}
+	}
+
+	if w.deterministic {
+		// Key/value pairs set after the writer was created are appended to
+		// the metadata, they are sorted so the order of calls does not matter.
+		sortKeyValueMetadata(w.metadata)
+	}
+
 	w.fileMetaData = &format.FileMetaData{
 		Version:          parquetFileFormatVersion,
 		Schema:           w.schemaElements,
@@ -906,6 +1370,14 @@ func (w *writer) writeFileFooter() error {
 		CreatedBy:        w.createdBy,
 		ColumnOrders:     w.columnOrders,
 	}
+	if w.metadataChecksums {
+		if err := addMetadataChecksums(new(thrift.CompactProtocol), w.fileMetaData); err != nil {
+			return err
+		}
+	}
+	// The footer is encoded with the thrift compact protocol, as required by
+	// the parquet specification. OpenFile also accepts footers encoded with the
+	// binary protocol, but the writer never produces them.
 	footer, err := thrift.Marshal(new(thrift.CompactProtocol), w.fileMetaData)
 	if err != nil {
 		return err
@@ -957,39 +1429,14 @@ func (w *writer) writeRowGroup(rowGroupSchema *Schema, rowGroupSortingColumns []
 	}
 	fileOffset := w.writer.offset
 
-	for i, c := range w.columns {
-		w.columnIndex[i] = format.ColumnIndex(c.columnIndex.ColumnIndex())
-
-		if c.dictionary != nil {
-			c.columnChunk.MetaData.DictionaryPageOffset = w.writer.offset
-			if err := c.writeDictionaryPage(&w.writer, c.dictionary); err != nil {
-				return 0, fmt.Errorf("writing dictionary page of row group colum %d: %w", i, err)
-			}
-		}
-
-		// Skip columns with nil pageBuffer (e.g., empty struct groups with no leaf columns)
-		if c.pageBuffer == nil {
-			continue
-		}
-
-		dataPageOffset := w.writer.offset
-		c.columnChunk.MetaData.DataPageOffset = dataPageOffset
-		for j := range c.offsetIndex.PageLocations {
-			c.offsetIndex.PageLocations[j].Offset += dataPageOffset
-		}
-
-		if offset, err := c.pageBuffer.Seek(0, io.SeekStart); err != nil {
+	for _, i := range w.chunkOrder {
+		if err := w.writeColumnPages(i); err != nil {
 			return 0, err
-		} else if offset != 0 {
-			return 0, fmt.Errorf("resetting parquet page buffer to the start expected offset zero but got %d", offset)
-		}
-		if _, err := io.Copy(&w.writer, c.pageBuffer); err != nil {
-			return 0, fmt.Errorf("writing buffered pages of row group column %d: %w", i, err)
 		}
 	}
 
-	for _, c := range w.columns {
-		if len(c.filter) > 0 {
+	for _, i := range w.chunkOrder {
+		if c := w.columns[i]; len(c.filter) > 0 {
 			c.columnChunk.MetaData.BloomFilterOffset = w.writer.offset
 			if err := c.writeBloomFilter(&w.writer); err != nil {
 				return 0, err
@@ -1000,46 +1447,16 @@ func (w *writer) writeRowGroup(rowGroupSchema *Schema, rowGroupSortingColumns []
 	totalByteSize := int64(0)
 	totalCompressedSize := int64(0)
 
-	for i := range w.columnChunk {
-		c := &w.columnChunk[i].MetaData
-		sortPageEncodingStats(c.EncodingStats)
-		totalByteSize += int64(c.TotalUncompressedSize)
-		totalCompressedSize += int64(c.TotalCompressedSize)
-	}
-
-	sortingColumns := w.sortingColumns
-	if len(sortingColumns) == 0 && len(rowGroupSortingColumns) > 0 {
-		sortingColumns = make([]format.SortingColumn, 0, len(rowGroupSortingColumns))
-		forEachLeafColumnOf(rowGroupSchema, func(leaf leafColumn) {
-			if sortingIndex := searchSortingColumn(rowGroupSortingColumns, leaf.path); sortingIndex < len(sortingColumns) {
-				sortingColumns[sortingIndex] = format.SortingColumn{
-					ColumnIdx:  int32(leaf.columnIndex),
-					Descending: rowGroupSortingColumns[sortingIndex].Descending(),
-					NullsFirst: rowGroupSortingColumns[sortingIndex].NullsFirst(),
-				}
-			}
-		})
-	}
+	sortingColumns := w.rowGroupSortingColumns(rowGroupSchema, rowGroupSortingColumns)
 
 	columns := make([]format.ColumnChunk, len(w.columnChunk))
-	copy(columns, w.columnChunk)
-
 	columnIndex := make([]format.ColumnIndex, len(w.columnIndex))
-	copy(columnIndex, w.columnIndex)
-
 	offsetIndex := make([]format.OffsetIndex, len(w.offsetIndex))
-	copy(offsetIndex, w.offsetIndex)
 
 	for i := range columns {
-		c := &columns[i]
-		c.MetaData.EncodingStats = make([]format.PageEncodingStats, len(c.MetaData.EncodingStats))
-		copy(c.MetaData.EncodingStats, w.columnChunk[i].MetaData.EncodingStats)
-	}
-
-	for i := range offsetIndex {
-		c := &offsetIndex[i]
-		c.PageLocations = make([]format.PageLocation, len(c.PageLocations))
-		copy(c.PageLocations, w.offsetIndex[i].PageLocations)
+		columns[i], columnIndex[i], offsetIndex[i] = w.writtenColumnChunk(i)
+		totalByteSize += columns[i].MetaData.TotalUncompressedSize
+		totalCompressedSize += columns[i].MetaData.TotalCompressedSize
 	}
 
 	w.rowGroups = append(w.rowGroups, format.RowGroup{
@@ -1057,61 +1474,477 @@ func (w *writer) writeRowGroup(rowGroupSchema *Schema, rowGroupSortingColumns []
 	return numRows, nil
 }
 
-func (w *writer) WriteRows(rows []Row) (int, error) {
-	return w.writeRows(len(rows), func(start, end int) (int, error) {
-		defer func() {
-			for i, values := range w.values {
-				clearValues(values)
-				w.values[i] = values[:0]
-			}
-		}()
+// writeColumnPages writes the dictionary and data pages buffered by the column
+// writer at index i to the output.
+func (w *writer) writeColumnPages(i int) error {
+	c := w.columns[i]
+	w.columnIndex[i] = format.ColumnIndex(c.columnIndex.ColumnIndex())
 
-		// TODO: if an error occurs in this method the writer may be left in an
-		// partially functional state. Applications are not expected to continue
-		// using the writer after getting an error, but maybe we could ensure that
-		// we are preventing further use as well?
-		for _, row := range rows[start:end] {
-			row.Range(func(columnIndex int, columnValues []Value) bool {
-				w.values[columnIndex] = append(w.values[columnIndex], columnValues...)
-				return true
-			})
+	if dict := c.chunkDictionary(); dict != nil {
+		c.columnChunk.MetaData.DictionaryPageOffset = w.writer.offset
+		if err := c.writeDictionaryPage(&w.writer, dict); err != nil {
+			return fmt.Errorf("writing dictionary page of row group colum %d: %w", i, err)
 		}
+	}
 
-		for i, values := range w.values {
-			if len(values) > 0 {
-				if _, err := w.columns[i].WriteRowValues(values); err != nil {
-					return 0, err
-				}
-			}
-		}
+	// Skip columns with nil pageBuffer (e.g., empty struct groups with no leaf columns)
+	if c.pageBuffer == nil {
+		return nil
+	}
 
-		return end - start, nil
-	})
+	dataPageOffset := w.writer.offset
+	c.columnChunk.MetaData.DataPageOffset = dataPageOffset
+	for j := range c.offsetIndex.PageLocations {
+		c.offsetIndex.PageLocations[j].Offset += dataPageOffset
+	}
+
+	if offset, err := c.pageBuffer.Seek(0, io.SeekStart); err != nil {
+		return err
+	} else if offset != 0 {
+		return fmt.Errorf("resetting parquet page buffer to the start expected offset zero but got %d", offset)
+	}
+	if _, err := io.Copy(&w.writer, c.pageBuffer); err != nil {
+		return fmt.Errorf("writing buffered pages of row group column %d: %w", i, err)
+	}
+	return nil
 }
 
-func (w *writer) writeRows(numRows int, write func(i, j int) (int, error)) (int, error) {
-	written := 0
+// writtenColumnChunk returns copies of the metadata, column index, and offset
+// index of the column chunk written by the column writer at index i, which
+// retains its own for the next row groups.
+func (w *writer) writtenColumnChunk(i int) (format.ColumnChunk, format.ColumnIndex, format.OffsetIndex) {
+	columnChunk := w.columnChunk[i]
+	sortPageEncodingStats(columnChunk.MetaData.EncodingStats)
+	columnChunk.MetaData.EncodingStats = slices.Clone(columnChunk.MetaData.EncodingStats)
 
-	for written < numRows {
-		remain := w.maxRows - w.numRows
-		length := numRows - written
+	if w.columns[i].constantPages > 0 {
+		// The encodings of column chunks are shared between row groups,
+		// they are only extended for the ones that had constant pages.
+		encodings := slices.Clone(columnChunk.MetaData.Encoding)
+		encodings = addEncoding(encodings, format.Plain)
+		encodings = addEncoding(encodings, format.RLEDictionary)
+		sortPageEncodings(encodings)
+		columnChunk.MetaData.Encoding = encodings
+	}
 
-		if remain <= 0 {
-			remain = w.maxRows
+	offsetIndex := w.offsetIndex[i]
+	offsetIndex.PageLocations = slices.Clone(offsetIndex.PageLocations)
+	return columnChunk, w.columnIndex[i], offsetIndex
+}
 
-			if err := w.flush(); err != nil {
-				return written, err
+// rowGroupSortingColumns returns the sorting columns recorded in the metadata of
+// a row group, the ones configured on the writer take precedence over the ones
+// of the row group being written.
+func (w *writer) rowGroupSortingColumns(rowGroupSchema *Schema, rowGroupSortingColumns []SortingColumn) []format.SortingColumn {
+	sortingColumns := w.sortingColumns
+	if len(sortingColumns) == 0 && len(rowGroupSortingColumns) > 0 {
+		sortingColumns = make([]format.SortingColumn, 0, len(rowGroupSortingColumns))
+		forEachLeafColumnOf(rowGroupSchema, func(leaf leafColumn) {
+			if sortingIndex := searchSortingColumn(rowGroupSortingColumns, leaf.path); sortingIndex < len(sortingColumns) {
+				sortingColumns[sortingIndex] = format.SortingColumn{
+					ColumnIdx:  int32(leaf.columnIndex),
+					Descending: rowGroupSortingColumns[sortingIndex].Descending(),
+					NullsFirst: rowGroupSortingColumns[sortingIndex].NullsFirst(),
+				}
 			}
-		}
+		})
+	}
+	return sortingColumns
+}
 
-		if remain < int64(length) {
-			length = int(remain)
+// columnChunkCopy holds the parts of a column chunk of a file which are copied
+// when writing its row group without decoding the pages.
+type columnChunkCopy struct {
+	chunk       *FileColumnChunk
+	columnIndex *format.ColumnIndex
+	offsetIndex *format.OffsetIndex
+	bloomFilter *FileBloomFilter
+}
+
+// prepareRowGroupCopy returns the column chunks of rowGroup to copy to the
+// output, or nil if the row group cannot be copied without re-encoding its
+// pages, in which case the rows must be written one by one.
+//
+// The row group can be copied when each column chunk is compressed with the
+// codec of the column writer, only uses encodings that the column writer may
+// produce, and has the page index and bloom filter that the writer would have
+// written.
+func (w *writer) prepareRowGroupCopy(rowGroup *FileRowGroup) ([]columnChunkCopy, error) {
+	// Files written with null bitmaps have more columns than the rows, and the
+	// values of each column must be seen when verifying the file on close. The
+	// order of the rows must be verified as well unless the row group declares
+	// that it is sorted on the columns that the writer checks.
+	if w.verify || len(w.columns) != len(rowGroup.columns) {
+		return nil, nil
+	}
+	if w.sortingCheck != nil && !sortingColumnsHavePrefix(rowGroup.SortingColumns(), w.sortingCheck.columns) {
+		return nil, nil
+	}
+
+	chunks := make([]columnChunkCopy, len(w.columns))
+	for i, c := range w.columns {
+		chunk, ok := rowGroup.columns[i].(*FileColumnChunk)
+		if !ok {
+			return nil, nil
+		}
+		var err error
+		if chunks[i], ok, err = c.prepareColumnChunkCopy(chunk); !ok || err != nil {
+			return nil, err
 		}
+	}
+	return chunks, nil
+}
 
-		// Since the writer cannot flush pages across row boundaries, calls to
-		// WriteRows with very large slices can result in greatly exceeding the
-		// target page size. To set a limit to the impact of these large writes
-		// we chunk the input in slices of 64 rows.
+// prepareColumnChunkCopy returns the parts of chunk to copy to the output, or
+// false if the column chunk cannot be copied without re-encoding its pages.
+func (c *ColumnWriter) prepareColumnChunkCopy(chunk *FileColumnChunk) (columnChunkCopy, bool, error) {
+	if !c.canCopyColumnChunk(chunk.chunk) {
+		return columnChunkCopy{}, false, nil
+	}
+	chunkCopy := columnChunkCopy{chunk: chunk}
+
+	if !c.skipPageIndex {
+		columnIndex, err := chunk.readColumnIndex()
+		if err != nil {
+			return columnChunkCopy{}, false, err
+		}
+		offsetIndex, err := chunk.readOffsetIndex(chunk.reader())
+		if err != nil {
+			return columnChunkCopy{}, false, err
+		}
+		if columnIndex == nil || offsetIndex == nil {
+			return columnChunkCopy{}, false, nil
+		}
+		chunkCopy.columnIndex = columnIndex.index
+		chunkCopy.offsetIndex = offsetIndex.index
+	}
+
+	if c.columnFilter != nil {
+		bloomFilter, err := chunk.readBloomFilter(chunk.reader())
+		if err != nil {
+			return columnChunkCopy{}, false, err
+		}
+		if bloomFilter == nil {
+			return columnChunkCopy{}, false, nil
+		}
+		chunkCopy.bloomFilter = bloomFilter
+	}
+	return chunkCopy, true, nil
+}
+
+// canCopyColumnChunk returns true if the pages of the column chunk can be
+// written to the output as-is by c.
+func (c *ColumnWriter) canCopyColumnChunk(chunk *format.ColumnChunk) bool {
+	metadata := &chunk.MetaData
+	if chunk.FilePath != "" || chunk.EncryptedColumnMetadata != nil {
+		return false
+	}
+	if len(c.compressionChoices) > 0 || metadata.Codec != c.compression.CompressionCodec() {
+		return false
+	}
+	if columnTransformName(metadata) != c.transformName() {
+		return false
+	}
+	// The values must be seen to count the distinct values of the column.
+	if c.distinctValues != nil {
+		return false
+	}
+	for _, encoding := range metadata.Encoding {
+		// Repetition levels are RLE encoded even when the column writer only
+		// lists the encoding for definition levels.
+		if encoding != format.RLE && !slices.Contains(c.encodings, encoding) {
+			return false
+		}
+	}
+	return true
+}
+
+// copyRowGroup writes the column chunks of rowGroup returned by
+// prepareRowGroupCopy to the output, adjusting the offsets recorded in their
+// metadata and page index to their new location in the file.
+//
+// The column chunks which have no chunk to copy are the ones written by the
+// column writers, which must then hold the values of all the rows of rowGroup.
+func (w *writer) copyRowGroup(rowGroup *FileRowGroup, sortingColumns []SortingColumn, chunks []columnChunkCopy) (int64, error) {
+	numRows := rowGroup.NumRows()
+	if numRows == 0 {
+		return 0, nil
+	}
+	if len(w.rowGroups) == MaxRowGroups {
+		return 0, ErrTooManyRowGroups
+	}
+
+	defer func() {
+		for i, chunk := range chunks {
+			if chunk.chunk == nil {
+				w.columns[i].reset()
+				w.columnIndex[i] = format.ColumnIndex{}
+			}
+		}
+	}()
+
+	for i, chunk := range chunks {
+		if chunk.chunk != nil {
+			continue
+		}
+		c := w.columns[i]
+		if n := c.totalRowCount(); n != numRows {
+			return 0, fmt.Errorf("cannot copy row group of %d rows with column %q holding %d rows", numRows, c.columnPath, n)
+		}
+		if err := c.Flush(); err != nil {
+			return 0, err
+		}
+		if err := c.flushFilterPages(); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := w.writeFileHeader(); err != nil {
+		return 0, err
+	}
+	fileOffset := w.writer.offset
+
+	columns := make([]format.ColumnChunk, len(chunks))
+	columnIndex := make([]format.ColumnIndex, len(chunks))
+	offsetIndex := make([]format.OffsetIndex, len(chunks))
+	totalByteSize := int64(0)
+	totalCompressedSize := int64(0)
+
+	for _, i := range w.chunkOrder {
+		chunk := chunks[i]
+		if chunk.chunk == nil {
+			if err := w.writeColumnPages(i); err != nil {
+				return 0, err
+			}
+			continue
+		}
+		metadata := chunk.chunk.chunk.MetaData
+		// Some writers do not set the dictionary page offset, the dictionary
+		// page is then the first page of the column chunk.
+		start := metadata.DataPageOffset
+		if metadata.DictionaryPageOffset != 0 && metadata.DictionaryPageOffset < start {
+			start = metadata.DictionaryPageOffset
+		}
+		delta := w.writer.offset - start
+
+		pages := io.NewSectionReader(chunk.chunk.reader(), start, metadata.TotalCompressedSize)
+		if _, err := io.CopyN(&w.writer, pages, metadata.TotalCompressedSize); err != nil {
+			return 0, fmt.Errorf("copying pages of row group column %d: %w", i, err)
+		}
+
+		metadata.Encoding = slices.Clone(metadata.Encoding)
+		metadata.PathInSchema = w.columns[i].columnPath
+		metadata.KeyValueMetadata = slices.Clone(metadata.KeyValueMetadata)
+		metadata.EncodingStats = slices.Clone(metadata.EncodingStats)
+		metadata.DataPageOffset += delta
+		if metadata.DictionaryPageOffset != 0 {
+			metadata.DictionaryPageOffset += delta
+		}
+		if metadata.IndexPageOffset != 0 {
+			metadata.IndexPageOffset += delta
+		}
+		metadata.BloomFilterOffset = 0
+		metadata.BloomFilterLength = nil
+		if w.columns[i].skipStatistics {
+			metadata.Statistics = format.Statistics{}
+		}
+		columns[i] = format.ColumnChunk{MetaData: metadata}
+
+		if chunk.columnIndex != nil {
+			columnIndex[i] = *chunk.columnIndex
+		}
+		if chunk.offsetIndex != nil {
+			offsetIndex[i].PageLocations = make([]format.PageLocation, len(chunk.offsetIndex.PageLocations))
+			for j, location := range chunk.offsetIndex.PageLocations {
+				location.Offset += delta
+				offsetIndex[i].PageLocations[j] = location
+			}
+			offsetIndex[i].UnencodedByteArrayDataBytes = chunk.offsetIndex.UnencodedByteArrayDataBytes
+		}
+
+		totalByteSize += metadata.TotalUncompressedSize
+		totalCompressedSize += metadata.TotalCompressedSize
+	}
+
+	for _, i := range w.chunkOrder {
+		chunk := chunks[i]
+		c := w.columns[i]
+		if chunk.chunk == nil {
+			if len(c.filter) > 0 {
+				c.columnChunk.MetaData.BloomFilterOffset = w.writer.offset
+				if err := c.writeBloomFilter(&w.writer); err != nil {
+					return 0, err
+				}
+			}
+			columns[i], columnIndex[i], offsetIndex[i] = w.writtenColumnChunk(i)
+			totalByteSize += columns[i].MetaData.TotalUncompressedSize
+			totalCompressedSize += columns[i].MetaData.TotalCompressedSize
+			continue
+		}
+		if chunk.bloomFilter == nil {
+			continue
+		}
+		c.filter = slices.Grow(c.filter[:0], int(chunk.bloomFilter.Size()))[:chunk.bloomFilter.Size()]
+		if _, err := chunk.bloomFilter.ReadAt(c.filter, 0); err != nil {
+			return 0, fmt.Errorf("reading bloom filter of row group column %d: %w", i, err)
+		}
+		columns[i].MetaData.BloomFilterOffset = w.writer.offset
+		err := c.writeBloomFilter(&w.writer)
+		c.filter = c.filter[:0]
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	w.rowGroups = append(w.rowGroups, format.RowGroup{
+		Columns:             columns,
+		TotalByteSize:       totalByteSize,
+		NumRows:             numRows,
+		SortingColumns:      w.rowGroupSortingColumns(rowGroup.Schema(), sortingColumns),
+		FileOffset:          fileOffset,
+		TotalCompressedSize: totalCompressedSize,
+		Ordinal:             int16(len(w.rowGroups)),
+	})
+
+	w.columnIndexes = append(w.columnIndexes, columnIndex)
+	w.offsetIndexes = append(w.offsetIndexes, offsetIndex)
+	return numRows, nil
+}
+
+func (w *writer) WriteRows(rows []Row) (int, error) {
+	return w.writeRows(len(rows), func(start, end int) (int, error) {
+		// Only the rows preceding the first row out of order are written.
+		var err error
+		if w.sortingCheck != nil {
+			var n int
+			n, err = w.sortingCheck.check(rows[start:end])
+			end = start + n
+		}
+
+		if err := w.writeRowValues(rows[start:end]); err != nil {
+			return 0, err
+		}
+		return end - start, err
+	})
+}
+
+// writeRowValues writes the values of rows to the column writers.
+func (w *writer) writeRowValues(rows []Row) error {
+	defer func() {
+		for i, values := range w.values {
+			clearValues(values)
+			w.values[i] = values[:0]
+		}
+	}()
+
+	// TODO: if an error occurs in this method the writer may be left in an
+	// partially functional state. Applications are not expected to continue
+	// using the writer after getting an error, but maybe we could ensure that
+	// we are preventing further use as well?
+	for _, row := range rows {
+		row.Range(func(columnIndex int, columnValues []Value) bool {
+			w.values[columnIndex] = append(w.values[columnIndex], columnValues...)
+			return true
+		})
+	}
+
+	for i, values := range w.values {
+		if len(values) > 0 {
+			if _, err := w.columns[i].WriteRowValues(values); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (w *writer) writeColumns(columns [][]Value) (int, error) {
+	if len(columns) != len(w.columns) {
+		return 0, fmt.Errorf("cannot write %d columns to a writer with %d columns", len(columns), len(w.columns))
+	}
+	if len(columns) == 0 {
+		return 0, nil
+	}
+
+	// Offsets of the first value of each row in the columns, with an extra
+	// offset at the end of the values of each column.
+	rowOffsets := make([][]int, len(columns))
+	for i, values := range columns {
+		c := w.columns[i]
+		column := c.columnPath.String()
+		offsets := make([]int, 0, len(values)+1)
+		for j, v := range values {
+			if err := checkValueLevels(column, v, int(c.maxRepetitionLevel), int(c.maxDefinitionLevel)); err != nil {
+				return 0, err
+			}
+			if v.RepetitionLevel() == 0 {
+				offsets = append(offsets, j)
+			} else if j == 0 {
+				return 0, fmt.Errorf("column %q: the first value must have a repetition level of zero", column)
+			}
+		}
+		if i > 0 && len(offsets) != len(rowOffsets[0])-1 {
+			return 0, fmt.Errorf("%w: column %q has %d rows, column %q has %d rows", ErrMisalignedColumns,
+				w.columns[0].columnPath, len(rowOffsets[0])-1, column, len(offsets))
+		}
+		rowOffsets[i] = append(offsets, len(values))
+	}
+
+	numRows := len(rowOffsets[0]) - 1
+	return w.writeRows(numRows, func(start, end int) (int, error) {
+		var err error
+		if w.sortingCheck != nil {
+			var n int
+			n, err = w.sortingCheck.checkFunc(end-start, func(row Row, j int) Row {
+				for i, values := range columns {
+					for _, v := range values[rowOffsets[i][start+j]:rowOffsets[i][start+j+1]] {
+						row = append(row, v.Level(int(v.RepetitionLevel()), int(v.DefinitionLevel()), i))
+					}
+				}
+				return row
+			})
+			end = start + n
+		}
+
+		for i, values := range columns {
+			buffer := w.values[i][:0]
+			for _, v := range values[rowOffsets[i][start]:rowOffsets[i][end]] {
+				buffer = append(buffer, v.Level(int(v.RepetitionLevel()), int(v.DefinitionLevel()), i))
+			}
+			_, err := w.columns[i].WriteRowValues(buffer)
+			clearValues(buffer)
+			w.values[i] = buffer[:0]
+			if err != nil {
+				return 0, err
+			}
+		}
+		return end - start, err
+	})
+}
+
+func (w *writer) writeRows(numRows int, write func(i, j int) (int, error)) (int, error) {
+	written := 0
+
+	for written < numRows {
+		remain := w.maxRows - w.numRows
+		length := numRows - written
+
+		if remain <= 0 {
+			remain = w.maxRows
+
+			if err := w.flush(); err != nil {
+				return written, err
+			}
+		}
+
+		if remain < int64(length) {
+			length = int(remain)
+		}
+
+		// Since the writer cannot flush pages across row boundaries, calls to
+		// WriteRows with very large slices can result in greatly exceeding the
+		// target page size. To set a limit to the impact of these large writes
+		// we chunk the input in slices of 64 rows.
 		//
 		// Note that this mechanism isn't perfect; for example, values may hold
 		// large byte slices which could still cause the column buffers to grow
@@ -1127,11 +1960,42 @@ func (w *writer) writeRows(numRows int, write func(i, j int) (int, error)) (int,
 		if err != nil {
 			return written, err
 		}
+
+		if w.maxBufferedBytes > 0 && w.bufferedBytes() >= w.maxBufferedBytes {
+			if err := w.releaseBufferedBytes(); err != nil {
+				return written, err
+			}
+		}
 	}
 
 	return written, nil
 }
 
+func (w *writer) bufferedBytes() (size int64) {
+	for _, c := range w.columns {
+		size += c.bufferedBytes()
+	}
+	return size
+}
+
+// releaseBufferedBytes is called when the memory held by the column writers
+// crossed the configured limit. Pages are first spilled to the configured
+// buffer pool if any, and the row group is flushed if it was not enough to
+// bring the memory usage back under the limit.
+func (w *writer) releaseBufferedBytes() error {
+	if w.spillBuffers != nil {
+		for _, c := range w.columns {
+			if err := c.spill(w.spillBuffers); err != nil {
+				return err
+			}
+		}
+		if w.bufferedBytes() < w.maxBufferedBytes {
+			return nil
+		}
+	}
+	return w.flush()
+}
+
 // The WriteValues method is intended to work in pair with WritePage to allow
 // programs to target writing values to specific columns of of the writer.
 func (w *writer) WriteValues(values []Value) (numValues int, err error) {
@@ -1223,6 +2087,14 @@ func (wb *writerBuffers) encode(page Page, enc encoding.Encoding) (err error) {
 	return err
 }
 
+// encodeConstant encodes the values of page as indexes into a dictionary of a
+// single value: a bit width of zero followed by one run of zero indexes.
+func (wb *writerBuffers) encodeConstant(page Page) {
+	numValues := page.NumValues() - page.NumNulls()
+	wb.page = append(wb.page[:0], 0)
+	wb.page = binary.AppendUvarint(wb.page, uint64(numValues)<<1)
+}
+
 func (wb *writerBuffers) compress(codec compress.Codec) (err error) {
 	wb.scratch, err = codec.Encode(wb.scratch[:0], wb.page)
 	wb.swapPageAndScratchBuffers()
@@ -1238,6 +2110,10 @@ type ColumnWriter struct {
 	pool       BufferPool
 	pageBuffer io.ReadWriteSeeker
 	numPages   int
+	// When pages were spilled, spillPool is the pool that pageBuffer was
+	// obtained from, and pageBufferSize no longer counts the pages it holds.
+	spillPool      BufferPool
+	pageBufferSize int64
 
 	columnPath   columnPath
 	columnType   Type
@@ -1248,9 +2124,26 @@ type ColumnWriter struct {
 	compression  compress.Codec
 	dictionary   Dictionary
 
+	// Codecs that the compression of each column chunk is selected from when
+	// the writer uses adaptive compression, compressionChosen is set once the
+	// codec of the current column chunk was selected.
+	compressionChoices []compress.Codec
+	compressionChosen  bool
+
+	// Writer of the null bitmap column computed from the definition levels of
+	// the pages of this column, if any.
+	nullBitmap       *ColumnWriter
+	nullBitmapValues []Value
+
 	dataPageType       format.PageType
 	maxRepetitionLevel byte
 	maxDefinitionLevel byte
+	// True if the leaf node of the column is optional and dictionary-encoded,
+	// in which case loweredValues holds a copy of the values written to the
+	// column where null values were lowered from the maximum definition level
+	// (see lowerNullValues).
+	lowerNulls    bool
+	loweredValues []Value
 
 	buffers *writerBuffers
 
@@ -1265,11 +2158,58 @@ type ColumnWriter struct {
 	bufferSize      int32
 	writePageStats  bool
 	writePageBounds bool
+	skipStatistics  bool
+	skipPageIndex   bool
+	canonicalFloats bool
 	isCompressed    bool
 	encodings       []format.Encoding
 
+	// When the writer detects constant columns, constantDictionary holds the
+	// value of the constant pages of the current column chunk, and
+	// constantPages counts the pages that were encoded as indexes into it.
+	constantDictionary Dictionary
+	constantPages      int
+
+	// When the size of the dictionary is bounded, the column chunk falls back
+	// to PLAIN-encoded pages of fallbackType once the dictionary grew beyond
+	// maxDictionarySize. The buffers of the dictionary-encoded and fallback
+	// pages are swapped between columnBuffer and swapBuffer when the column
+	// writer changes mode.
+	maxDictionarySize  int64
+	fallback           bool
+	fallbackType       Type
+	dictionaryType     Type
+	dictionaryEncoding encoding.Encoding
+	swapBuffer         ColumnBuffer
+
+	// Target size of encoded pages when the DataPageSize option is set, the
+	// bufferSize is then adjusted after each page (see resizeBuffer), up to
+	// maxBufferSize.
+	dataPageSize  int64
+	maxBufferSize int64
+
+	// Transform configured for the column with TransformColumn, if any,
+	// applied to the values of data pages before they are encoded.
+	transform *ColumnTransform
+
+	// Epoch of TimestampEpoch columns formatted as RFC 3339, recorded in the
+	// column chunk metadata; empty for other columns.
+	timestampEpoch string
+
+	// Time unit of duration columns (e.g. MICROS), recorded in the column
+	// chunk metadata; empty for other columns.
+	durationUnit string
+
 	columnChunk *format.ColumnChunk
 	offsetIndex *format.OffsetIndex
+
+	// Hash of the values written to the column, only set when the writer
+	// verifies files on close.
+	valueHash *valueHash
+
+	// Set of the distinct values written to the column, only set when the
+	// column was declared with the "ndv" tag.
+	distinctValues *distinctValues
 }
 
 func (c *ColumnWriter) reset() {
@@ -1282,11 +2222,25 @@ func (c *ColumnWriter) reset() {
 	if c.dictionary != nil {
 		c.dictionary.Reset()
 	}
+	if c.fallback {
+		c.restoreDictionaryEncoding()
+	}
+	if c.constantDictionary != nil {
+		c.constantDictionary.Reset()
+	}
+	c.constantPages = 0
 	if c.pageBuffer != nil {
-		c.pool.PutBuffer(c.pageBuffer)
+		if c.spillPool != nil {
+			c.spillPool.PutBuffer(c.pageBuffer)
+		} else {
+			c.pool.PutBuffer(c.pageBuffer)
+		}
 		c.pageBuffer = nil
 	}
+	c.spillPool = nil
+	c.pageBufferSize = 0
 	c.numPages = 0
+	c.compressionChosen = false
 	// Bloom filters may change in size between row groups, but we retain the
 	// buffer to avoid reallocating large memory blocks.
 	c.filter = c.filter[:0]
@@ -1312,6 +2266,46 @@ func (c *ColumnWriter) totalRowCount() int64 {
 	return n
 }
 
+func (c *ColumnWriter) bufferedBytes() int64 {
+	size := c.pageBufferSize
+	if c.columnBuffer != nil {
+		size += c.columnBuffer.Size()
+	}
+	if c.dictionary != nil {
+		size += c.dictionary.Page().Size()
+	}
+	return size
+}
+
+// spill flushes the buffered values to a page and moves the pages held in
+// memory to a buffer obtained from pool. Pages written afterwards are appended
+// to the same buffer until the column writer is reset.
+func (c *ColumnWriter) spill(pool BufferPool) error {
+	if err := c.Flush(); err != nil {
+		return err
+	}
+	if c.pageBuffer == nil || c.spillPool != nil {
+		return nil
+	}
+	if _, err := c.pageBuffer.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	buffer := pool.GetBuffer()
+	if _, err := buffer.Seek(0, io.SeekStart); err != nil {
+		pool.PutBuffer(buffer)
+		return err
+	}
+	if _, err := io.Copy(buffer, c.pageBuffer); err != nil {
+		pool.PutBuffer(buffer)
+		return fmt.Errorf("spilling pages of parquet column %q: %w", c.columnPath, err)
+	}
+	c.pool.PutBuffer(c.pageBuffer)
+	c.pageBuffer = buffer
+	c.spillPool = pool
+	c.pageBufferSize = 0
+	return nil
+}
+
 // Flush writes any buffered data to the underlying [io.Writer].
 func (c *ColumnWriter) Flush() (err error) {
 	if c.columnBuffer == nil {
@@ -1319,8 +2313,109 @@ func (c *ColumnWriter) Flush() (err error) {
 	}
 	if c.columnBuffer.Len() > 0 {
 		defer c.columnBuffer.Reset()
-		_, err = c.writeDataPage(c.columnBuffer.Page())
+		page := c.columnBuffer.Page()
+		if c.valueHash != nil {
+			if err := c.valueHash.writePage(page); err != nil {
+				return err
+			}
+		}
+		if c.distinctValues != nil {
+			if err := c.distinctValues.writePage(page); err != nil {
+				return err
+			}
+		}
+		if c.nullBitmap != nil {
+			if err := c.writeNullBitmap(page); err != nil {
+				return err
+			}
+		}
+		bufferedSize := c.columnBuffer.Size()
+		uncompressedSize := c.columnChunk.MetaData.TotalUncompressedSize
+		_, err = c.writeDataPage(page)
+		if err == nil && c.dataPageSize > 0 {
+			c.resizeBuffer(bufferedSize, c.columnChunk.MetaData.TotalUncompressedSize-uncompressedSize)
+		}
 	}
+	if err == nil && c.dictionaryFull() {
+		c.fallbackToPlainEncoding()
+	}
+	return err
+}
+
+// maxBufferSizeFactor bounds the size of column buffers when the DataPageSize
+// option is set, relative to the larger of the data page and page buffer
+// sizes. Values which encode to almost nothing (e.g. constant columns) would
+// otherwise grow the buffers without limit.
+const maxBufferSizeFactor = 4
+
+// resizeBuffer adjusts the size that the column buffer is flushed at so the
+// next pages get close to the configured data page size, assuming that they
+// have the same ratio between the sizes of the encoded page and of the buffer
+// than the last page. The buffer size at most doubles after each page, so a
+// single page of unusually small values does not inflate the next buffer.
+func (c *ColumnWriter) resizeBuffer(bufferedSize, pageSize int64) {
+	if bufferedSize <= 0 || pageSize <= 0 {
+		return
+	}
+	size := float64(c.dataPageSize) * float64(bufferedSize) / float64(pageSize)
+	size = min(size, 2*float64(c.bufferSize), float64(c.maxBufferSize), math.MaxInt32)
+	c.bufferSize = int32(max(1, size))
+}
+
+// resetBufferSize discards the buffer size learned from the previous pages when
+// the encoding of the column changes.
+func (c *ColumnWriter) resetBufferSize() {
+	if c.dataPageSize > 0 {
+		c.bufferSize = int32(min(c.dataPageSize, math.MaxInt32))
+	}
+}
+
+// dictionaryFull returns true if the dictionary of the column chunk grew beyond
+// the configured limit and the column writer did not fall back to the PLAIN
+// encoding yet.
+func (c *ColumnWriter) dictionaryFull() bool {
+	return c.maxDictionarySize > 0 && !c.fallback && c.dictionary.Page().Size() > c.maxDictionarySize
+}
+
+// fallbackToPlainEncoding switches the column writer to write PLAIN-encoded
+// pages for the remainder of the column chunk. It must be called after the
+// buffered values were flushed, since they are indexes into the dictionary.
+func (c *ColumnWriter) fallbackToPlainEncoding() {
+	c.fallback = true
+	c.resetBufferSize()
+	c.dictionaryType, c.columnType = c.columnType, c.fallbackType
+	c.dictionaryEncoding, c.encoding = c.encoding, &Plain
+	if c.swapBuffer == nil {
+		c.swapBuffer = c.newColumnBuffer()
+	}
+	c.columnBuffer, c.swapBuffer = c.swapBuffer, c.columnBuffer
+	c.isCompressed = isCompressed(c.compression)
+}
+
+// restoreDictionaryEncoding reverts the effect of fallbackToPlainEncoding when
+// the column writer starts a new column chunk.
+func (c *ColumnWriter) restoreDictionaryEncoding() {
+	c.fallback = false
+	c.resetBufferSize()
+	c.columnType = c.dictionaryType
+	c.encoding = c.dictionaryEncoding
+	if c.columnBuffer != nil {
+		c.columnBuffer.Reset()
+	}
+	c.columnBuffer, c.swapBuffer = c.swapBuffer, c.columnBuffer
+	c.isCompressed = isCompressed(c.compression) && c.dataPageType != format.DataPageV2
+}
+
+// writeNullBitmap writes to the null bitmap column whether each value of the
+// page is not null. Null bitmaps are only computed for columns which are not
+// repeated, each value of the page is a row.
+func (c *ColumnWriter) writeNullBitmap(page Page) error {
+	values := c.nullBitmapValues[:0]
+	for _, level := range page.DefinitionLevels() {
+		values = append(values, ValueOf(level == c.maxDefinitionLevel))
+	}
+	c.nullBitmapValues = values
+	_, err := c.nullBitmap.WriteRowValues(values)
 	return err
 }
 
@@ -1331,7 +2426,7 @@ func (c *ColumnWriter) flushFilterPages() (err error) {
 
 	// If there is a dictionary, it contains all the values that we need to
 	// write to the filter.
-	if dict := c.dictionary; dict != nil {
+	if dict := c.dictionary; dict != nil && !c.fallback {
 		// Need to always attempt to resize the filter, as the writer might
 		// be reused after resetting which would have reset the length of
 		// the filter to 0.
@@ -1340,8 +2435,12 @@ func (c *ColumnWriter) flushFilterPages() (err error) {
 	}
 
 	// When the filter was already allocated, pages have been written to it as
-	// they were seen by the column writer.
+	// they were seen by the column writer. Pages written before falling back
+	// to the PLAIN encoding were not, their values are in the dictionary.
 	if len(c.filter) > 0 {
+		if c.fallback {
+			return c.writePageToFilter(c.dictionary.Page())
+		}
 		return nil
 	}
 
@@ -1373,6 +2472,16 @@ func (c *ColumnWriter) flushFilterPages() (err error) {
 		index:              int16(c.bufferIndex),
 	}
 
+	// Constant pages and pages written before falling back to the PLAIN
+	// encoding are decoded as indexes into the dictionary of the chunk, its
+	// values are written to the filter instead of the values of those pages.
+	dict := c.chunkDictionary()
+	if dict != nil {
+		if err := c.writePageToFilter(dict.Page()); err != nil {
+			return err
+		}
+	}
+
 	var pageReader io.Reader = c.pageBuffer
 	if offset, err := c.pageBuffer.Seek(0, io.SeekStart); err != nil {
 		return err
@@ -1415,12 +2524,14 @@ func (c *ColumnWriter) flushFilterPages() (err error) {
 
 		switch header.Type {
 		case format.DataPage:
-			page, err = column.decodeDataPageV1(DataPageHeaderV1{header.DataPageHeader}, pbuf, nil, header.UncompressedPageSize)
+			page, err = column.decodeDataPageV1(DataPageHeaderV1{header.DataPageHeader}, pbuf, dict, header.UncompressedPageSize, c.transform)
 		case format.DataPageV2:
-			page, err = column.decodeDataPageV2(DataPageHeaderV2{header.DataPageHeaderV2}, pbuf, nil, header.UncompressedPageSize)
+			page, err = column.decodeDataPageV2(DataPageHeaderV2{header.DataPageHeaderV2}, pbuf, dict, header.UncompressedPageSize, c.transform)
 		}
 		if page != nil {
-			err = c.writePageToFilter(page)
+			if page.Dictionary() == nil {
+				err = c.writePageToFilter(page)
+			}
 			Release(page)
 		}
 		if err != nil {
@@ -1445,6 +2556,9 @@ func (c *ColumnWriter) resizeBloomFilter(numValues int64) {
 
 func (c *ColumnWriter) newColumnBuffer() ColumnBuffer {
 	column := c.columnType.NewColumnBuffer(int(c.bufferIndex), c.columnType.EstimateNumValues(int(c.bufferSize)))
+	if c.canonicalFloats {
+		column = newCanonicalFloatColumnBuffer(column)
+	}
 	switch {
 	case c.maxRepetitionLevel > 0:
 		column = newRepeatedColumnBuffer(column, c.maxRepetitionLevel, c.maxDefinitionLevel, nullsGoLast)
@@ -1463,6 +2577,9 @@ func (c *ColumnWriter) newColumnBuffer() ColumnBuffer {
 // configured page buffer size, and a single row is not permitted to span two
 // pages.
 func (c *ColumnWriter) WriteRowValues(rows []Value) (int, error) {
+	rows = c.lowerNullValues(rows)
+	defer c.clearLoweredValues()
+
 	var startingRows int64
 	if c.columnBuffer == nil {
 		// Lazily create the row group column so we don't need to allocate it if
@@ -1475,7 +2592,7 @@ func (c *ColumnWriter) WriteRowValues(rows []Value) (int, error) {
 		return 0, err
 	}
 	numRows := int(int64(c.columnBuffer.Len()) - startingRows)
-	if c.columnBuffer.Size() >= int64(c.bufferSize) {
+	if c.columnBuffer.Size() >= int64(c.bufferSize) || c.dictionaryFull() {
 		return numRows, c.Flush()
 	}
 	return numRows, nil
@@ -1498,7 +2615,41 @@ func (c *ColumnWriter) writeValues(values []Value) (numValues int, err error) {
 	if c.columnBuffer == nil {
 		c.columnBuffer = c.newColumnBuffer()
 	}
-	return c.columnBuffer.WriteValues(values)
+	defer c.clearLoweredValues()
+	return c.columnBuffer.WriteValues(c.lowerNullValues(values))
+}
+
+// lowerNullValues returns the values with the null values of optional
+// dictionary-encoded columns that have the maximum definition level lowered by
+// one level, so they are represented by their definition level instead of being
+// inserted in the dictionary as the zero value of the column type, which would
+// also skew the statistics of the column.
+//
+// The values are copied when they need to be modified, the caller must call
+// clearLoweredValues after using the returned slice.
+func (c *ColumnWriter) lowerNullValues(values []Value) []Value {
+	if !c.lowerNulls {
+		return values
+	}
+	isNullValue := func(v Value) bool {
+		return v.definitionLevel == c.maxDefinitionLevel && v.IsNull()
+	}
+	i := slices.IndexFunc(values, isNullValue)
+	if i < 0 {
+		return values
+	}
+	c.loweredValues = append(c.loweredValues[:0], values...)
+	for j := range c.loweredValues[i:] {
+		if v := &c.loweredValues[i+j]; isNullValue(*v) {
+			v.definitionLevel--
+		}
+	}
+	return c.loweredValues
+}
+
+func (c *ColumnWriter) clearLoweredValues() {
+	clearValues(c.loweredValues)
+	c.loweredValues = c.loweredValues[:0]
 }
 
 func (c *ColumnWriter) writeBloomFilter(w io.Writer) error {
@@ -1528,8 +2679,24 @@ func (c *ColumnWriter) writeDataPage(page Page) (int64, error) {
 		buf.encodeDefinitionLevels(page, c.maxDefinitionLevel)
 	}
 
-	if err := buf.encode(page, c.encoding); err != nil {
-		return 0, fmt.Errorf("encoding parquet data page: %w", err)
+	pageEncoding := c.encoding
+	if c.isConstantPage(page) {
+		buf.encodeConstant(page)
+		pageEncoding = &RLEDictionary
+		c.constantPages++
+	} else {
+		data := page
+		if c.transform != nil && page.Dictionary() == nil {
+			// Only the encoded values are transformed, the statistics and
+			// filters below are computed from the values of the page.
+			var err error
+			if data, err = c.transformPage(page); err != nil {
+				return 0, fmt.Errorf("transforming parquet data page: %w", err)
+			}
+		}
+		if err := buf.encode(data, c.encoding); err != nil {
+			return 0, fmt.Errorf("encoding parquet data page: %w", err)
+		}
 	}
 	if c.dataPageType == format.DataPage {
 		buf.prependLevelsToDataPageV1(c.maxDefinitionLevel, c.maxDefinitionLevel)
@@ -1539,6 +2706,9 @@ func (c *ColumnWriter) writeDataPage(page Page) (int64, error) {
 	if uncompressedPageSize > maxUncompressedPageSize {
 		return 0, fmt.Errorf("page size limit exceeded: %d>%d", uncompressedPageSize, maxUncompressedPageSize)
 	}
+	if len(c.compressionChoices) > 0 && !c.compressionChosen {
+		c.chooseCompression(buf)
+	}
 	if c.isCompressed {
 		if err := buf.compress(c.compression); err != nil {
 			return 0, fmt.Errorf("compressing parquet data page: %w", err)
@@ -1574,7 +2744,7 @@ func (c *ColumnWriter) writeDataPage(page Page) (int64, error) {
 	case format.DataPage:
 		pageHeader.DataPageHeader = &format.DataPageHeader{
 			NumValues:               int32(numValues),
-			Encoding:                c.encoding.Encoding(),
+			Encoding:                pageEncoding.Encoding(),
 			DefinitionLevelEncoding: format.RLE,
 			RepetitionLevelEncoding: format.RLE,
 			Statistics:              statistics,
@@ -1584,7 +2754,7 @@ func (c *ColumnWriter) writeDataPage(page Page) (int64, error) {
 			NumValues:                  int32(numValues),
 			NumNulls:                   int32(numNulls),
 			NumRows:                    int32(numRows),
-			Encoding:                   c.encoding.Encoding(),
+			Encoding:                   pageEncoding.Encoding(),
 			DefinitionLevelsByteLength: int32(len(buf.definitions)),
 			RepetitionLevelsByteLength: int32(len(buf.repetitions)),
 			IsCompressed:               &c.isCompressed,
@@ -1625,6 +2795,91 @@ func (c *ColumnWriter) writeDataPage(page Page) (int64, error) {
 	return numValues, nil
 }
 
+// transformName returns the name of the column transform, or an empty string
+// if the values of the column are not transformed.
+func (c *ColumnWriter) transformName() string {
+	if c.transform == nil {
+		return ""
+	}
+	return c.transform.Name
+}
+
+// transformPage returns a page holding the non-null values of page transformed
+// by the forward function of the column transform.
+func (c *ColumnWriter) transformPage(page Page) (Page, error) {
+	typ := page.Type()
+	numValues := int(page.NumValues() - page.NumNulls())
+	data, err := transformValues(typ, page.Column(), numValues, page.Data(), c.transform.Forward)
+	if err != nil {
+		return nil, err
+	}
+	return typ.NewPage(page.Column(), numValues, data), nil
+}
+
+// isConstantPage returns true if all the non-null values of page are equal to
+// the value of the constant dictionary of the column chunk, inserting the value
+// in the dictionary if this is the first constant page of the chunk.
+func (c *ColumnWriter) isConstantPage(page Page) bool {
+	if c.constantDictionary == nil || page.Dictionary() != nil {
+		return false
+	}
+	minValue, maxValue, ok := page.Bounds()
+	if !ok || !Equal(minValue, maxValue) {
+		return false
+	}
+	switch c.constantDictionary.Len() {
+	case 0:
+		var indexes [1]int32
+		c.constantDictionary.Insert(indexes[:], []Value{minValue})
+		return true
+	default:
+		return Equal(c.constantDictionary.Index(0), minValue)
+	}
+}
+
+// chunkDictionary returns the dictionary to write before the data pages of the
+// current column chunk, or nil if the pages do not reference a dictionary.
+func (c *ColumnWriter) chunkDictionary() Dictionary {
+	if c.dictionary != nil {
+		return c.dictionary
+	}
+	if c.constantPages > 0 {
+		return c.constantDictionary
+	}
+	return nil
+}
+
+// adaptiveCompressionSampleSize is the maximum size of the page data sampled
+// to select the codec of column chunks with adaptive compression.
+const adaptiveCompressionSampleSize = 64 * 1024
+
+// chooseCompression selects the codec producing the smallest output when
+// compressing a sample of the page data held in buf, and uses it for the
+// remaining pages of the column chunk.
+func (c *ColumnWriter) chooseCompression(buf *writerBuffers) {
+	sample := buf.page[:min(len(buf.page), adaptiveCompressionSampleSize)]
+	bestSize := -1
+
+	for _, codec := range c.compressionChoices {
+		size := len(sample)
+		if isCompressed(codec) {
+			compressed, err := codec.Encode(buf.scratch[:0], sample)
+			buf.scratch = compressed[:0]
+			if err != nil {
+				continue
+			}
+			size = len(compressed)
+		}
+		if bestSize < 0 || size < bestSize {
+			bestSize, c.compression = size, codec
+		}
+	}
+
+	c.compressionChosen = true
+	c.isCompressed = isCompressed(c.compression) && (c.dataPageType != format.DataPageV2 || c.dictionary == nil || c.fallback)
+	c.columnChunk.MetaData.Codec = c.compression.CompressionCodec()
+}
+
 func (c *ColumnWriter) writeDictionaryPage(output io.Writer, dict Dictionary) (err error) {
 	buf := c.buffers
 	buf.reset()
@@ -1697,6 +2952,9 @@ func (c *ColumnWriter) writePageTo(size int64, writeTo func(io.Writer) (int64, e
 	if written != size {
 		return fmt.Errorf("writing parquet column page expected %dB but got %dB: %w", size, written, io.ErrShortWrite)
 	}
+	if c.spillPool == nil {
+		c.pageBufferSize += written
+	}
 	c.numPages++
 	return nil
 }
@@ -1731,7 +2989,9 @@ func (c *ColumnWriter) recordPageStats(headerSize int32, header *format.PageHead
 
 		c.columnIndex.IndexPage(numValues, numNulls, minValue, maxValue)
 		c.columnChunk.MetaData.NumValues += numValues
-		c.columnChunk.MetaData.Statistics.NullCount += numNulls
+		if !c.skipStatistics {
+			c.columnChunk.MetaData.Statistics.NullCount += numNulls
+		}
 
 		if pageHasBounds {
 			var existingMaxValue, existingMinValue Value
@@ -1863,3 +3123,165 @@ var (
 	_ io.ReaderFrom   = (*offsetTrackingWriter)(nil)
 	_ io.StringWriter = (*offsetTrackingWriter)(nil)
 )
+
+// sortingCheck verifies that the rows written to a writer are sorted on its
+// sorting columns, see SortingColumnsChecked.
+type sortingCheck struct {
+	columns []SortingColumn
+	compare func(Row, Row) int
+	// Copy of the last row checked, which the next row is compared to, nil if
+	// no rows were checked yet.
+	last Row
+	// Index of the next row in the file.
+	rowIndex int64
+	// Buffer of rows constructed by checkFunc.
+	rows []Row
+}
+
+func (s *sortingCheck) reset() {
+	s.last = nil
+	s.rowIndex = 0
+}
+
+// check returns the number of rows at the beginning of rows which are sorted
+// after the rows previously checked, and an error wrapping ErrUnsortedRows if
+// one of the rows is out of order.
+func (s *sortingCheck) check(rows []Row) (int, error) {
+	for i, row := range rows {
+		prev := s.last
+		if i > 0 {
+			prev = rows[i-1]
+		}
+		if prev != nil && s.compare(prev, row) > 0 {
+			s.commit(rows[:i])
+			return i, fmt.Errorf("row %d: %w", s.rowIndex, ErrUnsortedRows)
+		}
+	}
+	s.commit(rows)
+	return len(rows), nil
+}
+
+// checkFunc is like check but the rows are constructed by calling makeRow
+// with a row to append the values to and the index of the row.
+func (s *sortingCheck) checkFunc(numRows int, makeRow func(Row, int) Row) (int, error) {
+	rows := s.buffer(numRows)
+	defer clearRows(rows)
+	for i := range rows {
+		rows[i] = makeRow(rows[i][:0], i)
+	}
+	return s.check(rows)
+}
+
+// buffer returns a slice of numRows rows which the rows to check can be
+// constructed in. The rows must be cleared once they were checked.
+func (s *sortingCheck) buffer(numRows int) []Row {
+	s.rows = slices.Grow(s.rows[:0], numRows)[:numRows]
+	return s.rows
+}
+
+// checkRowGroup verifies the order of a row group declaring that it is sorted
+// on the columns checked by s. Only the first row of the row group needs to be
+// compared to the rows previously checked, the last row of the row group is
+// then read to be compared with the rows checked next.
+func (s *sortingCheck) checkRowGroup(rowGroup RowGroup) error {
+	numRows := rowGroup.NumRows()
+	if numRows == 0 {
+		return nil
+	}
+	rows := rowGroup.Rows()
+	defer rows.Close()
+	buf := s.buffer(1)
+	defer clearRows(buf)
+
+	if err := readRowAt(rows, buf, 0); err != nil {
+		return err
+	}
+	if s.last != nil && s.compare(s.last, buf[0]) > 0 {
+		return fmt.Errorf("row %d: %w", s.rowIndex, ErrUnsortedRows)
+	}
+	if numRows > 1 {
+		if err := readRowAt(rows, buf, numRows-1); err != nil {
+			return err
+		}
+	}
+	s.last = buf[0].Clone()
+	s.rowIndex += numRows
+	return nil
+}
+
+// readRowAt reads the row at rowIndex from rows into buf[0].
+func readRowAt(rows Rows, buf []Row, rowIndex int64) error {
+	if err := rows.SeekToRow(rowIndex); err != nil {
+		return err
+	}
+	n, err := rows.ReadRows(buf[:1])
+	if n == 1 {
+		return nil
+	}
+	if err == nil || err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+func (s *sortingCheck) commit(rows []Row) {
+	if len(rows) > 0 {
+		// The values of the rows may reference memory of the application, the
+		// last row is cloned to remain valid.
+		s.last = rows[len(rows)-1].Clone()
+		s.rowIndex += int64(len(rows))
+	}
+}
+
+// valueHash computes a hash of a sequence of values, including their
+// repetition and definition levels.
+type valueHash struct {
+	hash   hash.Hash64
+	buffer []byte
+	values []Value
+}
+
+func newValueHash() *valueHash {
+	return &valueHash{hash: fnv.New64a()}
+}
+
+func (h *valueHash) reset() { h.hash.Reset() }
+
+func (h *valueHash) sum() uint64 { return h.hash.Sum64() }
+
+func (h *valueHash) write(values []Value) {
+	for i := range values {
+		v := &values[i]
+		h.buffer = append(h.buffer[:0], byte(v.Kind()), byte(v.repetitionLevel), byte(v.definitionLevel))
+		if !v.isNull() {
+			h.buffer = v.AppendBytes(h.buffer)
+		}
+		h.hash.Write(h.buffer)
+	}
+}
+
+func (h *valueHash) writePage(page Page) error {
+	return h.writeValues(page.Values())
+}
+
+func (h *valueHash) writeColumnChunk(chunk ColumnChunk) error {
+	r := NewColumnChunkValueReader(chunk)
+	defer r.Close()
+	return h.writeValues(r)
+}
+
+func (h *valueHash) writeValues(r ValueReader) error {
+	if h.values == nil {
+		h.values = make([]Value, defaultValueBufferSize)
+	}
+	for {
+		n, err := r.ReadValues(h.values)
+		h.write(h.values[:n])
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+	}
+}
@@ -2,12 +2,16 @@ package parquet_test
 
 import (
 	"bytes"
+	"cmp"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"math/rand"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"reflect"
 	"slices"
 	"strconv"
@@ -22,6 +26,7 @@ import (
 	"github.com/parquet-go/parquet-go"
 	"github.com/parquet-go/parquet-go/compress"
 	"github.com/parquet-go/parquet-go/encoding"
+	"github.com/parquet-go/parquet-go/format"
 	"github.com/parquet-go/parquet-go/internal/unsafecast"
 )
 
@@ -1557,6 +1562,114 @@ func TestWriterMaxRowsPerRowGroup(t *testing.T) {
 	}
 }
 
+func TestWriterMaxBufferedBytes(t *testing.T) {
+	type Row struct {
+		ID      int64  `parquet:"id"`
+		Payload string `parquet:"payload"`
+	}
+
+	const numRows = 10_000
+	const maxBufferedBytes = 256 * 1024
+
+	rows := make([]Row, numRows)
+	for i := range rows {
+		rows[i] = Row{ID: int64(i), Payload: strings.Repeat(strconv.Itoa(i%10), 512)}
+	}
+
+	tests := []struct {
+		scenario string
+		options  []parquet.WriterOption
+		check    func(*testing.T, *parquet.File, *countingBufferPool)
+	}{
+		{
+			scenario: "flush row groups",
+			check: func(t *testing.T, f *parquet.File, _ *countingBufferPool) {
+				rowGroups := f.RowGroups()
+				if len(rowGroups) < 2 {
+					t.Fatalf("expected the writer to flush multiple row groups, got %d", len(rowGroups))
+				}
+				for i, rowGroup := range rowGroups {
+					// The limit is checked every 64 rows, each row holds ~0.5KiB
+					// of payload.
+					if size := rowGroup.NumRows() * 520; size > maxBufferedBytes+64*1024 {
+						t.Errorf("row group %d is too large: %d rows (~%dB)", i, rowGroup.NumRows(), size)
+					}
+				}
+			},
+		},
+		{
+			scenario: "spill to disk",
+			check: func(t *testing.T, f *parquet.File, spill *countingBufferPool) {
+				if n := len(f.RowGroups()); n != 1 {
+					t.Errorf("expected a single row group when spilling pages, got %d", n)
+				}
+				if spill.gets == 0 {
+					t.Error("no pages were spilled")
+				}
+				if spill.gets != spill.puts {
+					t.Errorf("spill buffers were not all released: %d gets, %d puts", spill.gets, spill.puts)
+				}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			spill := &countingBufferPool{BufferPool: parquet.NewFileBufferPool(t.TempDir(), "spill.*")}
+			options := []parquet.WriterOption{
+				parquet.PageBufferSize(16 * 1024),
+				parquet.MaxBufferedBytes(maxBufferedBytes),
+			}
+			if test.scenario == "spill to disk" {
+				options = append(options, parquet.SpillBuffers(spill))
+			}
+
+			output := new(bytes.Buffer)
+			writer := parquet.NewGenericWriter[Row](output, options...)
+			if _, err := writer.Write(rows); err != nil {
+				t.Fatal(err)
+			}
+			if err := writer.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := parquet.OpenFile(bytes.NewReader(output.Bytes()), int64(output.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.check(t, f, spill)
+
+			got, err := parquet.Read[Row](bytes.NewReader(output.Bytes()), int64(output.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(rows) {
+				t.Fatalf("wrong number of rows: want=%d got=%d", len(rows), len(got))
+			}
+			for i := range rows {
+				if got[i] != rows[i] {
+					t.Fatalf("row %d mismatch: want=%d got=%d", i, rows[i].ID, got[i].ID)
+				}
+			}
+		})
+	}
+}
+
+type countingBufferPool struct {
+	parquet.BufferPool
+	gets, puts int
+}
+
+func (p *countingBufferPool) GetBuffer() io.ReadWriteSeeker {
+	p.gets++
+	return p.BufferPool.GetBuffer()
+}
+
+func (p *countingBufferPool) PutBuffer(buf io.ReadWriteSeeker) {
+	p.puts++
+	p.BufferPool.PutBuffer(buf)
+}
+
 func TestSetKeyValueMetadata(t *testing.T) {
 	testKey := "test-key"
 	testValue := "test-value"
@@ -1654,6 +1767,71 @@ func TestSetKeyValueMetadataOverwritesExisting(t *testing.T) {
 	}
 }
 
+func TestWriterSchemaName(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name"`
+	}
+	rows := []Row{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}}
+
+	const want = `message spark_schema {
+	required int64 id (INT(64,true));
+	required binary name (STRING);
+}`
+
+	check := func(t *testing.T, buf *bytes.Buffer) {
+		f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if name := f.Metadata().Schema[0].Name; name != "spark_schema" {
+			t.Errorf("wrong name of the root schema element: %q", name)
+		}
+		if got := f.Schema().String(); got != want {
+			t.Errorf("wrong schema:\nwant:\n%s\ngot:\n%s", want, got)
+		}
+		if _, ok := f.Schema().Lookup("name"); !ok {
+			t.Error("column path changed after renaming the schema")
+		}
+		got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(got, rows) {
+			t.Errorf("wrong rows: want=%+v got=%+v", rows, got)
+		}
+	}
+
+	t.Run("GenericWriter", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		w := parquet.NewGenericWriter[Row](buf, parquet.SchemaName("spark_schema"))
+		if _, err := w.Write(rows); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if name := w.Schema().Name(); name != "Row" {
+			t.Errorf("the schema of rows should not be renamed: %q", name)
+		}
+		check(t, buf)
+	})
+
+	t.Run("Writer", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		w := parquet.NewWriter(buf, parquet.SchemaOf(new(Row)), parquet.SchemaName("spark_schema"))
+		for i := range rows {
+			if err := w.Write(&rows[i]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		check(t, buf)
+	})
+}
+
 func TestColumnMaxValueAndMinValue(t *testing.T) {
 	type testStruct struct {
 		A string `parquet:"a,plain"`
@@ -1723,6 +1901,246 @@ func TestColumnSkipPageBounds(t *testing.T) {
 	}
 }
 
+func TestColumnNoStatsTag(t *testing.T) {
+	type location struct {
+		Lat float64 `parquet:"lat"`
+		Lng float64 `parquet:"lng"`
+	}
+	type testStruct struct {
+		ID       int64    `parquet:"id"`
+		Secret   *string  `parquet:"secret,optional,nostats"`
+		Location location `parquet:"location,nostats"`
+	}
+
+	rows := make([]testStruct, 100)
+	for i := range rows {
+		rows[i] = testStruct{ID: int64(i), Location: location{Lat: float64(i), Lng: -float64(i)}}
+		if i%2 == 0 {
+			secret := fmt.Sprintf("secret-%d", i)
+			rows[i].Secret = &secret
+		}
+	}
+
+	b := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[testStruct](b, parquet.PageBufferSize(256))
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(b.Bytes()), int64(b.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, column := range f.Metadata().RowGroups[0].Columns {
+		path := strings.Join(column.MetaData.PathInSchema, ".")
+		statistics := column.MetaData.Statistics
+
+		if path == "id" {
+			if statistics.MinValue == nil || statistics.MaxValue == nil {
+				t.Errorf("column %d (%s): expected statistics to be written", i, path)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(statistics, format.Statistics{}) {
+			t.Errorf("column %d (%s): expected no statistics, got %+v", i, path, statistics)
+		}
+	}
+
+	got, err := parquet.Read[testStruct](bytes.NewReader(b.Bytes()), int64(b.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, rows) {
+		t.Error("rows mismatch after reading back columns without statistics")
+	}
+}
+
+func TestColumnTagsOfRewrittenFields(t *testing.T) {
+	type inner struct {
+		A string `parquet:"a,nostats,ndv"`
+		B string `parquet:"b,bloom,index"`
+	}
+	type testStruct struct {
+		ID int64 `parquet:"id"`
+		In inner `parquet:"in,zstd"`
+	}
+
+	rows := make([]testStruct, 100)
+	for i := range rows {
+		rows[i] = testStruct{ID: int64(i), In: inner{A: fmt.Sprintf("a-%d", i%10), B: fmt.Sprintf("b-%d", i)}}
+	}
+
+	for _, test := range []struct {
+		scenario string
+		options  []parquet.WriterOption
+	}{
+		{scenario: "compressed group"},
+		{
+			scenario: "reordered group",
+			options:  []parquet.WriterOption{parquet.ColumnIndexOrder([]string{"in", "b"}, []string{"in", "a"}, []string{"id"})},
+		},
+	} {
+		t.Run(test.scenario, func(t *testing.T) {
+			b := new(bytes.Buffer)
+			w := parquet.NewGenericWriter[testStruct](b, test.options...)
+			if _, err := w.Write(rows); err != nil {
+				t.Fatal(err)
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := parquet.OpenFile(bytes.NewReader(b.Bytes()), int64(b.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for i, chunk := range f.RowGroups()[0].ColumnChunks() {
+				column := f.Metadata().RowGroups[0].Columns[i]
+				path := strings.Join(column.MetaData.PathInSchema, ".")
+				if column.MetaData.Codec != format.Zstd && path != "id" {
+					t.Errorf("column %s: wrong codec %v", path, column.MetaData.Codec)
+				}
+
+				hasStats := !reflect.DeepEqual(column.MetaData.Statistics, format.Statistics{})
+				if hasStats != (path != "in.a") {
+					t.Errorf("column %s: wrong presence of statistics: %+v", path, column.MetaData.Statistics)
+				}
+				if hasBloomFilter := chunk.BloomFilter() != nil; hasBloomFilter != (path == "in.b") {
+					t.Errorf("column %s: wrong presence of bloom filter", path)
+				}
+			}
+
+			if count, ok := f.DistinctCount("in", "a"); !ok || count != 10 {
+				t.Errorf("wrong distinct count of in.a: want=10 got=%d (ok=%t)", count, ok)
+			}
+			if _, ok := f.DistinctCount("in", "b"); ok {
+				t.Error("unexpected distinct count of in.b")
+			}
+		})
+	}
+}
+
+func writePageIndexTagsFile[T any](t *testing.T, rows []T) *parquet.File {
+	t.Helper()
+	b := new(bytes.Buffer)
+	if err := parquet.Write(b, rows, parquet.PageBufferSize(256), parquet.MaxRowsPerRowGroup(50)); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(b.Bytes()), int64(b.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func TestColumnPageIndexTags(t *testing.T) {
+	type location struct {
+		Lat float64 `parquet:"lat"`
+		Lng float64 `parquet:"lng"`
+	}
+
+	checkPageIndexes := func(t *testing.T, f *parquet.File, indexed ...string) {
+		t.Helper()
+		for _, rowGroup := range f.RowGroups() {
+			for _, chunk := range rowGroup.ColumnChunks() {
+				path := strings.Join(rowGroup.Schema().Columns()[chunk.Column()], ".")
+				_, columnIndexErr := chunk.ColumnIndex()
+				_, offsetIndexErr := chunk.OffsetIndex()
+				if slices.Contains(indexed, path) {
+					if columnIndexErr != nil || offsetIndexErr != nil {
+						t.Errorf("column %s: expected page indexes, got %v / %v", path, columnIndexErr, offsetIndexErr)
+					}
+				} else {
+					if !errors.Is(columnIndexErr, parquet.ErrMissingColumnIndex) {
+						t.Errorf("column %s: expected no column index, got %v", path, columnIndexErr)
+					}
+					if !errors.Is(offsetIndexErr, parquet.ErrMissingOffsetIndex) {
+						t.Errorf("column %s: expected no offset index, got %v", path, offsetIndexErr)
+					}
+				}
+			}
+		}
+	}
+
+	t.Run("index", func(t *testing.T) {
+		type testStruct struct {
+			Name      string   `parquet:"name"`
+			Timestamp int64    `parquet:"timestamp,index"`
+			Location  location `parquet:"location,index"`
+			Payload   []byte   `parquet:"payload"`
+		}
+
+		rows := make([]testStruct, 100)
+		for i := range rows {
+			rows[i] = testStruct{
+				Name:      fmt.Sprintf("name-%d", i),
+				Timestamp: int64(i),
+				Location:  location{Lat: float64(i), Lng: -float64(i)},
+				Payload:   []byte(strconv.Itoa(i)),
+			}
+		}
+
+		f := writePageIndexTagsFile(t, rows)
+		checkPageIndexes(t, f, "timestamp", "location.lat", "location.lng")
+
+		got, err := parquet.Read[testStruct](f, f.Size())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, rows) {
+			t.Error("rows mismatch after reading back columns without page indexes")
+		}
+
+		// Seeking uses the offset index of the columns that have one, and
+		// scans the pages of the others.
+		r := parquet.NewGenericReader[testStruct](f)
+		defer r.Close()
+		if err := r.SeekToRow(75); err != nil {
+			t.Fatal(err)
+		}
+		row := make([]testStruct, 1)
+		if _, err := r.Read(row); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(row[0], rows[75]) {
+			t.Errorf("wrong row after seeking: want=%+v got=%+v", rows[75], row[0])
+		}
+	})
+
+	t.Run("noindex", func(t *testing.T) {
+		type testStruct struct {
+			Payload  []byte   `parquet:"payload,noindex"`
+			ID       int64    `parquet:"id"`
+			Location location `parquet:"location,noindex"`
+		}
+
+		rows := make([]testStruct, 100)
+		for i := range rows {
+			rows[i] = testStruct{
+				Payload:  []byte(strconv.Itoa(i)),
+				ID:       int64(i),
+				Location: location{Lat: float64(i), Lng: -float64(i)},
+			}
+		}
+
+		f := writePageIndexTagsFile(t, rows)
+		checkPageIndexes(t, f, "id")
+
+		got, err := parquet.Read[testStruct](f, f.Size())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, rows) {
+			t.Error("rows mismatch after reading back columns without page indexes")
+		}
+	})
+}
+
 func TestIssueNotAllowedDefaultEncoding(t *testing.T) {
 	const expectedPanic = "cannot use encoding DELTA_LENGTH_BYTE_ARRAY for kind BOOLEAN"
 
@@ -1987,3 +2405,2614 @@ func TestColumnValidation(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestWriterColumnIndexOrder(t *testing.T) {
+	type Address struct {
+		City string `parquet:"city"`
+		Zip  string `parquet:"zip"`
+	}
+	type Record struct {
+		ID      int64   `parquet:"id"`
+		Name    string  `parquet:"name"`
+		Address Address `parquet:"address"`
+		Score   float64 `parquet:"score"`
+	}
+
+	rows := []Record{
+		{ID: 1, Name: "a", Address: Address{City: "Paris", Zip: "75001"}, Score: 0.5},
+		{ID: 2, Name: "b", Address: Address{City: "Lyon", Zip: "69001"}, Score: 1.5},
+	}
+
+	order := [][]string{
+		{"score"},
+		{"address", "zip"},
+		{"address", "city"},
+		{"id"},
+		{"name"},
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Record](buf, parquet.ColumnIndexOrder(order...))
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if columns := f.Schema().Columns(); !reflect.DeepEqual(columns, order) {
+		t.Errorf("wrong column order:\nwant: %q\ngot:  %q", order, columns)
+	}
+	for i, chunk := range f.Metadata().RowGroups[0].Columns {
+		if path := chunk.MetaData.PathInSchema; !slices.Equal(path, order[i]) {
+			t.Errorf("wrong path of column chunk %d: want=%q got=%q", i, order[i], path)
+		}
+	}
+
+	got, err := parquet.Read[Record](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, rows) {
+		t.Errorf("rows mismatch:\nwant: %+v\ngot:  %+v", rows, got)
+	}
+}
+
+func TestWriterColumnIndexOrderInvalid(t *testing.T) {
+	type Address struct {
+		City string `parquet:"city"`
+		Zip  string `parquet:"zip"`
+	}
+	type Record struct {
+		ID      int64   `parquet:"id"`
+		Address Address `parquet:"address"`
+	}
+
+	tests := []struct {
+		scenario string
+		order    [][]string
+	}{
+		{scenario: "missing column", order: [][]string{{"id"}, {"address", "city"}}},
+		{scenario: "unknown column", order: [][]string{{"id"}, {"address", "city"}, {"nope"}}},
+		{scenario: "duplicate column", order: [][]string{{"id"}, {"id"}, {"address", "city"}}},
+		{scenario: "split group", order: [][]string{{"address", "city"}, {"id"}, {"address", "zip"}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Fatal("expected a panic for an invalid column order")
+				}
+			}()
+			parquet.NewGenericWriter[Record](io.Discard, parquet.ColumnIndexOrder(test.order...))
+		})
+	}
+}
+
+func TestWriterColumnChunkGroups(t *testing.T) {
+	type Address struct {
+		City string `parquet:"city"`
+		Zip  string `parquet:"zip"`
+	}
+	type Record struct {
+		ID      int64   `parquet:"id"`
+		Name    string  `parquet:"name,dict"`
+		Address Address `parquet:"address"`
+		Score   float64 `parquet:"score"`
+	}
+
+	rows := make([]Record, 1000)
+	for i := range rows {
+		rows[i] = Record{
+			ID:      int64(i),
+			Name:    fmt.Sprintf("name-%d", i%10),
+			Address: Address{City: fmt.Sprintf("city-%d", i%7), Zip: fmt.Sprintf("%05d", i)},
+			Score:   float64(i) / 2,
+		}
+	}
+
+	groups := parquet.ColumnChunkGroups(
+		[][]string{{"score"}, {"id"}},
+		[][]string{{"address"}},
+	)
+	want := [][]string{{"score"}, {"id"}, {"address", "city"}, {"address", "zip"}, {"name"}}
+
+	// physicalOrder returns the paths of the column chunks of each row group
+	// sorted by their offset in the file.
+	physicalOrder := func(f *parquet.File) [][][]string {
+		var order [][][]string
+		for _, rowGroup := range f.Metadata().RowGroups {
+			columns := slices.Clone(rowGroup.Columns)
+			slices.SortFunc(columns, func(a, b format.ColumnChunk) int {
+				return cmp.Compare(chunkOffset(&a), chunkOffset(&b))
+			})
+			paths := make([][]string, len(columns))
+			for i, c := range columns {
+				paths[i] = c.MetaData.PathInSchema
+			}
+			order = append(order, paths)
+		}
+		return order
+	}
+
+	check := func(t *testing.T, buf *bytes.Buffer) {
+		t.Helper()
+		f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if columns := f.Schema().Columns(); !reflect.DeepEqual(columns, parquet.SchemaOf(new(Record)).Columns()) {
+			t.Errorf("the logical order of columns changed: %q", columns)
+		}
+		for i, paths := range physicalOrder(f) {
+			if !reflect.DeepEqual(paths, want) {
+				t.Errorf("wrong physical order of the column chunks of row group %d:\nwant: %q\ngot:  %q", i, want, paths)
+			}
+		}
+
+		got, err := parquet.Read[Record](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, rows) {
+			t.Error("rows read from the file do not match the rows written")
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Record](buf, groups, parquet.MaxRowsPerRowGroup(300))
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	check(t, buf)
+
+	// Row groups copied from another file follow the same layout.
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	copied := new(bytes.Buffer)
+	cw := parquet.NewWriter(copied, f.Schema(), groups)
+	for _, rowGroup := range f.RowGroups() {
+		if _, err := cw.WriteRowGroup(rowGroup); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := cw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	check(t, copied)
+}
+
+func chunkOffset(c *format.ColumnChunk) int64 {
+	if offset := c.MetaData.DictionaryPageOffset; offset != 0 {
+		return offset
+	}
+	return c.MetaData.DataPageOffset
+}
+
+func TestWriterColumnChunkGroupsInvalid(t *testing.T) {
+	type Address struct {
+		City string `parquet:"city"`
+		Zip  string `parquet:"zip"`
+	}
+	type Record struct {
+		ID      int64   `parquet:"id"`
+		Address Address `parquet:"address"`
+	}
+
+	tests := []struct {
+		scenario string
+		groups   [][][]string
+	}{
+		{scenario: "unknown column", groups: [][][]string{{{"nope"}}}},
+		{scenario: "empty path", groups: [][][]string{{{}}}},
+		{scenario: "duplicate column", groups: [][][]string{{{"id"}}, {{"id"}}}},
+		{scenario: "column of a group", groups: [][][]string{{{"address"}}, {{"address", "zip"}}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Fatal("expected a panic for invalid column chunk groups")
+				}
+			}()
+			parquet.NewGenericWriter[Record](io.Discard, parquet.ColumnChunkGroups(test.groups...))
+		})
+	}
+}
+func TestWriteFixedSizeArrays(t *testing.T) {
+	type Row struct {
+		Vector  [4]float32 `parquet:"vector"`
+		Packed  [4]float32 `parquet:"packed,fixed"`
+		Indexes [8]int32   `parquet:"indexes,list"`
+		Counts  [8]int32   `parquet:"counts,fixed"`
+	}
+
+	schema := parquet.SchemaOf(new(Row))
+	const want = `message Row {
+	repeated float vector;
+	required fixed_len_byte_array(16) packed;
+	required group indexes (LIST) {
+		repeated group list {
+			required int32 element (INT(32,true));
+		}
+	}
+	required fixed_len_byte_array(32) counts;
+}`
+	if got := schema.String(); got != want {
+		t.Fatalf("wrong schema:\nwant:\n%s\ngot:\n%s", want, got)
+	}
+
+	rows := make([]Row, 10)
+	for i := range rows {
+		for j := range 4 {
+			rows[i].Vector[j] = float32(i) + float32(j)/10
+			rows[i].Packed[j] = -float32(i) - float32(j)/10
+		}
+		for j := range 8 {
+			rows[i].Indexes[j] = int32(i * j)
+			rows[i].Counts[j] = int32(i - j)
+		}
+	}
+
+	t.Run("GenericWriter", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		if err := parquet.Write(buf, rows); err != nil {
+			t.Fatal(err)
+		}
+		got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, rows) {
+			t.Errorf("rows mismatch:\nwant = %+v\ngot  = %+v", rows, got)
+		}
+	})
+
+	t.Run("Writer", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		w := parquet.NewWriter(buf, schema)
+		for i := range rows {
+			if err := w.Write(&rows[i]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		r := parquet.NewReader(bytes.NewReader(buf.Bytes()), schema)
+		for i := range rows {
+			var got Row
+			if err := r.Read(&got); err != nil {
+				t.Fatal(err)
+			}
+			if got != rows[i] {
+				t.Errorf("row %d mismatch:\nwant = %+v\ngot  = %+v", i, rows[i], got)
+			}
+		}
+	})
+
+	t.Run("LittleEndian", func(t *testing.T) {
+		// Packed arrays are stored in little-endian order regardless of the
+		// byte order of the CPU.
+		buf := new(bytes.Buffer)
+		if err := parquet.Write(buf, rows[3:4]); err != nil {
+			t.Fatal(err)
+		}
+		r := parquet.NewReader(bytes.NewReader(buf.Bytes()))
+		defer r.Close()
+		row := make([]parquet.Row, 1)
+		if _, err := r.ReadRows(row); err != nil && err != io.EOF {
+			t.Fatal(err)
+		}
+		want := []byte{}
+		for _, v := range rows[3].Counts {
+			want = binary.LittleEndian.AppendUint32(want, uint32(v))
+		}
+		counts, _ := schema.Lookup("counts")
+		var values []parquet.Value
+		row[0].Range(func(columnIndex int, columnValues []parquet.Value) bool {
+			if columnIndex == counts.ColumnIndex {
+				values = columnValues
+			}
+			return true
+		})
+		if len(values) != 1 || !bytes.Equal(values[0].ByteArray(), want) {
+			t.Errorf("wrong bytes of packed array:\nwant = %x\ngot  = %v", want, values)
+		}
+	})
+
+	t.Run("TooManyValues", func(t *testing.T) {
+		type Short struct {
+			Vector [2]float32 `parquet:"vector"`
+		}
+		row := schema.Deconstruct(nil, &rows[1])
+		var got Short
+		if err := parquet.SchemaOf(new(Short)).Reconstruct(&got, row[:4]); err == nil {
+			t.Errorf("expected an error reconstructing 4 values into %T, got %+v", got.Vector, got)
+		}
+	})
+}
+
+func TestWriteFixedLenByteArrays(t *testing.T) {
+	type Row struct {
+		H    [32]byte  `parquet:"h"`
+		ID   [16]byte  `parquet:"id"`
+		UUID uuid.UUID `parquet:"uuid"`
+		Tag  [16]byte  `parquet:"tag,uuid"`
+		Key  [5]byte   `parquet:"key,fixed"`
+	}
+
+	schema := parquet.SchemaOf(new(Row))
+	const want = `message Row {
+	required fixed_len_byte_array(32) h;
+	required fixed_len_byte_array(16) id;
+	required fixed_len_byte_array(16) uuid (UUID);
+	required fixed_len_byte_array(16) tag (UUID);
+	required fixed_len_byte_array(5) key;
+}`
+	if got := schema.String(); got != want {
+		t.Fatalf("wrong schema:\nwant:\n%s\ngot:\n%s", want, got)
+	}
+
+	rows := make([]Row, 100)
+	for i := range rows {
+		for j := range rows[i].H {
+			rows[i].H[j] = byte(i*31 + j*7)
+		}
+		rows[i].ID[0] = byte(i)
+		rows[i].UUID[15] = byte(i)
+		rows[i].Tag[1] = byte(i)
+		rows[i].Key = [5]byte{byte(i), 0xff, 0, byte(255 - i), 1}
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows); err != nil {
+		t.Fatal(err)
+	}
+	got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, rows) {
+		t.Errorf("rows mismatch:\nwant = %+v\ngot  = %+v", rows, got)
+	}
+}
+
+func TestWriterVerifyOnClose(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id,plain"`
+		Name string `parquet:"name,dict"`
+	}
+
+	rows := make([]Row, 100)
+	for i := range rows {
+		rows[i] = Row{ID: 1e10 + int64(i), Name: fmt.Sprintf("name-%d", i%10)}
+	}
+
+	write := func(output io.Writer) error {
+		w := parquet.NewGenericWriter[Row](output,
+			parquet.VerifyOnClose(true),
+			parquet.MaxRowsPerRowGroup(30),
+		)
+		if _, err := w.Write(rows); err != nil {
+			return err
+		}
+		return w.Close()
+	}
+
+	t.Run("buffer", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		if err := write(buf); err != nil {
+			t.Fatal(err)
+		}
+		got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(got, rows) {
+			t.Error("rows mismatch")
+		}
+	})
+
+	t.Run("file", func(t *testing.T) {
+		f, err := os.Create(filepath.Join(t.TempDir(), "data.parquet"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		if err := write(f); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("file with a prefix", func(t *testing.T) {
+		// The file read back starts at the position of the output when the
+		// writer was created, not at the beginning of the output.
+		f, err := os.Create(filepath.Join(t.TempDir(), "data.parquet"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		if _, err := f.WriteString("prefix"); err != nil {
+			t.Fatal(err)
+		}
+		if err := write(f); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("corrupted", func(t *testing.T) {
+		// The value of the row in the middle of the file only appears in the
+		// data page since it is neither the min nor the max of the column.
+		target := binary.LittleEndian.AppendUint64(nil, uint64(rows[len(rows)/2].ID))
+		output := &corruptedReadBuffer{target: target}
+
+		err := write(output)
+		if !errors.Is(err, parquet.ErrVerificationFailed) {
+			t.Fatalf("expected verification to fail, got %v", err)
+		}
+		if !output.corrupted {
+			t.Fatal("the value to corrupt was not found in the output")
+		}
+	})
+}
+
+// corruptedReadBuffer is an output which corrupts the data read back from it,
+// flipping the bits of the first occurrence of the target byte sequence.
+type corruptedReadBuffer struct {
+	bytes.Buffer
+	target    []byte
+	corrupted bool
+}
+
+func (b *corruptedReadBuffer) ReadAt(p []byte, off int64) (int, error) {
+	data := slices.Clone(b.Bytes())
+	if i := bytes.Index(data, b.target); i >= 0 {
+		data[i] ^= 0xFF
+		b.corrupted = true
+	}
+	return bytes.NewReader(data).ReadAt(p, off)
+}
+
+func (b *corruptedReadBuffer) Seek(offset int64, whence int) (int64, error) {
+	if offset != 0 || whence != io.SeekCurrent {
+		return 0, errors.New("corruptedReadBuffer only reports the current offset")
+	}
+	return int64(b.Len()), nil
+}
+
+func TestWriteOptionalNestedStruct(t *testing.T) {
+	type Inner struct {
+		A int64   `parquet:"a"`
+		B string  `parquet:"b,optional"`
+		C []int32 `parquet:"c"`
+	}
+	type Row struct {
+		ID    int64  `parquet:"id"`
+		Inner Inner  `parquet:"inner,optional"`
+		Ptr   *Inner `parquet:"ptr"`
+	}
+
+	schema := parquet.SchemaOf(new(Row))
+	const want = `message Row {
+	required int64 id (INT(64,true));
+	optional group inner {
+		required int64 a (INT(64,true));
+		optional binary b (STRING);
+		repeated int32 c (INT(32,true));
+	}
+	optional group ptr {
+		required int64 a (INT(64,true));
+		optional binary b (STRING);
+		repeated int32 c (INT(32,true));
+	}
+}`
+	if got := schema.String(); got != want {
+		t.Fatalf("wrong schema:\nwant:\n%s\ngot:\n%s", want, got)
+	}
+
+	rows := []Row{
+		{ID: 1},
+		{ID: 2, Inner: Inner{A: 3, B: "x", C: []int32{1, 2}}, Ptr: &Inner{A: 4}},
+		{ID: 3, Inner: Inner{B: "y"}, Ptr: &Inner{}},
+	}
+
+	// Definition levels of the columns inner.a, inner.b, ptr.a and ptr.b for
+	// each row; a zero struct is written as a null group.
+	wantLevels := [][4]int{
+		{0, 0, 0, 0},
+		{1, 2, 1, 1},
+		{1, 2, 1, 1},
+	}
+
+	check := func(t *testing.T, buf *bytes.Buffer) {
+		f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := parquet.NewReader(f)
+		defer r.Close()
+
+		got := make([]parquet.Row, len(rows))
+		if n, err := r.ReadRows(got); n != len(rows) {
+			t.Fatalf("reading rows: n=%d err=%v", n, err)
+		}
+		for i, row := range got {
+			levels := [4]int{}
+			for _, v := range row {
+				switch v.Column() {
+				case 1:
+					levels[0] = v.DefinitionLevel()
+				case 2:
+					levels[1] = v.DefinitionLevel()
+				case 4:
+					levels[2] = v.DefinitionLevel()
+				case 5:
+					levels[3] = v.DefinitionLevel()
+				}
+			}
+			if levels != wantLevels[i] {
+				t.Errorf("row %d: wrong definition levels: want=%v got=%v", i, wantLevels[i], levels)
+			}
+		}
+
+		values, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range values {
+			if values[i].ID != rows[i].ID ||
+				values[i].Inner.A != rows[i].Inner.A ||
+				values[i].Inner.B != rows[i].Inner.B ||
+				!slices.Equal(values[i].Inner.C, rows[i].Inner.C) ||
+				(values[i].Ptr == nil) != (rows[i].Ptr == nil) {
+				t.Errorf("row %d mismatch:\nwant = %+v\ngot  = %+v", i, rows[i], values[i])
+			}
+		}
+	}
+
+	t.Run("GenericWriter", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		if err := parquet.Write(buf, rows); err != nil {
+			t.Fatal(err)
+		}
+		check(t, buf)
+	})
+
+	t.Run("Writer", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		w := parquet.NewWriter(buf, schema)
+		for i := range rows {
+			if err := w.Write(&rows[i]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		check(t, buf)
+	})
+}
+
+func TestWriteListOfOptionalStatistics(t *testing.T) {
+	type Row struct {
+		Values []*int32 `parquet:"values,list,optional"`
+	}
+
+	schema := parquet.SchemaOf(new(Row))
+	const want = `message Row {
+	optional group values (LIST) {
+		repeated group list {
+			optional int32 element (INT(32,true));
+		}
+	}
+}`
+	if got := schema.String(); got != want {
+		t.Fatalf("wrong schema:\nwant:\n%s\ngot:\n%s", want, got)
+	}
+
+	one, three := int32(1), int32(3)
+	rows := []Row{
+		{Values: []*int32{&one, nil, &three}},
+		{Values: []*int32{nil}},
+		{Values: []*int32{&three, &one}},
+	}
+
+	// Definition levels of the values in each row, the null elements have
+	// the definition level of the repeated group.
+	wantLevels := [][]int{
+		{3, 2, 3},
+		{2},
+		{3, 3},
+	}
+
+	check := func(t *testing.T, buf *bytes.Buffer) {
+		f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		chunk := f.Metadata().RowGroups[0].Columns[0].MetaData
+		if stats := chunk.Statistics; stats.NullCount != 2 {
+			t.Errorf("wrong null count in statistics: want=2 got=%d", stats.NullCount)
+		}
+		if minValue := int32(binary.LittleEndian.Uint32(chunk.Statistics.MinValue)); minValue != 1 {
+			t.Errorf("wrong min value in statistics: want=1 got=%d", minValue)
+		}
+		if maxValue := int32(binary.LittleEndian.Uint32(chunk.Statistics.MaxValue)); maxValue != 3 {
+			t.Errorf("wrong max value in statistics: want=3 got=%d", maxValue)
+		}
+
+		columnIndex, err := f.RowGroups()[0].ColumnChunks()[0].ColumnIndex()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if nullCount := columnIndex.NullCount(0); nullCount != 2 {
+			t.Errorf("wrong null count in column index: want=2 got=%d", nullCount)
+		}
+
+		r := parquet.NewReader(f)
+		defer r.Close()
+
+		got := make([]parquet.Row, len(rows))
+		if n, err := r.ReadRows(got); n != len(rows) {
+			t.Fatalf("reading rows: n=%d err=%v", n, err)
+		}
+		for i, row := range got {
+			levels := make([]int, len(row))
+			for j, v := range row {
+				levels[j] = v.DefinitionLevel()
+			}
+			if !slices.Equal(levels, wantLevels[i]) {
+				t.Errorf("row %d: wrong definition levels: want=%v got=%v", i, wantLevels[i], levels)
+			}
+		}
+
+		values, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(values, rows) {
+			t.Errorf("wrong rows:\nwant = %+v\ngot  = %+v", rows, values)
+		}
+	}
+
+	t.Run("GenericWriter", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		if err := parquet.Write(buf, rows); err != nil {
+			t.Fatal(err)
+		}
+		check(t, buf)
+	})
+
+	t.Run("Writer", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		w := parquet.NewWriter(buf, schema)
+		for i := range rows {
+			if err := w.Write(&rows[i]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		check(t, buf)
+	})
+}
+
+func TestWriteUnsignedDecimals(t *testing.T) {
+	type Row struct {
+		Amount uint64 `parquet:"amount,decimal(2:18)"`
+		Small  uint32 `parquet:"small,decimal(2:9)"`
+		Large  uint32 `parquet:"large,decimal(0:10)"`
+		Refund uint64 `parquet:"refund,decimal(2:12)"`
+		Count  uint64 `parquet:"count,decimal(0:5)"`
+	}
+
+	const want = `message Row {
+	required int64 amount (DECIMAL(18,2));
+	required int32 small (DECIMAL(9,2));
+	required int64 large (DECIMAL(10,0));
+	required int64 refund (DECIMAL(12,2));
+	required int32 count (DECIMAL(5,0));
+}`
+	if got := parquet.SchemaOf(new(Row)).String(); got != want {
+		t.Fatalf("wrong schema:\nwant:\n%s\ngot:\n%s", want, got)
+	}
+
+	rows := []Row{
+		{Amount: 999999999999999999, Small: 999999999, Large: math.MaxUint32, Refund: 999999999999, Count: 99999},
+		{Amount: 12345, Small: 42, Refund: 1250, Count: 7},
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows); err != nil {
+		t.Fatal(err)
+	}
+	got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, rows) {
+		t.Errorf("rows mismatch:\nwant = %+v\ngot  = %+v", rows, got)
+	}
+
+	for _, row := range []Row{{Small: 1_000_000_000}, {Refund: 1e12}, {Count: 1_000_000}} {
+		if err := parquet.Write(new(bytes.Buffer), []Row{row}); err == nil {
+			t.Errorf("expected an error writing a value exceeding the precision of the decimal: %+v", row)
+		}
+		if _, err := parquet.NewGenericWriter[Row](new(bytes.Buffer)).Write([]Row{row}); err == nil {
+			t.Errorf("expected an error writing a value exceeding the precision of the decimal with GenericWriter: %+v", row)
+		}
+		if err := parquet.NewWriter(new(bytes.Buffer), parquet.SchemaOf(Row{})).Write(&row); err == nil {
+			t.Errorf("expected an error writing a value exceeding the precision of the decimal with Writer: %+v", row)
+		}
+	}
+}
+
+func TestWriteFloatDecimals(t *testing.T) {
+	type Row struct {
+		HalfEven float64 `parquet:"half_even,decimal(2:10)"`
+		HalfUp   float64 `parquet:"half_up,decimal(2:10:round=half-up)"`
+		Truncate float64 `parquet:"truncate,decimal(2:10:round=truncate)"`
+		Float32  float32 `parquet:"float32,decimal(2:9:round=half-up)"`
+		Optional float64 `parquet:"optional,optional,decimal(2:10:round=half-even)"`
+	}
+
+	const want = `message Row {
+	required int64 half_even (DECIMAL(10,2));
+	required int64 half_up (DECIMAL(10,2));
+	required int64 truncate (DECIMAL(10,2));
+	required int32 float32 (DECIMAL(9,2));
+	optional int64 optional (DECIMAL(10,2));
+}`
+	if got := parquet.SchemaOf(new(Row)).String(); got != want {
+		t.Fatalf("wrong schema:\nwant:\n%s\ngot:\n%s", want, got)
+	}
+
+	tests := []struct {
+		value    float64
+		halfEven float64
+		halfUp   float64
+		truncate float64
+	}{
+		{value: 0.125, halfEven: 0.12, halfUp: 0.13, truncate: 0.12},
+		{value: 0.135, halfEven: 0.14, halfUp: 0.14, truncate: 0.13},
+		{value: -0.125, halfEven: -0.12, halfUp: -0.13, truncate: -0.12},
+		{value: 2.675, halfEven: 2.68, halfUp: 2.68, truncate: 2.67},
+		{value: 1.005, halfEven: 1, halfUp: 1.01, truncate: 1},
+		{value: 0.1251, halfEven: 0.13, halfUp: 0.13, truncate: 0.12},
+		{value: 0.999, halfEven: 1, halfUp: 1, truncate: 0.99},
+		{value: -0.005, halfEven: 0, halfUp: -0.01, truncate: 0},
+		{value: 123456.78, halfEven: 123456.78, halfUp: 123456.78, truncate: 123456.78},
+	}
+
+	rows := make([]Row, len(tests))
+	wantRows := make([]Row, len(tests))
+	for i, test := range tests {
+		rows[i] = Row{
+			HalfEven: test.value,
+			HalfUp:   test.value,
+			Truncate: test.value,
+			Float32:  float32(test.value),
+			Optional: test.value,
+		}
+		wantRows[i] = Row{
+			HalfEven: test.halfEven,
+			HalfUp:   test.halfUp,
+			Truncate: test.truncate,
+			Float32:  float32(test.halfUp),
+			Optional: test.halfEven,
+		}
+	}
+	rows = append(rows, Row{})
+	wantRows = append(wantRows, Row{})
+
+	check := func(t *testing.T, buf *bytes.Buffer) {
+		t.Helper()
+		got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, wantRows) {
+			t.Errorf("rows mismatch:\nwant = %+v\ngot  = %+v", wantRows, got)
+		}
+	}
+
+	t.Run("GenericWriter", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		if err := parquet.Write(buf, rows); err != nil {
+			t.Fatal(err)
+		}
+		check(t, buf)
+	})
+
+	t.Run("Writer", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		w := parquet.NewWriter(buf, parquet.SchemaOf(new(Row)))
+		for i := range rows {
+			if err := w.Write(&rows[i]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		check(t, buf)
+	})
+
+	for _, row := range []Row{{HalfEven: 1e8}, {HalfUp: 99999999.995}, {Truncate: math.NaN()}, {HalfEven: math.Inf(-1)}, {Truncate: 1e12}} {
+		if err := parquet.Write(new(bytes.Buffer), []Row{row}); err == nil {
+			t.Errorf("expected an error writing a value which cannot be represented by the decimal: %+v", row)
+		}
+		w := parquet.NewWriter(new(bytes.Buffer), parquet.SchemaOf(new(Row)))
+		if err := w.Write(&row); err == nil {
+			t.Errorf("expected an error writing a value which cannot be represented by the decimal with Writer.Write: %+v", row)
+		}
+	}
+}
+
+func TestWriterBloomFilterTag(t *testing.T) {
+	type Meta struct {
+		Host string `parquet:"host"`
+		Zone string `parquet:"zone,bloom(fpp=0.1)"`
+	}
+	type Row struct {
+		ID    int64  `parquet:"id,bloom"`
+		Name  string `parquet:"name,bloom(fpp=0.001)"`
+		Other string `parquet:"other"`
+		Meta  Meta   `parquet:"meta,bloom"`
+	}
+
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i] = Row{
+			ID:    int64(i),
+			Name:  fmt.Sprintf("name-%d", i),
+			Other: fmt.Sprintf("other-%d", i),
+			Meta:  Meta{Host: fmt.Sprintf("host-%d", i), Zone: fmt.Sprintf("zone-%d", i)},
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filters := make(map[string]parquet.BloomFilter)
+	for i, path := range f.Schema().Columns() {
+		if filter := f.RowGroups()[0].ColumnChunks()[i].BloomFilter(); filter != nil {
+			filters[strings.Join(path, ".")] = filter
+		}
+	}
+	if len(filters) != 4 || filters["other"] != nil {
+		t.Fatalf("wrong columns with bloom filters: %v", filters)
+	}
+
+	for path, value := range map[string]parquet.Value{
+		"id":        parquet.Int64Value(42),
+		"name":      parquet.ByteArrayValue([]byte("name-42")),
+		"meta.host": parquet.ByteArrayValue([]byte("host-42")),
+		"meta.zone": parquet.ByteArrayValue([]byte("zone-42")),
+	} {
+		if ok, err := filters[path].Check(value); err != nil {
+			t.Fatal(err)
+		} else if !ok {
+			t.Errorf("value %v not found in the bloom filter of %s", value, path)
+		}
+	}
+
+	// The size of the filters depends on their false positive probability.
+	if !(filters["name"].Size() > filters["id"].Size() && filters["id"].Size() > filters["meta.zone"].Size()) {
+		t.Errorf("wrong sizes of bloom filters: name=%d id=%d meta.zone=%d",
+			filters["name"].Size(), filters["id"].Size(), filters["meta.zone"].Size())
+	}
+
+	// Filters configured on the writer take precedence over the tags.
+	buf.Reset()
+	w := parquet.NewGenericWriter[Row](buf, parquet.BloomFilters(parquet.SplitBlockFilter(1, "name")))
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	f, err = parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size := f.RowGroups()[0].ColumnChunks()[1].BloomFilter().Size(); size >= filters["name"].Size() {
+		t.Errorf("the bloom filter configured on the writer was not used: size=%d", size)
+	}
+}
+
+func TestWriterCanonicalizeFloats(t *testing.T) {
+	type Row struct {
+		F32  float32  `parquet:"f32"`
+		F64  float64  `parquet:"f64"`
+		Opt  *float64 `parquet:"opt,optional"`
+		Dict float64  `parquet:"dict,dict"`
+	}
+
+	negZero32 := float32(math.Copysign(0, -1))
+	negZero64 := math.Copysign(0, -1)
+	nan32 := math.Float32frombits(0x7FC00123)
+	nan64 := math.Float64frombits(0x7FF8000000000123)
+
+	makeRows := func(nan32 float32, nan64 float64) []Row {
+		return []Row{
+			{F32: negZero32, F64: negZero64, Opt: &negZero64, Dict: negZero64},
+			{F32: nan32, F64: nan64, Opt: &nan64, Dict: nan64},
+			{F32: 1, F64: 1, Dict: 0},
+		}
+	}
+
+	write := func(t *testing.T, rows []Row, options ...parquet.WriterOption) []byte {
+		buf := new(bytes.Buffer)
+		w := parquet.NewGenericWriter[Row](buf, options...)
+		if _, err := w.Write(rows); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+
+	readBits := func(t *testing.T, data []byte) [][]uint64 {
+		f, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var columns [][]uint64
+		for _, chunk := range f.RowGroups()[0].ColumnChunks() {
+			var bits []uint64
+			pages := chunk.Pages()
+			for {
+				p, err := pages.ReadPage()
+				if err != nil {
+					if err != io.EOF {
+						t.Fatal(err)
+					}
+					break
+				}
+				values := make([]parquet.Value, p.NumValues())
+				n, _ := p.Values().ReadValues(values)
+				for _, v := range values[:n] {
+					switch v.Kind() {
+					case parquet.Float:
+						bits = append(bits, uint64(math.Float32bits(v.Float())))
+					case parquet.Double:
+						bits = append(bits, math.Float64bits(v.Double()))
+					}
+				}
+				parquet.Release(p)
+			}
+			pages.Close()
+			columns = append(columns, bits)
+		}
+		return columns
+	}
+
+	t.Run("enabled", func(t *testing.T) {
+		data := write(t, makeRows(nan32, nan64), parquet.CanonicalizeFloats(true))
+		want := [][]uint64{
+			{0, 0x7FC00000, uint64(math.Float32bits(1))},
+			{0, 0x7FF8000000000000, math.Float64bits(1)},
+			{0, 0x7FF8000000000000},
+			{0, 0x7FF8000000000000, 0},
+		}
+		if got := readBits(t, data); !reflect.DeepEqual(got, want) {
+			t.Errorf("wrong bit patterns stored:\nwant = %x\ngot  = %x", want, got)
+		}
+
+		// Different NaN payloads must produce byte-identical files.
+		other := write(t, makeRows(float32(math.NaN()), math.Float64frombits(0x7FF0000000000042)), parquet.CanonicalizeFloats(true))
+		if !bytes.Equal(data, other) {
+			t.Error("writing the same logical values produced different files")
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		data := write(t, makeRows(nan32, nan64))
+		got := readBits(t, data)
+		if got[0][0] != uint64(math.Float32bits(negZero32)) || got[1][1] != math.Float64bits(nan64) {
+			t.Errorf("expected the values to be stored as-is by default, got %x", got)
+		}
+	})
+}
+
+func TestWriterAdaptiveCompression(t *testing.T) {
+	type Row struct {
+		Text   string `parquet:"text,plain"`
+		Random []byte `parquet:"random,plain"`
+		Tagged string `parquet:"tagged,plain,zstd"`
+	}
+
+	prng := rand.New(rand.NewSource(0))
+	rows := make([]Row, 1000)
+	for i := range rows {
+		random := make([]byte, 32)
+		prng.Read(random)
+		rows[i] = Row{
+			Text:   strings.Repeat("the quick brown fox ", 4),
+			Random: random,
+			Tagged: "tagged",
+		}
+	}
+
+	for _, dataPageVersion := range []int{1, 2} {
+		t.Run(fmt.Sprintf("v%d", dataPageVersion), func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			w := parquet.NewGenericWriter[Row](buf,
+				parquet.DataPageVersion(dataPageVersion),
+				parquet.MaxRowsPerRowGroup(500),
+				parquet.AdaptiveCompression(&parquet.Uncompressed, &parquet.Snappy),
+			)
+			if _, err := w.Write(rows); err != nil {
+				t.Fatal(err)
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := []format.CompressionCodec{format.Snappy, format.Uncompressed, format.Zstd}
+			for i, rowGroup := range f.Metadata().RowGroups {
+				for j, chunk := range rowGroup.Columns {
+					if chunk.MetaData.Codec != want[j] {
+						t.Errorf("row group %d: column %q: wrong codec: want=%s got=%s", i, chunk.MetaData.PathInSchema, want[j], chunk.MetaData.Codec)
+					}
+				}
+			}
+
+			got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, rows) {
+				t.Error("rows mismatch after reading back the file")
+			}
+		})
+	}
+}
+
+func TestWriterBitPackedBooleans(t *testing.T) {
+	type Row struct {
+		Packed   bool  `parquet:"packed,bitpacked"`
+		Optional *bool `parquet:"optional,optional,bitpacked"`
+		Default  bool  `parquet:"default"`
+	}
+
+	yes := true
+	rows := make([]Row, 20)
+	for i := range rows {
+		rows[i].Packed = i%3 == 0
+		rows[i].Default = i%2 == 0
+		if i%4 == 0 {
+			rows[i].Optional = &yes
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](buf, parquet.DefaultEncodingFor(parquet.Boolean, &parquet.RLE))
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The RLE encoding of the optional column is the encoding of its
+	// definition levels.
+	wantEncodings := [][]format.Encoding{
+		{format.Plain},
+		{format.Plain, format.RLE},
+		{format.RLE},
+	}
+	wantPageEncodings := []format.Encoding{format.Plain, format.Plain, format.RLE}
+	for i, column := range f.Metadata().RowGroups[0].Columns {
+		if !slices.Equal(column.MetaData.Encoding, wantEncodings[i]) {
+			t.Errorf("wrong encodings of column %q: want=%v got=%v", column.MetaData.PathInSchema, wantEncodings[i], column.MetaData.Encoding)
+		}
+		for _, stats := range column.MetaData.EncodingStats {
+			if stats.Encoding != wantPageEncodings[i] {
+				t.Errorf("wrong encoding of %v page of column %q: want=%v got=%v", stats.PageType, column.MetaData.PathInSchema, wantPageEncodings[i], stats.Encoding)
+			}
+		}
+	}
+
+	got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, rows) {
+		t.Errorf("wrong rows:\nwant = %+v\ngot  = %+v", rows, got)
+	}
+}
+
+func TestWriterDeltaByteArrayStrings(t *testing.T) {
+	type Row struct {
+		URL      string  `parquet:"url,delta"`
+		Path     []byte  `parquet:"path,delta"`
+		Referrer *string `parquet:"referrer,optional,delta"`
+		Agent    string  `parquet:"agent,deltalength"`
+		Payload  []byte  `parquet:"payload,deltalength"`
+	}
+
+	rows := make([]Row, 100)
+	for i := range rows {
+		path := fmt.Sprintf("/articles/%04d/comments", i)
+		rows[i] = Row{
+			URL:     "https://example.com" + path,
+			Path:    []byte(path),
+			Agent:   strings.Repeat("x", i%7),
+			Payload: []byte(fmt.Sprint(i * i)),
+		}
+		if i%3 == 0 {
+			rows[i].Referrer = &rows[i].URL
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantEncodings := []format.Encoding{
+		format.DeltaByteArray,
+		format.DeltaByteArray,
+		format.DeltaByteArray,
+		format.DeltaLengthByteArray,
+		format.DeltaLengthByteArray,
+	}
+	for i, column := range f.Metadata().RowGroups[0].Columns {
+		if !slices.Contains(column.MetaData.Encoding, wantEncodings[i]) {
+			t.Errorf("wrong encodings of column %q: want=%v got=%v", column.MetaData.PathInSchema, wantEncodings[i], column.MetaData.Encoding)
+		}
+	}
+
+	got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, rows) {
+		t.Errorf("wrong rows:\nwant = %+v\ngot  = %+v", rows, got)
+	}
+
+	// The encodings are read from the page headers, the tags of the type used
+	// to read the rows do not need to declare them.
+	type PlainRow struct {
+		URL      string  `parquet:"url"`
+		Path     []byte  `parquet:"path"`
+		Referrer *string `parquet:"referrer,optional"`
+		Agent    string  `parquet:"agent"`
+		Payload  []byte  `parquet:"payload"`
+	}
+	plain, err := parquet.Read[PlainRow](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, row := range plain {
+		if want := PlainRow(rows[i]); !reflect.DeepEqual(row, want) {
+			t.Errorf("wrong row %d: want=%+v got=%+v", i, want, row)
+		}
+	}
+}
+
+func TestWriterNullBitmap(t *testing.T) {
+	type Address struct {
+		City *string `parquet:"city,optional"`
+	}
+	type Row struct {
+		ID      int64    `parquet:"id"`
+		Email   *string  `parquet:"email,optional"`
+		Address Address  `parquet:"address"`
+		Tags    []string `parquet:"tags"`
+	}
+	type Mask struct {
+		Email    *string `parquet:"email,optional"`
+		EmailSet bool    `parquet:"email_set"`
+		Address  Address `parquet:"address"`
+		CitySet  bool    `parquet:"city_set"`
+	}
+
+	prng := rand.New(rand.NewSource(0))
+	rows := make([]Row, 5000)
+	for i := range rows {
+		rows[i] = Row{ID: int64(i), Tags: []string{"a"}}
+		if prng.Intn(3) != 0 {
+			email := fmt.Sprintf("user-%d@example.com", i)
+			rows[i].Email = &email
+		}
+		if prng.Intn(2) != 0 {
+			city := fmt.Sprintf("city-%d", i%10)
+			rows[i].Address.City = &city
+		}
+	}
+
+	options := []parquet.WriterOption{
+		parquet.PageBufferSize(1024),
+		parquet.MaxRowsPerRowGroup(2000),
+		parquet.NullBitmap("email_set", "email"),
+		parquet.NullBitmap("city_set", "address", "city"),
+	}
+
+	for _, test := range []struct {
+		scenario string
+		write    func(*bytes.Buffer) error
+	}{
+		{
+			scenario: "generic writer",
+			write: func(buf *bytes.Buffer) error {
+				w := parquet.NewGenericWriter[Row](buf, options...)
+				if _, err := w.Write(rows); err != nil {
+					return err
+				}
+				return w.Close()
+			},
+		},
+		{
+			scenario: "writer",
+			write: func(buf *bytes.Buffer) error {
+				w := parquet.NewWriter(buf, append([]parquet.WriterOption{parquet.SchemaOf(Row{})}, options...)...)
+				for i := range rows {
+					if err := w.Write(&rows[i]); err != nil {
+						return err
+					}
+				}
+				return w.Close()
+			},
+		},
+	} {
+		t.Run(test.scenario, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			if err := test.write(buf); err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n := len(f.RowGroups()); n != 3 {
+				t.Errorf("wrong number of row groups: want=3 got=%d", n)
+			}
+			columns := f.Schema().Columns()
+			if n := len(columns); n != 6 {
+				t.Fatalf("wrong number of columns: want=6 got=%d", n)
+			}
+			if leaf, ok := f.Schema().Lookup("email_set"); !ok || leaf.Node.Type().Kind() != parquet.Boolean || leaf.Node.Optional() {
+				t.Fatal("the null bitmap must be a required boolean column")
+			}
+
+			got, err := parquet.Read[Mask](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(rows) {
+				t.Fatalf("wrong number of rows: want=%d got=%d", len(rows), len(got))
+			}
+			for i, row := range got {
+				if row.EmailSet != (row.Email != nil) || row.EmailSet != (rows[i].Email != nil) {
+					t.Fatalf("row %d: email mask mismatch: email=%v mask=%t", i, row.Email, row.EmailSet)
+				}
+				if row.CitySet != (row.Address.City != nil) || row.CitySet != (rows[i].Address.City != nil) {
+					t.Fatalf("row %d: city mask mismatch: city=%v mask=%t", i, row.Address.City, row.CitySet)
+				}
+			}
+		})
+	}
+
+	t.Run("invalid columns", func(t *testing.T) {
+		for _, option := range []parquet.WriterOption{
+			parquet.NullBitmap("id_set", "id"),
+			parquet.NullBitmap("tags_set", "tags"),
+			parquet.NullBitmap("missing_set", "missing"),
+			parquet.NullBitmap("id", "email"),
+		} {
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Error("expected the writer constructor to panic")
+					}
+				}()
+				parquet.NewGenericWriter[Row](new(bytes.Buffer), option)
+			}()
+		}
+	})
+}
+
+func TestWriterDeterministicOutput(t *testing.T) {
+	type Row struct {
+		ID     int64             `parquet:"id"`
+		Name   string            `parquet:"name,dict,zstd"`
+		Score  *float64          `parquet:"score,optional"`
+		Tags   []string          `parquet:"tags,list"`
+		Labels map[string]string `parquet:"labels"`
+	}
+
+	rows := make([]Row, 1000)
+	for i := range rows {
+		score := float64(i) / 3
+		rows[i] = Row{
+			ID:     int64(i),
+			Name:   fmt.Sprintf("name-%d", i%17),
+			Tags:   []string{"a", fmt.Sprintf("tag-%d", i%5)},
+			Labels: map[string]string{"x": "1", "y": "2", "z": strconv.Itoa(i)},
+		}
+		if i%3 != 0 {
+			rows[i].Score = &score
+		}
+	}
+
+	write := func(keys ...string) []byte {
+		buf := new(bytes.Buffer)
+		w := parquet.NewGenericWriter[Row](buf,
+			parquet.DeterministicOutput(true),
+			parquet.MaxRowsPerRowGroup(300),
+			parquet.PageBufferSize(1024),
+			parquet.KeyValueMetadata("b", "2"),
+			parquet.KeyValueMetadata("a", "1"),
+			parquet.BloomFilters(parquet.SplitBlockFilter(10, "name")),
+		)
+		for _, key := range keys {
+			w.SetKeyValueMetadata(key, "value of "+key)
+		}
+		for i := 0; i < len(rows); i += 100 {
+			if _, err := w.Write(rows[i : i+100]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+
+	data1 := write("d", "c", "e")
+	data2 := write("e", "d", "c")
+	if !bytes.Equal(data1, data2) {
+		t.Fatal("writing the same rows twice produced different outputs")
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(data1), int64(len(data1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	metadata := f.Metadata()
+	if metadata.CreatedBy != "github.com/parquet-go/parquet-go" {
+		t.Errorf("wrong created_by: %q", metadata.CreatedBy)
+	}
+	keys := make([]string, len(metadata.KeyValueMetadata))
+	for i, kv := range metadata.KeyValueMetadata {
+		keys[i] = kv.Key
+	}
+	if want := []string{"a", "b", "c", "d", "e"}; !slices.Equal(keys, want) {
+		t.Errorf("wrong key/value metadata order: want=%q got=%q", want, keys)
+	}
+}
+
+func TestWriterDeterministicOutputMaps(t *testing.T) {
+	type Row struct {
+		Labels map[string]int64 `parquet:"labels"`
+		Flags  map[bool]int32   `parquet:"flags"`
+	}
+
+	row := Row{Labels: make(map[string]int64), Flags: map[bool]int32{false: 0, true: 1}}
+	for i := range 50 {
+		row.Labels[fmt.Sprintf("label-%d", i)] = int64(i)
+	}
+
+	write := func(t *testing.T, writeRow func(io.Writer) error) []byte {
+		buf := new(bytes.Buffer)
+		if err := writeRow(buf); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+
+	for _, test := range []struct {
+		scenario string
+		writeRow func(io.Writer) error
+	}{
+		{
+			scenario: "Writer",
+			writeRow: func(output io.Writer) error {
+				w := parquet.NewWriter(output, parquet.SchemaOf(row), parquet.DeterministicOutput(true))
+				if err := w.Write(row); err != nil {
+					return err
+				}
+				return w.Close()
+			},
+		},
+		{
+			scenario: "GenericWriter",
+			writeRow: func(output io.Writer) error {
+				w := parquet.NewGenericWriter[Row](output, parquet.DeterministicOutput(true))
+				if _, err := w.Write([]Row{row}); err != nil {
+					return err
+				}
+				return w.Close()
+			},
+		},
+	} {
+		t.Run(test.scenario, func(t *testing.T) {
+			want := write(t, test.writeRow)
+			for range 10 {
+				if got := write(t, test.writeRow); !bytes.Equal(got, want) {
+					t.Fatal("writing the same map values twice produced different outputs")
+				}
+			}
+
+			rows, err := parquet.Read[Row](bytes.NewReader(want), int64(len(want)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(rows) != 1 || !reflect.DeepEqual(rows[0], row) {
+				t.Error("rows mismatch after reading back maps written in order")
+			}
+		})
+	}
+}
+
+func TestWriterConstantColumns(t *testing.T) {
+	type Row struct {
+		Date   string  `parquet:"date"`
+		Region *string `parquet:"region,optional"`
+		Shard  int32   `parquet:"shard"`
+		ID     int64   `parquet:"id"`
+	}
+
+	region := "us-east-1"
+	rows := make([]Row, 10000)
+	for i := range rows {
+		rows[i] = Row{Date: "2024-01-01", ID: int64(i)}
+		if i%4 != 0 {
+			rows[i].Region = &region
+		}
+		if i >= 5000 {
+			// The value changes in the middle of the row group, the pages
+			// holding the second value are written with the column encoding.
+			rows[i].Shard = 1
+		}
+	}
+
+	write := func(options ...parquet.WriterOption) *parquet.File {
+		buf := new(bytes.Buffer)
+		w := parquet.NewGenericWriter[Row](buf, options...)
+		if _, err := w.Write(rows); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return f
+	}
+
+	// The column chunk of a constant required column holds a dictionary page
+	// of one value and a data page of a few bytes.
+	single := write(parquet.ConstantColumns(true))
+	if size := single.Metadata().RowGroups[0].Columns[0].MetaData.TotalCompressedSize; size > 128 {
+		t.Errorf("column \"date\": constant column chunk is too large: %dB", size)
+	}
+
+	// Small pages make sure that columns are written with multiple pages.
+	plain := write(parquet.PageBufferSize(4096))
+	constant := write(
+		parquet.PageBufferSize(4096),
+		parquet.ConstantColumns(true),
+		parquet.BloomFilters(
+			parquet.SplitBlockFilter(10, "date"),
+			parquet.SplitBlockFilter(10, "shard"),
+		),
+	)
+
+	plainColumns := plain.Metadata().RowGroups[0].Columns
+	constantColumns := constant.Metadata().RowGroups[0].Columns
+
+	for i, name := range []string{"date", "region"} {
+		if size, plainSize := constantColumns[i].MetaData.TotalCompressedSize, plainColumns[i].MetaData.TotalCompressedSize; size > plainSize/20 {
+			t.Errorf("column %q: constant column chunk is too large: %dB (plain: %dB)", name, size, plainSize)
+		}
+		if !slices.Contains(constantColumns[i].MetaData.Encoding, format.RLEDictionary) {
+			t.Errorf("column %q: missing RLE_DICTIONARY encoding: %v", name, constantColumns[i].MetaData.Encoding)
+		}
+		if constantColumns[i].MetaData.DictionaryPageOffset == 0 {
+			t.Errorf("column %q: missing dictionary page", name)
+		}
+	}
+
+	if size, plainSize := constantColumns[2].MetaData.TotalCompressedSize, plainColumns[2].MetaData.TotalCompressedSize; size >= plainSize {
+		t.Errorf("column \"shard\": chunk with constant pages is not smaller than plain: %dB >= %dB", size, plainSize)
+	}
+	if slices.Contains(constantColumns[3].MetaData.Encoding, format.RLEDictionary) {
+		t.Errorf("column \"id\": unexpected RLE_DICTIONARY encoding of non-constant column: %v", constantColumns[3].MetaData.Encoding)
+	}
+	if slices.Contains(plainColumns[0].MetaData.Encoding, format.RLEDictionary) {
+		t.Errorf("column \"date\": unexpected RLE_DICTIONARY encoding without the option: %v", plainColumns[0].MetaData.Encoding)
+	}
+
+	columnChunks := constant.RowGroups()[0].ColumnChunks()
+	for _, check := range []struct {
+		column int
+		value  parquet.Value
+	}{
+		{column: 0, value: parquet.ValueOf("2024-01-01")},
+		{column: 2, value: parquet.ValueOf(int32(0))},
+		{column: 2, value: parquet.ValueOf(int32(1))},
+	} {
+		if ok, err := columnChunks[check.column].BloomFilter().Check(check.value); err != nil {
+			t.Fatal(err)
+		} else if !ok {
+			t.Errorf("column %d: value %v missing from the bloom filter", check.column, check.value)
+		}
+	}
+
+	got := make([]Row, len(rows))
+	n, err := parquet.NewGenericReader[Row](constant).Read(got)
+	if err != nil && !errors.Is(err, io.EOF) {
+		t.Fatal(err)
+	}
+	if n != len(rows) {
+		t.Fatalf("wrong number of rows read: want=%d got=%d", len(rows), n)
+	}
+	if !reflect.DeepEqual(got, rows) {
+		t.Error("rows read from the file with constant columns mismatch")
+	}
+}
+
+func TestWriterMaxDictionarySize(t *testing.T) {
+	type Row struct {
+		Name string `parquet:"name,dict"`
+		Kind string `parquet:"kind,dict"`
+	}
+
+	const maxDictionarySize = 4096
+	rows := make([]Row, 20000)
+	for i := range rows {
+		rows[i] = Row{
+			Name: fmt.Sprintf("name-%06d", i),
+			Kind: fmt.Sprintf("kind-%d", i%10),
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](buf,
+		parquet.PageBufferSize(1024),
+		parquet.MaxRowsPerRowGroup(10000),
+		parquet.MaxDictionarySize(maxDictionarySize),
+		parquet.BloomFilters(parquet.SplitBlockFilter(10, "name")),
+	)
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(f.RowGroups()); n != 2 {
+		t.Fatalf("wrong number of row groups: %d", n)
+	}
+
+	countDataPages := func(stats []format.PageEncodingStats, encoding format.Encoding) (count int32) {
+		for _, s := range stats {
+			if s.PageType != format.DictionaryPage && s.Encoding == encoding {
+				count += s.Count
+			}
+		}
+		return count
+	}
+
+	for i, rowGroup := range f.RowGroups() {
+		// Each row group starts with an empty dictionary, the column of high
+		// cardinality falls back to PLAIN-encoded pages in both.
+		columns := f.Metadata().RowGroups[i].Columns
+		name, kind := columns[0].MetaData.EncodingStats, columns[1].MetaData.EncodingStats
+		if countDataPages(name, format.RLEDictionary) == 0 || countDataPages(name, format.Plain) == 0 {
+			t.Errorf("row group %d: column \"name\" must have dictionary-encoded and fallback pages: %+v", i, name)
+		}
+		if countDataPages(kind, format.Plain) != 0 {
+			t.Errorf("row group %d: column \"kind\" must not fall back to the PLAIN encoding: %+v", i, kind)
+		}
+
+		pages := rowGroup.ColumnChunks()[0].Pages()
+		page, err := pages.ReadPage()
+		if err != nil {
+			t.Fatal(err)
+		}
+		dict := page.Dictionary()
+		if dict == nil {
+			t.Fatalf("row group %d: missing dictionary of column \"name\"", i)
+		}
+		// The dictionary may exceed the limit by the values of a batch of rows.
+		if size := dict.Page().Size(); size > 2*maxDictionarySize {
+			t.Errorf("row group %d: dictionary is too large: %dB", i, size)
+		}
+		pages.Close()
+
+		bloomFilter := rowGroup.ColumnChunks()[0].BloomFilter()
+		for _, row := range []Row{rows[10000*i], rows[10000*i+9999]} {
+			if ok, err := bloomFilter.Check(parquet.ValueOf(row.Name)); err != nil {
+				t.Fatal(err)
+			} else if !ok {
+				t.Errorf("row group %d: value %q missing from the bloom filter", i, row.Name)
+			}
+		}
+	}
+
+	got := make([]Row, len(rows))
+	n, err := parquet.NewGenericReader[Row](f).Read(got)
+	if err != nil && !errors.Is(err, io.EOF) {
+		t.Fatal(err)
+	}
+	if n != len(rows) {
+		t.Fatalf("wrong number of rows read: want=%d got=%d", len(rows), n)
+	}
+	if !reflect.DeepEqual(got, rows) {
+		t.Error("rows read from the file with bounded dictionaries mismatch")
+	}
+}
+
+func TestWriterDictionaryEncodedNestedColumns(t *testing.T) {
+	type Row struct {
+		Tags []string `parquet:"tags,optional,list,dict"`
+	}
+
+	rows := make([]Row, 100)
+	for i := range rows {
+		switch i % 3 {
+		case 0:
+			rows[i].Tags = []string{"a", "b", "a"}
+		case 1:
+			rows[i].Tags = []string{"b"}
+		}
+	}
+
+	for _, test := range []struct {
+		scenario string
+		options  []parquet.WriterOption
+	}{
+		{
+			scenario: "struct tag",
+		},
+		{
+			scenario: "encoded list",
+			options: []parquet.WriterOption{
+				parquet.NewSchema("Row", parquet.Group{
+					"tags": parquet.Encoded(parquet.Optional(parquet.List(parquet.String())), &parquet.RLEDictionary),
+				}),
+			},
+		},
+	} {
+		t.Run(test.scenario, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			w := parquet.NewGenericWriter[Row](buf, test.options...)
+			if _, err := w.Write(rows); err != nil {
+				t.Fatal(err)
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			leaf, ok := f.Schema().Lookup("tags", "list", "element")
+			if !ok {
+				t.Fatal("missing list element column")
+			}
+			if leaf.MaxRepetitionLevel != 1 || leaf.MaxDefinitionLevel != 2 {
+				t.Errorf("wrong levels of list element column: repetition=%d definition=%d", leaf.MaxRepetitionLevel, leaf.MaxDefinitionLevel)
+			}
+			if encoding := leaf.Node.Encoding(); encoding == nil || encoding.Encoding() != format.RLEDictionary {
+				t.Errorf("wrong encoding of list element column: %v", encoding)
+			}
+			metadata := f.Metadata().RowGroups[0].Columns[0].MetaData
+			if !slices.Contains(metadata.Encoding, format.RLEDictionary) || metadata.DictionaryPageOffset == 0 {
+				t.Errorf("list element column is not dictionary-encoded: %v", metadata.Encoding)
+			}
+
+			got := make([]Row, len(rows))
+			n, err := parquet.NewGenericReader[Row](f).Read(got)
+			if err != nil && !errors.Is(err, io.EOF) {
+				t.Fatal(err)
+			}
+			if n != len(rows) {
+				t.Fatalf("wrong number of rows read: want=%d got=%d", len(rows), n)
+			}
+			// Null lists are read back as empty slices.
+			for i := range rows {
+				if !slices.Equal(got[i].Tags, rows[i].Tags) {
+					t.Fatalf("row %d mismatch: want=%q got=%q", i, rows[i].Tags, got[i].Tags)
+				}
+			}
+		})
+	}
+}
+
+func TestGenericWriterWriteRowGroup(t *testing.T) {
+	type Row struct {
+		ID   int64    `parquet:"id,bloom"`
+		Name string   `parquet:"name,dict"`
+		Tags []string `parquet:"tags,list"`
+	}
+
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i] = Row{
+			ID:   int64(i),
+			Name: fmt.Sprintf("name-%d", i%10),
+			Tags: []string{"a", "b", "c"}[:i%3],
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](buf, parquet.Compression(&parquet.Snappy), parquet.MaxRowsPerRowGroup(600))
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	source, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chunkData := func(t *testing.T, f *parquet.File, rowGroup, column int) []byte {
+		t.Helper()
+		metadata := f.Metadata().RowGroups[rowGroup].Columns[column].MetaData
+		offset := metadata.DataPageOffset
+		if metadata.DictionaryPageOffset != 0 {
+			offset = metadata.DictionaryPageOffset
+		}
+		data := make([]byte, metadata.TotalCompressedSize)
+		if _, err := f.ReadAt(data, offset); err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+
+	for _, test := range []struct {
+		scenario string
+		codec    compress.Codec
+		copied   bool
+	}{
+		{scenario: "same codec", codec: &parquet.Snappy, copied: true},
+		{scenario: "different codec", codec: &parquet.Zstd, copied: false},
+	} {
+		t.Run(test.scenario, func(t *testing.T) {
+			output := new(bytes.Buffer)
+			w := parquet.NewGenericWriter[Row](output, parquet.Compression(test.codec))
+			for i, rowGroup := range source.RowGroups() {
+				n, err := w.WriteRowGroup(rowGroup)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if n != rowGroup.NumRows() {
+					t.Errorf("wrong number of rows written from row group %d: want=%d got=%d", i, rowGroup.NumRows(), n)
+				}
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := parquet.OpenFile(bytes.NewReader(output.Bytes()), int64(output.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(f.RowGroups()) != len(source.RowGroups()) {
+				t.Fatalf("wrong number of row groups: want=%d got=%d", len(source.RowGroups()), len(f.RowGroups()))
+			}
+
+			for i, rowGroup := range f.Metadata().RowGroups {
+				for j, column := range rowGroup.Columns {
+					if column.MetaData.Codec != test.codec.CompressionCodec() {
+						t.Errorf("wrong codec of column %d in row group %d: want=%v got=%v", j, i, test.codec.CompressionCodec(), column.MetaData.Codec)
+					}
+					copied := bytes.Equal(chunkData(t, f, i, j), chunkData(t, source, i, j))
+					if copied != test.copied {
+						t.Errorf("pages of column %d in row group %d copied: want=%t got=%t", j, i, test.copied, copied)
+					}
+				}
+			}
+
+			got, err := parquet.Read[Row](bytes.NewReader(output.Bytes()), int64(output.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(rows) {
+				t.Fatalf("wrong number of rows: want=%d got=%d", len(rows), len(got))
+			}
+			for i := range rows {
+				if got[i].ID != rows[i].ID || got[i].Name != rows[i].Name || !slices.Equal(got[i].Tags, rows[i].Tags) {
+					t.Fatalf("wrong row at index %d: want=%+v got=%+v", i, rows[i], got[i])
+				}
+			}
+
+			for i, rowGroup := range f.RowGroups() {
+				chunk := rowGroup.ColumnChunks()[0]
+				filter := chunk.BloomFilter()
+				if filter == nil {
+					t.Fatalf("missing bloom filter in row group %d", i)
+				}
+				if ok, err := filter.Check(parquet.Int64Value(int64(i) * 600)); err != nil {
+					t.Fatal(err)
+				} else if !ok {
+					t.Errorf("value not found in the bloom filter of row group %d", i)
+				}
+
+				columnIndex, err := chunk.ColumnIndex()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if minValue := columnIndex.MinValue(0); minValue.Int64() != int64(i)*600 {
+					t.Errorf("wrong min value in the column index of row group %d: want=%d got=%d", i, i*600, minValue.Int64())
+				}
+				offsetIndex, err := chunk.OffsetIndex()
+				if err != nil {
+					t.Fatal(err)
+				}
+				pages := chunk.Pages()
+				if err := pages.SeekToRow(offsetIndex.FirstRowIndex(offsetIndex.NumPages() - 1)); err != nil {
+					t.Fatal(err)
+				}
+				if _, err := pages.ReadPage(); err != nil {
+					t.Fatal(err)
+				}
+				pages.Close()
+			}
+		})
+	}
+}
+
+func TestGenericWriterWriteRowGroupWidenedSchema(t *testing.T) {
+	type RowV1 struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name"`
+	}
+	type RowV2 struct {
+		ID    int64   `parquet:"id"`
+		Email *string `parquet:"email,optional"`
+		Name  string  `parquet:"name"`
+	}
+
+	buf := new(bytes.Buffer)
+	w1 := parquet.NewGenericWriter[RowV1](buf, parquet.MaxRowsPerRowGroup(2))
+	if _, err := w1.Write([]RowV1{{1, "a"}, {2, "b"}, {3, "c"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w1.Close(); err != nil {
+		t.Fatal(err)
+	}
+	existing, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Append rows of the superset struct after the row groups of the file.
+	email := "d@example.com"
+	output := new(bytes.Buffer)
+	w2 := parquet.NewGenericWriter[RowV2](output)
+	for _, rowGroup := range existing.RowGroups() {
+		if _, err := w2.WriteRowGroup(rowGroup); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := w2.Write([]RowV2{{ID: 4, Email: &email, Name: "d"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w2.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := parquet.Read[RowV2](bytes.NewReader(output.Bytes()), int64(output.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []RowV2{
+		{ID: 1, Name: "a"},
+		{ID: 2, Name: "b"},
+		{ID: 3, Name: "c"},
+		{ID: 4, Email: &email, Name: "d"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong rows:\nwant: %+v\ngot:  %+v", want, got)
+	}
+
+	t.Run("incompatible schemas", func(t *testing.T) {
+		type RowChangedType struct {
+			ID   int32  `parquet:"id"`
+			Name string `parquet:"name"`
+		}
+		type RowAddedRequired struct {
+			ID    int64  `parquet:"id"`
+			Name  string `parquet:"name"`
+			Email string `parquet:"email"`
+		}
+		type RowRemovedField struct {
+			ID int64 `parquet:"id"`
+		}
+		rowGroup := existing.RowGroups()[0]
+
+		for _, w := range []interface {
+			WriteRowGroup(parquet.RowGroup) (int64, error)
+		}{
+			parquet.NewGenericWriter[RowChangedType](io.Discard),
+			parquet.NewGenericWriter[RowAddedRequired](io.Discard),
+			parquet.NewGenericWriter[RowRemovedField](io.Discard),
+		} {
+			if _, err := w.WriteRowGroup(rowGroup); !errors.Is(err, parquet.ErrRowGroupSchemaMismatch) {
+				t.Errorf("expected ErrRowGroupSchemaMismatch, got %v", err)
+			}
+		}
+	})
+}
+
+func TestWriterWriteColumns(t *testing.T) {
+	type Row struct {
+		ID   int64    `parquet:"id"`
+		Name *string  `parquet:"name,optional"`
+		Tags []string `parquet:"tags"`
+	}
+
+	name := "bob"
+	want := []Row{
+		{ID: 1, Name: &name, Tags: []string{"a", "b"}},
+		{ID: 2, Tags: []string{}},
+		{ID: 3, Name: &name, Tags: []string{"c"}},
+	}
+
+	// The values carry levels computed by the application, and column indexes
+	// which do not match their position (ignored by the writer).
+	columns := [][]parquet.Value{
+		{
+			parquet.Int64Value(1).Level(0, 0, 5),
+			parquet.Int64Value(2).Level(0, 0, 5),
+			parquet.Int64Value(3).Level(0, 0, 5),
+		},
+		{
+			parquet.ByteArrayValue([]byte(name)).Level(0, 1, 0),
+			parquet.NullValue().Level(0, 0, 0),
+			parquet.ByteArrayValue([]byte(name)).Level(0, 1, 0),
+		},
+		{
+			parquet.ByteArrayValue([]byte("a")).Level(0, 1, 0),
+			parquet.ByteArrayValue([]byte("b")).Level(1, 1, 0),
+			parquet.NullValue().Level(0, 0, 0),
+			parquet.ByteArrayValue([]byte("c")).Level(0, 1, 0),
+		},
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewWriter(buf, parquet.SchemaOf(new(Row)), parquet.MaxRowsPerRowGroup(2))
+	n, err := w.WriteColumns(columns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != len(want) {
+		t.Errorf("wrong number of rows written: want=%d got=%d", len(want), n)
+	}
+
+	invalid := []struct {
+		scenario string
+		columns  [][]parquet.Value
+	}{
+		{"missing columns", columns[:2]},
+		{"repetition level too high", [][]parquet.Value{columns[0], columns[1], {parquet.ByteArrayValue([]byte("a")).Level(0, 1, 0), parquet.ByteArrayValue([]byte("b")).Level(2, 1, 0)}}},
+		{"definition level too high", [][]parquet.Value{{parquet.Int64Value(1).Level(0, 1, 0)}, columns[1][:1], columns[2][:2]}},
+		{"null value at the maximum definition level", [][]parquet.Value{columns[0][:1], {parquet.NullValue().Level(0, 1, 0)}, columns[2][:2]}},
+		{"first value not starting a row", [][]parquet.Value{columns[0][:1], columns[1][:1], columns[2][1:2]}},
+	}
+	for _, test := range invalid {
+		if _, err := w.WriteColumns(test.columns); err == nil {
+			t.Errorf("%s: expected an error", test.scenario)
+		}
+	}
+	if _, err := w.WriteColumns([][]parquet.Value{columns[0], columns[1][:1], columns[2]}); !errors.Is(err, parquet.ErrMisalignedColumns) {
+		t.Errorf("expected ErrMisalignedColumns, got %v", err)
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(f.RowGroups()); n != 2 {
+		t.Errorf("wrong number of row groups: want=2 got=%d", n)
+	}
+	got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong rows:\nwant: %+v\ngot:  %+v", want, got)
+	}
+
+	// Reading the pages of the column chunks returns the values that were
+	// written, with their levels.
+	for i, column := range columns {
+		var values []parquet.Value
+		for _, rowGroup := range f.RowGroups() {
+			pages := rowGroup.ColumnChunks()[i].Pages()
+			for {
+				p, err := pages.ReadPage()
+				if err != nil {
+					if err != io.EOF {
+						t.Fatal(err)
+					}
+					break
+				}
+				buffer := make([]parquet.Value, p.NumValues())
+				p.Values().ReadValues(buffer)
+				values = append(values, buffer...)
+			}
+			pages.Close()
+		}
+		if len(values) != len(column) {
+			t.Fatalf("column %d: wrong number of values: want=%d got=%d", i, len(column), len(values))
+		}
+		for j, v := range values {
+			want := column[j].Level(int(column[j].RepetitionLevel()), int(column[j].DefinitionLevel()), i)
+			if !parquet.Equal(v, want) || v.RepetitionLevel() != want.RepetitionLevel() || v.DefinitionLevel() != want.DefinitionLevel() || v.Column() != i {
+				t.Errorf("column %d: value %d: want=%+v got=%+v", i, j, want, v)
+			}
+		}
+	}
+}
+
+func TestWriterDictionaryNullValues(t *testing.T) {
+	schema := parquet.NewSchema("test", parquet.Group{
+		"name": parquet.Optional(parquet.Encoded(parquet.String(), &parquet.RLEDictionary)),
+		"tags": parquet.List(parquet.Optional(parquet.Encoded(parquet.String(), &parquet.RLEDictionary))),
+	})
+
+	// Null values are written at the definition level of non-null values,
+	// which the writer must not insert in the dictionaries.
+	rows := []parquet.Row{
+		{parquet.ByteArrayValue([]byte("a")).Level(0, 1, 0), parquet.ByteArrayValue([]byte("x")).Level(0, 2, 1)},
+		{parquet.NullValue().Level(0, 1, 0), parquet.NullValue().Level(0, 2, 1), parquet.ByteArrayValue([]byte("y")).Level(1, 2, 1)},
+		{parquet.NullValue().Level(0, 0, 0), parquet.NullValue().Level(0, 1, 1)},
+		{parquet.ByteArrayValue([]byte("b")).Level(0, 1, 0), parquet.NullValue().Level(0, 0, 1)},
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewWriter(buf, schema)
+	if _, err := w.WriteRows(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Columns which are not dictionary-encoded keep accepting null values at
+	// the maximum definition level.
+	plain := parquet.NewSchema("test", parquet.Group{"name": parquet.Optional(parquet.String())})
+	if _, err := parquet.NewWriter(new(bytes.Buffer), plain).WriteRows([]parquet.Row{{parquet.NullValue().Level(0, 1, 0)}}); err != nil {
+		t.Errorf("writing a null value at the maximum definition level of a plain column: %v", err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, test := range []struct {
+		dictionary []string
+		nullCount  int64
+		min, max   string
+	}{
+		{dictionary: []string{"a", "b"}, nullCount: 2, min: "a", max: "b"},
+		{dictionary: []string{"x", "y"}, nullCount: 3, min: "x", max: "y"},
+	} {
+		pages := f.RowGroups()[0].ColumnChunks()[i].Pages()
+		p, err := pages.ReadPage()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var dictionary []string
+		for d, j := p.Dictionary(), 0; j < d.Len(); j++ {
+			v := d.Index(int32(j))
+			if v.IsNull() {
+				t.Errorf("column %d: null value in the dictionary at index %d", i, j)
+			}
+			dictionary = append(dictionary, v.String())
+		}
+		pages.Close()
+		if !slices.Equal(dictionary, test.dictionary) {
+			t.Errorf("column %d: wrong dictionary: want=%q got=%q", i, test.dictionary, dictionary)
+		}
+
+		stats := f.Metadata().RowGroups[0].Columns[i].MetaData.Statistics
+		if stats.NullCount != test.nullCount {
+			t.Errorf("column %d: wrong null count: want=%d got=%d", i, test.nullCount, stats.NullCount)
+		}
+		if min, max := string(stats.MinValue), string(stats.MaxValue); min != test.min || max != test.max {
+			t.Errorf("column %d: wrong bounds: want=[%q,%q] got=[%q,%q]", i, test.min, test.max, min, max)
+		}
+	}
+
+	got := make([]parquet.Row, len(rows)+1)
+	n, err := parquet.NewReader(f).ReadRows(got)
+	if err != nil && err != io.EOF {
+		t.Fatal(err)
+	}
+	want := []string{"[a x]", "[<null> <null> y]", "[<null> <null>]", "[b <null>]"}
+	for i, row := range got[:n] {
+		if s := fmt.Sprint(row); s != want[i] {
+			t.Errorf("row %d: want=%s got=%s", i, want[i], s)
+		}
+	}
+	if n != len(want) {
+		t.Errorf("wrong number of rows: want=%d got=%d", len(want), n)
+	}
+}
+
+func TestWriterDataPageSize(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name,dict"`
+		Kind string `parquet:"kind,dict"`
+	}
+
+	const dataPageSize = 8192
+	rows := make([]Row, 50000)
+	for i := range rows {
+		rows[i] = Row{
+			ID:   int64(i) * 7919,
+			Name: fmt.Sprintf("name-%06d", i),
+			Kind: fmt.Sprintf("kind-%d", i%10),
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](buf,
+		parquet.DataPageSize(dataPageSize),
+		parquet.MaxDictionarySize(16384),
+	)
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The name column falls back to the PLAIN encoding, the size of its
+	// buffers is learned again after the change of encoding.
+	stats := f.Metadata().RowGroups[0].Columns[1].MetaData.EncodingStats
+	if !slices.ContainsFunc(stats, func(s format.PageEncodingStats) bool {
+		return s.PageType != format.DictionaryPage && s.Encoding == format.Plain
+	}) {
+		t.Fatalf("column \"name\" must fall back to the PLAIN encoding: %+v", stats)
+	}
+
+	for i, chunk := range f.RowGroups()[0].ColumnChunks() {
+		offsetIndex, err := chunk.OffsetIndex()
+		if err != nil {
+			t.Fatal(err)
+		}
+		numPages := offsetIndex.NumPages()
+		if numPages < 4 {
+			t.Errorf("column %d: too few pages: %d", i, numPages)
+			continue
+		}
+		// Pages are uncompressed, their compressed size is the size of the
+		// encoded page and its header. The first page of the column chunk, the
+		// first fallback page, and the last page are not sized after the
+		// previous pages.
+		large := 0
+		for j := 1; j < numPages-1; j++ {
+			size := offsetIndex.CompressedPageSize(j)
+			if size > dataPageSize*3/2 {
+				t.Errorf("column %d: page %d: size too large: %d", i, j, size)
+			}
+			if size >= dataPageSize/2 {
+				large++
+			}
+		}
+		if large < (numPages-2)*3/4 {
+			t.Errorf("column %d: too many pages smaller than the target: %d/%d", i, numPages-2-large, numPages-2)
+		}
+	}
+
+	got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, rows) {
+		t.Error("rows read do not match the rows written")
+	}
+}
+
+func TestWriterDataPageSizeConstantColumn(t *testing.T) {
+	type Row struct {
+		Value int64 `parquet:"value,dict"`
+	}
+
+	// Constant values encode to almost nothing, the size of the buffers must
+	// remain bounded instead of growing with the ratio between the sizes of
+	// the buffers and of the pages.
+	const (
+		dataPageSize   = 64 * 1024
+		pageBufferSize = 16 * 1024
+		maxBufferSize  = 4 * dataPageSize
+		numRows        = 1_000_000
+		batchSize      = 1000
+	)
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](buf,
+		parquet.DataPageSize(dataPageSize),
+		parquet.PageBufferSize(pageBufferSize),
+	)
+	rows := make([]Row, batchSize)
+	for i := range rows {
+		rows[i].Value = 42
+	}
+	for range numRows / batchSize {
+		if _, err := w.Write(rows); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	offsetIndex, err := f.RowGroups()[0].ColumnChunks()[0].OffsetIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	numPages := offsetIndex.NumPages()
+	// The buffers of dictionary-encoded columns hold 4 bytes indexes.
+	if numPages < numRows*4/maxBufferSize {
+		t.Fatalf("too few pages: %d", numPages)
+	}
+	prevNumRows := int64(0)
+	for i := range numPages {
+		last := int64(numRows)
+		if i+1 < numPages {
+			last = offsetIndex.FirstRowIndex(i + 1)
+		}
+		pageNumRows := last - offsetIndex.FirstRowIndex(i)
+		if pageNumRows*4 > maxBufferSize+batchSize*4 {
+			t.Errorf("page %d: buffer grew beyond the limit: %d rows", i, pageNumRows)
+		}
+		if prevNumRows > 0 && pageNumRows > 2*prevNumRows+batchSize {
+			t.Errorf("page %d: buffer grew more than twice the previous size: %d > 2x%d rows", i, pageNumRows, prevNumRows)
+		}
+		prevNumRows = pageNumRows
+	}
+}
+
+func TestGenericWriterSchemaOverride(t *testing.T) {
+	type Row struct {
+		Name  string   `parquet:"name"`
+		Age   int32    `parquet:"age"`
+		Score *float64 `parquet:"score"`
+		Tags  []string `parquet:"tags"`
+	}
+
+	// The schema makes the name optional, changes the encodings and
+	// compression of the columns, and orders the columns by name.
+	schema := parquet.NewSchema("Row", parquet.Group{
+		"name":  parquet.Optional(parquet.Encoded(parquet.String(), &parquet.DeltaByteArray)),
+		"age":   parquet.Compressed(parquet.Encoded(parquet.Int(32), &parquet.DeltaBinaryPacked), &parquet.Zstd),
+		"score": parquet.Optional(parquet.Leaf(parquet.DoubleType)),
+		"tags":  parquet.Repeated(parquet.String()),
+	})
+
+	score := 4.5
+	rows := []Row{
+		{Name: "Luke", Age: 42, Score: &score, Tags: []string{"a", "b"}},
+		{Age: 7, Tags: []string{}},
+		{Name: "Leia", Age: 41},
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](buf, schema)
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := schema.String(), f.Schema().String(); want != got {
+		t.Errorf("wrong schema:\nwant:\n%s\ngot:\n%s", want, got)
+	}
+
+	columns := f.Metadata().RowGroups[0].Columns
+	if codec := columns[0].MetaData.Codec; codec != format.Zstd {
+		t.Errorf("wrong codec of the age column: %s", codec)
+	}
+	if !slices.Contains(columns[0].MetaData.Encoding, format.DeltaBinaryPacked) {
+		t.Errorf("wrong encodings of the age column: %v", columns[0].MetaData.Encoding)
+	}
+	if !slices.Contains(columns[1].MetaData.Encoding, format.DeltaByteArray) {
+		t.Errorf("wrong encodings of the name column: %v", columns[1].MetaData.Encoding)
+	}
+	// The empty name is written as a null value of the optional column.
+	if nulls := columns[1].MetaData.Statistics.NullCount; nulls != 1 {
+		t.Errorf("wrong number of nulls in the name column: want=1 got=%d", nulls)
+	}
+
+	got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows[2].Tags = []string{}
+	if !reflect.DeepEqual(got, rows) {
+		t.Errorf("wrong rows:\nwant: %+v\ngot:  %+v", rows, got)
+	}
+
+	for _, test := range []struct {
+		scenario string
+		schema   *parquet.Schema
+	}{
+		{
+			scenario: "mismatching type",
+			schema: parquet.NewSchema("Row", parquet.Group{
+				"name":  parquet.String(),
+				"age":   parquet.Int(64),
+				"score": parquet.Optional(parquet.Leaf(parquet.DoubleType)),
+				"tags":  parquet.Repeated(parquet.String()),
+			}),
+		},
+		{
+			scenario: "missing column",
+			schema: parquet.NewSchema("Row", parquet.Group{
+				"name":  parquet.String(),
+				"score": parquet.Optional(parquet.Leaf(parquet.DoubleType)),
+				"tags":  parquet.Repeated(parquet.String()),
+			}),
+		},
+	} {
+		t.Run(test.scenario, func(t *testing.T) {
+			w := parquet.NewGenericWriter[Row](new(bytes.Buffer), test.schema)
+			if _, err := w.Write(rows); err == nil {
+				t.Error("expected an error writing rows with an incompatible schema")
+			}
+		})
+	}
+}
+
+func TestWriterSortingColumnsChecked(t *testing.T) {
+	type Row struct {
+		Group int64  `parquet:"group"`
+		Time  int64  `parquet:"time"`
+		Name  string `parquet:"name"`
+	}
+
+	option := parquet.SortingColumnsChecked(
+		parquet.Ascending("group"),
+		parquet.Descending("time"),
+	)
+
+	sorted := []Row{
+		{Group: 1, Time: 30, Name: "a"},
+		{Group: 1, Time: 20, Name: "b"},
+		{Group: 1, Time: 20, Name: "c"},
+		{Group: 2, Time: 50, Name: "d"},
+		{Group: 2, Time: 10, Name: "e"},
+		{Group: 3, Time: 90, Name: "f"},
+	}
+
+	t.Run("sorted", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		w := parquet.NewGenericWriter[Row](buf, option)
+		// The order is verified across calls to Write.
+		for _, batch := range [][]Row{sorted[:2], sorted[2:5], sorted[5:]} {
+			if n, err := w.Write(batch); err != nil {
+				t.Fatal(err)
+			} else if n != len(batch) {
+				t.Fatalf("wrong number of rows written: want=%d got=%d", len(batch), n)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []format.SortingColumn{{ColumnIdx: 0}, {ColumnIdx: 1, Descending: true}}
+		if got := f.Metadata().RowGroups[0].SortingColumns; !reflect.DeepEqual(got, want) {
+			t.Errorf("wrong sorting columns:\nwant: %+v\ngot:  %+v", want, got)
+		}
+		rows, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(rows, sorted) {
+			t.Errorf("wrong rows:\nwant: %+v\ngot:  %+v", sorted, rows)
+		}
+	})
+
+	unsorted := slices.Clone(sorted)
+	unsorted[3], unsorted[4] = unsorted[4], unsorted[3]
+
+	t.Run("GenericWriter", func(t *testing.T) {
+		w := parquet.NewGenericWriter[Row](io.Discard, option)
+		if _, err := w.Write(unsorted[:2]); err != nil {
+			t.Fatal(err)
+		}
+		n, err := w.Write(unsorted[2:])
+		if !errors.Is(err, parquet.ErrUnsortedRows) {
+			t.Fatalf("wrong error: want=%v got=%v", parquet.ErrUnsortedRows, err)
+		}
+		if !strings.Contains(err.Error(), "row 4") {
+			t.Errorf("the error does not report the index of the row: %v", err)
+		}
+		if n != 2 {
+			t.Errorf("wrong number of rows written before the error: want=2 got=%d", n)
+		}
+	})
+
+	t.Run("Writer", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		w := parquet.NewWriter(buf, parquet.SchemaOf(new(Row)), option)
+		for i, row := range unsorted {
+			err := w.Write(row)
+			if i == 4 {
+				if !errors.Is(err, parquet.ErrUnsortedRows) {
+					t.Fatalf("wrong error: want=%v got=%v", parquet.ErrUnsortedRows, err)
+				}
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	})
+
+	t.Run("WriteColumns", func(t *testing.T) {
+		w := parquet.NewWriter(io.Discard, parquet.SchemaOf(new(Row)), option)
+		columns := make([][]parquet.Value, 3)
+		for _, row := range unsorted {
+			columns[0] = append(columns[0], parquet.Int64Value(row.Group))
+			columns[1] = append(columns[1], parquet.Int64Value(row.Time))
+			columns[2] = append(columns[2], parquet.ByteArrayValue([]byte(row.Name)))
+		}
+		n, err := w.WriteColumns(columns)
+		if !errors.Is(err, parquet.ErrUnsortedRows) {
+			t.Fatalf("wrong error: want=%v got=%v", parquet.ErrUnsortedRows, err)
+		}
+		if n != 4 {
+			t.Errorf("wrong number of rows written before the error: want=4 got=%d", n)
+		}
+	})
+
+	t.Run("WriteRowGroup", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		if err := parquet.Write(buf, unsorted); err != nil {
+			t.Fatal(err)
+		}
+		f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		w := parquet.NewWriter(io.Discard, f.Schema(), option)
+		if _, err := w.WriteRowGroup(f.RowGroups()[0]); !errors.Is(err, parquet.ErrUnsortedRows) {
+			t.Fatalf("wrong error: want=%v got=%v", parquet.ErrUnsortedRows, err)
+		}
+	})
+
+	t.Run("WriteRowGroup declaring the sorting columns", func(t *testing.T) {
+		files := make([]*parquet.File, 2)
+		for i, rows := range [][]Row{sorted[:3], sorted[3:]} {
+			// Each row is written to its own page, which tells whether the
+			// pages were copied or re-encoded by the writer.
+			buf := new(bytes.Buffer)
+			w := parquet.NewGenericWriter[Row](buf, option, parquet.PageBufferSize(1))
+			for _, row := range rows {
+				if _, err := w.Write([]Row{row}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+			f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			files[i] = f
+		}
+
+		// The pages of row groups sorted on the checked columns are copied,
+		// only their first and last rows are read to verify their order.
+		buf := new(bytes.Buffer)
+		w := parquet.NewWriter(buf, files[0].Schema(), option)
+		for _, f := range files {
+			if _, err := w.WriteRowGroup(f.RowGroups()[0]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, rowGroup := range f.RowGroups() {
+			offsetIndex, err := rowGroup.ColumnChunks()[0].OffsetIndex()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want, got := rowGroup.NumRows(), int64(offsetIndex.NumPages()); got != want {
+				t.Errorf("the pages of row group %d were not copied: want=%d pages got=%d", i, want, got)
+			}
+		}
+		rows, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(rows, sorted) {
+			t.Errorf("wrong rows:\nwant: %+v\ngot:  %+v", sorted, rows)
+		}
+
+		w = parquet.NewWriter(io.Discard, files[0].Schema(), option)
+		if _, err := w.WriteRowGroup(files[1].RowGroups()[0]); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.WriteRowGroup(files[0].RowGroups()[0]); !errors.Is(err, parquet.ErrUnsortedRows) {
+			t.Fatalf("wrong error: want=%v got=%v", parquet.ErrUnsortedRows, err)
+		}
+	})
+}